@@ -2,74 +2,319 @@ package main
 
 import (
 	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/adapter/postgres"
+	"TimeTrack/internal/adapter/sqlite"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/auth"
+	"TimeTrack/internal/bot"
 	"TimeTrack/internal/calendar"
+	"TimeTrack/internal/config"
+	"TimeTrack/internal/department"
+	"TimeTrack/internal/directory"
+	"TimeTrack/internal/document"
+	"TimeTrack/internal/health"
+	"TimeTrack/internal/importer"
+	"TimeTrack/internal/metrics"
+	"TimeTrack/internal/notify"
+	"TimeTrack/internal/openapi"
+	"TimeTrack/internal/payroll"
+	"TimeTrack/internal/project"
 	"TimeTrack/internal/report"
+	"TimeTrack/internal/schedule"
+	"TimeTrack/internal/shift"
 	"TimeTrack/internal/standard"
+	"TimeTrack/internal/tracing"
 	types "TimeTrack/internal/type"
 	"TimeTrack/internal/vacation"
+	"TimeTrack/internal/webhook"
+	"context"
 	"database/sql"
+	"fmt"
+	"io"
 	"log/slog"
+	"slices"
+	"strings"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 )
 
 type application struct {
-	config config
-	db     *sql.DB
-	logger *slog.Logger
+	config  *config.Config
+	db      *sql.DB
+	store   store
+	metrics *metrics.Metrics
+	logger  *slog.Logger
+	// background - фоновые задачи сервисов, их запускает и останавливает run
+	background []func(ctx context.Context)
 }
 
-type config struct {
-	addr string
-	db   dbConfig
+// store - запросы, построчные выборки и транзакции выбранной СУБД
+type store struct {
+	repo.Querier
+	repo.Streamer
+	repo.TxBeginner
 }
 
-type dbConfig struct {
-	dsn string
+// newStore - хранилище над запросами q и транзакциями tx
+func newStore(q *repo.Queries, tx repo.TxBeginner) store {
+	return store{q, q, tx}
 }
 
-func (app *application) mount() *fiber.App {
+// instrument - то же хранилище с измерением длительности запросов и span на каждый запрос
+func (s store) instrument(m *metrics.Metrics) store {
+	return store{
+		m.Querier(tracing.Querier(s.Querier)),
+		m.Streamer(tracing.Streamer(s.Streamer)),
+		m.TxBeginner(tracing.TxBeginner(s.TxBeginner)),
+	}
+}
+
+// openStore подключается к базе: MySQL - сгенерированный sqlc пакет repo,
+// PostgreSQL и SQLite - те же запросы в своем диалекте (internal/adapter/postgres,
+// internal/adapter/sqlite). Файл SQLite и его схема создаются при первом запуске.
+// Если база не отвечает за DB_CONNECT_TIMEOUT, возвращает ошибку - сервис не
+// запускается без базы.
+func openStore(cfg config.DBConfig) (*sql.DB, store, error) {
+	var db *sql.DB
+	var st store
+	var err error
+
+	switch cfg.Driver {
+	case "mysql":
+		db, err = sql.Open("mysql", cfg.DSN)
+		st = newStore(repo.New(db), repo.NewTxBeginner(db))
+	case "postgres":
+		db, err = sql.Open(postgres.Driver, cfg.DSN)
+		st = newStore(postgres.New(db), postgres.NewTxBeginner(db))
+	case "sqlite":
+		db, err = sqlite.Open(context.Background(), cfg.SqlitePath)
+		st = newStore(sqlite.New(db), sqlite.NewTxBeginner(db))
+	default:
+		err = fmt.Errorf("unsupported database driver %q", cfg.Driver)
+	}
+	if err != nil {
+		return nil, store{}, err
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, store{}, fmt.Errorf("connect to %s: %w", cfg.Driver, err)
+	}
+
+	return db, st, nil
+}
+
+// newDirectory - каталог LDAP или nil, если LDAP_URL не задан
+func newDirectory(cfg config.LDAPConfig) directory.Directory {
+	return directory.NewLDAP(directory.LDAPConfig{
+		URL:                 cfg.URL,
+		BindDN:              cfg.BindDN,
+		Password:            cfg.Password,
+		BaseDN:              cfg.BaseDN,
+		Filter:              cfg.Filter,
+		IDAttribute:         cfg.IDAttr,
+		LoginAttribute:      cfg.LoginAttr,
+		DepartmentAttribute: cfg.DepartmentAttr,
+	})
+}
+
+// newMailSender - SMTP-отправитель; без SMTP_ADDR письма только пишутся в лог
+func newMailSender(cfg config.SMTPConfig, logger *slog.Logger) notify.Sender {
+	return notify.NewSender(notify.SMTPConfig{
+		Addr:     cfg.Addr,
+		Username: cfg.Username,
+		Password: cfg.Password,
+		From:     cfg.From,
+	}, logger)
+}
+
+// apiVersion - версия API в спецификации OpenAPI
+const apiVersion = "1.0.0"
+
+// spec - спецификация OpenAPI всех маршрутов /v1; список совпадает с маршрутами
+// в mount, это проверяет контрактный тест. telegram - подключен ли бот к Telegram.
+func spec(telegram bool) (*openapi3.T, error) {
+	return openapi.New(apiVersion,
+		openapi.Operations(),
+		report.Operations(),
+		importer.Operations(),
+		vacation.Operations(),
+		calendar.Operations(),
+		types.Operations(),
+		standard.Operations(),
+		schedule.Operations(),
+		shift.Operations(),
+		project.Operations(),
+		department.Operations(),
+		document.Operations(),
+		payroll.Operations(),
+		webhook.Operations(),
+		notify.Operations(),
+		bot.Operations(telegram),
+		directory.Operations(),
+		auth.Operations(),
+	)
+}
+
+func (app *application) mount() (*fiber.App, error) {
 	fiber := fiber.New(fiber.Config{
-		Prefork: true,
+		Prefork:      app.config.Prefork,
+		ErrorHandler: apperr.Handler(app.logger),
+		// Тело больше BodyLimit читается потоком: импорт табеля принимает файлы
+		// до importer.MaxFileSize, остальные маршруты ограничивает limitBody
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
 		// EnablePrintRoutes: true,
 	})
 
-	fiber.Use(cors.New())
+	fiber.Use(limitBody(fiber.Config().BodyLimit, importPath))
+	fiber.Use(app.metrics.Middleware())
+	fiber.Use(tracing.Middleware(isProbe))
+	fiber.Use(cors.New(cors.Config{
+		AllowOrigins: strings.Join(app.config.CORS.AllowOrigins, ","),
+	}))
 	fiber.Use(logger.New(logger.Config{
 		Format: "[${ip}]:${port} | ${latency} | ${status} - ${method} ${path} \n",
+		Next:   isProbe,
 	}))
 
-	reportService := report.NewService(repo.New(app.db), app.db)
+	healthService := health.NewService(app.store, app.db)
+	healthHandler := health.NewHandler(healthService, app.logger)
+
+	webhookService := webhook.NewService(app.store, app.store, webhook.Config{
+		AllowPrivate: app.config.Webhook.AllowPrivate,
+	}, app.logger)
+	webhookHandler := webhook.NewHandler(webhookService, app.logger)
+	app.background = append(app.background, webhookService.Run)
+
+	calendarService := calendar.NewService(app.store, app.store, webhookService)
+	calendarHandler := calendar.NewHandler(calendarService, app.logger)
+
+	scheduleService := schedule.NewService(app.store, app.store, calendarService)
+	scheduleHandler := schedule.NewHandler(scheduleService, app.logger)
+
+	shiftService := shift.NewService(app.store, app.store)
+	shiftHandler := shift.NewHandler(shiftService, app.logger)
+
+	reportService := report.NewService(app.store, app.store, scheduleService, shiftService, calendarService, webhookService)
 	reportHandler := report.NewHandler(reportService, app.logger)
 
-	vacationService := vacation.NewService(repo.New(app.db), app.db)
+	importService := importer.NewService(app.store, app.store)
+	importHandler := importer.NewHandler(importService, app.logger)
+
+	notifyService := notify.NewService(app.store, app.store, newMailSender(app.config.SMTP, app.logger), app.logger)
+	notifyHandler := notify.NewHandler(notifyService, app.logger)
+
+	vacationService := vacation.NewService(app.store, app.store, app.store, webhookService, notifyService)
 	vacationHandler := vacation.NewHandler(vacationService, app.logger)
 
-	calendarService := calendar.NewService(repo.New(app.db), app.db)
-	calendarHandler := calendar.NewHandler(calendarService, app.logger)
+	documentService := document.NewService(app.store, app.store, vacationService, app.config.PdfFont)
+	documentHandler := document.NewHandler(documentService, app.logger)
+
+	payrollService := payroll.NewService(app.store, app.store, calendarService, scheduleService, vacationService)
+	payrollHandler := payroll.NewHandler(payrollService, app.logger)
+
+	botService := bot.NewService(app.store, app.store, bot.NewChat(bot.TelegramConfig{
+		Token:  app.config.Telegram.Token,
+		APIURL: app.config.Telegram.APIURL,
+		Secret: app.config.Telegram.Secret,
+	}, app.logger), reportService, vacationService, app.logger)
+	botHandler := bot.NewHandler(botService, app.logger)
 
-	standardService := standard.NewService(repo.New(app.db), app.db)
+	standardService := standard.NewService(app.store, app.store)
 	standardHandler := standard.NewHandler(standardService, app.logger)
 
-	typesService := types.NewService(repo.New(app.db), app.db)
+	typesService := types.NewService(app.store, app.store)
 	typesHandler := types.NewHandler(typesService, app.logger)
 
+	projectService := project.NewService(app.store, app.store)
+	projectHandler := project.NewHandler(projectService, app.logger)
+
+	departmentService := department.NewService(app.store, app.store)
+	departmentHandler := department.NewHandler(departmentService, app.logger)
+
+	directoryService := directory.NewService(app.store, app.store, newDirectory(app.config.LDAP))
+	directoryHandler := directory.NewHandler(directoryService, app.logger)
+
+	app.scheduleDirectorySync(directoryService)
+
+	sessions := auth.NewSessions(app.config.Auth.Secret, app.config.Auth.SessionTTL)
+	authService := auth.NewService(app.store, app.store, auth.OIDCConfig{
+		Issuer:       app.config.Auth.OIDC.Issuer,
+		ClientID:     app.config.Auth.OIDC.ClientID,
+		ClientSecret: app.config.Auth.OIDC.ClientSecret,
+		RedirectURL:  app.config.Auth.OIDC.RedirectURL,
+		Scopes:       app.config.Auth.OIDC.Scopes,
+		UserClaim:    app.config.Auth.OIDC.UserClaim,
+		RoleClaim:    app.config.Auth.OIDC.RoleClaim,
+		AdminGroups:  app.config.Auth.OIDC.AdminGroups,
+		FrontendURL:  app.config.Auth.OIDC.FrontendURL,
+	}, sessions)
+	authHandler := auth.NewHandler(authService, app.logger)
+
+	// Пробы живости и готовности и метрики - без версии API и без сессии
+	fiber.Get("/healthz", healthHandler.Live)
+	fiber.Get("/readyz", healthHandler.Ready)
+	fiber.Get("/metrics", app.metrics.Handler())
+	app.metrics.Register(metrics.NewBusiness(app.store))
+
+	// без токена Telegram вебхук бота не регистрируется: обновлениям неоткуда приходить
+	telegram := app.config.Telegram.Token != ""
+	doc, err := spec(telegram)
+	if err != nil {
+		return nil, err
+	}
+	specHandler, err := openapi.Handler(doc)
+	if err != nil {
+		return nil, err
+	}
+
 	v1 := fiber.Group("v1")
-	// admin := v1.Group("/admin")
+	// Вход, вебхук Telegram (проверяется своим секретом) и документация доступны без сессии
+	v1.Use(auth.Middleware(sessions, app.config.Auth.Required,
+		"/v1/auth/oidc/", "/v1/bot/telegram/", openapi.SpecPath, openapi.DocsPath))
+	// Подписки, шаблоны документов, синхронизация каталога, календарь, нормы, проекты,
+	// ночное окно, макет выгрузки и закрытие месяца - только для admin
+	admin := auth.RequireRole(auth.RoleAdmin)
 
 	report := v1.Group("/report")
 	calendar := v1.Group("/calendar")
 	vacation := v1.Group("/vacation")
 	standard := v1.Group("/standard")
 	types := v1.Group("/type")
+	schedule := v1.Group("/schedule")
+	shift := v1.Group("/shift")
+	project := v1.Group("/project")
+	department := v1.Group("/department")
+	document := v1.Group("/document")
+	payroll := v1.Group("/payroll")
+	webhook := v1.Group("/webhook", admin)
+	notify := v1.Group("/notify")
+	bot := v1.Group("/bot")
+	directory := v1.Group("/directory")
+	authGroup := v1.Group("/auth")
 
 	report.Get("/list/:user/:month/:year", reportHandler.List)
 	report.Get("/monthstats/:user/:month/:year", reportHandler.MonthStats)
+	report.Get("/missing/:user/:month/:year", reportHandler.MissingDays)
+	report.Get("/export/:month/:year.xlsx", reportHandler.Export)
 	report.Post("/create", reportHandler.Create)
 	report.Post("/update", reportHandler.Update)
 	report.Delete("/delete/:user/:day/:month/:year", reportHandler.Delete)
+	report.Get("/day/:user/:day/:month/:year", reportHandler.Day)
+	report.Post("/day", reportHandler.SetDay)
+	report.Delete("/delete-entry/:entry", reportHandler.DeleteEntry)
+	report.Post("/import", importHandler.Import)
 
 	vacation.Get("/list/:year", vacationHandler.ListAll)
 	vacation.Get("/list/:user/:year", vacationHandler.List)
@@ -78,20 +323,151 @@ func (app *application) mount() *fiber.App {
 	vacation.Post("/create", vacationHandler.Create)
 	vacation.Post("/change-status", vacationHandler.ChangeStatus)
 	vacation.Delete("/delete/:vacation", vacationHandler.Delete)
+	vacation.Get("/:id/document.pdf", documentHandler.VacationPDF)
 
 	calendar.Get("/list/:month/:year", calendarHandler.ListMonth)
 	calendar.Get("/list/:year", calendarHandler.ListYear)
-	calendar.Post("/create", calendarHandler.Create)
+	calendar.Post("/create", admin, calendarHandler.Create)
+	calendar.Get("/day/:date", calendarHandler.Day)
+	calendar.Get("/hours/:date", calendarHandler.ExpectedHours)
+	calendar.Get("/workdays/:from/:to", calendarHandler.WorkingDays)
+	calendar.Get("/add-workdays/:date/:days", calendarHandler.AddWorkingDays)
 
 	types.Get("/list", typesHandler.List)
 
-	standard.Post("/create", standardHandler.Create)
-	standard.Post("/update", standardHandler.Update)
+	standard.Post("/create", admin, standardHandler.Create)
+	standard.Post("/update", admin, standardHandler.Update)
 	standard.Get("/listforsetting/:year", standardHandler.ListForSetting)
 
-	return fiber
+	schedule.Get("/list", scheduleHandler.List)
+	schedule.Post("/create", scheduleHandler.Create)
+	schedule.Delete("/delete/:schedule", scheduleHandler.Delete)
+	schedule.Get("/user/:user", scheduleHandler.UserSchedules)
+	schedule.Post("/assign", scheduleHandler.Assign)
+	schedule.Delete("/unassign/:assignment", scheduleHandler.Unassign)
+	schedule.Get("/expected/:user/:month/:year", scheduleHandler.Expected)
+
+	shift.Get("/list/:month/:year", shiftHandler.ListAll)
+	shift.Get("/list/:user/:month/:year", shiftHandler.List)
+	shift.Post("/create", shiftHandler.Plan)
+	shift.Post("/update", shiftHandler.Update)
+	shift.Delete("/delete/:shift", shiftHandler.Delete)
+	shift.Get("/night-window", shiftHandler.GetNightWindow)
+	shift.Post("/night-window", admin, shiftHandler.SetNightWindow)
+
+	project.Get("/list", projectHandler.List)
+	project.Post("/create", admin, projectHandler.Create)
+	project.Post("/update", projectHandler.Update)
+	project.Get("/tasks/:project", projectHandler.Tasks)
+	project.Post("/task/create", projectHandler.CreateTask)
+	project.Post("/task/update", projectHandler.UpdateTask)
+	project.Get("/allocation/:report", projectHandler.Allocations)
+	project.Post("/allocate", projectHandler.Allocate)
+	project.Get("/totals/:month/:year", projectHandler.TotalsByMonth)
+	project.Get("/totals/user/:user/:month/:year", projectHandler.TotalsByUser)
+	project.Get("/totals/department/:department/:month/:year", projectHandler.TotalsByDepartment)
+
+	department.Get("/list", departmentHandler.List)
+	department.Post("/create", departmentHandler.Create)
+	department.Post("/assign", departmentHandler.Assign)
+	department.Get("/user/:user", departmentHandler.UserDepartment)
+	department.Get("/users/:department", departmentHandler.Users)
+
+	document.Get("/template/list", admin, documentHandler.Templates)
+	document.Get("/template/:kind", admin, documentHandler.Template)
+	document.Post("/template/update", admin, documentHandler.SaveTemplate)
+	document.Delete("/template/:kind", admin, documentHandler.ResetTemplate)
+
+	payroll.Get("/list/:month/:year", payrollHandler.List)
+	payroll.Get("/export/:month/:year", payrollHandler.Export)
+	payroll.Get("/layout", payrollHandler.GetLayout)
+	payroll.Post("/layout", admin, payrollHandler.SetLayout)
+	payroll.Get("/months/:year", payrollHandler.Months)
+	payroll.Post("/close/:month/:year", admin, payrollHandler.Close)
+	payroll.Delete("/close/:month/:year", admin, payrollHandler.Reopen)
+
+	webhook.Get("/events", webhookHandler.Events)
+	webhook.Get("/list", webhookHandler.List)
+	webhook.Post("/create", webhookHandler.Create)
+	webhook.Post("/update", webhookHandler.Update)
+	webhook.Delete("/delete/:webhook", webhookHandler.Delete)
+	webhook.Get("/deliveries/:webhook", webhookHandler.Deliveries)
+	webhook.Post("/redeliver/:delivery", webhookHandler.Redeliver)
+
+	notify.Get("/contact/:user", notifyHandler.Contact)
+	notify.Post("/contact", notifyHandler.SetContact)
+	notify.Post("/remind", notifyHandler.Remind)
+
+	if telegram {
+		bot.Post("/telegram/update", botHandler.Update)
+	}
+	bot.Post("/link-code", botHandler.LinkCode)
+	bot.Post("/remind", botHandler.Remind)
+
+	directory.Get("/users", directoryHandler.Users)
+	directory.Post("/sync", admin, directoryHandler.Sync)
+
+	authGroup.Get("/oidc/login", authHandler.Login)
+	authGroup.Get("/oidc/callback", authHandler.Callback)
+	authGroup.Get("/me", authHandler.Me)
+
+	v1.Get("/openapi.json", specHandler)
+	v1.Get("/docs", openapi.Docs(openapi.SpecPath))
+
+	return fiber, nil
+}
+
+// importPath - импорт табеля: единственный маршрут, принимающий тело больше BodyLimit
+const importPath = "/v1/report/import"
+
+// limitBody читает тело запроса не больше limit байт (413 при превышении) на всех
+// маршрутах, кроме except: со StreamRequestBody Fiber сам не ограничивает размер тела
+func limitBody(limit int, except ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		stream := c.Context().RequestBodyStream()
+		if stream == nil || c.Request().Header.ContentLength() == 0 || slices.Contains(except, c.Path()) {
+			return c.Next()
+		}
+
+		body, err := io.ReadAll(io.LimitReader(stream, int64(limit)+1))
+		if err != nil {
+			return fiber.ErrBadRequest
+		}
+		if len(body) > limit {
+			// остаток тела не прочитан - соединение дальше не используется
+			c.Context().SetConnectionClose()
+			return fiber.ErrRequestEntityTooLarge
+		}
+		c.Request().SetBody(body)
+		return c.Next()
+	}
+}
+
+// isProbe - /healthz, /readyz и /metrics не пишутся в журнал запросов:
+// оркестратор и Prometheus вызывают их каждые несколько секунд
+func isProbe(c *fiber.Ctx) bool {
+	switch c.Path() {
+	case "/healthz", "/readyz", "/metrics":
+		return true
+	}
+	return false
+}
+
+// scheduleDirectorySync добавляет синхронизацию с LDAP по расписанию в фоновые задачи:
+// она останавливается вместе с приложением
+func (app *application) scheduleDirectorySync(service directory.Service) {
+	if app.config.LDAP.SyncInterval <= 0 {
+		return
+	}
+	app.background = append(app.background, func(ctx context.Context) {
+		directory.Schedule(ctx, service, app.config.LDAP.SyncInterval, app.logger)
+	})
 }
 
-func (app *application) run(f *fiber.App) error {
-	return f.ListenTLS(app.config.addr, "cert.pem", "key.pem")
+// listen принимает запросы по HTTPS или, при TLS_ENABLED=false, по HTTP
+func (app *application) listen(f *fiber.App) error {
+	if app.config.TLS.Enabled {
+		return f.ListenTLS(app.config.Addr, app.config.TLS.CertFile, app.config.TLS.KeyFile)
+	}
+	return f.Listen(app.config.Addr)
 }
@@ -0,0 +1,549 @@
+package main
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/adapter/sqlite"
+	"TimeTrack/internal/auth"
+	"TimeTrack/internal/config"
+	"TimeTrack/internal/metrics"
+	"TimeTrack/internal/openapi"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gofiber/fiber/v2"
+)
+
+const userID = "0b9c7f5e-4a3d-4c1e-9f7a-2d8e6b1c5a40"
+
+// telegramSecret - секрет вебхука бота тестового сервера
+const telegramSecret = "telegram-secret"
+
+// newTestApp - сервер со всеми маршрутами над SQLite во временном каталоге
+// с видами отметок work, medical и holiday. Бот подключен к заглушке Bot API;
+// options меняют конфигурацию перед запуском.
+func newTestApp(t *testing.T, options ...func(*config.Config)) *fiber.App {
+	t.Helper()
+	ctx := context.Background()
+
+	telegram := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(telegram.Close)
+
+	db, err := sqlite.Open(ctx, filepath.Join(t.TempDir(), "api.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	st := newStore(sqlite.New(db), sqlite.NewTxBeginner(db))
+	for _, rt := range []repo.CreateTypeParams{
+		{ID: "t-work", Name: "Явка", SystemName: "work", Code: "Я"},
+		{ID: "t-medical", Name: "Больничный", SystemName: "medical", Code: "Б"},
+		{ID: "t-holiday", Name: "Праздник", SystemName: "holiday", Code: "В"},
+	} {
+		if err := st.CreateType(ctx, rt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := &config.Config{
+		CORS:     config.CORSConfig{AllowOrigins: []string{"*"}},
+		Auth:     config.AuthConfig{Secret: "test-secret"},
+		Telegram: config.TelegramConfig{Token: "123:token", APIURL: telegram.URL, Secret: telegramSecret},
+		// подписчики вебхуков в тестах - httptest на loopback
+		Webhook: config.WebhookConfig{AllowPrivate: true},
+	}
+	for _, option := range options {
+		option(cfg)
+	}
+
+	app := application{
+		config:  cfg,
+		db:      db,
+		store:   st,
+		metrics: metrics.New(db),
+		logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	f, err := app.mount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// bearer - заголовок Authorization с сессией сотрудника userID и ролью role
+func bearer(t *testing.T, role string) string {
+	t.Helper()
+	token, _, err := auth.NewSessions("test-secret", 0).Issue(userID, role, "Иван Петров", "ivan@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "Bearer " + token
+}
+
+// loadSpec - спецификация, которую отдает сервер, после разбора и проверки
+func loadSpec(t *testing.T, f *fiber.App) *openapi3.T {
+	t.Helper()
+	resp, err := f.Test(httptest.NewRequest(http.MethodGet, openapi.SpecPath, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("%s: status = %d", openapi.SpecPath, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// TestSpecRoutes - в спецификации ровно те маршруты /v1, которые зарегистрированы
+func TestSpecRoutes(t *testing.T) {
+	f := newTestApp(t)
+	doc := loadSpec(t, f)
+
+	var registered []string
+	for _, route := range f.GetRoutes(true) {
+		if route.Method == http.MethodHead || !strings.HasPrefix(route.Path, "/v1/") {
+			continue
+		}
+		registered = append(registered, route.Method+" "+openapi.Path(route.Path))
+	}
+
+	var documented []string
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			documented = append(documented, method+" "+path)
+		}
+	}
+
+	slices.Sort(registered)
+	slices.Sort(documented)
+	for _, route := range registered {
+		if _, found := slices.BinarySearch(documented, route); !found {
+			t.Errorf("route %s is not in the spec", route)
+		}
+	}
+	for _, route := range documented {
+		if _, found := slices.BinarySearch(registered, route); !found {
+			t.Errorf("spec operation %s has no route", route)
+		}
+	}
+}
+
+// contractStep - один запрос контрактного теста. В route, path и body подставляются
+// значения {имя}, сохраненные предыдущими шагами: save - имя, под которым запоминается
+// поле id ответа (объекта или первого элемента списка).
+type contractStep struct {
+	method  string
+	route   string
+	path    string
+	body    string
+	status  int
+	save    string
+	content string
+}
+
+// TestContract - запросы и ответы всех маршрутов /v1 соответствуют спецификации:
+// тела запросов проходят схему, статус ответа документирован, тело ответа проходит
+// схему этого статуса (ошибки - схему default). Маршрут без шага - ошибка теста.
+func TestContract(t *testing.T) {
+	f := newTestApp(t)
+	doc := loadSpec(t, f)
+
+	// получатель вебхуков: доставки событий из шагов ниже завершаются успешно
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(hook.Close)
+
+	user := "/" + userID
+	u := `"userId":"` + userID + `"`
+	// маршруты администрирования требуют сессию admin; остальным она не мешает
+	admin := bearer(t, auth.RoleAdmin)
+
+	// шаги выполняются по порядку: записи создаются до чтения, месяц закрывается
+	// после изменений отметок и снова открывается перед удалением
+	tests := []contractStep{
+		{method: "GET", route: "/v1/type/list", path: "/v1/type/list", status: 200},
+
+		{method: "POST", route: "/v1/standard/create", path: "/v1/standard/create", body: `{"month":5,"year":2025,"hours":159,"genderId":1}`, status: 201, save: "standard"},
+		{method: "POST", route: "/v1/standard/update", path: "/v1/standard/update", body: `{"id":"{standard}","hours":160}`, status: 200},
+		{method: "GET", route: "/v1/standard/listforsetting/:year", path: "/v1/standard/listforsetting/2025", status: 200},
+
+		{method: "POST", route: "/v1/calendar/create", path: "/v1/calendar/create", body: `{"day":1,"month":5,"year":2025,"typeId":"t-holiday"}`, status: 201},
+		{method: "GET", route: "/v1/calendar/list/:month/:year", path: "/v1/calendar/list/5/2025", status: 200},
+		{method: "GET", route: "/v1/calendar/list/:year", path: "/v1/calendar/list/2025", status: 200},
+		{method: "GET", route: "/v1/calendar/day/:date", path: "/v1/calendar/day/2025-05-01", status: 200},
+		{method: "GET", route: "/v1/calendar/hours/:date", path: "/v1/calendar/hours/2025-05-05", status: 200},
+		{method: "GET", route: "/v1/calendar/workdays/:from/:to", path: "/v1/calendar/workdays/2025-05-01/2025-05-31", status: 200},
+		{method: "GET", route: "/v1/calendar/add-workdays/:date/:days", path: "/v1/calendar/add-workdays/2025-04-30/1", status: 200},
+
+		{method: "GET", route: "/v1/webhook/events", path: "/v1/webhook/events", status: 200},
+		{method: "POST", route: "/v1/webhook/create", path: "/v1/webhook/create", body: `{"url":"{hook}","secret":"contract-secret","events":["*"]}`, status: 201, save: "webhook"},
+		{method: "POST", route: "/v1/webhook/update", path: "/v1/webhook/update", body: `{"id":"{webhook}","url":"{hook}","events":["report.created","report.updated"],"isActive":true}`, status: 200},
+		{method: "GET", route: "/v1/webhook/list", path: "/v1/webhook/list", status: 200},
+
+		{method: "POST", route: "/v1/report/create", path: "/v1/report/create", body: `{` + u + `,"day":5,"month":5,"year":2025,"hours":8,"typeSystemName":"work","start":"09:00","end":"17:00"}`, status: 201, save: "report"},
+		{method: "POST", route: "/v1/report/update", path: "/v1/report/update", body: `{"id":"{report}","hours":7,"typeSystemName":"work","start":"09:00","end":"16:00"}`, status: 200},
+		{method: "POST", route: "/v1/report/day", path: "/v1/report/day", body: `{` + u + `,"day":6,"month":5,"year":2025,"entries":[{"hours":8,"typeSystemName":"work"}]}`, status: 200},
+		{method: "POST", route: "/v1/report/import", path: "/v1/report/import", body: "user_id,date,hours,type\n" + userID + ",2025-05-07,8,work\n", status: 200, content: openapi.MIMECSV},
+		{method: "GET", route: "/v1/report/list/:user/:month/:year", path: "/v1/report/list" + user + "/5/2025", status: 200},
+		{method: "GET", route: "/v1/report/day/:user/:day/:month/:year", path: "/v1/report/day" + user + "/5/5/2025", status: 200},
+		{method: "GET", route: "/v1/report/monthstats/:user/:month/:year", path: "/v1/report/monthstats" + user + "/5/2025", status: 200},
+		{method: "GET", route: "/v1/report/missing/:user/:month/:year", path: "/v1/report/missing" + user + "/5/2025", status: 200},
+		{method: "GET", route: "/v1/report/export/:month/:year.xlsx", path: "/v1/report/export/5/2025.xlsx", status: 200},
+
+		{method: "GET", route: "/v1/webhook/deliveries/:webhook", path: "/v1/webhook/deliveries/{webhook}", status: 200, save: "delivery"},
+		{method: "POST", route: "/v1/webhook/redeliver/:delivery", path: "/v1/webhook/redeliver/{delivery}", status: 202},
+
+		{method: "POST", route: "/v1/vacation/create", path: "/v1/vacation/create", body: `{` + u + `,"startDate":"2025-07-07T00:00:00Z","endDate":"2025-07-20T00:00:00Z","year":2025,"description":"Летний отпуск"}`, status: 200, save: "vacation"},
+		{method: "POST", route: "/v1/vacation/change-status", path: "/v1/vacation/change-status", body: `{"id":"{vacation}","status":"approved"}`, status: 200},
+		{method: "GET", route: "/v1/vacation/list/:year", path: "/v1/vacation/list/2025", status: 200},
+		{method: "GET", route: "/v1/vacation/list/:user/:year", path: "/v1/vacation/list" + user + "/2025", status: 200},
+		{method: "GET", route: "/v1/vacation/stats/:user/:year", path: "/v1/vacation/stats" + user + "/2025", status: 200},
+		{method: "GET", route: "/v1/vacation/years/:user", path: "/v1/vacation/years" + user, status: 200},
+		{method: "GET", route: "/v1/vacation/:id/document.pdf", path: "/v1/vacation/{vacation}/document.pdf", status: 200},
+
+		{method: "GET", route: "/v1/document/template/list", path: "/v1/document/template/list", status: 200},
+		{method: "POST", route: "/v1/document/template/update", path: "/v1/document/template/update", body: `{"kind":"vacation_application","title":"Заявление на отпуск","body":"Прошу предоставить отпуск с {{.StartDate}} по {{.EndDate}}."}`, status: 200},
+		{method: "GET", route: "/v1/document/template/:kind", path: "/v1/document/template/vacation_application", status: 200},
+		{method: "DELETE", route: "/v1/document/template/:kind", path: "/v1/document/template/vacation_application", status: 200},
+
+		{method: "POST", route: "/v1/schedule/create", path: "/v1/schedule/create", body: `{"name":"Пятидневка","kind":"weekly","hours":[8,8,8,8,8,0,0]}`, status: 201, save: "schedule"},
+		{method: "GET", route: "/v1/schedule/list", path: "/v1/schedule/list", status: 200},
+		{method: "POST", route: "/v1/schedule/assign", path: "/v1/schedule/assign", body: `{` + u + `,"scheduleId":"{schedule}","effectiveFrom":"2025-01-01T00:00:00Z"}`, status: 201, save: "assignment"},
+		{method: "GET", route: "/v1/schedule/user/:user", path: "/v1/schedule/user" + user, status: 200},
+		{method: "GET", route: "/v1/schedule/expected/:user/:month/:year", path: "/v1/schedule/expected" + user + "/5/2025", status: 200},
+		{method: "DELETE", route: "/v1/schedule/unassign/:assignment", path: "/v1/schedule/unassign/{assignment}", status: 200},
+		{method: "DELETE", route: "/v1/schedule/delete/:schedule", path: "/v1/schedule/delete/{schedule}", status: 200},
+
+		{method: "POST", route: "/v1/shift/night-window", path: "/v1/shift/night-window", body: `{"start":"22:00","end":"06:00"}`, status: 200},
+		{method: "GET", route: "/v1/shift/night-window", path: "/v1/shift/night-window", status: 200},
+		{method: "POST", route: "/v1/shift/create", path: "/v1/shift/create", body: `{` + u + `,"day":12,"month":5,"year":2025,"start":"20:00","end":"08:00"}`, status: 201, save: "shift"},
+		{method: "POST", route: "/v1/shift/update", path: "/v1/shift/update", body: `{"id":"{shift}","start":"21:00","end":"09:00"}`, status: 200},
+		{method: "GET", route: "/v1/shift/list/:month/:year", path: "/v1/shift/list/5/2025", status: 200},
+		{method: "GET", route: "/v1/shift/list/:user/:month/:year", path: "/v1/shift/list" + user + "/5/2025", status: 200},
+		{method: "DELETE", route: "/v1/shift/delete/:shift", path: "/v1/shift/delete/{shift}", status: 200},
+
+		{method: "POST", route: "/v1/department/create", path: "/v1/department/create", body: `{"name":"Разработка"}`, status: 201, save: "department"},
+		{method: "POST", route: "/v1/department/assign", path: "/v1/department/assign", body: `{` + u + `,"departmentId":"{department}"}`, status: 200},
+		{method: "GET", route: "/v1/department/list", path: "/v1/department/list", status: 200},
+		{method: "GET", route: "/v1/department/user/:user", path: "/v1/department/user" + user, status: 200},
+		{method: "GET", route: "/v1/department/users/:department", path: "/v1/department/users/{department}", status: 200},
+
+		{method: "POST", route: "/v1/project/create", path: "/v1/project/create", body: `{"code":"TT","name":"TimeTrack","client":"ООО Ромашка"}`, status: 201, save: "project"},
+		{method: "POST", route: "/v1/project/update", path: "/v1/project/update", body: `{"id":"{project}","name":"TimeTrack API","client":"ООО Ромашка","isActive":true}`, status: 200},
+		{method: "GET", route: "/v1/project/list", path: "/v1/project/list", status: 200},
+		{method: "POST", route: "/v1/project/task/create", path: "/v1/project/task/create", body: `{"projectId":"{project}","name":"Бэкенд"}`, status: 201, save: "task"},
+		{method: "POST", route: "/v1/project/task/update", path: "/v1/project/task/update", body: `{"id":"{task}","name":"Бэкенд и API","isActive":true}`, status: 200},
+		{method: "GET", route: "/v1/project/tasks/:project", path: "/v1/project/tasks/{project}", status: 200},
+		{method: "POST", route: "/v1/project/allocate", path: "/v1/project/allocate", body: `{"reportId":"{report}","allocations":[{"projectId":"{project}","taskId":"{task}","hours":4}]}`, status: 200},
+		{method: "GET", route: "/v1/project/allocation/:report", path: "/v1/project/allocation/{report}", status: 200},
+		{method: "GET", route: "/v1/project/totals/:month/:year", path: "/v1/project/totals/5/2025", status: 200},
+		{method: "GET", route: "/v1/project/totals/user/:user/:month/:year", path: "/v1/project/totals/user" + user + "/5/2025", status: 200},
+		{method: "GET", route: "/v1/project/totals/department/:department/:month/:year", path: "/v1/project/totals/department/{department}/5/2025", status: 200},
+
+		{method: "POST", route: "/v1/notify/contact", path: "/v1/notify/contact", body: `{` + u + `,"email":"ivan@example.com","name":"Иван Петров"}`, status: 200},
+		{method: "GET", route: "/v1/notify/contact/:user", path: "/v1/notify/contact" + user, status: 200},
+		{method: "POST", route: "/v1/notify/remind", path: "/v1/notify/remind", status: 200},
+
+		{method: "POST", route: "/v1/bot/link-code", path: "/v1/bot/link-code", status: 201},
+		{method: "POST", route: "/v1/bot/telegram/update", path: "/v1/bot/telegram/update", body: `{"update_id":1,"message":{"message_id":1,"chat":{"id":1001},"text":"/help"}}`, status: 200},
+		{method: "POST", route: "/v1/bot/remind", path: "/v1/bot/remind", status: 200},
+
+		{method: "GET", route: "/v1/directory/users", path: "/v1/directory/users", status: 200},
+		{method: "POST", route: "/v1/directory/sync", path: "/v1/directory/sync", status: 503},
+
+		{method: "GET", route: "/v1/payroll/layout", path: "/v1/payroll/layout", status: 200},
+		{method: "POST", route: "/v1/payroll/layout", path: "/v1/payroll/layout", body: `{"format":"csv","delimiter":";","header":true,"columns":[{"field":"user_id","title":"Сотрудник","width":0,"align":"","decimals":0},{"field":"worked_hours","title":"Часы","width":0,"align":"","decimals":2}]}`, status: 200},
+		{method: "POST", route: "/v1/payroll/close/:month/:year", path: "/v1/payroll/close/5/2025", status: 201},
+		{method: "GET", route: "/v1/payroll/months/:year", path: "/v1/payroll/months/2025", status: 200},
+		{method: "GET", route: "/v1/payroll/list/:month/:year", path: "/v1/payroll/list/5/2025", status: 200},
+		{method: "GET", route: "/v1/payroll/export/:month/:year", path: "/v1/payroll/export/5/2025", status: 200},
+		{method: "POST", route: "/v1/report/create", path: "/v1/report/create", body: `{` + u + `,"day":8,"month":5,"year":2025,"hours":8,"typeSystemName":"work"}`, status: 409},
+		{method: "DELETE", route: "/v1/payroll/close/:month/:year", path: "/v1/payroll/close/5/2025", status: 200},
+
+		{method: "DELETE", route: "/v1/report/delete-entry/:entry", path: "/v1/report/delete-entry/{report}", status: 200},
+		{method: "DELETE", route: "/v1/report/delete/:user/:day/:month/:year", path: "/v1/report/delete" + user + "/6/5/2025", status: 200},
+		{method: "DELETE", route: "/v1/vacation/delete/:vacation", path: "/v1/vacation/delete/{vacation}", status: 200},
+		{method: "DELETE", route: "/v1/webhook/delete/:webhook", path: "/v1/webhook/delete/{webhook}", status: 200},
+
+		{method: "GET", route: "/v1/openapi.json", path: "/v1/openapi.json", status: 200},
+		{method: "GET", route: "/v1/docs", path: "/v1/docs", status: 200},
+		{method: "GET", route: "/v1/auth/oidc/login", path: "/v1/auth/oidc/login", status: 503},
+		{method: "GET", route: "/v1/auth/oidc/callback", path: "/v1/auth/oidc/callback", status: 400},
+		{method: "GET", route: "/v1/auth/me", path: "/v1/auth/me", status: 200},
+		{method: "POST", route: "/v1/report/create", path: "/v1/report/create", body: `{` + u + `,"day":31,"month":6,"year":2025,"typeSystemName":"work"}`, status: 400},
+		{method: "POST", route: "/v1/report/create", path: "/v1/report/create", body: `{` + u + `,"day":2,"month":6,"year":2025,"typeSystemName":"unknown"}`, status: 400},
+		{method: "POST", route: "/v1/report/update", path: "/v1/report/update", body: `{"id":"{report}","hours":8,"typeSystemName":"work"}`, status: 404},
+	}
+
+	saved := map[string]string{"hook": hook.URL}
+	covered := make(map[string]bool)
+	options := &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}
+	for _, tt := range tests {
+		pairs := make([]string, 0, 2*len(saved))
+		for name, value := range saved {
+			pairs = append(pairs, "{"+name+"}", value)
+		}
+		fill := strings.NewReplacer(pairs...)
+		path, body := fill.Replace(tt.path), fill.Replace(tt.body)
+
+		name := tt.method + " " + path
+		covered[tt.method+" "+openapi.Path(tt.route)] = true
+		item := doc.Paths.Find(openapi.Path(tt.route))
+		if item == nil || item.GetOperation(tt.method) == nil {
+			t.Fatalf("%s: %s %s is not in the spec", name, tt.method, tt.route)
+		}
+		route := &routers.Route{
+			Spec:      doc,
+			Path:      openapi.Path(tt.route),
+			PathItem:  item,
+			Method:    tt.method,
+			Operation: item.GetOperation(tt.method),
+		}
+
+		req := httptest.NewRequest(tt.method, path, strings.NewReader(body))
+		req.Header.Set(fiber.HeaderAuthorization, admin)
+		// секрет проверяет только вебхук бота
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", telegramSecret)
+		if body != "" {
+			content := tt.content
+			if content == "" {
+				content = fiber.MIMEApplicationJSON
+			}
+			req.Header.Set(fiber.HeaderContentType, content)
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: params(tt.route, path),
+			Route:      route,
+			Options:    options,
+		}
+		// ответы 4xx проверяют отказ обработчика на запросы, которые проходят схему
+		if err := openapi3filter.ValidateRequest(context.Background(), input); err != nil {
+			t.Errorf("%s: request does not match the spec: %v", name, err)
+			continue
+		}
+		req.Body = io.NopCloser(strings.NewReader(body))
+
+		resp, err := f.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s: status = %d, want %d: %s", name, resp.StatusCode, tt.status, respBody)
+			continue
+		}
+		if tt.status < 300 && route.Operation.Responses.Status(tt.status) == nil {
+			t.Errorf("%s: status %d is not documented", name, tt.status)
+			continue
+		}
+
+		err = openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 resp.StatusCode,
+			Header:                 resp.Header,
+			Body:                   io.NopCloser(bytes.NewReader(respBody)),
+			Options:                options,
+		})
+		if err != nil {
+			t.Errorf("%s: response does not match the spec: %v\n%s", name, err, respBody)
+		}
+
+		if tt.save != "" {
+			id := responseID(respBody)
+			if id == "" {
+				t.Fatalf("%s: no id to save as {%s}: %s", name, tt.save, respBody)
+			}
+			saved[tt.save] = id
+		}
+	}
+
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			if !covered[method+" "+path] {
+				t.Errorf("%s %s has no contract step", method, path)
+			}
+		}
+	}
+}
+
+// responseID - поле id ответа-объекта или первого элемента ответа-списка
+func responseID(body []byte) string {
+	var object struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(body, &object) == nil {
+		return object.ID
+	}
+	var list []struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(body, &list) == nil && len(list) > 0 {
+		return list[0].ID
+	}
+	return ""
+}
+
+// TestAdminRoutes - маршруты, которые спецификация отмечает ролью admin, отклоняют
+// запрос без сессии и сессию с ролью user, хотя AUTH_REQUIRED не включен
+func TestAdminRoutes(t *testing.T) {
+	f := newTestApp(t)
+	doc := loadSpec(t, f)
+	user := bearer(t, auth.RoleUser)
+
+	admin := 0
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			if !strings.Contains(op.Description, "admin") {
+				continue
+			}
+			admin++
+			// значения параметров не важны: роль проверяется до обработчика
+			target := strings.NewReplacer("{", "", "}", "").Replace(path)
+			for header, want := range map[string]int{"": http.StatusUnauthorized, user: http.StatusForbidden} {
+				req := httptest.NewRequest(method, target, nil)
+				if header != "" {
+					req.Header.Set(fiber.HeaderAuthorization, header)
+				}
+				resp, err := f.Test(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if resp.StatusCode != want {
+					t.Errorf("%s %s with session %t: status = %d, want %d", method, path, header != "", resp.StatusCode, want)
+				}
+			}
+		}
+	}
+	if admin == 0 {
+		t.Fatal("no admin routes in the spec")
+	}
+}
+
+// TestBotNotConfigured - без токена Telegram вебхука бота нет, а код привязки
+// выдается только для сотрудника своей сессии
+func TestBotNotConfigured(t *testing.T) {
+	f := newTestApp(t, func(cfg *config.Config) { cfg.Telegram = config.TelegramConfig{} })
+	doc := loadSpec(t, f)
+
+	if doc.Paths.Find("/v1/bot/telegram/update") != nil {
+		t.Error("telegram webhook is in the spec without a token")
+	}
+	update := httptest.NewRequest(http.MethodPost, "/v1/bot/telegram/update",
+		strings.NewReader(`{"update_id":1,"message":{"message_id":1,"chat":{"id":1001},"text":"/link CODE"}}`))
+	update.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if resp, err := f.Test(update); err != nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("telegram webhook without a token: %v, %v", resp.StatusCode, err)
+	}
+
+	link := httptest.NewRequest(http.MethodPost, "/v1/bot/link-code", strings.NewReader(`{"userId":"`+userID+`"}`))
+	link.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if resp, err := f.Test(link); err != nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("link code without a session: %v, %v", resp.StatusCode, err)
+	}
+
+	link = httptest.NewRequest(http.MethodPost, "/v1/bot/link-code", nil)
+	link.Header.Set(fiber.HeaderAuthorization, bearer(t, auth.RoleUser))
+	resp, err := f.Test(link)
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("link code: %v, %v", resp.StatusCode, err)
+	}
+	var code repo.ReportChatLinkCode
+	if err := json.NewDecoder(resp.Body).Decode(&code); err != nil || code.UserID != userID {
+		t.Fatalf("link code = %+v, %v", code, err)
+	}
+}
+
+// TestBodyLimit - импорт принимает файл больше BodyLimit, остальные маршруты - нет
+func TestBodyLimit(t *testing.T) {
+	f := newTestApp(t)
+	large := strings.Repeat("x", fiber.DefaultBodyLimit)
+
+	file := "user_id,date,hours,type,comment\n" + userID + ",2025-05-07,8,work," + large + "\n"
+	req := httptest.NewRequest(http.MethodPost, "/v1/report/import?dryRun=true", strings.NewReader(file))
+	req.Header.Set(fiber.HeaderContentType, openapi.MIMECSV)
+	req.Header.Set(fiber.HeaderAuthorization, bearer(t, auth.RoleAdmin))
+	resp, err := f.Test(req, -1)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("large import: %v, %v", resp.StatusCode, err)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/vacation/create", strings.NewReader(`{"comment":"`+large+`"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderAuthorization, bearer(t, auth.RoleAdmin))
+	if resp, err := f.Test(req, -1); err != nil || resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Fatalf("large body: %v, %v", resp.StatusCode, err)
+	}
+}
+
+// TestSpecExport - выгрузка в CSV документирована у маршрутов export.Respond
+func TestSpecExport(t *testing.T) {
+	f := newTestApp(t)
+	doc := loadSpec(t, f)
+
+	resp, err := f.Test(httptest.NewRequest(http.MethodGet, "/v1/calendar/list/2025?format=csv", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	contentType, _, _ := strings.Cut(resp.Header.Get(fiber.HeaderContentType), ";")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+
+	op := doc.Paths.Find("/v1/calendar/list/{year}").Get
+	if op.Responses.Status(http.StatusOK).Value.Content.Get(contentType) == nil {
+		t.Fatalf("content type %q is not documented", contentType)
+	}
+	var formats []string
+	for _, p := range op.Parameters {
+		if p.Value.Name == "format" {
+			for _, v := range p.Value.Schema.Value.Enum {
+				formats = append(formats, v.(string))
+			}
+		}
+	}
+	if !slices.Contains(formats, "csv") {
+		t.Fatalf("format enum = %v", formats)
+	}
+}
+
+// params - значения параметров пути Fiber route в пути path
+func params(route, path string) map[string]string {
+	out := make(map[string]string)
+	values := strings.Split(path, "/")
+	for i, segment := range strings.Split(route, "/") {
+		if name, ok := strings.CutPrefix(segment, ":"); ok && i < len(values) {
+			// параметр с расширением: :year.xlsx -> year
+			name, ext, _ := strings.Cut(name, ".")
+			out[name] = strings.TrimSuffix(values[i], "."+ext)
+		}
+	}
+	return out
+}
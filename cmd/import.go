@@ -0,0 +1,65 @@
+package main
+
+import (
+	"TimeTrack/internal/importer"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// runImport - подкоманда импорта табелей из CSV:
+//
+//	go run ./cmd import -file timesheets.csv [-dry-run] [-batch 500]
+//
+// Итог импорта выводится в stdout в формате JSON.
+func runImport(st store, args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	path := fs.String("file", "", "CSV file with columns user, date, hours, type (\"-\" for stdin)")
+	dryRun := fs.Bool("dry-run", false, "validate the file without writing anything")
+	batch := fs.Int("batch", importer.DefaultBatchSize, "rows per transaction")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *path == "" {
+		fmt.Fprintln(os.Stderr, "import: -file is required")
+		fs.Usage()
+		return 2
+	}
+
+	var input io.Reader = os.Stdin
+	if *path != "-" {
+		f, err := os.Open(*path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		input = f
+	}
+
+	service := importer.NewService(st, st)
+	result, err := service.Import(context.Background(), input, importer.Options{
+		DryRun:    *dryRun,
+		BatchSize: *batch,
+	})
+
+	if result != nil {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(result)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import: %v\n", err)
+		return 1
+	}
+	if len(result.Errors) > 0 {
+		return 1
+	}
+
+	return 0
+}
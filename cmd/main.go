@@ -1,42 +1,98 @@
 package main
 
 import (
-	"TimeTrack/internal/env"
-	"database/sql"
+	"TimeTrack/internal/config"
+	"TimeTrack/internal/metrics"
+	"TimeTrack/internal/tracing"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 )
 
-func main() {
-	env := env.Env{}
-	env.Init()
+// tracingFlushTimeout - сколько ждать отправки spans при остановке
+const tracingFlushTimeout = 5 * time.Second
 
-	cfg := config{
-		addr: env.GetAddr(),
-		db: dbConfig{
-			dsn: env.GetDbString(),
-		},
+func main() {
+	cfg, args, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
 	}
 
-	db, err := sql.Open("mysql", cfg.db.dsn)
+	db, store, err := openStore(cfg.DB)
 	if err != nil {
-		panic("error con database")
+		fmt.Fprintf(os.Stderr, "database: %v\n", err)
+		os.Exit(1)
 	}
 
 	defer db.Close()
 
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
+		Level: cfg.LogLevel,
 	}))
 
+	if len(args) > 0 {
+		var code int
+		switch args[0] {
+		case "import":
+			code = runImport(store, args[1:])
+		case "remind":
+			code = runRemind(store, cfg, logger, args[1:])
+		case "sync-ldap":
+			code = runSync(store, cfg)
+		default:
+			fmt.Fprintf(os.Stderr, "unknown command %q\n", args[0])
+			code = 2
+		}
+		db.Close()
+		os.Exit(code)
+	}
+
+	// запросы сервера к базе измеряются для /metrics и трассируются,
+	// подкоманды работают без метрик и трассировки
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.TracingEndpoint)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tracing: %v\n", err)
+		os.Exit(1)
+	}
+
+	m := metrics.New(db)
 	app := application{
-		config: cfg,
-		db:     db,
-		logger: logger,
+		config:  cfg,
+		db:      db,
+		store:   store.instrument(m),
+		metrics: m,
+		logger:  logger,
+	}
+
+	f, err := app.mount()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mount: %v\n", err)
+		os.Exit(1)
 	}
 
 	// Run the application
-	app.run(app.mount())
+	runErr := app.run(f)
+
+	// отправить накопленные spans до выхода
+	ctx, cancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Warn("failed to flush traces", slog.String("error", err.Error()))
+	}
+	cancel()
+
+	if runErr != nil {
+		logger.Error("server stopped", slog.String("error", runErr.Error()))
+		db.Close()
+		os.Exit(1)
+	}
 }
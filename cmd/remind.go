@@ -0,0 +1,42 @@
+package main
+
+import (
+	"TimeTrack/internal/config"
+	"TimeTrack/internal/notify"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+// runRemind - подкоманда рассылки напоминаний о предстоящих отпусках, для запуска раз в день по cron:
+//
+//	go run ./cmd remind [-days 3]
+//
+// Итог рассылки выводится в stdout в формате JSON.
+func runRemind(st store, cfg *config.Config, logger *slog.Logger, args []string) int {
+	fs := flag.NewFlagSet("remind", flag.ContinueOnError)
+	days := fs.Int("days", notify.DefaultRemindDays, "days before the vacation starts")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	service := notify.NewService(st, st, newMailSender(cfg.SMTP, logger), logger)
+	result, err := service.Remind(context.Background(), *days)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "remind: %v\n", err)
+		return 1
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(result)
+
+	if result.Failed > 0 {
+		return 1
+	}
+
+	return 0
+}
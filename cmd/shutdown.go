@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// killDelay - запас сверх SHUTDOWN_TIMEOUT, после которого главный процесс
+// Prefork принудительно завершает дочерние
+const killDelay = 5 * time.Second
+
+// run принимает запросы до SIGTERM или SIGINT, затем перестает принимать новые
+// соединения и ждет завершения начатых запросов (не дольше SHUTDOWN_TIMEOUT).
+// При Prefork главный процесс только запускает дочерние и передает им сигнал.
+// Фоновые задачи сервисов работают до сигнала, run дожидается их завершения.
+func (app *application) run(f *fiber.App) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	stopBackground := app.startBackground(ctx)
+	defer stopBackground()
+
+	if app.config.Prefork && !fiber.IsChild() {
+		return app.supervise(ctx)
+	}
+
+	errc := make(chan error, 1)
+	go func() { errc <- app.listen(f) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	app.logger.Info("shutting down", slog.Int("pid", os.Getpid()))
+	var err error
+	if app.config.ShutdownTimeout > 0 {
+		err = f.ShutdownWithTimeout(app.config.ShutdownTimeout)
+	} else {
+		err = f.Shutdown()
+	}
+	if err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+
+	return <-errc
+}
+
+// startBackground запускает фоновые задачи сервисов до отмены ctx. При Prefork - только
+// в главном процессе, чтобы дочерние не дублировали их. Возвращает функцию, которая
+// останавливает задачи и ждет их завершения.
+func (app *application) startBackground(ctx context.Context) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	var tasks sync.WaitGroup
+	if !fiber.IsChild() {
+		for _, task := range app.background {
+			tasks.Go(func() { task(ctx) })
+		}
+	}
+
+	return func() {
+		cancel()
+		tasks.Wait()
+	}
+}
+
+type childExit struct {
+	pid   int
+	state *os.ProcessState
+}
+
+// supervise - главный процесс при Prefork: запускает по дочернему процессу на
+// ядро (они принимают соединения на одном порту) и ждет сигнала. Встроенный в
+// Fiber главный процесс при остановке убивает дочерние, не дав им ответить на
+// начатые запросы; здесь они получают SIGTERM и завершаются сами. Если дочерний
+// процесс завершился без сигнала, останавливаются остальные и возвращается ошибка.
+func (app *application) supervise(ctx context.Context) error {
+	n := runtime.GOMAXPROCS(0)
+	children := make(map[int]*os.Process, n)
+	exited := make(chan childExit, n)
+
+	var err error
+	for range n {
+		cmd := exec.Command(os.Args[0], os.Args[1:]...)
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		cmd.Env = append(os.Environ(), "FIBER_PREFORK_CHILD=1")
+		if err = cmd.Start(); err != nil {
+			err = fmt.Errorf("start child process: %w", err)
+			break
+		}
+		children[cmd.Process.Pid] = cmd.Process
+		go func() {
+			cmd.Wait()
+			exited <- childExit{pid: cmd.Process.Pid, state: cmd.ProcessState}
+		}()
+	}
+
+	if err == nil {
+		app.logger.Info("server started",
+			slog.String("addr", app.config.Addr),
+			slog.Int("processes", len(children)),
+		)
+
+		select {
+		case <-ctx.Done():
+			app.logger.Info("shutting down", slog.Int("processes", len(children)))
+		case e := <-exited:
+			delete(children, e.pid)
+			err = fmt.Errorf("child process %d stopped: %s", e.pid, e.state)
+		}
+	}
+
+	signalAll := func(sig os.Signal) {
+		for _, process := range children {
+			process.Signal(sig)
+		}
+	}
+	signalAll(syscall.SIGTERM)
+
+	var kill <-chan time.Time
+	if app.config.ShutdownTimeout > 0 {
+		kill = time.After(app.config.ShutdownTimeout + killDelay)
+	}
+	for len(children) > 0 {
+		select {
+		case e := <-exited:
+			delete(children, e.pid)
+		case <-kill:
+			app.logger.Warn("child processes did not stop in time", slog.Int("processes", len(children)))
+			signalAll(os.Kill)
+			kill = nil
+		}
+	}
+
+	return err
+}
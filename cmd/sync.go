@@ -0,0 +1,30 @@
+package main
+
+import (
+	"TimeTrack/internal/config"
+	"TimeTrack/internal/directory"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// runSync - подкоманда разовой синхронизации сотрудников из LDAP:
+//
+//	go run ./cmd sync-ldap
+//
+// Итог выводится в stdout в формате JSON.
+func runSync(st store, cfg *config.Config) int {
+	service := directory.NewService(st, st, newDirectory(cfg.LDAP))
+	result, err := service.Sync(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sync-ldap: %v\n", err)
+		return 1
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(result)
+
+	return 0
+}
@@ -0,0 +1,858 @@
+// Package contract - общий набор тестов хранилища: любая реализация repo.Querier
+// (MySQL, PostgreSQL, SQLite, хранилище в памяти) должна проходить его одинаково.
+// Проверяются соединения, COALESCE, сортировка, агрегаты, значения по умолчанию
+// из схемы, сравнение строк без учета регистра и транзакции.
+package contract
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+// Store - проверяемое хранилище
+type Store interface {
+	repo.Querier
+	repo.Streamer
+	repo.TxBeginner
+}
+
+// Tables - таблицы схемы; Reset очищает их перед каждым тестом
+var Tables = []string{
+	"report_allocation",
+	"report_calendar",
+	"report_chat_link",
+	"report_chat_link_code",
+	"report_department",
+	"report_directory_user",
+	"report_document_template",
+	"report_month_close",
+	"report_project",
+	"report_schedule",
+	"report_schedule_day",
+	"report_setting",
+	"report_shift",
+	"report_standard",
+	"report_task",
+	"report_type",
+	"report_user",
+	"report_user_contact",
+	"report_user_department",
+	"report_user_schedule",
+	"report_vacation",
+	"report_webhook",
+	"report_webhook_delivery",
+}
+
+// Reset приводит базу к начальному состоянию: пустые таблицы и строка настроек
+// со значениями по умолчанию. Запросы одинаковы для всех СУБД.
+func Reset(t *testing.T, db *sql.DB) {
+	t.Helper()
+	for _, table := range Tables {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			t.Fatalf("clean %s: %v", table, err)
+		}
+	}
+	if _, err := db.Exec("INSERT INTO report_setting (id) VALUES (1)"); err != nil {
+		t.Fatalf("create settings: %v", err)
+	}
+}
+
+// Run выполняет набор. open возвращает пустое хранилище в начальном состоянии
+// (см. Reset) и вызывается заново для каждого теста.
+func Run(t *testing.T, open func(t *testing.T) Store) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, ctx context.Context, s Store)
+	}{
+		{"Types", testTypes},
+		{"Calendar", testCalendar},
+		{"ReportUser", testReportUser},
+		{"ReportUserUnique", testReportUserUnique},
+		{"MonthClose", testMonthClose},
+		{"Allocations", testAllocations},
+		{"Vacations", testVacations},
+		{"Schedules", testSchedules},
+		{"Settings", testSettings},
+		{"SchemaVersion", testSchemaVersion},
+		{"Departments", testDepartments},
+		{"Directory", testDirectory},
+		{"Webhooks", testWebhooks},
+		{"WebhookRetries", testWebhookRetries},
+		{"ChatLinkCodes", testChatLinkCodes},
+		{"TxCommit", testTxCommit},
+		{"TxRollback", testTxRollback},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, context.Background(), open(t))
+		})
+	}
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+// sameDate сравнивает значения столбца DATE без учета часового пояса драйвера
+func sameDate(a, b time.Time) bool {
+	return a.Format(time.DateOnly) == b.Format(time.DateOnly)
+}
+
+func createTypes(t *testing.T, ctx context.Context, s Store) {
+	t.Helper()
+	for _, rt := range []repo.CreateTypeParams{
+		{ID: "t-work", Name: "Явка", SystemName: "work", Code: "Я"},
+		{ID: "t-medical", Name: "Больничный", SystemName: "medical", Code: "Б"},
+		{ID: "t-weekend", Name: "выходной", SystemName: "weekend", Code: "В"},
+		{ID: "t-holiday", Name: "Праздник", SystemName: "holiday", Code: "П"},
+	} {
+		must(t, s.CreateType(ctx, rt))
+	}
+}
+
+func testTypes(t *testing.T, ctx context.Context, s Store) {
+	createTypes(t, ctx, s)
+
+	all, err := s.GetTypeAll(ctx)
+	must(t, err)
+	var names []string
+	for _, rt := range all {
+		names = append(names, rt.Name)
+	}
+	want := []string{"Больничный", "выходной", "Праздник", "Явка"}
+	if len(names) != len(want) {
+		t.Fatalf("names = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("names = %v, want %v (ORDER BY name without case)", names, want)
+		}
+	}
+
+	rt, err := s.GetTypeBySystemName(ctx, "WORK")
+	must(t, err)
+	if rt.ID != "t-work" {
+		t.Fatalf("by system name = %+v", rt)
+	}
+
+	must(t, s.UpdateType(ctx, repo.UpdateTypeParams{ID: "t-work", Name: "Работа", SystemName: "work", Code: "Р"}))
+	rt, err = s.GetTypeById(ctx, "t-work")
+	must(t, err)
+	if rt.Name != "Работа" || rt.Code != "Р" {
+		t.Fatalf("updated = %+v", rt)
+	}
+
+	must(t, s.DeleteType(ctx, "t-work"))
+	if _, err := s.GetTypeById(ctx, "t-work"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("deleted: err = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func testCalendar(t *testing.T, ctx context.Context, s Store) {
+	createTypes(t, ctx, s)
+	for _, c := range []repo.CreateCalendarDayParams{
+		{ID: "c-0509", Day: 9, Month: 5, Year: 2025, IsPaidVacation: true, TypeID: "t-holiday"},
+		{ID: "c-0501", Day: 1, Month: 5, Year: 2025, Description: sql.NullString{String: "Праздник Весны и Труда", Valid: true}, TypeID: "t-holiday"},
+		{ID: "c-0103", Day: 3, Month: 1, Year: 2025, TypeID: "t-weekend"},
+		{ID: "c-2024", Day: 1, Month: 5, Year: 2024, TypeID: "t-holiday"},
+	} {
+		must(t, s.CreateCalendarDay(ctx, c))
+	}
+
+	may, err := s.GetCalendarDays(ctx, repo.GetCalendarDaysParams{Month: 5, Year: 2025})
+	must(t, err)
+	if len(may) != 2 || may[0].ID != "c-0501" || may[1].ID != "c-0509" {
+		t.Fatalf("May = %+v", may)
+	}
+	if may[0].Description != "Праздник Весны и Труда" || may[1].Description != "" {
+		t.Fatalf("descriptions = %q, %q", may[0].Description, may[1].Description)
+	}
+	if may[0].IsPaidVacation || !may[1].IsPaidVacation {
+		t.Fatalf("is_paid_vacation = %v, %v", may[0].IsPaidVacation, may[1].IsPaidVacation)
+	}
+	if may[0].TypeSystemName != "holiday" || may[0].TypeName != "Праздник" {
+		t.Fatalf("type = %q/%q", may[0].TypeSystemName, may[0].TypeName)
+	}
+
+	year, err := s.GetCalendarDaysAll(ctx, 2025)
+	must(t, err)
+	if len(year) != 3 || year[0].ID != "c-0103" || year[2].ID != "c-0509" {
+		t.Fatalf("year = %+v", year)
+	}
+
+	holidays, err := s.GetCalendarDaysAllByType(ctx, repo.GetCalendarDaysAllByTypeParams{Year: 2025, SystemName: "Holiday"})
+	must(t, err)
+	if len(holidays) != 2 {
+		t.Fatalf("holidays = %d, want 2", len(holidays))
+	}
+
+	day, err := s.GetCalendarDay(ctx, repo.GetCalendarDayParams{Day: 9, Month: 5, Year: 2025})
+	must(t, err)
+	if day.Description.Valid {
+		t.Fatalf("GetCalendarDay description = %+v, want NULL", day.Description)
+	}
+
+	exists, err := s.CheckCalendarDayExists(ctx, repo.CheckCalendarDayExistsParams{Day: 1, Month: 5, Year: 2025})
+	must(t, err)
+	if exists != 1 {
+		t.Fatalf("exists = %d, want 1", exists)
+	}
+
+	must(t, s.UpdateCalendarDay(ctx, repo.UpdateCalendarDayParams{ID: "c-0509", Description: sql.NullString{String: "Перенос", Valid: true}, TypeID: "t-weekend"}))
+	day, err = s.GetCalendarDay(ctx, repo.GetCalendarDayParams{Day: 9, Month: 5, Year: 2025})
+	must(t, err)
+	if day.Description.String != "Перенос" || day.TypeSystemName != "weekend" {
+		t.Fatalf("updated = %+v", day)
+	}
+
+	// Дни с удаленным типом пропадают из выборок (INNER JOIN)
+	must(t, s.DeleteType(ctx, "t-weekend"))
+	year, err = s.GetCalendarDaysAll(ctx, 2025)
+	must(t, err)
+	if len(year) != 1 {
+		t.Fatalf("year without weekend type = %d rows, want 1", len(year))
+	}
+}
+
+func createReport(t *testing.T, ctx context.Context, s Store, id, userID string, day int32, hours float64, typeID string) {
+	t.Helper()
+	must(t, s.CreateReportUser(ctx, repo.CreateReportUserParams{
+		ID: id, UserID: userID, Day: day, Month: 5, Year: 2025, Hours: hours, TypeID: typeID,
+	}))
+}
+
+// testReportUserUnique - вторая отметка того же вида за день нарушает уникальный индекс
+func testReportUserUnique(t *testing.T, ctx context.Context, s Store) {
+	createTypes(t, ctx, s)
+	createReport(t, ctx, s, "r-1", "u-1", 5, 8, "t-work")
+	createReport(t, ctx, s, "r-2", "u-1", 5, 2, "t-medical")
+	createReport(t, ctx, s, "r-3", "u-1", 6, 8, "t-work")
+
+	err := s.CreateReportUser(ctx, repo.CreateReportUserParams{
+		ID: "r-4", UserID: "u-1", Day: 5, Month: 5, Year: 2025, Hours: 1, TypeID: "t-work",
+	})
+	if !repo.IsDuplicateKey(err) {
+		t.Fatalf("duplicate insert: err = %v, want duplicate key", err)
+	}
+
+	err = s.UpdateReportUser(ctx, repo.UpdateReportUserParams{ID: "r-2", Hours: 2, TypeID: "t-work"})
+	if !repo.IsDuplicateKey(err) {
+		t.Fatalf("duplicate update: err = %v, want duplicate key", err)
+	}
+	must(t, s.UpdateReportUser(ctx, repo.UpdateReportUserParams{ID: "r-1", Hours: 7, TypeID: "t-work"}))
+}
+
+// testMonthClose - закрытие месяца, повторное закрытие нарушает уникальный индекс
+func testMonthClose(t *testing.T, ctx context.Context, s Store) {
+	if _, err := s.GetMonthClose(ctx, repo.GetMonthCloseParams{Month: 5, Year: 2025}); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("open month: err = %v, want sql.ErrNoRows", err)
+	}
+
+	must(t, s.CloseMonth(ctx, repo.CloseMonthParams{Month: 5, Year: 2025}))
+	must(t, s.CloseMonth(ctx, repo.CloseMonthParams{Month: 4, Year: 2025}))
+	must(t, s.CloseMonth(ctx, repo.CloseMonthParams{Month: 12, Year: 2024}))
+	if err := s.CloseMonth(ctx, repo.CloseMonthParams{Month: 5, Year: 2025}); !repo.IsDuplicateKey(err) {
+		t.Fatalf("close twice: err = %v, want duplicate key", err)
+	}
+
+	closed, err := s.GetMonthClose(ctx, repo.GetMonthCloseParams{Month: 5, Year: 2025})
+	must(t, err)
+	if closed.Month != 5 || closed.Year != 2025 || closed.CloseAt.IsZero() {
+		t.Fatalf("closed month = %+v", closed)
+	}
+
+	months, err := s.GetMonthClosesByYear(ctx, 2025)
+	must(t, err)
+	if len(months) != 2 || months[0].Month != 4 || months[1].Month != 5 {
+		t.Fatalf("closed months = %+v, want April and May", months)
+	}
+
+	must(t, s.ReopenMonth(ctx, repo.ReopenMonthParams{Month: 5, Year: 2025}))
+	if _, err := s.GetMonthClose(ctx, repo.GetMonthCloseParams{Month: 5, Year: 2025}); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("reopened month: err = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func testReportUser(t *testing.T, ctx context.Context, s Store) {
+	createTypes(t, ctx, s)
+	const user = "u-1"
+
+	createReport(t, ctx, s, "r-1", user, 5, 7.5, "t-work")
+	createReport(t, ctx, s, "r-2", user, 5, 0.25, "t-medical")
+	createReport(t, ctx, s, "r-3", user, 6, 8, "t-medical")
+	createReport(t, ctx, s, "r-4", user, 3, 4, "t-weekend")
+	createReport(t, ctx, s, "r-5", "u-2", 5, 8, "t-work")
+	must(t, s.CreateReportUser(ctx, repo.CreateReportUserParams{
+		ID: "r-6", UserID: user, Day: 7, Month: 5, Year: 2025, Hours: 8, TypeID: "t-work",
+		StartMinute: sql.NullInt32{Int32: 1080, Valid: true}, EndMinute: sql.NullInt32{Int32: 120, Valid: true}, NightHours: 4,
+	}))
+
+	month, err := s.GetReportUserForMonth(ctx, repo.GetReportUserForMonthParams{UserID: user, Month: 5, Year: 2025})
+	must(t, err)
+	if len(month) != 5 || month[0].Day != 3 || month[4].Day != 7 {
+		t.Fatalf("month = %+v", month)
+	}
+	if last := month[4]; !last.StartMinute.Valid || last.StartMinute.Int32 != 1080 || last.NightHours != 4 {
+		t.Fatalf("interval = %+v", last)
+	}
+	if month[0].StartMinute.Valid {
+		t.Fatalf("start_minute = %+v, want NULL", month[0].StartMinute)
+	}
+
+	day, err := s.GetReportUserForDay(ctx, repo.GetReportUserForDayParams{UserID: user, Day: 5, Month: 5, Year: 2025})
+	must(t, err)
+	if len(day) != 2 || day[0].TypeName != "Больничный" || day[1].TypeName != "Явка" {
+		t.Fatalf("day = %+v", day)
+	}
+
+	total, err := s.GetReportUserDayTotalHours(ctx, repo.GetReportUserDayTotalHoursParams{UserID: user, Day: 5, Month: 5, Year: 2025})
+	must(t, err)
+	if total != 7.75 {
+		t.Fatalf("day total = %v, want 7.75", total)
+	}
+	empty, err := s.GetReportUserDayTotalHours(ctx, repo.GetReportUserDayTotalHoursParams{UserID: user, Day: 20, Month: 5, Year: 2025})
+	must(t, err)
+	if empty != 0 {
+		t.Fatalf("empty day total = %v, want 0", empty)
+	}
+
+	monthTotal, err := s.GetReportUserTotalHours(ctx, repo.GetReportUserTotalHoursParams{UserID: user, Month: 5, Year: 2025})
+	must(t, err)
+	if monthTotal != 27.75 {
+		t.Fatalf("month total = %v, want 27.75", monthTotal)
+	}
+
+	night, err := s.GetReportUserNightHours(ctx, repo.GetReportUserNightHoursParams{UserID: user, Month: 5, Year: 2025})
+	must(t, err)
+	if night != 4 {
+		t.Fatalf("night hours = %v, want 4", night)
+	}
+
+	work, err := s.GetReportUserCountWork(ctx, repo.GetReportUserCountWorkParams{UserID: user, Month: 5, Year: 2025})
+	must(t, err)
+	if work != 3 {
+		t.Fatalf("work days = %d, want 3 (days 3, 5, 7)", work)
+	}
+
+	medical, err := s.GetReportUserCountByType(ctx, repo.GetReportUserCountByTypeParams{UserID: user, Month: 5, Year: 2025, TypeID: "t-medical"})
+	must(t, err)
+	if medical != 2 {
+		t.Fatalf("medical days = %d, want 2", medical)
+	}
+
+	stats, err := s.GetReportUserStatsByType(ctx, repo.GetReportUserStatsByTypeParams{UserID: user, Month: 5, Year: 2025})
+	must(t, err)
+	if len(stats) != 3 || stats[0].TypeSystemName != "medical" || stats[2].TypeSystemName != "work" {
+		t.Fatalf("stats = %+v", stats)
+	}
+	if stats[0].DaysCount != 2 || stats[0].TotalHours != 8.25 || stats[2].DaysCount != 2 || stats[2].TotalHours != 15.5 {
+		t.Fatalf("stats = %+v", stats)
+	}
+
+	all, err := s.GetReportUserForMonthAll(ctx, repo.GetReportUserForMonthAllParams{Month: 5, Year: 2025})
+	must(t, err)
+	if len(all) != 6 || all[0].UserID != user || all[5].UserID != "u-2" || all[0].TypeCode != "В" {
+		t.Fatalf("month all = %+v", all)
+	}
+
+	exists, err := s.CheckReportUserTypeExists(ctx, repo.CheckReportUserTypeExistsParams{UserID: "U-1", Day: 5, Month: 5, Year: 2025, TypeID: "t-work"})
+	must(t, err)
+	if exists != 1 {
+		t.Fatalf("type exists = %d, want 1", exists)
+	}
+
+	must(t, s.UpdateReportUser(ctx, repo.UpdateReportUserParams{ID: "r-1", Hours: 6.5, TypeID: "t-work"}))
+	entry, err := s.GetReportUserById(ctx, "r-1")
+	must(t, err)
+	if entry.Hours != 6.5 || entry.TypeName != "Явка" {
+		t.Fatalf("updated = %+v", entry)
+	}
+
+	tx, err := s.Begin(ctx)
+	must(t, err)
+	locked, err := tx.GetReportUserByIdForUpdate(ctx, "r-1")
+	must(t, err)
+	if locked.Hours != 6.5 || locked.TypeSystemName != "work" {
+		t.Fatalf("locked = %+v", locked)
+	}
+	must(t, tx.LockReportUserDay(ctx, repo.LockReportUserDayParams{UserID: user, Day: 5, Month: 5, Year: 2025}))
+	must(t, tx.Rollback())
+
+	must(t, s.DeleteReportUser(ctx, repo.DeleteReportUserParams{UserID: user, Day: 5, Month: 5, Year: 2025}))
+	count, err := s.CheckReportUserExists(ctx, repo.CheckReportUserExistsParams{UserID: user, Day: 5, Month: 5, Year: 2025})
+	must(t, err)
+	if count != 0 {
+		t.Fatalf("after delete = %d, want 0", count)
+	}
+	if _, err := s.GetReportUserById(ctx, "r-5"); err != nil {
+		t.Fatalf("other user's entry was deleted: %v", err)
+	}
+}
+
+func testAllocations(t *testing.T, ctx context.Context, s Store) {
+	createTypes(t, ctx, s)
+	createReport(t, ctx, s, "r-1", "u-1", 5, 8, "t-work")
+	createReport(t, ctx, s, "r-2", "u-2", 5, 8, "t-work")
+	createReport(t, ctx, s, "r-3", "u-1", 6, 8, "t-work")
+
+	must(t, s.CreateProject(ctx, repo.CreateProjectParams{ID: "p-b", Code: "beta", Name: "Beta", IsActive: true}))
+	must(t, s.CreateProject(ctx, repo.CreateProjectParams{ID: "p-a", Code: "ALPHA", Name: "Alpha", Client: sql.NullString{String: "ООО Ромашка", Valid: true}, IsActive: true}))
+	must(t, s.CreateTask(ctx, repo.CreateTaskParams{ID: "k-1", ProjectID: "p-a", Name: "Разработка", IsActive: true}))
+
+	projects, err := s.GetProjects(ctx)
+	must(t, err)
+	if len(projects) != 2 || projects[0].ID != "p-a" || projects[0].Client != "ООО Ромашка" || projects[1].Client != "" {
+		t.Fatalf("projects = %+v", projects)
+	}
+
+	codeExists, err := s.CheckProjectCodeExists(ctx, "Alpha")
+	must(t, err)
+	if codeExists != 1 {
+		t.Fatalf("code exists = %d, want 1", codeExists)
+	}
+
+	for _, a := range []repo.CreateAllocationParams{
+		{ID: "a-1", ReportID: "r-1", ProjectID: "p-b", Hours: 2.5},
+		{ID: "a-2", ReportID: "r-1", ProjectID: "p-a", TaskID: sql.NullString{String: "k-1", Valid: true}, Hours: 5.5},
+		{ID: "a-3", ReportID: "r-2", ProjectID: "p-a", Hours: 8},
+		{ID: "a-4", ReportID: "r-3", ProjectID: "p-a", Hours: 8},
+	} {
+		must(t, s.CreateAllocation(ctx, a))
+	}
+
+	byReport, err := s.GetAllocationsByReport(ctx, "r-1")
+	must(t, err)
+	if len(byReport) != 2 || byReport[0].ProjectCode != "ALPHA" || byReport[0].TaskName != "Разработка" || byReport[1].TaskName != "" {
+		t.Fatalf("allocations = %+v", byReport)
+	}
+
+	totals, err := s.GetProjectTotalsByMonth(ctx, repo.GetProjectTotalsByMonthParams{Month: 5, Year: 2025})
+	must(t, err)
+	if len(totals) != 2 || totals[0].ProjectID != "p-a" || totals[0].TotalHours != 21.5 || totals[1].TotalHours != 2.5 {
+		t.Fatalf("month totals = %+v", totals)
+	}
+
+	must(t, s.CreateDepartment(ctx, repo.CreateDepartmentParams{ID: "d-1", Name: "Разработка"}))
+	must(t, s.CreateUserDepartment(ctx, repo.CreateUserDepartmentParams{UserID: "u-1", DepartmentID: "d-1"}))
+	byDepartment, err := s.GetProjectTotalsByDepartment(ctx, repo.GetProjectTotalsByDepartmentParams{DepartmentID: "d-1", Month: 5, Year: 2025})
+	must(t, err)
+	if len(byDepartment) != 2 || byDepartment[0].TotalHours != 13.5 {
+		t.Fatalf("department totals = %+v", byDepartment)
+	}
+
+	must(t, s.DeleteAllocationsByDay(ctx, repo.DeleteAllocationsByDayParams{UserID: "u-1", Day: 5, Month: 5, Year: 2025}))
+	byUser, err := s.GetProjectTotalsByUser(ctx, repo.GetProjectTotalsByUserParams{UserID: "u-1", Month: 5, Year: 2025})
+	must(t, err)
+	if len(byUser) != 1 || byUser[0].TotalHours != 8 {
+		t.Fatalf("user totals after delete = %+v", byUser)
+	}
+}
+
+func testVacations(t *testing.T, ctx context.Context, s Store) {
+	for _, v := range []repo.CreateVacationParams{
+		{ID: "v-1", UserID: "u-2", StartDate: date(2025, 5, 5), EndDate: date(2025, 5, 18), Year: 2025, Status: repo.ReportVacationStatusApproved},
+		{ID: "v-2", UserID: "u-1", StartDate: date(2025, 4, 28), EndDate: date(2025, 5, 4), Year: 2025, Description: sql.NullString{String: "дача", Valid: true}, Status: repo.ReportVacationStatusApproved},
+		{ID: "v-3", UserID: "u-1", StartDate: date(2025, 5, 10), EndDate: date(2025, 5, 12), Year: 2025, Status: repo.ReportVacationStatusConsideration},
+		{ID: "v-4", UserID: "u-1", StartDate: date(2026, 1, 10), EndDate: date(2026, 1, 20), Year: 2026, Status: repo.ReportVacationStatusApproved},
+	} {
+		must(t, s.CreateVacation(ctx, v))
+	}
+
+	pending, err := s.CountVacationsByStatus(ctx, repo.ReportVacationStatusConsideration)
+	must(t, err)
+	if pending != 1 {
+		t.Fatalf("vacations in consideration = %d, want 1", pending)
+	}
+
+	v, err := s.GetVacationById(ctx, "v-2")
+	must(t, err)
+	if !sameDate(v.StartDate, date(2025, 4, 28)) || !sameDate(v.EndDate, date(2025, 5, 4)) {
+		t.Fatalf("dates = %s..%s", v.StartDate, v.EndDate)
+	}
+	if v.Description != "дача" || v.CreateAt.IsZero() {
+		t.Fatalf("vacation = %+v", v)
+	}
+
+	inRange, err := s.GetVacationsApprovedInRange(ctx, repo.GetVacationsApprovedInRangeParams{StartDate: date(2025, 5, 31), EndDate: date(2025, 5, 1)})
+	must(t, err)
+	if len(inRange) != 2 || inRange[0].ID != "v-2" || inRange[1].ID != "v-1" {
+		t.Fatalf("approved in May = %+v", inRange)
+	}
+	if inRange[1].Description != "" {
+		t.Fatalf("description = %q, want empty", inRange[1].Description)
+	}
+
+	starting, err := s.GetVacationsApprovedStartingOn(ctx, date(2025, 5, 5))
+	must(t, err)
+	if len(starting) != 1 || starting[0].ID != "v-1" {
+		t.Fatalf("starting on May 5 = %+v", starting)
+	}
+
+	approved, err := s.GetVacationApproved(ctx, "u-1")
+	must(t, err)
+	if len(approved) != 2 {
+		t.Fatalf("approved = %d, want 2", len(approved))
+	}
+
+	byYear, err := s.GetVacationsByYear(ctx, repo.GetVacationsByYearParams{UserID: "u-1", Year: 2025})
+	must(t, err)
+	if len(byYear) != 2 {
+		t.Fatalf("by year = %d, want 2", len(byYear))
+	}
+
+	// построчная выборка совпадает с выборкой списком
+	all, err := s.GetAdminVacationsByYear(ctx, 2025)
+	must(t, err)
+	var streamed []repo.GetAdminVacationsByYearRow
+	for row, err := range s.StreamAdminVacationsByYear(ctx, 2025) {
+		must(t, err)
+		streamed = append(streamed, row)
+	}
+	if len(all) != 3 || len(streamed) != len(all) {
+		t.Fatalf("streamed %d of %d vacations, want 3", len(streamed), len(all))
+	}
+	for i := range all {
+		if streamed[i].ID != all[i].ID || streamed[i].Description != all[i].Description {
+			t.Fatalf("streamed[%d] = %+v, want %+v", i, streamed[i], all[i])
+		}
+	}
+
+	years, err := s.GetYearsVacation(ctx, "u-1")
+	must(t, err)
+	if len(years) != 2 || years[0] != 2026 || years[1] != 2025 {
+		t.Fatalf("years = %v, want [2026 2025]", years)
+	}
+
+	must(t, s.UpdateVacationStatus(ctx, repo.UpdateVacationStatusParams{ID: "v-3", Status: repo.ReportVacationStatusRejected}))
+	v, err = s.GetVacationById(ctx, "v-3")
+	must(t, err)
+	if v.Status != repo.ReportVacationStatusRejected {
+		t.Fatalf("status = %q, want rejected", v.Status)
+	}
+
+	must(t, s.DeleteVacation(ctx, "v-3"))
+	if _, err := s.GetVacationById(ctx, "v-3"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("deleted: err = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func testSchedules(t *testing.T, ctx context.Context, s Store) {
+	must(t, s.CreateSchedule(ctx, repo.CreateScheduleParams{ID: "s-5", Name: "Пятидневка", Kind: repo.ReportScheduleKindWeekly, CycleLength: 7}))
+	must(t, s.CreateSchedule(ctx, repo.CreateScheduleParams{
+		ID: "s-2", Name: "два через два", Kind: repo.ReportScheduleKindShift, CycleLength: 4,
+		CycleStart: sql.NullTime{Time: date(2025, 1, 1), Valid: true},
+	}))
+	for i, hours := range []float64{11.5, 11.5, 0, 0} {
+		must(t, s.CreateScheduleDay(ctx, repo.CreateScheduleDayParams{ID: "sd-" + string(rune('a'+i)), ScheduleID: "s-2", DayIndex: int32(3 - i), Hours: hours}))
+	}
+
+	schedules, err := s.GetSchedules(ctx)
+	must(t, err)
+	if len(schedules) != 2 || schedules[0].ID != "s-2" {
+		t.Fatalf("schedules = %+v", schedules)
+	}
+
+	sch, err := s.GetScheduleById(ctx, "s-2")
+	must(t, err)
+	if sch.Kind != repo.ReportScheduleKindShift || !sch.CycleStart.Valid || !sameDate(sch.CycleStart.Time, date(2025, 1, 1)) {
+		t.Fatalf("schedule = %+v", sch)
+	}
+
+	days, err := s.GetScheduleDays(ctx, "s-2")
+	must(t, err)
+	if len(days) != 4 || days[0].DayIndex != 0 || days[3].Hours != 11.5 {
+		t.Fatalf("days = %+v", days)
+	}
+
+	must(t, s.CreateUserSchedule(ctx, repo.CreateUserScheduleParams{
+		ID: "us-2", UserID: "u-1", ScheduleID: "s-2", EffectiveFrom: date(2025, 6, 1),
+	}))
+	must(t, s.CreateUserSchedule(ctx, repo.CreateUserScheduleParams{
+		ID: "us-1", UserID: "u-1", ScheduleID: "s-5", EffectiveFrom: date(2025, 1, 1),
+		EffectiveTo: sql.NullTime{Time: date(2025, 5, 31), Valid: true},
+	}))
+
+	assignments, err := s.GetUserSchedules(ctx, "u-1")
+	must(t, err)
+	if len(assignments) != 2 || assignments[0].ID != "us-1" || assignments[0].ScheduleName != "Пятидневка" {
+		t.Fatalf("assignments = %+v", assignments)
+	}
+	if !assignments[0].EffectiveTo.Valid || !sameDate(assignments[0].EffectiveTo.Time, date(2025, 5, 31)) || assignments[1].EffectiveTo.Valid {
+		t.Fatalf("effective_to = %+v, %+v", assignments[0].EffectiveTo, assignments[1].EffectiveTo)
+	}
+
+	users, err := s.CountUserSchedulesBySchedule(ctx, "s-2")
+	must(t, err)
+	if users != 1 {
+		t.Fatalf("users = %d, want 1", users)
+	}
+
+	must(t, s.DeleteScheduleDays(ctx, "s-2"))
+	days, err = s.GetScheduleDays(ctx, "s-2")
+	must(t, err)
+	if len(days) != 0 {
+		t.Fatalf("days after delete = %d", len(days))
+	}
+}
+
+func testSettings(t *testing.T, ctx context.Context, s Store) {
+	duration, err := s.GetSettingVacationDuration(ctx)
+	must(t, err)
+	if duration != 30 {
+		t.Fatalf("vacation duration = %d, want 30", duration)
+	}
+
+	window, err := s.GetSettingNightWindow(ctx)
+	must(t, err)
+	if window.NightStartMinute != 1320 || window.NightEndMinute != 360 {
+		t.Fatalf("night window = %+v", window)
+	}
+
+	layout, err := s.GetSettingPayrollLayout(ctx)
+	must(t, err)
+	if layout.Valid {
+		t.Fatalf("payroll layout = %+v, want NULL", layout)
+	}
+
+	must(t, s.UpdateSettingNightWindow(ctx, repo.UpdateSettingNightWindowParams{NightStartMinute: 1380, NightEndMinute: 300}))
+	must(t, s.UpdateSettingPayrollLayout(ctx, sql.NullString{String: `{"format":"csv"}`, Valid: true}))
+
+	window, err = s.GetSettingNightWindow(ctx)
+	must(t, err)
+	layout, err = s.GetSettingPayrollLayout(ctx)
+	must(t, err)
+	if window.NightStartMinute != 1380 || window.NightEndMinute != 300 || layout.String != `{"format":"csv"}` {
+		t.Fatalf("settings = %+v, %+v", window, layout)
+	}
+}
+
+// testSchemaVersion - схема хранилища соответствует версии, которую ждет код
+func testSchemaVersion(t *testing.T, ctx context.Context, s Store) {
+	version, err := s.GetSchemaVersion(ctx)
+	must(t, err)
+	if version != repo.SchemaVersion {
+		t.Fatalf("schema version = %d, want %d", version, repo.SchemaVersion)
+	}
+}
+
+func testDepartments(t *testing.T, ctx context.Context, s Store) {
+	must(t, s.CreateDepartment(ctx, repo.CreateDepartmentParams{ID: "d-2", Name: "Склад"}))
+	must(t, s.CreateDepartment(ctx, repo.CreateDepartmentParams{ID: "d-1", Name: "бухгалтерия"}))
+
+	departments, err := s.GetDepartments(ctx)
+	must(t, err)
+	if len(departments) != 2 || departments[0].ID != "d-1" {
+		t.Fatalf("departments = %+v", departments)
+	}
+
+	for _, u := range []string{"u-3", "u-1", "u-2"} {
+		must(t, s.CreateUserDepartment(ctx, repo.CreateUserDepartmentParams{UserID: u, DepartmentID: "d-2"}))
+	}
+	must(t, s.CreateDirectoryUser(ctx, repo.CreateDirectoryUserParams{ID: "u-1", Dn: "uid=1", IsActive: true}))
+	must(t, s.CreateDirectoryUser(ctx, repo.CreateDirectoryUserParams{ID: "u-2", Dn: "uid=2", IsActive: false}))
+
+	// u-3 нет в каталоге - считается активным
+	users, err := s.GetDepartmentUsers(ctx, "d-2")
+	must(t, err)
+	if len(users) != 2 || users[0] != "u-1" || users[1] != "u-3" {
+		t.Fatalf("department users = %v, want [u-1 u-3]", users)
+	}
+
+	ud, err := s.GetUserDepartment(ctx, "u-1")
+	must(t, err)
+	if ud.DepartmentName != "Склад" {
+		t.Fatalf("user department = %+v", ud)
+	}
+
+	must(t, s.DeleteUserDepartment(ctx, "u-1"))
+	if _, err := s.GetUserDepartment(ctx, "u-1"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("deleted: err = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func testDirectory(t *testing.T, ctx context.Context, s Store) {
+	must(t, s.CreateDirectoryUser(ctx, repo.CreateDirectoryUserParams{ID: "u-1", Dn: "uid=ivanov", Login: "ivanov", Name: "Иванов", Email: "Ivanov@Example.com", IsActive: true}))
+
+	user, err := s.GetDirectoryUserByEmail(ctx, "ivanov@example.com")
+	must(t, err)
+	if user.ID != "u-1" || !user.IsActive || user.SyncAt.IsZero() {
+		t.Fatalf("user = %+v", user)
+	}
+
+	inactive, err := s.CheckDirectoryUserInactive(ctx, "u-1")
+	must(t, err)
+	if inactive != 0 {
+		t.Fatalf("inactive = %d, want 0", inactive)
+	}
+
+	must(t, s.UpdateDirectoryUser(ctx, repo.UpdateDirectoryUserParams{ID: "u-1", Dn: "uid=ivanov", Login: "ivanov", Name: "Иванов И.", Email: "ivanov@example.com", IsActive: true}))
+	must(t, s.DeactivateDirectoryUser(ctx, "u-1"))
+
+	user, err = s.GetDirectoryUserById(ctx, "u-1")
+	must(t, err)
+	if user.IsActive || user.Name != "Иванов И." {
+		t.Fatalf("user = %+v", user)
+	}
+	inactive, err = s.CheckDirectoryUserInactive(ctx, "u-1")
+	must(t, err)
+	if inactive != 1 {
+		t.Fatalf("inactive = %d, want 1", inactive)
+	}
+}
+
+func testWebhooks(t *testing.T, ctx context.Context, s Store) {
+	must(t, s.CreateWebhook(ctx, repo.CreateWebhookParams{ID: "w-1", Url: "https://example.com/hook", Secret: "s", Events: "*", IsActive: true}))
+	must(t, s.CreateWebhook(ctx, repo.CreateWebhookParams{ID: "w-2", Url: "https://example.com/off", Secret: "s", Events: "report.created", IsActive: false}))
+
+	active, err := s.GetActiveWebhooks(ctx)
+	must(t, err)
+	if len(active) != 1 || active[0].ID != "w-1" || active[0].CreateAt.IsZero() {
+		t.Fatalf("active = %+v", active)
+	}
+
+	must(t, s.UpdateWebhook(ctx, repo.UpdateWebhookParams{ID: "w-2", Url: "https://example.com/on", Events: "*", IsActive: true}))
+	hooks, err := s.GetWebhooks(ctx)
+	must(t, err)
+	if len(hooks) != 2 {
+		t.Fatalf("webhooks = %d, want 2", len(hooks))
+	}
+
+	must(t, s.CreateWebhookDelivery(ctx, repo.CreateWebhookDeliveryParams{ID: "dl-1", WebhookID: "w-1", Event: "report.created", Payload: "{}"}))
+	delivery, err := s.GetWebhookDeliveryById(ctx, "dl-1")
+	must(t, err)
+	if delivery.Status != "pending" || delivery.Attempts != 0 || delivery.ResponseCode.Valid || delivery.Error.Valid || delivery.UpdateAt.IsZero() {
+		t.Fatalf("new delivery = %+v", delivery)
+	}
+
+	must(t, s.UpdateWebhookDelivery(ctx, repo.UpdateWebhookDeliveryParams{
+		ID: "dl-1", Status: "failed", Attempts: 1,
+		ResponseCode: sql.NullInt32{Int32: 502, Valid: true}, Error: sql.NullString{String: "bad gateway", Valid: true},
+	}))
+	deliveries, err := s.GetWebhookDeliveries(ctx, "w-1")
+	must(t, err)
+	if len(deliveries) != 1 || deliveries[0].Status != "failed" || deliveries[0].ResponseCode.Int32 != 502 || deliveries[0].Error.String != "bad gateway" {
+		t.Fatalf("deliveries = %+v", deliveries)
+	}
+
+	must(t, s.DeleteWebhookDeliveries(ctx, "w-1"))
+	must(t, s.DeleteWebhook(ctx, "w-1"))
+	if _, err := s.GetWebhookById(ctx, "w-1"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("deleted: err = %v, want sql.ErrNoRows", err)
+	}
+	deliveries, err = s.GetWebhookDeliveries(ctx, "w-1")
+	must(t, err)
+	if len(deliveries) != 0 {
+		t.Fatalf("deliveries after delete = %d", len(deliveries))
+	}
+}
+
+// testWebhookRetries - выборка ожидающих доставок, время попытки которых наступило,
+// и захват доставки одним исполнителем
+func testWebhookRetries(t *testing.T, ctx context.Context, s Store) {
+	at := func(hour int) sql.NullTime {
+		return sql.NullTime{Time: date(2025, time.May, 5).Add(time.Duration(hour) * time.Hour), Valid: true}
+	}
+
+	must(t, s.CreateWebhook(ctx, repo.CreateWebhookParams{ID: "w-1", Url: "https://example.com/hook", Secret: "s", Events: "*", IsActive: true}))
+	for _, d := range []repo.CreateWebhookDeliveryParams{
+		{ID: "dl-1", NextAttemptAt: at(12)},
+		{ID: "dl-2", NextAttemptAt: at(10)},
+		{ID: "dl-3", NextAttemptAt: at(14)},
+		{ID: "dl-4", NextAttemptAt: at(9)},
+	} {
+		d.WebhookID, d.Event, d.Payload = "w-1", "report.created", "{}"
+		must(t, s.CreateWebhookDelivery(ctx, d))
+	}
+	must(t, s.UpdateWebhookDelivery(ctx, repo.UpdateWebhookDeliveryParams{ID: "dl-4", Status: "success", Attempts: 1}))
+
+	due, err := s.GetDueWebhookDeliveries(ctx, at(12))
+	must(t, err)
+	if len(due) != 2 || due[0].ID != "dl-2" || due[1].ID != "dl-1" || !due[0].NextAttemptAt.Time.Equal(at(10).Time) {
+		t.Fatalf("due = %+v, want dl-2 and dl-1", due)
+	}
+
+	claimed, err := s.ClaimWebhookDelivery(ctx, repo.ClaimWebhookDeliveryParams{ID: "dl-2", LeaseUntil: at(13), Now: at(12)})
+	must(t, err)
+	if claimed != 1 {
+		t.Fatalf("claimed = %d, want 1", claimed)
+	}
+	claimed, err = s.ClaimWebhookDelivery(ctx, repo.ClaimWebhookDeliveryParams{ID: "dl-2", LeaseUntil: at(13), Now: at(12)})
+	must(t, err)
+	if claimed != 0 {
+		t.Fatalf("claimed twice = %d, want 0", claimed)
+	}
+
+	due, err = s.GetDueWebhookDeliveries(ctx, at(12))
+	must(t, err)
+	if len(due) != 1 || due[0].ID != "dl-1" {
+		t.Fatalf("due after claim = %+v, want dl-1", due)
+	}
+}
+
+func testChatLinkCodes(t *testing.T, ctx context.Context, s Store) {
+	// Запас в двое суток: DATETIME в MySQL хранится без часового пояса,
+	// а NOW() берется в поясе сервера
+	now, margin := time.Now(), 48*time.Hour
+	must(t, s.CreateChatLinkCode(ctx, repo.CreateChatLinkCodeParams{Code: "OLD", UserID: "u-1", ExpireAt: now.Add(-margin)}))
+	must(t, s.CreateChatLinkCode(ctx, repo.CreateChatLinkCodeParams{Code: "USED", UserID: "u-2", ExpireAt: now.Add(margin)}))
+	must(t, s.CreateChatLinkCode(ctx, repo.CreateChatLinkCodeParams{Code: "KEEP", UserID: "u-3", ExpireAt: now.Add(margin)}))
+
+	code, err := s.GetChatLinkCode(ctx, "keep")
+	must(t, err)
+	if code.UserID != "u-3" {
+		t.Fatalf("code = %+v", code)
+	}
+
+	// Удаляется использованный код и заодно все просроченные
+	must(t, s.DeleteChatLinkCode(ctx, "USED"))
+	for code, want := range map[string]bool{"OLD": false, "USED": false, "KEEP": true} {
+		_, err := s.GetChatLinkCode(ctx, code)
+		if found := err == nil; found != want {
+			t.Errorf("%s: found = %v, want %v (err = %v)", code, found, want, err)
+		}
+	}
+
+	must(t, s.CreateChatLink(ctx, repo.CreateChatLinkParams{ChatID: 1234567890123, UserID: "u-3"}))
+	link, err := s.GetChatLinkByChat(ctx, 1234567890123)
+	must(t, err)
+	if link.UserID != "u-3" || link.CreateAt.IsZero() {
+		t.Fatalf("link = %+v", link)
+	}
+}
+
+func testTxCommit(t *testing.T, ctx context.Context, s Store) {
+	tx, err := s.Begin(ctx)
+	must(t, err)
+	must(t, tx.CreateType(ctx, repo.CreateTypeParams{ID: "t-work", Name: "Явка", SystemName: "work"}))
+	must(t, tx.Commit())
+
+	if _, err := s.GetTypeById(ctx, "t-work"); err != nil {
+		t.Fatalf("committed row: %v", err)
+	}
+}
+
+func testTxRollback(t *testing.T, ctx context.Context, s Store) {
+	tx, err := s.Begin(ctx)
+	must(t, err)
+	must(t, tx.CreateType(ctx, repo.CreateTypeParams{ID: "t-work", Name: "Явка", SystemName: "work"}))
+	if _, err := tx.GetTypeById(ctx, "t-work"); err != nil {
+		t.Fatalf("row inside tx: %v", err)
+	}
+	must(t, tx.Rollback())
+
+	if _, err := s.GetTypeById(ctx, "t-work"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("rolled back row: err = %v, want sql.ErrNoRows", err)
+	}
+}
@@ -0,0 +1,140 @@
+// Package dialect - выполнение запросов сгенерированного sqlc пакета repo на
+// другой СУБД (PostgreSQL, SQLite).
+//
+// Код запросов общий: текст каждого запроса MySQL подменяется одноименным
+// запросом диалекта из query/*.sql адаптера (по строке "-- name: ...").
+// Запросы должны возвращать те же столбцы в том же порядке и принимать
+// параметры в порядке ? исходного запроса MySQL.
+package dialect
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// Queries - текст запросов диалекта по имени запроса sqlc
+type Queries map[string]string
+
+// MustLoad разбирает файлы query/*.sql так же, как sqlc: запрос начинается
+// строкой "-- name: ..." и продолжается до следующей такой строки
+func MustLoad(files fs.FS) Queries {
+	paths, err := fs.Glob(files, "query/*.sql")
+	if err != nil {
+		panic(err)
+	}
+
+	result := make(Queries)
+	for _, path := range paths {
+		content, err := fs.ReadFile(files, path)
+		if err != nil {
+			panic(err)
+		}
+
+		for _, block := range strings.Split(string(content), "\n-- name: ")[1:] {
+			query := "-- name: " + strings.TrimSpace(trimSectionComments(block))
+			name := Name(query)
+			if _, exists := result[name]; exists {
+				panic(fmt.Sprintf("dialect: duplicate query %s in %s", name, path))
+			}
+			result[name] = strings.TrimSuffix(query, ";")
+		}
+	}
+
+	return result
+}
+
+// Name - имя из первой строки запроса sqlc "-- name: GetTypeById :one"
+func Name(query string) string {
+	rest, ok := strings.CutPrefix(query, "-- name: ")
+	if !ok {
+		return ""
+	}
+	name, _, _ := strings.Cut(rest, " ")
+	return name
+}
+
+// Params - число параметров запроса MySQL: знаки ? и именованные параметры
+// sqlc.arg(...), которые sqlc заменяет на ?
+func Params(query string) int {
+	return strings.Count(query, "?") + strings.Count(query, "sqlc.arg(")
+}
+
+// Translate возвращает запрос диалекта с тем же именем. Неизвестный запрос
+// передается как есть, и ошибку вернет СУБД: полноту набора проверяют тесты.
+func (q Queries) Translate(query string) string {
+	if translated, ok := q[Name(query)]; ok {
+		return translated
+	}
+	return query
+}
+
+// New - запросы пакета repo поверх соединения или транзакции СУБД диалекта
+func (q Queries) New(db repo.DBTX) *repo.Queries {
+	return repo.New(conn{db: db, queries: q})
+}
+
+// NewTxBeginner - транзакции с запросами диалекта
+func (q Queries) NewTxBeginner(db *sql.DB) repo.TxBeginner {
+	return &txBeginner{db: db, queries: q}
+}
+
+type txBeginner struct {
+	db      *sql.DB
+	queries Queries
+}
+
+func (b *txBeginner) Begin(ctx context.Context) (repo.Tx, error) {
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlTx{Queries: b.queries.New(tx), tx: tx}, nil
+}
+
+type sqlTx struct {
+	*repo.Queries
+	tx *sql.Tx
+}
+
+func (t *sqlTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *sqlTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// conn подменяет текст запросов MySQL запросами диалекта
+type conn struct {
+	db      repo.DBTX
+	queries Queries
+}
+
+func (c conn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.db.ExecContext(ctx, c.queries.Translate(query), args...)
+}
+
+func (c conn) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return c.db.PrepareContext(ctx, c.queries.Translate(query))
+}
+
+func (c conn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.db.QueryContext(ctx, c.queries.Translate(query), args...)
+}
+
+func (c conn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.db.QueryRowContext(ctx, c.queries.Translate(query), args...)
+}
+
+// trimSectionComments отрезает заголовок следующего раздела файла ("-- ====")
+func trimSectionComments(block string) string {
+	if i := strings.Index(block, "\n-- ===="); i >= 0 {
+		return block[:i]
+	}
+	return block
+}
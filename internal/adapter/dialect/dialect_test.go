@@ -0,0 +1,51 @@
+package dialect
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+var files = fstest.MapFS{
+	"query/report_type.sql": {Data: []byte(`-- ============================================
+-- REPORT_TYPE queries
+-- ============================================
+
+-- name: GetTypeById :one
+SELECT id, name, system_name, code
+FROM report_type
+WHERE id = $1;
+
+-- name: DeleteType :exec
+DELETE FROM report_type
+WHERE id = $1;
+-- ============================================
+-- REPORT_STANDARD queries
+-- ============================================
+`)},
+}
+
+func TestMustLoad(t *testing.T) {
+	queries := MustLoad(files)
+
+	if len(queries) != 2 {
+		t.Fatalf("loaded %d queries, want 2", len(queries))
+	}
+	if got, want := queries["DeleteType"], "-- name: DeleteType :exec\nDELETE FROM report_type\nWHERE id = $1"; got != want {
+		t.Fatalf("DeleteType = %q, want %q", got, want)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	queries := MustLoad(files)
+
+	got := queries.Translate("-- name: GetTypeById :one\nSELECT id, name, system_name, code\nFROM report_type\nWHERE id = ?\n")
+	if !strings.Contains(got, "WHERE id = $1") {
+		t.Fatalf("Translate = %q", got)
+	}
+
+	unknown := "SELECT 1"
+	if got := queries.Translate(unknown); got != unknown {
+		t.Fatalf("Translate(%q) = %q", unknown, got)
+	}
+}
@@ -0,0 +1,13 @@
+package memory_test
+
+import (
+	"TimeTrack/internal/adapter/contract"
+	"TimeTrack/internal/adapter/memory"
+	"testing"
+)
+
+func TestContract(t *testing.T) {
+	contract.Run(t, func(t *testing.T) contract.Store {
+		return memory.New()
+	})
+}
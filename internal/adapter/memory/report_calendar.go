@@ -0,0 +1,157 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"cmp"
+	"context"
+	"slices"
+)
+
+// calendarDays - report_calendar INNER JOIN report_type - NULL в description заменяется пустой строкой.
+// Строки всех запросов списка одинаковы, поэтому собираются в GetCalendarDaysRow.
+func (s *Store) calendarDays(match func(rc repo.ReportCalendar, rt repo.ReportType) bool) []repo.GetCalendarDaysRow {
+	var items []repo.GetCalendarDaysRow
+	for _, rc := range s.data.calendar {
+		for _, rt := range s.data.types {
+			if !eq(rc.TypeID, rt.ID) || !match(rc, rt) {
+				continue
+			}
+			items = append(items, repo.GetCalendarDaysRow{
+				ID:             rc.ID,
+				Day:            rc.Day,
+				Month:          rc.Month,
+				Year:           rc.Year,
+				Description:    rc.Description.String,
+				IsPaidVacation: rc.IsPaidVacation,
+				TypeID:         rc.TypeID,
+				TypeName:       rt.Name,
+				TypeSystemName: rt.SystemName,
+			})
+		}
+	}
+	return items
+}
+
+// byDay - ORDER BY rc.day ASC
+func byDay(a, b repo.GetCalendarDaysRow) int {
+	return cmp.Compare(a.Day, b.Day)
+}
+
+// byMonthDay - ORDER BY rc.month ASC, rc.day ASC
+func byMonthDay(a, b repo.GetCalendarDaysRow) int {
+	return cmp.Or(cmp.Compare(a.Month, b.Month), cmp.Compare(a.Day, b.Day))
+}
+
+func (s *Store) GetCalendarDays(ctx context.Context, arg repo.GetCalendarDaysParams) ([]repo.GetCalendarDaysRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.calendarDays(func(rc repo.ReportCalendar, rt repo.ReportType) bool {
+		return rc.Month == arg.Month && rc.Year == arg.Year
+	})
+	slices.SortStableFunc(items, byDay)
+	return items, nil
+}
+
+func (s *Store) GetCalendarDaysByType(ctx context.Context, arg repo.GetCalendarDaysByTypeParams) ([]repo.GetCalendarDaysByTypeRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.calendarDays(func(rc repo.ReportCalendar, rt repo.ReportType) bool {
+		return rc.Month == arg.Month && rc.Year == arg.Year && eq(rt.SystemName, arg.SystemName)
+	})
+	slices.SortStableFunc(items, byDay)
+	return convert(items, func(r repo.GetCalendarDaysRow) repo.GetCalendarDaysByTypeRow { return repo.GetCalendarDaysByTypeRow(r) }), nil
+}
+
+func (s *Store) GetCalendarDaysAll(ctx context.Context, year int32) ([]repo.GetCalendarDaysAllRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.calendarDays(func(rc repo.ReportCalendar, rt repo.ReportType) bool {
+		return rc.Year == year
+	})
+	slices.SortStableFunc(items, byMonthDay)
+	return convert(items, func(r repo.GetCalendarDaysRow) repo.GetCalendarDaysAllRow { return repo.GetCalendarDaysAllRow(r) }), nil
+}
+
+func (s *Store) GetCalendarDaysAllByType(ctx context.Context, arg repo.GetCalendarDaysAllByTypeParams) ([]repo.GetCalendarDaysAllByTypeRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.calendarDays(func(rc repo.ReportCalendar, rt repo.ReportType) bool {
+		return rc.Year == arg.Year && eq(rt.SystemName, arg.SystemName)
+	})
+	slices.SortStableFunc(items, byMonthDay)
+	return convert(items, func(r repo.GetCalendarDaysRow) repo.GetCalendarDaysAllByTypeRow {
+		return repo.GetCalendarDaysAllByTypeRow(r)
+	}), nil
+}
+
+// GetCalendarDay - единственный запрос календаря без COALESCE: описание может быть NULL
+func (s *Store) GetCalendarDay(ctx context.Context, arg repo.GetCalendarDayParams) (repo.GetCalendarDayRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []repo.GetCalendarDayRow
+	for _, rc := range s.data.calendar {
+		if rc.Day != arg.Day || rc.Month != arg.Month || rc.Year != arg.Year {
+			continue
+		}
+		for _, rt := range s.data.types {
+			if eq(rc.TypeID, rt.ID) {
+				items = append(items, repo.GetCalendarDayRow{
+					ID:             rc.ID,
+					Day:            rc.Day,
+					Month:          rc.Month,
+					Year:           rc.Year,
+					Description:    rc.Description,
+					IsPaidVacation: rc.IsPaidVacation,
+					TypeID:         rc.TypeID,
+					TypeName:       rt.Name,
+					TypeSystemName: rt.SystemName,
+				})
+			}
+		}
+	}
+	return first(items)
+}
+
+func (s *Store) CreateCalendarDay(ctx context.Context, arg repo.CreateCalendarDayParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.calendar = append(s.data.calendar, repo.ReportCalendar(arg))
+	return nil
+}
+
+func (s *Store) UpdateCalendarDay(ctx context.Context, arg repo.UpdateCalendarDayParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.calendar {
+		if rc := &s.data.calendar[i]; eq(rc.ID, arg.ID) {
+			rc.Description, rc.TypeID = arg.Description, arg.TypeID
+		}
+	}
+	return nil
+}
+
+func (s *Store) DeleteCalendarDay(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.calendar = slices.DeleteFunc(s.data.calendar, func(rc repo.ReportCalendar) bool {
+		return eq(rc.ID, id)
+	})
+	return nil
+}
+
+func (s *Store) CheckCalendarDayExists(ctx context.Context, arg repo.CheckCalendarDayExistsParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return count(s.data.calendar, func(rc repo.ReportCalendar) bool {
+		return rc.Day == arg.Day && rc.Month == arg.Month && rc.Year == arg.Year
+	}), nil
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"slices"
+)
+
+func (s *Store) GetChatLinks(ctx context.Context) ([]repo.ReportChatLink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.chatLinks, all)
+	slices.SortStableFunc(items, func(a, b repo.ReportChatLink) int {
+		return a.CreateAt.Compare(b.CreateAt)
+	})
+	return items, nil
+}
+
+func (s *Store) GetChatLinkByChat(ctx context.Context, chatID int64) (repo.ReportChatLink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.chatLinks, func(l repo.ReportChatLink) bool {
+		return l.ChatID == chatID
+	}))
+}
+
+func (s *Store) CreateChatLink(ctx context.Context, arg repo.CreateChatLinkParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.chatLinks = append(s.data.chatLinks, repo.ReportChatLink{
+		ChatID:   arg.ChatID,
+		UserID:   arg.UserID,
+		CreateAt: s.timestamp(),
+	})
+	return nil
+}
+
+func (s *Store) DeleteChatLink(ctx context.Context, chatID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.chatLinks = slices.DeleteFunc(s.data.chatLinks, func(l repo.ReportChatLink) bool {
+		return l.ChatID == chatID
+	})
+	return nil
+}
+
+func (s *Store) GetChatLinkCode(ctx context.Context, code string) (repo.ReportChatLinkCode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.chatLinkCodes, func(c repo.ReportChatLinkCode) bool {
+		return eq(c.Code, code)
+	}))
+}
+
+func (s *Store) CreateChatLinkCode(ctx context.Context, arg repo.CreateChatLinkCodeParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.chatLinkCodes = append(s.data.chatLinkCodes, repo.ReportChatLinkCode(arg))
+	return nil
+}
+
+// DeleteChatLinkCode удаляет код и заодно все просроченные (expire_at < NOW())
+func (s *Store) DeleteChatLinkCode(ctx context.Context, code string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.timestamp()
+	s.data.chatLinkCodes = slices.DeleteFunc(s.data.chatLinkCodes, func(c repo.ReportChatLinkCode) bool {
+		return eq(c.Code, code) || c.ExpireAt.Before(now)
+	})
+	return nil
+}
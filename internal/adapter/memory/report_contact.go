@@ -0,0 +1,34 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"slices"
+)
+
+func (s *Store) GetUserContact(ctx context.Context, userID string) (repo.ReportUserContact, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.contacts, func(c repo.ReportUserContact) bool {
+		return eq(c.UserID, userID)
+	}))
+}
+
+func (s *Store) CreateUserContact(ctx context.Context, arg repo.CreateUserContactParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.contacts = append(s.data.contacts, repo.ReportUserContact(arg))
+	return nil
+}
+
+func (s *Store) DeleteUserContact(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.contacts = slices.DeleteFunc(s.data.contacts, func(c repo.ReportUserContact) bool {
+		return eq(c.UserID, userID)
+	})
+	return nil
+}
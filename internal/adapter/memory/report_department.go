@@ -0,0 +1,103 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"slices"
+)
+
+func (s *Store) GetDepartments(ctx context.Context) ([]repo.ReportDepartment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.departments, all)
+	slices.SortStableFunc(items, func(a, b repo.ReportDepartment) int {
+		return compareText(a.Name, b.Name)
+	})
+	return items, nil
+}
+
+func (s *Store) GetDepartmentById(ctx context.Context, id string) (repo.ReportDepartment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.departments, func(d repo.ReportDepartment) bool {
+		return eq(d.ID, id)
+	}))
+}
+
+func (s *Store) CreateDepartment(ctx context.Context, arg repo.CreateDepartmentParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.departments = append(s.data.departments, repo.ReportDepartment(arg))
+	return nil
+}
+
+// GetUserDepartment - report_user_department INNER JOIN report_department
+func (s *Store) GetUserDepartment(ctx context.Context, userID string) (repo.GetUserDepartmentRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []repo.GetUserDepartmentRow
+	for _, rud := range s.data.userDepartments {
+		if !eq(rud.UserID, userID) {
+			continue
+		}
+		for _, d := range s.data.departments {
+			if eq(rud.DepartmentID, d.ID) {
+				items = append(items, repo.GetUserDepartmentRow{
+					UserID:         rud.UserID,
+					DepartmentID:   rud.DepartmentID,
+					DepartmentName: d.Name,
+				})
+			}
+		}
+	}
+	return first(items)
+}
+
+// GetDepartmentUsers - LEFT JOIN report_directory_user: сотрудники без записи
+// в каталоге считаются активными (COALESCE(rdu.is_active, 1) = 1)
+func (s *Store) GetDepartmentUsers(ctx context.Context, departmentID string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []string
+	for _, rud := range s.data.userDepartments {
+		if !eq(rud.DepartmentID, departmentID) {
+			continue
+		}
+		users := where(s.data.directoryUsers, func(u repo.ReportDirectoryUser) bool {
+			return eq(u.ID, rud.UserID)
+		})
+		if len(users) == 0 {
+			items = append(items, rud.UserID)
+		}
+		for _, u := range users {
+			if u.IsActive {
+				items = append(items, rud.UserID)
+			}
+		}
+	}
+	slices.SortStableFunc(items, compareText)
+	return items, nil
+}
+
+func (s *Store) CreateUserDepartment(ctx context.Context, arg repo.CreateUserDepartmentParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.userDepartments = append(s.data.userDepartments, repo.ReportUserDepartment(arg))
+	return nil
+}
+
+func (s *Store) DeleteUserDepartment(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.userDepartments = slices.DeleteFunc(s.data.userDepartments, func(rud repo.ReportUserDepartment) bool {
+		return eq(rud.UserID, userID)
+	})
+	return nil
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"slices"
+)
+
+func (s *Store) GetDirectoryUsers(ctx context.Context) ([]repo.ReportDirectoryUser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.directoryUsers, all)
+	slices.SortStableFunc(items, func(a, b repo.ReportDirectoryUser) int {
+		return compareText(a.Name, b.Name)
+	})
+	return items, nil
+}
+
+func (s *Store) GetDirectoryUserById(ctx context.Context, id string) (repo.ReportDirectoryUser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.directoryUsers, func(u repo.ReportDirectoryUser) bool {
+		return eq(u.ID, id)
+	}))
+}
+
+func (s *Store) GetDirectoryUserByEmail(ctx context.Context, email string) (repo.ReportDirectoryUser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.directoryUsers, func(u repo.ReportDirectoryUser) bool {
+		return eq(u.Email, email)
+	}))
+}
+
+func (s *Store) CreateDirectoryUser(ctx context.Context, arg repo.CreateDirectoryUserParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.directoryUsers = append(s.data.directoryUsers, repo.ReportDirectoryUser{
+		ID:       arg.ID,
+		Dn:       arg.Dn,
+		Login:    arg.Login,
+		Name:     arg.Name,
+		Email:    arg.Email,
+		IsActive: arg.IsActive,
+		SyncAt:   s.timestamp(),
+	})
+	return nil
+}
+
+func (s *Store) UpdateDirectoryUser(ctx context.Context, arg repo.UpdateDirectoryUserParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.directoryUsers {
+		if u := &s.data.directoryUsers[i]; eq(u.ID, arg.ID) {
+			u.Dn, u.Login, u.Name, u.Email, u.IsActive = arg.Dn, arg.Login, arg.Name, arg.Email, arg.IsActive
+			u.SyncAt = s.timestamp()
+		}
+	}
+	return nil
+}
+
+func (s *Store) DeactivateDirectoryUser(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.directoryUsers {
+		if u := &s.data.directoryUsers[i]; eq(u.ID, id) {
+			u.IsActive = false
+			u.SyncAt = s.timestamp()
+		}
+	}
+	return nil
+}
+
+func (s *Store) CheckDirectoryUserInactive(ctx context.Context, id string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return count(s.data.directoryUsers, func(u repo.ReportDirectoryUser) bool {
+		return eq(u.ID, id) && !u.IsActive
+	}), nil
+}
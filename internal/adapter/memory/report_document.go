@@ -0,0 +1,50 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"slices"
+)
+
+func (s *Store) GetDocumentTemplates(ctx context.Context) ([]repo.ReportDocumentTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.documents, all)
+	slices.SortStableFunc(items, func(a, b repo.ReportDocumentTemplate) int {
+		return compareText(a.Kind, b.Kind)
+	})
+	return items, nil
+}
+
+func (s *Store) GetDocumentTemplate(ctx context.Context, kind string) (repo.ReportDocumentTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.documents, func(d repo.ReportDocumentTemplate) bool {
+		return eq(d.Kind, kind)
+	}))
+}
+
+func (s *Store) CreateDocumentTemplate(ctx context.Context, arg repo.CreateDocumentTemplateParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.documents = append(s.data.documents, repo.ReportDocumentTemplate{
+		Kind:     arg.Kind,
+		Title:    arg.Title,
+		Body:     arg.Body,
+		UpdateAt: s.timestamp(),
+	})
+	return nil
+}
+
+func (s *Store) DeleteDocumentTemplate(ctx context.Context, kind string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.documents = slices.DeleteFunc(s.data.documents, func(d repo.ReportDocumentTemplate) bool {
+		return eq(d.Kind, kind)
+	})
+	return nil
+}
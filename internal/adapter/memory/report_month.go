@@ -0,0 +1,58 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"cmp"
+	"context"
+	"slices"
+)
+
+func (s *Store) GetMonthClose(ctx context.Context, arg repo.GetMonthCloseParams) (repo.ReportMonthClose, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.monthCloses, func(m repo.ReportMonthClose) bool {
+		return m.Month == arg.Month && m.Year == arg.Year
+	}))
+}
+
+func (s *Store) GetMonthClosesByYear(ctx context.Context, year int32) ([]repo.ReportMonthClose, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.monthCloses, func(m repo.ReportMonthClose) bool {
+		return m.Year == year
+	})
+	slices.SortStableFunc(items, func(a, b repo.ReportMonthClose) int {
+		return cmp.Compare(a.Month, b.Month)
+	})
+	return items, nil
+}
+
+// CloseMonth - повторное закрытие нарушает уникальный индекс report_month_close_month
+func (s *Store) CloseMonth(ctx context.Context, arg repo.CloseMonthParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if slices.ContainsFunc(s.data.monthCloses, func(m repo.ReportMonthClose) bool {
+		return m.Month == arg.Month && m.Year == arg.Year
+	}) {
+		return repo.ErrDuplicateKey
+	}
+	s.data.monthCloses = append(s.data.monthCloses, repo.ReportMonthClose{
+		Month:   arg.Month,
+		Year:    arg.Year,
+		CloseAt: s.timestamp(),
+	})
+	return nil
+}
+
+func (s *Store) ReopenMonth(ctx context.Context, arg repo.ReopenMonthParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.monthCloses = slices.DeleteFunc(s.data.monthCloses, func(m repo.ReportMonthClose) bool {
+		return m.Month == arg.Month && m.Year == arg.Year
+	})
+	return nil
+}
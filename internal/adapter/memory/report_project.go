@@ -0,0 +1,277 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"slices"
+)
+
+func projectRow(rp repo.ReportProject) repo.GetProjectsRow {
+	return repo.GetProjectsRow{
+		ID:       rp.ID,
+		Code:     rp.Code,
+		Name:     rp.Name,
+		Client:   rp.Client.String,
+		IsActive: rp.IsActive,
+	}
+}
+
+func (s *Store) GetProjects(ctx context.Context) ([]repo.GetProjectsRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := convert(s.data.projects, projectRow)
+	slices.SortStableFunc(items, func(a, b repo.GetProjectsRow) int {
+		return compareText(a.Code, b.Code)
+	})
+	return items, nil
+}
+
+func (s *Store) GetProjectById(ctx context.Context, id string) (repo.GetProjectByIdRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.projects, func(rp repo.ReportProject) bool {
+		return eq(rp.ID, id)
+	})
+	return first(convert(items, func(rp repo.ReportProject) repo.GetProjectByIdRow {
+		return repo.GetProjectByIdRow(projectRow(rp))
+	}))
+}
+
+func (s *Store) CreateProject(ctx context.Context, arg repo.CreateProjectParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.projects = append(s.data.projects, repo.ReportProject(arg))
+	return nil
+}
+
+func (s *Store) UpdateProject(ctx context.Context, arg repo.UpdateProjectParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.projects {
+		if rp := &s.data.projects[i]; eq(rp.ID, arg.ID) {
+			rp.Name, rp.Client, rp.IsActive = arg.Name, arg.Client, arg.IsActive
+		}
+	}
+	return nil
+}
+
+func (s *Store) CheckProjectCodeExists(ctx context.Context, code string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return count(s.data.projects, func(rp repo.ReportProject) bool {
+		return eq(rp.Code, code)
+	}), nil
+}
+
+func (s *Store) GetTasksByProject(ctx context.Context, projectID string) ([]repo.ReportTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.tasks, func(t repo.ReportTask) bool {
+		return eq(t.ProjectID, projectID)
+	})
+	slices.SortStableFunc(items, func(a, b repo.ReportTask) int {
+		return compareText(a.Name, b.Name)
+	})
+	return items, nil
+}
+
+func (s *Store) GetTaskById(ctx context.Context, id string) (repo.ReportTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.tasks, func(t repo.ReportTask) bool {
+		return eq(t.ID, id)
+	}))
+}
+
+func (s *Store) CreateTask(ctx context.Context, arg repo.CreateTaskParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.tasks = append(s.data.tasks, repo.ReportTask(arg))
+	return nil
+}
+
+func (s *Store) UpdateTask(ctx context.Context, arg repo.UpdateTaskParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.tasks {
+		if t := &s.data.tasks[i]; eq(t.ID, arg.ID) {
+			t.Name, t.IsActive = arg.Name, arg.IsActive
+		}
+	}
+	return nil
+}
+
+// GetAllocationsByReport - INNER JOIN report_project, LEFT JOIN report_task
+func (s *Store) GetAllocationsByReport(ctx context.Context, reportID string) ([]repo.GetAllocationsByReportRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []repo.GetAllocationsByReportRow
+	for _, ra := range s.data.allocations {
+		if !eq(ra.ReportID, reportID) {
+			continue
+		}
+		for _, rp := range s.data.projects {
+			if !eq(ra.ProjectID, rp.ID) {
+				continue
+			}
+			tasks := where(s.data.tasks, func(t repo.ReportTask) bool {
+				return ra.TaskID.Valid && eq(t.ID, ra.TaskID.String)
+			})
+			if len(tasks) == 0 {
+				tasks = []repo.ReportTask{{}}
+			}
+			for _, t := range tasks {
+				items = append(items, repo.GetAllocationsByReportRow{
+					ID:          ra.ID,
+					ReportID:    ra.ReportID,
+					ProjectID:   ra.ProjectID,
+					TaskID:      ra.TaskID,
+					Hours:       ra.Hours,
+					ProjectCode: rp.Code,
+					ProjectName: rp.Name,
+					TaskName:    t.Name,
+				})
+			}
+		}
+	}
+	slices.SortStableFunc(items, func(a, b repo.GetAllocationsByReportRow) int {
+		return compareText(a.ProjectCode, b.ProjectCode)
+	})
+	return items, nil
+}
+
+func (s *Store) CreateAllocation(ctx context.Context, arg repo.CreateAllocationParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row := repo.ReportAllocation(arg)
+	row.Hours = float(row.Hours)
+	s.data.allocations = append(s.data.allocations, row)
+	return nil
+}
+
+func (s *Store) DeleteAllocationsByReport(ctx context.Context, reportID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.allocations = slices.DeleteFunc(s.data.allocations, func(ra repo.ReportAllocation) bool {
+		return eq(ra.ReportID, reportID)
+	})
+	return nil
+}
+
+// DeleteAllocationsByDay - report_id IN (отметки сотрудника за день)
+func (s *Store) DeleteAllocationsByDay(ctx context.Context, arg repo.DeleteAllocationsByDayParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reports := where(s.data.reports, func(ru repo.ReportUser) bool {
+		return eq(ru.UserID, arg.UserID) && ru.Day == arg.Day && ru.Month == arg.Month && ru.Year == arg.Year
+	})
+	s.data.allocations = slices.DeleteFunc(s.data.allocations, func(ra repo.ReportAllocation) bool {
+		return slices.ContainsFunc(reports, func(ru repo.ReportUser) bool {
+			return eq(ru.ID, ra.ReportID)
+		})
+	})
+	return nil
+}
+
+// projectTotal - строка итогов по проекту (GROUP BY rp.id, rp.code, rp.name)
+type projectTotal struct {
+	ProjectID   string
+	ProjectCode string
+	ProjectName string
+	TotalHours  float64
+}
+
+// projectTotals - report_allocation INNER JOIN report_user INNER JOIN report_project
+// с группировкой по проекту и сортировкой по коду. joined - сколько строк дает отметка
+// в остальных соединениях запроса (0 - отметка не подходит).
+func (s *Store) projectTotals(joined func(ru repo.ReportUser) int) []projectTotal {
+	var items []projectTotal
+	for _, ra := range s.data.allocations {
+		for _, ru := range s.data.reports {
+			n := joined(ru)
+			if !eq(ra.ReportID, ru.ID) || n == 0 {
+				continue
+			}
+			for _, rp := range s.data.projects {
+				if !eq(ra.ProjectID, rp.ID) {
+					continue
+				}
+				i := slices.IndexFunc(items, func(t projectTotal) bool {
+					return eq(t.ProjectID, rp.ID) && eq(t.ProjectCode, rp.Code) && eq(t.ProjectName, rp.Name)
+				})
+				if i < 0 {
+					items = append(items, projectTotal{ProjectID: rp.ID, ProjectCode: rp.Code, ProjectName: rp.Name})
+					i = len(items) - 1
+				}
+				items[i].TotalHours += ra.Hours * float64(n)
+			}
+		}
+	}
+
+	for i := range items {
+		items[i].TotalHours = float(items[i].TotalHours)
+	}
+	slices.SortStableFunc(items, func(a, b projectTotal) int {
+		return compareText(a.ProjectCode, b.ProjectCode)
+	})
+	return items
+}
+
+func (s *Store) GetProjectTotalsByMonth(ctx context.Context, arg repo.GetProjectTotalsByMonthParams) ([]repo.GetProjectTotalsByMonthRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.projectTotals(func(ru repo.ReportUser) int {
+		return joined(ru.Month == arg.Month && ru.Year == arg.Year)
+	})
+	return convert(items, func(t projectTotal) repo.GetProjectTotalsByMonthRow { return repo.GetProjectTotalsByMonthRow(t) }), nil
+}
+
+func (s *Store) GetProjectTotalsByUser(ctx context.Context, arg repo.GetProjectTotalsByUserParams) ([]repo.GetProjectTotalsByUserRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.projectTotals(func(ru repo.ReportUser) int {
+		return joined(eq(ru.UserID, arg.UserID) && ru.Month == arg.Month && ru.Year == arg.Year)
+	})
+	return convert(items, func(t projectTotal) repo.GetProjectTotalsByUserRow { return repo.GetProjectTotalsByUserRow(t) }), nil
+}
+
+// GetProjectTotalsByDepartment - дополнительно INNER JOIN report_user_department
+func (s *Store) GetProjectTotalsByDepartment(ctx context.Context, arg repo.GetProjectTotalsByDepartmentParams) ([]repo.GetProjectTotalsByDepartmentRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.projectTotals(func(ru repo.ReportUser) int {
+		if ru.Month != arg.Month || ru.Year != arg.Year {
+			return 0
+		}
+		return int(count(s.data.userDepartments, func(rud repo.ReportUserDepartment) bool {
+			return eq(rud.UserID, ru.UserID) && eq(rud.DepartmentID, arg.DepartmentID)
+		}))
+	})
+	return convert(items, func(t projectTotal) repo.GetProjectTotalsByDepartmentRow {
+		return repo.GetProjectTotalsByDepartmentRow(t)
+	}), nil
+}
+
+// joined - одна строка соединения, если условие выполнено
+func joined(ok bool) int {
+	if ok {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,141 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"cmp"
+	"context"
+	"slices"
+)
+
+func (s *Store) GetSchedules(ctx context.Context) ([]repo.ReportSchedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.schedules, all)
+	slices.SortStableFunc(items, func(a, b repo.ReportSchedule) int {
+		return compareText(a.Name, b.Name)
+	})
+	return items, nil
+}
+
+func (s *Store) GetScheduleById(ctx context.Context, id string) (repo.ReportSchedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.schedules, func(rs repo.ReportSchedule) bool {
+		return eq(rs.ID, id)
+	}))
+}
+
+func (s *Store) CreateSchedule(ctx context.Context, arg repo.CreateScheduleParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row := repo.ReportSchedule(arg)
+	row.CycleStart = nullDate(row.CycleStart)
+	s.data.schedules = append(s.data.schedules, row)
+	return nil
+}
+
+func (s *Store) DeleteSchedule(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.schedules = slices.DeleteFunc(s.data.schedules, func(rs repo.ReportSchedule) bool {
+		return eq(rs.ID, id)
+	})
+	return nil
+}
+
+func (s *Store) GetScheduleDays(ctx context.Context, scheduleID string) ([]repo.ReportScheduleDay, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.scheduleDays, func(d repo.ReportScheduleDay) bool {
+		return eq(d.ScheduleID, scheduleID)
+	})
+	slices.SortStableFunc(items, func(a, b repo.ReportScheduleDay) int {
+		return cmp.Compare(a.DayIndex, b.DayIndex)
+	})
+	return items, nil
+}
+
+func (s *Store) CreateScheduleDay(ctx context.Context, arg repo.CreateScheduleDayParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row := repo.ReportScheduleDay(arg)
+	row.Hours = float(row.Hours)
+	s.data.scheduleDays = append(s.data.scheduleDays, row)
+	return nil
+}
+
+func (s *Store) DeleteScheduleDays(ctx context.Context, scheduleID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.scheduleDays = slices.DeleteFunc(s.data.scheduleDays, func(d repo.ReportScheduleDay) bool {
+		return eq(d.ScheduleID, scheduleID)
+	})
+	return nil
+}
+
+// GetUserSchedules - report_user_schedule INNER JOIN report_schedule
+func (s *Store) GetUserSchedules(ctx context.Context, userID string) ([]repo.GetUserSchedulesRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []repo.GetUserSchedulesRow
+	for _, us := range s.data.userSchedules {
+		if !eq(us.UserID, userID) {
+			continue
+		}
+		for _, rs := range s.data.schedules {
+			if eq(us.ScheduleID, rs.ID) {
+				items = append(items, repo.GetUserSchedulesRow{
+					ID:            us.ID,
+					UserID:        us.UserID,
+					ScheduleID:    us.ScheduleID,
+					EffectiveFrom: us.EffectiveFrom,
+					EffectiveTo:   us.EffectiveTo,
+					ScheduleName:  rs.Name,
+					ScheduleKind:  rs.Kind,
+				})
+			}
+		}
+	}
+	slices.SortStableFunc(items, func(a, b repo.GetUserSchedulesRow) int {
+		return a.EffectiveFrom.Compare(b.EffectiveFrom)
+	})
+	return items, nil
+}
+
+func (s *Store) CreateUserSchedule(ctx context.Context, arg repo.CreateUserScheduleParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row := repo.ReportUserSchedule(arg)
+	row.EffectiveFrom = date(row.EffectiveFrom)
+	row.EffectiveTo = nullDate(row.EffectiveTo)
+	s.data.userSchedules = append(s.data.userSchedules, row)
+	return nil
+}
+
+func (s *Store) DeleteUserSchedule(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.userSchedules = slices.DeleteFunc(s.data.userSchedules, func(us repo.ReportUserSchedule) bool {
+		return eq(us.ID, id)
+	})
+	return nil
+}
+
+func (s *Store) CountUserSchedulesBySchedule(ctx context.Context, scheduleID string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return count(s.data.userSchedules, func(us repo.ReportUserSchedule) bool {
+		return eq(us.ScheduleID, scheduleID)
+	}), nil
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"database/sql"
+)
+
+// setting - строка report_setting с id = 1
+func (s *Store) setting() (*repo.ReportSetting, error) {
+	for i := range s.data.settings {
+		if s.data.settings[i].ID == 1 {
+			return &s.data.settings[i], nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (s *Store) GetSettingVacationDuration(ctx context.Context) (int32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	setting, err := s.setting()
+	if err != nil {
+		return 0, err
+	}
+	return setting.VacationDuration, nil
+}
+
+func (s *Store) GetSettingNightWindow(ctx context.Context) (repo.GetSettingNightWindowRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	setting, err := s.setting()
+	if err != nil {
+		return repo.GetSettingNightWindowRow{}, err
+	}
+	return repo.GetSettingNightWindowRow{
+		NightStartMinute: setting.NightStartMinute,
+		NightEndMinute:   setting.NightEndMinute,
+	}, nil
+}
+
+func (s *Store) UpdateSettingNightWindow(ctx context.Context, arg repo.UpdateSettingNightWindowParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if setting, err := s.setting(); err == nil {
+		setting.NightStartMinute = arg.NightStartMinute
+		setting.NightEndMinute = arg.NightEndMinute
+	}
+	return nil
+}
+
+func (s *Store) GetSettingPayrollLayout(ctx context.Context) (sql.NullString, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	setting, err := s.setting()
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return setting.PayrollLayout, nil
+}
+
+func (s *Store) UpdateSettingPayrollLayout(ctx context.Context, payrollLayout sql.NullString) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if setting, err := s.setting(); err == nil {
+		setting.PayrollLayout = payrollLayout
+	}
+	return nil
+}
+
+// GetSchemaVersion - хранилище в памяти всегда создается по текущей схеме
+func (s *Store) GetSchemaVersion(ctx context.Context) (int32, error) {
+	return repo.SchemaVersion, nil
+}
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"cmp"
+	"context"
+	"slices"
+)
+
+func (s *Store) GetShiftsForMonth(ctx context.Context, arg repo.GetShiftsForMonthParams) ([]repo.ReportShift, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.shifts, func(sh repo.ReportShift) bool {
+		return eq(sh.UserID, arg.UserID) && sh.Month == arg.Month && sh.Year == arg.Year
+	})
+	slices.SortStableFunc(items, func(a, b repo.ReportShift) int {
+		return cmp.Compare(a.Day, b.Day)
+	})
+	return items, nil
+}
+
+func (s *Store) GetShiftsForMonthAll(ctx context.Context, arg repo.GetShiftsForMonthAllParams) ([]repo.ReportShift, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.shifts, func(sh repo.ReportShift) bool {
+		return sh.Month == arg.Month && sh.Year == arg.Year
+	})
+	slices.SortStableFunc(items, func(a, b repo.ReportShift) int {
+		return cmp.Or(cmp.Compare(a.Day, b.Day), compareText(a.UserID, b.UserID))
+	})
+	return items, nil
+}
+
+func (s *Store) GetShift(ctx context.Context, arg repo.GetShiftParams) (repo.ReportShift, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.shifts, func(sh repo.ReportShift) bool {
+		return eq(sh.UserID, arg.UserID) && sh.Day == arg.Day && sh.Month == arg.Month && sh.Year == arg.Year
+	}))
+}
+
+func (s *Store) GetShiftById(ctx context.Context, id string) (repo.ReportShift, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.shifts, func(sh repo.ReportShift) bool {
+		return eq(sh.ID, id)
+	}))
+}
+
+func (s *Store) CreateShift(ctx context.Context, arg repo.CreateShiftParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.shifts = append(s.data.shifts, repo.ReportShift(arg))
+	return nil
+}
+
+func (s *Store) UpdateShift(ctx context.Context, arg repo.UpdateShiftParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.shifts {
+		if sh := &s.data.shifts[i]; eq(sh.ID, arg.ID) {
+			sh.StartMinute, sh.EndMinute = arg.StartMinute, arg.EndMinute
+		}
+	}
+	return nil
+}
+
+func (s *Store) DeleteShift(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.shifts = slices.DeleteFunc(s.data.shifts, func(sh repo.ReportShift) bool {
+		return eq(sh.ID, id)
+	})
+	return nil
+}
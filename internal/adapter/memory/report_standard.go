@@ -0,0 +1,78 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"cmp"
+	"context"
+	"slices"
+)
+
+func (s *Store) GetStandard(ctx context.Context, arg repo.GetStandardParams) (repo.ReportStandard, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.standards, func(st repo.ReportStandard) bool {
+		return st.Month == arg.Month && st.Year == arg.Year && st.GenderID == arg.GenderID
+	}))
+}
+
+func (s *Store) GetStandardByMonth(ctx context.Context, arg repo.GetStandardByMonthParams) ([]repo.ReportStandard, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return where(s.data.standards, func(st repo.ReportStandard) bool {
+		return st.Month == arg.Month && st.Year == arg.Year
+	}), nil
+}
+
+func (s *Store) GetStandardByYear(ctx context.Context, year int32) ([]repo.ReportStandard, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.standards, func(st repo.ReportStandard) bool {
+		return st.Year == year
+	})
+	slices.SortStableFunc(items, func(a, b repo.ReportStandard) int {
+		return cmp.Compare(a.Month, b.Month)
+	})
+	return items, nil
+}
+
+func (s *Store) CreateStandard(ctx context.Context, arg repo.CreateStandardParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.standards = append(s.data.standards, repo.ReportStandard(arg))
+	return nil
+}
+
+func (s *Store) UpdateStandard(ctx context.Context, arg repo.UpdateStandardParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.standards {
+		if st := &s.data.standards[i]; eq(st.ID, arg.ID) {
+			st.Hours = arg.Hours
+		}
+	}
+	return nil
+}
+
+func (s *Store) DeleteStandard(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.standards = slices.DeleteFunc(s.data.standards, func(st repo.ReportStandard) bool {
+		return eq(st.ID, id)
+	})
+	return nil
+}
+
+func (s *Store) CheckStandard(ctx context.Context, arg repo.CheckStandardParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return count(s.data.standards, func(st repo.ReportStandard) bool {
+		return st.Month == arg.Month && st.Year == arg.Year && st.GenderID == arg.GenderID
+	}), nil
+}
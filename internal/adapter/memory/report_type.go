@@ -0,0 +1,66 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"slices"
+)
+
+func (s *Store) GetTypeById(ctx context.Context, id string) (repo.ReportType, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.types, func(rt repo.ReportType) bool {
+		return eq(rt.ID, id)
+	}))
+}
+
+func (s *Store) GetTypeBySystemName(ctx context.Context, systemName string) (repo.ReportType, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.types, func(rt repo.ReportType) bool {
+		return eq(rt.SystemName, systemName)
+	}))
+}
+
+func (s *Store) GetTypeAll(ctx context.Context) ([]repo.ReportType, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.types, all)
+	slices.SortStableFunc(items, func(a, b repo.ReportType) int {
+		return compareText(a.Name, b.Name)
+	})
+	return items, nil
+}
+
+func (s *Store) CreateType(ctx context.Context, arg repo.CreateTypeParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.types = append(s.data.types, repo.ReportType(arg))
+	return nil
+}
+
+func (s *Store) UpdateType(ctx context.Context, arg repo.UpdateTypeParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.types {
+		if rt := &s.data.types[i]; eq(rt.ID, arg.ID) {
+			rt.Name, rt.SystemName, rt.Code = arg.Name, arg.SystemName, arg.Code
+		}
+	}
+	return nil
+}
+
+func (s *Store) DeleteType(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.types = slices.DeleteFunc(s.data.types, func(rt repo.ReportType) bool {
+		return eq(rt.ID, id)
+	})
+	return nil
+}
@@ -0,0 +1,320 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"cmp"
+	"context"
+	"slices"
+)
+
+// reportRows - report_user INNER JOIN report_type
+func (s *Store) reportRows(match func(ru repo.ReportUser, rt repo.ReportType) bool) []repo.GetReportUserForMonthRow {
+	var items []repo.GetReportUserForMonthRow
+	for _, ru := range s.data.reports {
+		for _, rt := range s.data.types {
+			if !eq(ru.TypeID, rt.ID) || !match(ru, rt) {
+				continue
+			}
+			items = append(items, repo.GetReportUserForMonthRow{
+				ID:             ru.ID,
+				UserID:         ru.UserID,
+				Day:            ru.Day,
+				Month:          ru.Month,
+				Year:           ru.Year,
+				Hours:          ru.Hours,
+				TypeID:         ru.TypeID,
+				StartMinute:    ru.StartMinute,
+				EndMinute:      ru.EndMinute,
+				NightHours:     ru.NightHours,
+				TypeName:       rt.Name,
+				TypeSystemName: rt.SystemName,
+			})
+		}
+	}
+	return items
+}
+
+// sumHours - CAST(COALESCE(SUM(...), 0.0) AS FLOAT)
+func sumHours(table []repo.ReportUser, match func(repo.ReportUser) bool, value func(repo.ReportUser) float64) float64 {
+	var sum float64
+	for _, ru := range table {
+		if match(ru) {
+			sum += value(ru)
+		}
+	}
+	return float(sum)
+}
+
+func hours(ru repo.ReportUser) float64 {
+	return ru.Hours
+}
+
+func nightHours(ru repo.ReportUser) float64 {
+	return ru.NightHours
+}
+
+// countDays - COUNT(DISTINCT day)
+func countDays(days []int32) int64 {
+	slices.Sort(days)
+	return int64(len(slices.Compact(days)))
+}
+
+func (s *Store) GetReportUserForMonth(ctx context.Context, arg repo.GetReportUserForMonthParams) ([]repo.GetReportUserForMonthRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.reportRows(func(ru repo.ReportUser, rt repo.ReportType) bool {
+		return eq(ru.UserID, arg.UserID) && ru.Month == arg.Month && ru.Year == arg.Year
+	})
+	slices.SortStableFunc(items, func(a, b repo.GetReportUserForMonthRow) int {
+		return cmp.Compare(a.Day, b.Day)
+	})
+	return items, nil
+}
+
+func (s *Store) GetReportUserForMonthAll(ctx context.Context, arg repo.GetReportUserForMonthAllParams) ([]repo.GetReportUserForMonthAllRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []repo.GetReportUserForMonthAllRow
+	for _, ru := range s.data.reports {
+		if ru.Month != arg.Month || ru.Year != arg.Year {
+			continue
+		}
+		for _, rt := range s.data.types {
+			if eq(ru.TypeID, rt.ID) {
+				items = append(items, repo.GetReportUserForMonthAllRow{
+					ID:             ru.ID,
+					UserID:         ru.UserID,
+					Day:            ru.Day,
+					Month:          ru.Month,
+					Year:           ru.Year,
+					Hours:          ru.Hours,
+					TypeID:         ru.TypeID,
+					NightHours:     ru.NightHours,
+					TypeName:       rt.Name,
+					TypeSystemName: rt.SystemName,
+					TypeCode:       rt.Code,
+				})
+			}
+		}
+	}
+	slices.SortStableFunc(items, func(a, b repo.GetReportUserForMonthAllRow) int {
+		return cmp.Or(compareText(a.UserID, b.UserID), cmp.Compare(a.Day, b.Day))
+	})
+	return items, nil
+}
+
+func (s *Store) GetReportUserById(ctx context.Context, id string) (repo.GetReportUserByIdRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.reportRows(func(ru repo.ReportUser, rt repo.ReportType) bool {
+		return eq(ru.ID, id)
+	})
+	return first(convert(items, func(r repo.GetReportUserForMonthRow) repo.GetReportUserByIdRow { return repo.GetReportUserByIdRow(r) }))
+}
+
+// GetReportUserByIdForUpdate - хранилище в памяти строки не блокирует: оно для тестов
+// сервисов, где транзакции не идут параллельно
+func (s *Store) GetReportUserByIdForUpdate(ctx context.Context, id string) (repo.GetReportUserByIdForUpdateRow, error) {
+	row, err := s.GetReportUserById(ctx, id)
+	return repo.GetReportUserByIdForUpdateRow(row), err
+}
+
+func (s *Store) GetReportUserForDay(ctx context.Context, arg repo.GetReportUserForDayParams) ([]repo.GetReportUserForDayRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.reportRows(func(ru repo.ReportUser, rt repo.ReportType) bool {
+		return eq(ru.UserID, arg.UserID) && ru.Day == arg.Day && ru.Month == arg.Month && ru.Year == arg.Year
+	})
+	slices.SortStableFunc(items, func(a, b repo.GetReportUserForMonthRow) int {
+		return compareText(a.TypeName, b.TypeName)
+	})
+	return convert(items, func(r repo.GetReportUserForMonthRow) repo.GetReportUserForDayRow {
+		return repo.GetReportUserForDayRow(r)
+	}), nil
+}
+
+func (s *Store) GetReportUserDayTotalHours(ctx context.Context, arg repo.GetReportUserDayTotalHoursParams) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return sumHours(s.data.reports, func(ru repo.ReportUser) bool {
+		return eq(ru.UserID, arg.UserID) && ru.Day == arg.Day && ru.Month == arg.Month && ru.Year == arg.Year
+	}, hours), nil
+}
+
+// GetReportUserStatsByType группирует по типу в порядке первого появления, затем сортирует по названию
+func (s *Store) GetReportUserStatsByType(ctx context.Context, arg repo.GetReportUserStatsByTypeParams) ([]repo.GetReportUserStatsByTypeRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	type group struct {
+		row  repo.GetReportUserStatsByTypeRow
+		days []int32
+		sum  float64
+	}
+
+	var groups []*group
+	for _, r := range s.reportRows(func(ru repo.ReportUser, rt repo.ReportType) bool {
+		return eq(ru.UserID, arg.UserID) && ru.Month == arg.Month && ru.Year == arg.Year
+	}) {
+		i := slices.IndexFunc(groups, func(g *group) bool {
+			return eq(g.row.TypeID, r.TypeID) && eq(g.row.TypeName, r.TypeName) && eq(g.row.TypeSystemName, r.TypeSystemName)
+		})
+		if i < 0 {
+			groups = append(groups, &group{row: repo.GetReportUserStatsByTypeRow{
+				TypeID:         r.TypeID,
+				TypeName:       r.TypeName,
+				TypeSystemName: r.TypeSystemName,
+			}})
+			i = len(groups) - 1
+		}
+		groups[i].days = append(groups[i].days, r.Day)
+		groups[i].sum += r.Hours
+	}
+
+	var items []repo.GetReportUserStatsByTypeRow
+	for _, g := range groups {
+		g.row.DaysCount = countDays(g.days)
+		g.row.TotalHours = float(g.sum)
+		items = append(items, g.row)
+	}
+	slices.SortStableFunc(items, func(a, b repo.GetReportUserStatsByTypeRow) int {
+		return compareText(a.TypeName, b.TypeName)
+	})
+	return items, nil
+}
+
+func (s *Store) GetReportUserTotalHours(ctx context.Context, arg repo.GetReportUserTotalHoursParams) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return sumHours(s.data.reports, func(ru repo.ReportUser) bool {
+		return eq(ru.UserID, arg.UserID) && ru.Month == arg.Month && ru.Year == arg.Year
+	}, hours), nil
+}
+
+func (s *Store) GetReportUserNightHours(ctx context.Context, arg repo.GetReportUserNightHoursParams) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return sumHours(s.data.reports, func(ru repo.ReportUser) bool {
+		return eq(ru.UserID, arg.UserID) && ru.Month == arg.Month && ru.Year == arg.Year
+	}, nightHours), nil
+}
+
+func (s *Store) GetReportUserCountByType(ctx context.Context, arg repo.GetReportUserCountByTypeParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var days []int32
+	for _, ru := range s.data.reports {
+		if eq(ru.UserID, arg.UserID) && ru.Month == arg.Month && ru.Year == arg.Year && eq(ru.TypeID, arg.TypeID) {
+			days = append(days, ru.Day)
+		}
+	}
+	return countDays(days), nil
+}
+
+func (s *Store) GetReportUserCountWork(ctx context.Context, arg repo.GetReportUserCountWorkParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var days []int32
+	for _, r := range s.reportRows(func(ru repo.ReportUser, rt repo.ReportType) bool {
+		return eq(ru.UserID, arg.UserID) && ru.Month == arg.Month && ru.Year == arg.Year &&
+			(eq(rt.SystemName, "work") || eq(rt.SystemName, "weekend"))
+	}) {
+		days = append(days, r.Day)
+	}
+	return countDays(days), nil
+}
+
+func (s *Store) CreateReportUser(ctx context.Context, arg repo.CreateReportUserParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row := repo.ReportUser(arg)
+	if s.duplicateReport(row) {
+		return repo.ErrDuplicateKey
+	}
+	row.Hours, row.NightHours = float(row.Hours), float(row.NightHours)
+	s.data.reports = append(s.data.reports, row)
+	return nil
+}
+
+func (s *Store) UpdateReportUser(ctx context.Context, arg repo.UpdateReportUserParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.reports {
+		if ru := &s.data.reports[i]; eq(ru.ID, arg.ID) {
+			changed := *ru
+			changed.TypeID = arg.TypeID
+			if s.duplicateReport(changed) {
+				return repo.ErrDuplicateKey
+			}
+			ru.Hours = float(arg.Hours)
+			ru.TypeID = arg.TypeID
+			ru.StartMinute, ru.EndMinute = arg.StartMinute, arg.EndMinute
+			ru.NightHours = float(arg.NightHours)
+		}
+	}
+	return nil
+}
+
+// duplicateReport - другая отметка того же вида за тот же день, как уникальный
+// индекс report_user_day_type
+func (s *Store) duplicateReport(row repo.ReportUser) bool {
+	return count(s.data.reports, func(ru repo.ReportUser) bool {
+		return !eq(ru.ID, row.ID) && eq(ru.UserID, row.UserID) && ru.Day == row.Day &&
+			ru.Month == row.Month && ru.Year == row.Year && eq(ru.TypeID, row.TypeID)
+	}) > 0
+}
+
+func (s *Store) CheckReportUserExists(ctx context.Context, arg repo.CheckReportUserExistsParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return count(s.data.reports, func(ru repo.ReportUser) bool {
+		return eq(ru.UserID, arg.UserID) && ru.Day == arg.Day && ru.Month == arg.Month && ru.Year == arg.Year
+	}), nil
+}
+
+func (s *Store) CheckReportUserTypeExists(ctx context.Context, arg repo.CheckReportUserTypeExistsParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return count(s.data.reports, func(ru repo.ReportUser) bool {
+		return eq(ru.UserID, arg.UserID) && ru.Day == arg.Day && ru.Month == arg.Month && ru.Year == arg.Year &&
+			eq(ru.TypeID, arg.TypeID)
+	}), nil
+}
+
+func (s *Store) DeleteReportUserById(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.reports = slices.DeleteFunc(s.data.reports, func(ru repo.ReportUser) bool {
+		return eq(ru.ID, id)
+	})
+	return nil
+}
+
+func (s *Store) DeleteReportUser(ctx context.Context, arg repo.DeleteReportUserParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.reports = slices.DeleteFunc(s.data.reports, func(ru repo.ReportUser) bool {
+		return eq(ru.UserID, arg.UserID) && ru.Day == arg.Day && ru.Month == arg.Month && ru.Year == arg.Year
+	})
+	return nil
+}
+
+// LockReportUserDay - как и GetReportUserByIdForUpdate, ничего не блокирует
+func (s *Store) LockReportUserDay(ctx context.Context, arg repo.LockReportUserDayParams) error {
+	return nil
+}
@@ -0,0 +1,205 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"cmp"
+	"context"
+	"iter"
+	"slices"
+	"time"
+)
+
+// vacationRows - выборка из report_vacation - NULL в description заменяется пустой строкой.
+// Все запросы списка возвращают одни и те же столбцы, поэтому собираются в GetVacationsRow.
+func (s *Store) vacationRows(match func(v repo.ReportVacation) bool) []repo.GetVacationsRow {
+	var items []repo.GetVacationsRow
+	for _, v := range s.data.vacations {
+		if match(v) {
+			items = append(items, repo.GetVacationsRow{
+				ID:          v.ID,
+				UserID:      v.UserID,
+				StartDate:   v.StartDate,
+				EndDate:     v.EndDate,
+				Year:        v.Year,
+				Description: v.Description.String,
+				Status:      v.Status,
+				CreateAt:    v.CreateAt,
+			})
+		}
+	}
+	return items
+}
+
+// byCreateAtDesc - ORDER BY create_at DESC
+func byCreateAtDesc(a, b repo.GetVacationsRow) int {
+	return b.CreateAt.Compare(a.CreateAt)
+}
+
+func approved(v repo.ReportVacation) bool {
+	return v.Status == repo.ReportVacationStatusApproved
+}
+
+func (s *Store) GetVacations(ctx context.Context, userID string) ([]repo.GetVacationsRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.vacationRows(func(v repo.ReportVacation) bool {
+		return eq(v.UserID, userID)
+	})
+	slices.SortStableFunc(items, byCreateAtDesc)
+	return items, nil
+}
+
+func (s *Store) GetVacationsByYear(ctx context.Context, arg repo.GetVacationsByYearParams) ([]repo.GetVacationsByYearRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.vacationRows(func(v repo.ReportVacation) bool {
+		return eq(v.UserID, arg.UserID) && v.Year == arg.Year
+	})
+	slices.SortStableFunc(items, byCreateAtDesc)
+	return convert(items, func(r repo.GetVacationsRow) repo.GetVacationsByYearRow { return repo.GetVacationsByYearRow(r) }), nil
+}
+
+func (s *Store) GetAdminVacationsByYear(ctx context.Context, year int32) ([]repo.GetAdminVacationsByYearRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.vacationRows(func(v repo.ReportVacation) bool {
+		return v.Year == year
+	})
+	slices.SortStableFunc(items, byCreateAtDesc)
+	return convert(items, func(r repo.GetVacationsRow) repo.GetAdminVacationsByYearRow {
+		return repo.GetAdminVacationsByYearRow(r)
+	}), nil
+}
+
+func (s *Store) GetVacationById(ctx context.Context, id string) (repo.GetVacationByIdRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.vacationRows(func(v repo.ReportVacation) bool {
+		return eq(v.ID, id)
+	})
+	return first(convert(items, func(r repo.GetVacationsRow) repo.GetVacationByIdRow { return repo.GetVacationByIdRow(r) }))
+}
+
+func (s *Store) GetVacationApproved(ctx context.Context, userID string) ([]repo.GetVacationApprovedRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.vacationRows(func(v repo.ReportVacation) bool {
+		return eq(v.UserID, userID) && approved(v)
+	})
+	return convert(items, func(r repo.GetVacationsRow) repo.GetVacationApprovedRow { return repo.GetVacationApprovedRow(r) }), nil
+}
+
+// GetVacationsApprovedInRange - start_date <= StartDate AND end_date >= EndDate
+func (s *Store) GetVacationsApprovedInRange(ctx context.Context, arg repo.GetVacationsApprovedInRangeParams) ([]repo.GetVacationsApprovedInRangeRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.vacationRows(func(v repo.ReportVacation) bool {
+		return approved(v) && !v.StartDate.After(arg.StartDate) && !v.EndDate.Before(arg.EndDate)
+	})
+	slices.SortStableFunc(items, func(a, b repo.GetVacationsRow) int {
+		return cmp.Or(compareText(a.UserID, b.UserID), a.StartDate.Compare(b.StartDate))
+	})
+	return convert(items, func(r repo.GetVacationsRow) repo.GetVacationsApprovedInRangeRow {
+		return repo.GetVacationsApprovedInRangeRow(r)
+	}), nil
+}
+
+func (s *Store) GetVacationsApprovedStartingOn(ctx context.Context, startDate time.Time) ([]repo.GetVacationsApprovedStartingOnRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.vacationRows(func(v repo.ReportVacation) bool {
+		return approved(v) && v.StartDate.Equal(startDate)
+	})
+	slices.SortStableFunc(items, func(a, b repo.GetVacationsRow) int {
+		return compareText(a.UserID, b.UserID)
+	})
+	return convert(items, func(r repo.GetVacationsRow) repo.GetVacationsApprovedStartingOnRow {
+		return repo.GetVacationsApprovedStartingOnRow(r)
+	}), nil
+}
+
+func (s *Store) GetYearsVacation(ctx context.Context, userID string) ([]int32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []int32
+	for _, v := range s.data.vacations {
+		if eq(v.UserID, userID) && !slices.Contains(items, v.Year) {
+			items = append(items, v.Year)
+		}
+	}
+	slices.SortFunc(items, func(a, b int32) int {
+		return cmp.Compare(b, a)
+	})
+	return items, nil
+}
+
+func (s *Store) CountVacationsByStatus(ctx context.Context, status repo.ReportVacationStatus) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return count(s.data.vacations, func(v repo.ReportVacation) bool {
+		return eq(string(v.Status), string(status))
+	}), nil
+}
+
+func (s *Store) CreateVacation(ctx context.Context, arg repo.CreateVacationParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.vacations = append(s.data.vacations, repo.ReportVacation{
+		ID:          arg.ID,
+		UserID:      arg.UserID,
+		StartDate:   date(arg.StartDate),
+		EndDate:     date(arg.EndDate),
+		Year:        arg.Year,
+		Description: arg.Description,
+		Status:      arg.Status,
+		CreateAt:    s.timestamp(),
+	})
+	return nil
+}
+
+func (s *Store) UpdateVacationStatus(ctx context.Context, arg repo.UpdateVacationStatusParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.vacations {
+		if v := &s.data.vacations[i]; eq(v.ID, arg.ID) {
+			v.Status = arg.Status
+		}
+	}
+	return nil
+}
+
+func (s *Store) DeleteVacation(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.vacations = slices.DeleteFunc(s.data.vacations, func(v repo.ReportVacation) bool {
+		return eq(v.ID, id)
+	})
+	return nil
+}
+
+func (s *Store) StreamAdminVacationsByYear(ctx context.Context, year int32) iter.Seq2[repo.GetAdminVacationsByYearRow, error] {
+	return func(yield func(repo.GetAdminVacationsByYearRow, error) bool) {
+		items, err := s.GetAdminVacationsByYear(ctx, year)
+		if err != nil {
+			yield(repo.GetAdminVacationsByYearRow{}, err)
+			return
+		}
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"database/sql"
+	"slices"
+)
+
+// maxDeliveries - LIMIT запросов GetWebhookDeliveries и GetDueWebhookDeliveries
+const maxDeliveries = 100
+
+func (s *Store) GetWebhooks(ctx context.Context) ([]repo.ReportWebhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.webhooks, all)
+	slices.SortStableFunc(items, func(a, b repo.ReportWebhook) int {
+		return a.CreateAt.Compare(b.CreateAt)
+	})
+	return items, nil
+}
+
+func (s *Store) GetActiveWebhooks(ctx context.Context) ([]repo.ReportWebhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return where(s.data.webhooks, func(w repo.ReportWebhook) bool {
+		return w.IsActive
+	}), nil
+}
+
+func (s *Store) GetWebhookById(ctx context.Context, id string) (repo.ReportWebhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.webhooks, func(w repo.ReportWebhook) bool {
+		return eq(w.ID, id)
+	}))
+}
+
+func (s *Store) CreateWebhook(ctx context.Context, arg repo.CreateWebhookParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.webhooks = append(s.data.webhooks, repo.ReportWebhook{
+		ID:       arg.ID,
+		Url:      arg.Url,
+		Secret:   arg.Secret,
+		Events:   arg.Events,
+		IsActive: arg.IsActive,
+		CreateAt: s.timestamp(),
+	})
+	return nil
+}
+
+func (s *Store) UpdateWebhook(ctx context.Context, arg repo.UpdateWebhookParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.webhooks {
+		if w := &s.data.webhooks[i]; eq(w.ID, arg.ID) {
+			w.Url, w.Events, w.IsActive = arg.Url, arg.Events, arg.IsActive
+		}
+	}
+	return nil
+}
+
+func (s *Store) DeleteWebhook(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.webhooks = slices.DeleteFunc(s.data.webhooks, func(w repo.ReportWebhook) bool {
+		return eq(w.ID, id)
+	})
+	return nil
+}
+
+func (s *Store) GetWebhookDeliveries(ctx context.Context, webhookID string) ([]repo.ReportWebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.webhookDelivery, func(d repo.ReportWebhookDelivery) bool {
+		return eq(d.WebhookID, webhookID)
+	})
+	slices.SortStableFunc(items, func(a, b repo.ReportWebhookDelivery) int {
+		return b.CreateAt.Compare(a.CreateAt)
+	})
+	if len(items) > maxDeliveries {
+		items = items[:maxDeliveries]
+	}
+	return items, nil
+}
+
+func (s *Store) GetWebhookDeliveryById(ctx context.Context, id string) (repo.ReportWebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return first(where(s.data.webhookDelivery, func(d repo.ReportWebhookDelivery) bool {
+		return eq(d.ID, id)
+	}))
+}
+
+func (s *Store) CreateWebhookDelivery(ctx context.Context, arg repo.CreateWebhookDeliveryParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.timestamp()
+	s.data.webhookDelivery = append(s.data.webhookDelivery, repo.ReportWebhookDelivery{
+		ID:            arg.ID,
+		WebhookID:     arg.WebhookID,
+		Event:         arg.Event,
+		Payload:       arg.Payload,
+		Status:        "pending",
+		NextAttemptAt: arg.NextAttemptAt,
+		CreateAt:      now,
+		UpdateAt:      now,
+	})
+	return nil
+}
+
+// UpdateWebhookDelivery - update_at ON UPDATE CURRENT_TIMESTAMP меняется, только если изменилась строка
+func (s *Store) UpdateWebhookDelivery(ctx context.Context, arg repo.UpdateWebhookDeliveryParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.webhookDelivery {
+		d := &s.data.webhookDelivery[i]
+		if !eq(d.ID, arg.ID) {
+			continue
+		}
+		before := *d
+		d.Status, d.Attempts, d.ResponseCode, d.Error, d.NextAttemptAt = arg.Status, arg.Attempts, arg.ResponseCode, arg.Error, arg.NextAttemptAt
+		if *d != before {
+			d.UpdateAt = s.timestamp()
+		}
+	}
+	return nil
+}
+
+func (s *Store) DeleteWebhookDeliveries(ctx context.Context, webhookID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.webhookDelivery = slices.DeleteFunc(s.data.webhookDelivery, func(d repo.ReportWebhookDelivery) bool {
+		return eq(d.WebhookID, webhookID)
+	})
+	return nil
+}
+
+// due - ожидающая доставка, время попытки которой наступило к now (NULL <= now ложно)
+func due(d repo.ReportWebhookDelivery, now sql.NullTime) bool {
+	return d.Status == "pending" && d.NextAttemptAt.Valid && now.Valid && !d.NextAttemptAt.Time.After(now.Time)
+}
+
+func (s *Store) GetDueWebhookDeliveries(ctx context.Context, nextAttemptAt sql.NullTime) ([]repo.ReportWebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := where(s.data.webhookDelivery, func(d repo.ReportWebhookDelivery) bool {
+		return due(d, nextAttemptAt)
+	})
+	slices.SortStableFunc(items, func(a, b repo.ReportWebhookDelivery) int {
+		return a.NextAttemptAt.Time.Compare(b.NextAttemptAt.Time)
+	})
+	if len(items) > maxDeliveries {
+		items = items[:maxDeliveries]
+	}
+	return items, nil
+}
+
+func (s *Store) ClaimWebhookDelivery(ctx context.Context, arg repo.ClaimWebhookDeliveryParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var claimed int64
+	for i := range s.data.webhookDelivery {
+		d := &s.data.webhookDelivery[i]
+		if eq(d.ID, arg.ID) && due(*d, arg.Now) {
+			d.NextAttemptAt = arg.LeaseUntil
+			d.UpdateAt = s.timestamp()
+			claimed++
+		}
+	}
+	return claimed, nil
+}
@@ -0,0 +1,225 @@
+// Package memory - реализация repo.Querier в памяти для тестов сервисов без MySQL.
+// Запросы повторяют семантику sqlc-запросов из adapter/mysql/sqlc/query: соединения,
+// COALESCE, сортировку, значения по умолчанию из schema.sql и сравнение строк без учета
+// регистра (utf8mb4_0900_ai_ci). Ограничения длины столбцов не проверяются.
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	_ repo.Querier    = (*Store)(nil)
+	_ repo.Streamer   = (*Store)(nil)
+	_ repo.TxBeginner = (*Store)(nil)
+)
+
+// data - содержимое таблиц в порядке вставки (как у InnoDB без первичного ключа)
+type data struct {
+	allocations     []repo.ReportAllocation
+	calendar        []repo.ReportCalendar
+	chatLinks       []repo.ReportChatLink
+	chatLinkCodes   []repo.ReportChatLinkCode
+	contacts        []repo.ReportUserContact
+	departments     []repo.ReportDepartment
+	directoryUsers  []repo.ReportDirectoryUser
+	documents       []repo.ReportDocumentTemplate
+	monthCloses     []repo.ReportMonthClose
+	projects        []repo.ReportProject
+	reports         []repo.ReportUser
+	scheduleDays    []repo.ReportScheduleDay
+	schedules       []repo.ReportSchedule
+	settings        []repo.ReportSetting
+	shifts          []repo.ReportShift
+	standards       []repo.ReportStandard
+	tasks           []repo.ReportTask
+	types           []repo.ReportType
+	userDepartments []repo.ReportUserDepartment
+	userSchedules   []repo.ReportUserSchedule
+	vacations       []repo.ReportVacation
+	webhookDelivery []repo.ReportWebhookDelivery
+	webhooks        []repo.ReportWebhook
+}
+
+func (d *data) clone() *data {
+	return &data{
+		allocations:     slices.Clone(d.allocations),
+		calendar:        slices.Clone(d.calendar),
+		chatLinks:       slices.Clone(d.chatLinks),
+		chatLinkCodes:   slices.Clone(d.chatLinkCodes),
+		contacts:        slices.Clone(d.contacts),
+		departments:     slices.Clone(d.departments),
+		directoryUsers:  slices.Clone(d.directoryUsers),
+		documents:       slices.Clone(d.documents),
+		monthCloses:     slices.Clone(d.monthCloses),
+		projects:        slices.Clone(d.projects),
+		reports:         slices.Clone(d.reports),
+		scheduleDays:    slices.Clone(d.scheduleDays),
+		schedules:       slices.Clone(d.schedules),
+		settings:        slices.Clone(d.settings),
+		shifts:          slices.Clone(d.shifts),
+		standards:       slices.Clone(d.standards),
+		tasks:           slices.Clone(d.tasks),
+		types:           slices.Clone(d.types),
+		userDepartments: slices.Clone(d.userDepartments),
+		userSchedules:   slices.Clone(d.userSchedules),
+		vacations:       slices.Clone(d.vacations),
+		webhookDelivery: slices.Clone(d.webhookDelivery),
+		webhooks:        slices.Clone(d.webhooks),
+	}
+}
+
+// Store - хранилище в памяти; безопасно для параллельного использования
+type Store struct {
+	mu   sync.Mutex
+	data *data
+	now  func() time.Time
+}
+
+// New возвращает пустое хранилище. Строка report_setting (id = 1) создается
+// со значениями по умолчанию из схемы, как после первоначальной настройки сервиса.
+func New() *Store {
+	return &Store{
+		data: &data{
+			settings: []repo.ReportSetting{{
+				ID:               1,
+				VacationDuration: 30,
+				NightStartMinute: 1320,
+				NightEndMinute:   360,
+			}},
+		},
+		now: time.Now,
+	}
+}
+
+// SetClock подменяет время для CURRENT_TIMESTAMP и NOW()
+func (s *Store) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// Begin открывает транзакцию над снимком данных. Commit заменяет данные хранилища
+// снимком целиком, поэтому изменения, сделанные параллельно вне транзакции, теряются.
+func (s *Store) Begin(ctx context.Context) (repo.Tx, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return &tx{
+		Store:  &Store{data: s.data.clone(), now: s.now},
+		parent: s,
+	}, nil
+}
+
+type tx struct {
+	*Store
+	parent *Store
+	done   bool
+}
+
+func (t *tx) Commit() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+
+	t.Store.mu.Lock()
+	defer t.Store.mu.Unlock()
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+
+	t.parent.data = t.Store.data.clone()
+	return nil
+}
+
+func (t *tx) Rollback() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+	return nil
+}
+
+// timestamp - значение CURRENT_TIMESTAMP: столбец TIMESTAMP хранит секунды
+func (s *Store) timestamp() time.Time {
+	return s.now().In(time.Local).Truncate(time.Second)
+}
+
+// eq сравнивает строки как utf8mb4_0900_ai_ci - без учета регистра
+func eq(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
+
+// compareText - порядок строк в ORDER BY при той же сортировке
+func compareText(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// float приводит значение к точности столбца FLOAT и CAST(... AS FLOAT)
+func float(v float64) float64 {
+	return float64(float32(v))
+}
+
+// date - значение столбца DATE: полночь в часовом поясе соединения (loc=Local)
+func date(t time.Time) time.Time {
+	y, m, d := t.In(time.Local).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+func nullDate(t sql.NullTime) sql.NullTime {
+	if !t.Valid {
+		return t
+	}
+	return sql.NullTime{Time: date(t.Time), Valid: true}
+}
+
+// first - результат запроса :one: первая строка или sql.ErrNoRows
+func first[T any](items []T) (T, error) {
+	if len(items) == 0 {
+		var zero T
+		return zero, sql.ErrNoRows
+	}
+	return items[0], nil
+}
+
+// where - строки таблицы, удовлетворяющие условию, в порядке вставки.
+// Пустой результат - nil, как у сгенерированных запросов :many.
+func where[T any](table []T, match func(T) bool) []T {
+	var items []T
+	for _, row := range table {
+		if match(row) {
+			items = append(items, row)
+		}
+	}
+	return items
+}
+
+// count - COUNT(*) по условию
+func count[T any](table []T, match func(T) bool) int64 {
+	var n int64
+	for _, row := range table {
+		if match(row) {
+			n++
+		}
+	}
+	return n
+}
+
+func all[T any](T) bool {
+	return true
+}
+
+// convert - строки другого запроса с теми же столбцами
+func convert[T, S any](rows []S, fn func(S) T) []T {
+	var items []T
+	for _, row := range rows {
+		items = append(items, fn(row))
+	}
+	return items
+}
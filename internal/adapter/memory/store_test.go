@@ -0,0 +1,61 @@
+package memory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestTxRollbackDiscardsChanges(t *testing.T) {
+	ctx := context.Background()
+	store := New()
+
+	tx, err := store.Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.CreateType(ctx, repo.CreateTypeParams{ID: "t-work", Name: "Явка", SystemName: "work"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.GetTypeById(ctx, "t-work"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("uncommitted row is visible: err = %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); !errors.Is(err, sql.ErrTxDone) {
+		t.Fatalf("commit after rollback: err = %v, want sql.ErrTxDone", err)
+	}
+	if _, err := store.GetTypeById(ctx, "t-work"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("rolled back row is visible: err = %v", err)
+	}
+}
+
+func TestTxCommit(t *testing.T) {
+	ctx := context.Background()
+	store := New()
+
+	tx, err := store.Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.CreateType(ctx, repo.CreateTypeParams{ID: "t-work", Name: "Явка", SystemName: "work"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); !errors.Is(err, sql.ErrTxDone) {
+		t.Fatalf("rollback after commit: err = %v, want sql.ErrTxDone", err)
+	}
+
+	got, err := store.GetTypeBySystemName(ctx, "WORK")
+	if err != nil {
+		t.Fatalf("committed row: %v", err)
+	}
+	if got.ID != "t-work" {
+		t.Fatalf("id = %q", got.ID)
+	}
+}
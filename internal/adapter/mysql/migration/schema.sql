@@ -28,7 +28,8 @@ CREATE TABLE report_standard (
 CREATE TABLE report_type (
   id varchar(36) NOT NULL,
   name varchar(50) NOT NULL,
-  system_name varchar(50) NOT NULL
+  system_name varchar(50) NOT NULL,
+  code varchar(4) NOT NULL DEFAULT ''
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 -- --------------------------------------------------------
 --
@@ -41,8 +42,15 @@ CREATE TABLE report_user (
   month int NOT NULL,
   year int NOT NULL,
   hours float NOT NULL DEFAULT 0.0,
-  type_id varchar(36) NOT NULL
+  type_id varchar(36) NOT NULL,
+  start_minute int DEFAULT NULL,
+  end_minute int DEFAULT NULL,
+  night_hours float NOT NULL DEFAULT 0.0
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+--
+-- Уникальный индекс report_user: одна отметка каждого вида за день
+--
+CREATE UNIQUE INDEX report_user_day_type ON report_user (user_id, day, month, year, type_id);
 -- --------------------------------------------------------
 --
 -- Структура таблицы report_vacation
@@ -63,6 +71,200 @@ CREATE TABLE report_vacation (
 --
 CREATE TABLE `report_setting` (
   `id` int NOT NULL,
-  `vacation_duration` int NOT NULL DEFAULT '30'
+  `vacation_duration` int NOT NULL DEFAULT '30',
+  `night_start_minute` int NOT NULL DEFAULT '1320',
+  `night_end_minute` int NOT NULL DEFAULT '360',
+  `payroll_layout` text DEFAULT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_schedule
+--
+CREATE TABLE report_schedule (
+  id varchar(36) NOT NULL,
+  name varchar(100) NOT NULL,
+  kind enum('weekly','shift') NOT NULL DEFAULT 'weekly',
+  cycle_length int NOT NULL DEFAULT 7,
+  cycle_start date DEFAULT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_schedule_day
+--
+CREATE TABLE report_schedule_day (
+  id varchar(36) NOT NULL,
+  schedule_id varchar(36) NOT NULL,
+  day_index int NOT NULL,
+  hours float NOT NULL DEFAULT 0.0
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_user_schedule
+--
+CREATE TABLE report_user_schedule (
+  id varchar(36) NOT NULL,
+  user_id varchar(36) NOT NULL,
+  schedule_id varchar(36) NOT NULL,
+  effective_from date NOT NULL,
+  effective_to date DEFAULT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_shift
+--
+CREATE TABLE report_shift (
+  id varchar(36) NOT NULL,
+  user_id varchar(36) NOT NULL,
+  day int NOT NULL,
+  month int NOT NULL,
+  year int NOT NULL,
+  start_minute int NOT NULL,
+  end_minute int NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_department
+--
+CREATE TABLE report_department (
+  id varchar(36) NOT NULL,
+  name varchar(100) NOT NULL
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
--- --------------------------------------------------------
\ No newline at end of file
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_user_department
+--
+CREATE TABLE report_user_department (
+  user_id varchar(36) NOT NULL,
+  department_id varchar(36) NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_project
+--
+CREATE TABLE report_project (
+  id varchar(36) NOT NULL,
+  code varchar(50) NOT NULL,
+  name varchar(100) NOT NULL,
+  client varchar(100) DEFAULT NULL,
+  is_active tinyint(1) NOT NULL DEFAULT '1'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_task
+--
+CREATE TABLE report_task (
+  id varchar(36) NOT NULL,
+  project_id varchar(36) NOT NULL,
+  name varchar(100) NOT NULL,
+  is_active tinyint(1) NOT NULL DEFAULT '1'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_allocation
+--
+CREATE TABLE report_allocation (
+  id varchar(36) NOT NULL,
+  report_id varchar(36) NOT NULL,
+  project_id varchar(36) NOT NULL,
+  task_id varchar(36) DEFAULT NULL,
+  hours float NOT NULL DEFAULT 0.0
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_document_template
+--
+CREATE TABLE report_document_template (
+  kind varchar(50) NOT NULL,
+  title varchar(200) NOT NULL,
+  body text NOT NULL,
+  update_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_webhook
+--
+CREATE TABLE report_webhook (
+  id varchar(36) NOT NULL,
+  url varchar(500) NOT NULL,
+  secret varchar(100) NOT NULL,
+  events varchar(500) NOT NULL DEFAULT '*',
+  is_active tinyint(1) NOT NULL DEFAULT '1',
+  create_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_webhook_delivery
+--
+CREATE TABLE report_webhook_delivery (
+  id varchar(36) NOT NULL,
+  webhook_id varchar(36) NOT NULL,
+  event varchar(50) NOT NULL,
+  payload text NOT NULL,
+  status varchar(20) NOT NULL DEFAULT 'pending',
+  attempts int NOT NULL DEFAULT 0,
+  response_code int DEFAULT NULL,
+  error varchar(500) DEFAULT NULL,
+  next_attempt_at timestamp NULL DEFAULT NULL,
+  create_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_user_contact
+--
+CREATE TABLE report_user_contact (
+  user_id varchar(36) NOT NULL,
+  email varchar(255) NOT NULL,
+  name varchar(100) NOT NULL DEFAULT '',
+  manager_id varchar(36) DEFAULT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_chat_link
+--
+CREATE TABLE report_chat_link (
+  chat_id bigint NOT NULL,
+  user_id varchar(36) NOT NULL,
+  create_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_chat_link_code
+--
+CREATE TABLE report_chat_link_code (
+  code varchar(16) NOT NULL,
+  user_id varchar(36) NOT NULL,
+  expire_at datetime NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_directory_user
+--
+CREATE TABLE report_directory_user (
+  id varchar(36) NOT NULL,
+  dn varchar(500) NOT NULL,
+  login varchar(100) NOT NULL DEFAULT '',
+  name varchar(200) NOT NULL DEFAULT '',
+  email varchar(255) NOT NULL DEFAULT '',
+  is_active tinyint(1) NOT NULL DEFAULT '1',
+  sync_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_month_close
+--
+CREATE TABLE report_month_close (
+  month int NOT NULL,
+  year int NOT NULL,
+  close_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+CREATE UNIQUE INDEX report_month_close_month ON report_month_close (month, year);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_schema_version
+--
+CREATE TABLE report_schema_version (
+  version int NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+INSERT INTO report_schema_version (version) VALUES (4);
+-- --------------------------------------------------------
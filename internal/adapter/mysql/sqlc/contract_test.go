@@ -0,0 +1,37 @@
+package repo_test
+
+import (
+	"TimeTrack/internal/adapter/contract"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// TestContract выполняется на базе из TEST_MYSQL_DSN с примененной migration/schema.sql,
+// например time_track:qwerty@tcp(localhost)/time_track_test?parseTime=true&loc=Local.
+// Таблицы очищаются.
+func TestContract(t *testing.T) {
+	dsn := os.Getenv("TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("TEST_MYSQL_DSN is not set")
+	}
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	contract.Run(t, func(t *testing.T) contract.Store {
+		contract.Reset(t, db)
+		q := repo.New(db)
+		return struct {
+			repo.Querier
+			repo.Streamer
+			repo.TxBeginner
+		}{q, q, repo.NewTxBeginner(db)}
+	})
+}
@@ -0,0 +1,46 @@
+package repo
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// ErrDuplicateKey - запись нарушает уникальный индекс. Хранилище в памяти возвращает
+// эту ошибку само, ошибки драйверов СУБД распознает IsDuplicateKey.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// Коды нарушения уникальности в драйверах
+const (
+	mysqlDuplicateEntry     = 1062    // ER_DUP_ENTRY
+	postgresUniqueViolation = "23505" // unique_violation
+	sqliteConstraintUnique  = 2067    // SQLITE_CONSTRAINT_UNIQUE
+	sqliteConstraintPrimary = 1555    // SQLITE_CONSTRAINT_PRIMARYKEY
+)
+
+// IsDuplicateKey сообщает, что запись нарушила уникальный индекс: ErrDuplicateKey
+// или ошибка MySQL, PostgreSQL (pgx) или SQLite (modernc) с кодом нарушения уникальности.
+// Драйверы PostgreSQL и SQLite распознаются по методам ошибки, чтобы пакет не зависел от них.
+func IsDuplicateKey(err error) bool {
+	if errors.Is(err, ErrDuplicateKey) {
+		return true
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDuplicateEntry
+	}
+
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) {
+		return pgErr.SQLState() == postgresUniqueViolation
+	}
+
+	var sqliteErr interface{ Code() int }
+	if errors.As(err, &sqliteErr) {
+		code := sqliteErr.Code()
+		return code == sqliteConstraintUnique || code == sqliteConstraintPrimary
+	}
+
+	return false
+}
@@ -11,6 +11,48 @@ import (
 	"time"
 )
 
+type ReportScheduleKind string
+
+const (
+	ReportScheduleKindWeekly ReportScheduleKind = "weekly"
+	ReportScheduleKindShift  ReportScheduleKind = "shift"
+)
+
+func (e *ReportScheduleKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReportScheduleKind(s)
+	case string:
+		*e = ReportScheduleKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReportScheduleKind: %T", src)
+	}
+	return nil
+}
+
+type NullReportScheduleKind struct {
+	ReportScheduleKind ReportScheduleKind `json:"reportScheduleKind"`
+	Valid              bool               `json:"valid"` // Valid is true if ReportScheduleKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReportScheduleKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReportScheduleKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReportScheduleKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReportScheduleKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReportScheduleKind), nil
+}
+
 type ReportVacationStatus string
 
 const (
@@ -55,6 +97,14 @@ func (ns NullReportVacationStatus) Value() (driver.Value, error) {
 	return string(ns.ReportVacationStatus), nil
 }
 
+type ReportAllocation struct {
+	ID        string         `json:"id"`
+	ReportID  string         `json:"reportId"`
+	ProjectID string         `json:"projectId"`
+	TaskID    sql.NullString `json:"taskId"`
+	Hours     float64        `json:"hours"`
+}
+
 type ReportCalendar struct {
 	ID             string         `json:"id"`
 	Day            int32          `json:"day"`
@@ -65,9 +115,89 @@ type ReportCalendar struct {
 	TypeID         string         `json:"typeId"`
 }
 
+type ReportChatLink struct {
+	ChatID   int64     `json:"chatId"`
+	UserID   string    `json:"userId"`
+	CreateAt time.Time `json:"createAt"`
+}
+
+type ReportChatLinkCode struct {
+	Code     string    `json:"code"`
+	UserID   string    `json:"userId"`
+	ExpireAt time.Time `json:"expireAt"`
+}
+
+type ReportDepartment struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ReportDirectoryUser struct {
+	ID       string    `json:"id"`
+	Dn       string    `json:"dn"`
+	Login    string    `json:"login"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	IsActive bool      `json:"isActive"`
+	SyncAt   time.Time `json:"syncAt"`
+}
+
+type ReportDocumentTemplate struct {
+	Kind     string    `json:"kind"`
+	Title    string    `json:"title"`
+	Body     string    `json:"body"`
+	UpdateAt time.Time `json:"updateAt"`
+}
+
+type ReportMonthClose struct {
+	Month   int32     `json:"month"`
+	Year    int32     `json:"year"`
+	CloseAt time.Time `json:"closeAt"`
+}
+
+type ReportProject struct {
+	ID       string         `json:"id"`
+	Code     string         `json:"code"`
+	Name     string         `json:"name"`
+	Client   sql.NullString `json:"client"`
+	IsActive bool           `json:"isActive"`
+}
+
+type ReportSchedule struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Kind        ReportScheduleKind `json:"kind"`
+	CycleLength int32              `json:"cycleLength"`
+	CycleStart  sql.NullTime       `json:"cycleStart"`
+}
+
+type ReportScheduleDay struct {
+	ID         string  `json:"id"`
+	ScheduleID string  `json:"scheduleId"`
+	DayIndex   int32   `json:"dayIndex"`
+	Hours      float64 `json:"hours"`
+}
+
+type ReportSchemaVersion struct {
+	Version int32 `json:"version"`
+}
+
 type ReportSetting struct {
-	ID               int32 `json:"id"`
-	VacationDuration int32 `json:"vacationDuration"`
+	ID               int32          `json:"id"`
+	VacationDuration int32          `json:"vacationDuration"`
+	NightStartMinute int32          `json:"nightStartMinute"`
+	NightEndMinute   int32          `json:"nightEndMinute"`
+	PayrollLayout    sql.NullString `json:"payrollLayout"`
+}
+
+type ReportShift struct {
+	ID          string `json:"id"`
+	UserID      string `json:"userId"`
+	Day         int32  `json:"day"`
+	Month       int32  `json:"month"`
+	Year        int32  `json:"year"`
+	StartMinute int32  `json:"startMinute"`
+	EndMinute   int32  `json:"endMinute"`
 }
 
 type ReportStandard struct {
@@ -78,20 +208,51 @@ type ReportStandard struct {
 	GenderID int32  `json:"genderId"`
 }
 
+type ReportTask struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"projectId"`
+	Name      string `json:"name"`
+	IsActive  bool   `json:"isActive"`
+}
+
 type ReportType struct {
 	ID         string `json:"id"`
 	Name       string `json:"name"`
 	SystemName string `json:"systemName"`
+	Code       string `json:"code"`
 }
 
 type ReportUser struct {
-	ID     string  `json:"id"`
-	UserID string  `json:"userId"`
-	Day    int32   `json:"day"`
-	Month  int32   `json:"month"`
-	Year   int32   `json:"year"`
-	Hours  float64 `json:"hours"`
-	TypeID string  `json:"typeId"`
+	ID          string        `json:"id"`
+	UserID      string        `json:"userId"`
+	Day         int32         `json:"day"`
+	Month       int32         `json:"month"`
+	Year        int32         `json:"year"`
+	Hours       float64       `json:"hours"`
+	TypeID      string        `json:"typeId"`
+	StartMinute sql.NullInt32 `json:"startMinute"`
+	EndMinute   sql.NullInt32 `json:"endMinute"`
+	NightHours  float64       `json:"nightHours"`
+}
+
+type ReportUserContact struct {
+	UserID    string         `json:"userId"`
+	Email     string         `json:"email"`
+	Name      string         `json:"name"`
+	ManagerID sql.NullString `json:"managerId"`
+}
+
+type ReportUserDepartment struct {
+	UserID       string `json:"userId"`
+	DepartmentID string `json:"departmentId"`
+}
+
+type ReportUserSchedule struct {
+	ID            string       `json:"id"`
+	UserID        string       `json:"userId"`
+	ScheduleID    string       `json:"scheduleId"`
+	EffectiveFrom time.Time    `json:"effectiveFrom"`
+	EffectiveTo   sql.NullTime `json:"effectiveTo"`
 }
 
 type ReportVacation struct {
@@ -104,3 +265,26 @@ type ReportVacation struct {
 	Status      ReportVacationStatus `json:"status"`
 	CreateAt    time.Time            `json:"createAt"`
 }
+
+type ReportWebhook struct {
+	ID       string    `json:"id"`
+	Url      string    `json:"url"`
+	Secret   string    `json:"secret"`
+	Events   string    `json:"events"`
+	IsActive bool      `json:"isActive"`
+	CreateAt time.Time `json:"createAt"`
+}
+
+type ReportWebhookDelivery struct {
+	ID            string         `json:"id"`
+	WebhookID     string         `json:"webhookId"`
+	Event         string         `json:"event"`
+	Payload       string         `json:"payload"`
+	Status        string         `json:"status"`
+	Attempts      int32          `json:"attempts"`
+	ResponseCode  sql.NullInt32  `json:"responseCode"`
+	Error         sql.NullString `json:"error"`
+	NextAttemptAt sql.NullTime   `json:"nextAttemptAt"`
+	CreateAt      time.Time      `json:"createAt"`
+	UpdateAt      time.Time      `json:"updateAt"`
+}
@@ -6,23 +6,65 @@ package repo
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 type Querier interface {
 	CheckCalendarDayExists(ctx context.Context, arg CheckCalendarDayExistsParams) (int64, error)
+	CheckDirectoryUserInactive(ctx context.Context, id string) (int64, error)
+	CheckProjectCodeExists(ctx context.Context, code string) (int64, error)
 	CheckReportUserExists(ctx context.Context, arg CheckReportUserExistsParams) (int64, error)
+	CheckReportUserTypeExists(ctx context.Context, arg CheckReportUserTypeExistsParams) (int64, error)
 	CheckStandard(ctx context.Context, arg CheckStandardParams) (int64, error)
+	ClaimWebhookDelivery(ctx context.Context, arg ClaimWebhookDeliveryParams) (int64, error)
+	CloseMonth(ctx context.Context, arg CloseMonthParams) error
+	CountUserSchedulesBySchedule(ctx context.Context, scheduleID string) (int64, error)
+	CountVacationsByStatus(ctx context.Context, status ReportVacationStatus) (int64, error)
+	CreateAllocation(ctx context.Context, arg CreateAllocationParams) error
 	CreateCalendarDay(ctx context.Context, arg CreateCalendarDayParams) error
+	CreateChatLink(ctx context.Context, arg CreateChatLinkParams) error
+	CreateChatLinkCode(ctx context.Context, arg CreateChatLinkCodeParams) error
+	CreateDepartment(ctx context.Context, arg CreateDepartmentParams) error
+	CreateDirectoryUser(ctx context.Context, arg CreateDirectoryUserParams) error
+	CreateDocumentTemplate(ctx context.Context, arg CreateDocumentTemplateParams) error
+	CreateProject(ctx context.Context, arg CreateProjectParams) error
 	CreateReportUser(ctx context.Context, arg CreateReportUserParams) error
+	CreateSchedule(ctx context.Context, arg CreateScheduleParams) error
+	CreateScheduleDay(ctx context.Context, arg CreateScheduleDayParams) error
+	CreateShift(ctx context.Context, arg CreateShiftParams) error
 	CreateStandard(ctx context.Context, arg CreateStandardParams) error
+	CreateTask(ctx context.Context, arg CreateTaskParams) error
 	CreateType(ctx context.Context, arg CreateTypeParams) error
+	CreateUserContact(ctx context.Context, arg CreateUserContactParams) error
+	CreateUserDepartment(ctx context.Context, arg CreateUserDepartmentParams) error
+	CreateUserSchedule(ctx context.Context, arg CreateUserScheduleParams) error
 	CreateVacation(ctx context.Context, arg CreateVacationParams) error
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) error
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) error
+	DeactivateDirectoryUser(ctx context.Context, id string) error
+	DeleteAllocationsByDay(ctx context.Context, arg DeleteAllocationsByDayParams) error
+	DeleteAllocationsByReport(ctx context.Context, reportID string) error
 	DeleteCalendarDay(ctx context.Context, id string) error
+	DeleteChatLink(ctx context.Context, chatID int64) error
+	DeleteChatLinkCode(ctx context.Context, code string) error
+	DeleteDocumentTemplate(ctx context.Context, kind string) error
 	DeleteReportUser(ctx context.Context, arg DeleteReportUserParams) error
+	DeleteReportUserById(ctx context.Context, id string) error
+	DeleteSchedule(ctx context.Context, id string) error
+	DeleteScheduleDays(ctx context.Context, scheduleID string) error
+	DeleteShift(ctx context.Context, id string) error
 	DeleteStandard(ctx context.Context, id string) error
 	DeleteType(ctx context.Context, id string) error
+	DeleteUserContact(ctx context.Context, userID string) error
+	DeleteUserDepartment(ctx context.Context, userID string) error
+	DeleteUserSchedule(ctx context.Context, id string) error
 	DeleteVacation(ctx context.Context, id string) error
+	DeleteWebhook(ctx context.Context, id string) error
+	DeleteWebhookDeliveries(ctx context.Context, webhookID string) error
+	GetActiveWebhooks(ctx context.Context) ([]ReportWebhook, error)
 	GetAdminVacationsByYear(ctx context.Context, year int32) ([]GetAdminVacationsByYearRow, error)
+	GetAllocationsByReport(ctx context.Context, reportID string) ([]GetAllocationsByReportRow, error)
 	GetCalendarDay(ctx context.Context, arg GetCalendarDayParams) (GetCalendarDayRow, error)
 	// ============================================
 	// REPORT_CALENDAR queries
@@ -31,43 +73,140 @@ type Querier interface {
 	GetCalendarDaysAll(ctx context.Context, year int32) ([]GetCalendarDaysAllRow, error)
 	GetCalendarDaysAllByType(ctx context.Context, arg GetCalendarDaysAllByTypeParams) ([]GetCalendarDaysAllByTypeRow, error)
 	GetCalendarDaysByType(ctx context.Context, arg GetCalendarDaysByTypeParams) ([]GetCalendarDaysByTypeRow, error)
+	GetChatLinkByChat(ctx context.Context, chatID int64) (ReportChatLink, error)
+	// ============================================
+	// REPORT_CHAT_LINK_CODE queries
+	// ============================================
+	GetChatLinkCode(ctx context.Context, code string) (ReportChatLinkCode, error)
+	// ============================================
+	// REPORT_CHAT_LINK queries
+	// ============================================
+	GetChatLinks(ctx context.Context) ([]ReportChatLink, error)
+	GetDepartmentById(ctx context.Context, id string) (ReportDepartment, error)
+	GetDepartmentUsers(ctx context.Context, departmentID string) ([]string, error)
+	// ============================================
+	// REPORT_DEPARTMENT queries
+	// ============================================
+	GetDepartments(ctx context.Context) ([]ReportDepartment, error)
+	GetDirectoryUserByEmail(ctx context.Context, email string) (ReportDirectoryUser, error)
+	GetDirectoryUserById(ctx context.Context, id string) (ReportDirectoryUser, error)
+	// ============================================
+	// REPORT_DIRECTORY_USER queries
+	// ============================================
+	GetDirectoryUsers(ctx context.Context) ([]ReportDirectoryUser, error)
+	GetDocumentTemplate(ctx context.Context, kind string) (ReportDocumentTemplate, error)
+	// ============================================
+	// REPORT_DOCUMENT_TEMPLATE queries
+	// ============================================
+	GetDocumentTemplates(ctx context.Context) ([]ReportDocumentTemplate, error)
+	GetDueWebhookDeliveries(ctx context.Context, nextAttemptAt sql.NullTime) ([]ReportWebhookDelivery, error)
+	// ============================================
+	// REPORT_MONTH_CLOSE queries
+	// ============================================
+	GetMonthClose(ctx context.Context, arg GetMonthCloseParams) (ReportMonthClose, error)
+	GetMonthClosesByYear(ctx context.Context, year int32) ([]ReportMonthClose, error)
+	GetProjectById(ctx context.Context, id string) (GetProjectByIdRow, error)
+	GetProjectTotalsByDepartment(ctx context.Context, arg GetProjectTotalsByDepartmentParams) ([]GetProjectTotalsByDepartmentRow, error)
+	GetProjectTotalsByMonth(ctx context.Context, arg GetProjectTotalsByMonthParams) ([]GetProjectTotalsByMonthRow, error)
+	GetProjectTotalsByUser(ctx context.Context, arg GetProjectTotalsByUserParams) ([]GetProjectTotalsByUserRow, error)
+	// ============================================
+	// REPORT_PROJECT queries
+	// ============================================
+	GetProjects(ctx context.Context) ([]GetProjectsRow, error)
 	GetReportUserById(ctx context.Context, id string) (GetReportUserByIdRow, error)
+	GetReportUserByIdForUpdate(ctx context.Context, id string) (GetReportUserByIdForUpdateRow, error)
 	GetReportUserCountByType(ctx context.Context, arg GetReportUserCountByTypeParams) (int64, error)
 	GetReportUserCountWork(ctx context.Context, arg GetReportUserCountWorkParams) (int64, error)
+	GetReportUserDayTotalHours(ctx context.Context, arg GetReportUserDayTotalHoursParams) (float64, error)
+	GetReportUserForDay(ctx context.Context, arg GetReportUserForDayParams) ([]GetReportUserForDayRow, error)
 	// ============================================
 	// REPORT_USER queries
 	// ============================================
 	GetReportUserForMonth(ctx context.Context, arg GetReportUserForMonthParams) ([]GetReportUserForMonthRow, error)
+	GetReportUserForMonthAll(ctx context.Context, arg GetReportUserForMonthAllParams) ([]GetReportUserForMonthAllRow, error)
+	GetReportUserNightHours(ctx context.Context, arg GetReportUserNightHoursParams) (float64, error)
+	GetReportUserStatsByType(ctx context.Context, arg GetReportUserStatsByTypeParams) ([]GetReportUserStatsByTypeRow, error)
 	GetReportUserTotalHours(ctx context.Context, arg GetReportUserTotalHoursParams) (float64, error)
+	GetScheduleById(ctx context.Context, id string) (ReportSchedule, error)
+	GetScheduleDays(ctx context.Context, scheduleID string) ([]ReportScheduleDay, error)
+	// ============================================
+	// REPORT_SCHEDULE queries
+	// ============================================
+	GetSchedules(ctx context.Context) ([]ReportSchedule, error)
+	// ============================================
+	// REPORT_SCHEMA_VERSION queries
+	// ============================================
+	GetSchemaVersion(ctx context.Context) (int32, error)
+	GetSettingNightWindow(ctx context.Context) (GetSettingNightWindowRow, error)
+	GetSettingPayrollLayout(ctx context.Context) (sql.NullString, error)
 	// ============================================
 	// REPORT_SETTING queries
 	// ============================================
 	GetSettingVacationDuration(ctx context.Context) (int32, error)
+	GetShift(ctx context.Context, arg GetShiftParams) (ReportShift, error)
+	GetShiftById(ctx context.Context, id string) (ReportShift, error)
+	// ============================================
+	// REPORT_SHIFT queries
+	// ============================================
+	GetShiftsForMonth(ctx context.Context, arg GetShiftsForMonthParams) ([]ReportShift, error)
+	GetShiftsForMonthAll(ctx context.Context, arg GetShiftsForMonthAllParams) ([]ReportShift, error)
 	// ============================================
 	// REPORT_STANDART queries
 	// ============================================
 	GetStandard(ctx context.Context, arg GetStandardParams) (ReportStandard, error)
 	GetStandardByMonth(ctx context.Context, arg GetStandardByMonthParams) ([]ReportStandard, error)
 	GetStandardByYear(ctx context.Context, year int32) ([]ReportStandard, error)
+	GetTaskById(ctx context.Context, id string) (ReportTask, error)
+	GetTasksByProject(ctx context.Context, projectID string) ([]ReportTask, error)
 	GetTypeAll(ctx context.Context) ([]ReportType, error)
 	// ============================================
 	// REPORT_TYPE queries
 	// ============================================
 	GetTypeById(ctx context.Context, id string) (ReportType, error)
 	GetTypeBySystemName(ctx context.Context, systemName string) (ReportType, error)
+	// ============================================
+	// REPORT_USER_CONTACT queries
+	// ============================================
+	GetUserContact(ctx context.Context, userID string) (ReportUserContact, error)
+	GetUserDepartment(ctx context.Context, userID string) (GetUserDepartmentRow, error)
+	GetUserSchedules(ctx context.Context, userID string) ([]GetUserSchedulesRow, error)
 	GetVacationApproved(ctx context.Context, userID string) ([]GetVacationApprovedRow, error)
 	GetVacationById(ctx context.Context, id string) (GetVacationByIdRow, error)
 	// ============================================
 	// REPORT_VACATION queries
 	// ============================================
 	GetVacations(ctx context.Context, userID string) ([]GetVacationsRow, error)
+	GetVacationsApprovedInRange(ctx context.Context, arg GetVacationsApprovedInRangeParams) ([]GetVacationsApprovedInRangeRow, error)
+	GetVacationsApprovedStartingOn(ctx context.Context, startDate time.Time) ([]GetVacationsApprovedStartingOnRow, error)
 	GetVacationsByYear(ctx context.Context, arg GetVacationsByYearParams) ([]GetVacationsByYearRow, error)
+	GetWebhookById(ctx context.Context, id string) (ReportWebhook, error)
+	// ============================================
+	// REPORT_WEBHOOK_DELIVERY queries
+	// ============================================
+	GetWebhookDeliveries(ctx context.Context, webhookID string) ([]ReportWebhookDelivery, error)
+	GetWebhookDeliveryById(ctx context.Context, id string) (ReportWebhookDelivery, error)
+	// ============================================
+	// REPORT_WEBHOOK queries
+	// ============================================
+	GetWebhooks(ctx context.Context) ([]ReportWebhook, error)
 	GetYearsVacation(ctx context.Context, userID string) ([]int32, error)
+	// Блокирует отметки дня до конца транзакции. Блокировка по префиксу индекса
+	// report_user_day_type закрывает и промежуток, так что параллельная вставка в этот день ждет.
+	LockReportUserDay(ctx context.Context, arg LockReportUserDayParams) error
+	ReopenMonth(ctx context.Context, arg ReopenMonthParams) error
 	UpdateCalendarDay(ctx context.Context, arg UpdateCalendarDayParams) error
+	UpdateDirectoryUser(ctx context.Context, arg UpdateDirectoryUserParams) error
+	UpdateProject(ctx context.Context, arg UpdateProjectParams) error
 	UpdateReportUser(ctx context.Context, arg UpdateReportUserParams) error
+	UpdateSettingNightWindow(ctx context.Context, arg UpdateSettingNightWindowParams) error
+	UpdateSettingPayrollLayout(ctx context.Context, payrollLayout sql.NullString) error
+	UpdateShift(ctx context.Context, arg UpdateShiftParams) error
 	UpdateStandard(ctx context.Context, arg UpdateStandardParams) error
+	UpdateTask(ctx context.Context, arg UpdateTaskParams) error
 	UpdateType(ctx context.Context, arg UpdateTypeParams) error
 	UpdateVacationStatus(ctx context.Context, arg UpdateVacationStatusParams) error
+	UpdateWebhook(ctx context.Context, arg UpdateWebhookParams) error
+	UpdateWebhookDelivery(ctx context.Context, arg UpdateWebhookDeliveryParams) error
 }
 
 var _ Querier = (*Queries)(nil)
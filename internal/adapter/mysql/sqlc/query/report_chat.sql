@@ -0,0 +1,38 @@
+-- ============================================
+-- REPORT_CHAT_LINK queries
+-- ============================================
+
+-- name: GetChatLinks :many
+SELECT chat_id, user_id, create_at
+FROM report_chat_link
+ORDER BY create_at ASC;
+
+-- name: GetChatLinkByChat :one
+SELECT chat_id, user_id, create_at
+FROM report_chat_link
+WHERE chat_id = ?;
+
+-- name: CreateChatLink :exec
+INSERT INTO report_chat_link (chat_id, user_id)
+VALUES (?, ?);
+
+-- name: DeleteChatLink :exec
+DELETE FROM report_chat_link
+WHERE chat_id = ?;
+
+-- ============================================
+-- REPORT_CHAT_LINK_CODE queries
+-- ============================================
+
+-- name: GetChatLinkCode :one
+SELECT code, user_id, expire_at
+FROM report_chat_link_code
+WHERE code = ?;
+
+-- name: CreateChatLinkCode :exec
+INSERT INTO report_chat_link_code (code, user_id, expire_at)
+VALUES (?, ?, ?);
+
+-- name: DeleteChatLinkCode :exec
+DELETE FROM report_chat_link_code
+WHERE code = ? OR expire_at < NOW();
@@ -0,0 +1,16 @@
+-- ============================================
+-- REPORT_USER_CONTACT queries
+-- ============================================
+
+-- name: GetUserContact :one
+SELECT user_id, email, name, manager_id
+FROM report_user_contact
+WHERE user_id = ?;
+
+-- name: CreateUserContact :exec
+INSERT INTO report_user_contact (user_id, email, name, manager_id)
+VALUES (?, ?, ?, ?);
+
+-- name: DeleteUserContact :exec
+DELETE FROM report_user_contact
+WHERE user_id = ?;
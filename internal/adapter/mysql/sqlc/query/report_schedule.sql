@@ -0,0 +1,62 @@
+-- ============================================
+-- REPORT_SCHEDULE queries
+-- ============================================
+
+-- name: GetSchedules :many
+SELECT id, name, kind, cycle_length, cycle_start
+FROM report_schedule
+ORDER BY name ASC;
+
+-- name: GetScheduleById :one
+SELECT id, name, kind, cycle_length, cycle_start
+FROM report_schedule
+WHERE id = ?;
+
+-- name: CreateSchedule :exec
+INSERT INTO report_schedule (id, name, kind, cycle_length, cycle_start)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: DeleteSchedule :exec
+DELETE FROM report_schedule
+WHERE id = ?;
+
+-- name: GetScheduleDays :many
+SELECT id, schedule_id, day_index, hours
+FROM report_schedule_day
+WHERE schedule_id = ?
+ORDER BY day_index ASC;
+
+-- name: CreateScheduleDay :exec
+INSERT INTO report_schedule_day (id, schedule_id, day_index, hours)
+VALUES (?, ?, ?, ?);
+
+-- name: DeleteScheduleDays :exec
+DELETE FROM report_schedule_day
+WHERE schedule_id = ?;
+
+-- name: GetUserSchedules :many
+SELECT
+    rus.id,
+    rus.user_id,
+    rus.schedule_id,
+    rus.effective_from,
+    rus.effective_to,
+    rs.name as schedule_name,
+    rs.kind as schedule_kind
+FROM report_user_schedule rus
+INNER JOIN report_schedule rs ON rus.schedule_id = rs.id
+WHERE rus.user_id = ?
+ORDER BY rus.effective_from ASC;
+
+-- name: CreateUserSchedule :exec
+INSERT INTO report_user_schedule (id, user_id, schedule_id, effective_from, effective_to)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: DeleteUserSchedule :exec
+DELETE FROM report_user_schedule
+WHERE id = ?;
+
+-- name: CountUserSchedulesBySchedule :one
+SELECT COUNT(*) as users_count
+FROM report_user_schedule
+WHERE schedule_id = ?;
@@ -3,27 +3,27 @@
 -- ============================================
 
 -- name: GetTypeById :one
-SELECT id, name, system_name
+SELECT id, name, system_name, code
 FROM report_type
 WHERE id = ?;
 
 -- name: GetTypeBySystemName :one
-SELECT id, name, system_name
+SELECT id, name, system_name, code
 FROM report_type
 WHERE system_name = ?;
 
 -- name: GetTypeAll :many
-SELECT id, name, system_name
+SELECT id, name, system_name, code
 FROM report_type
 ORDER BY name ASC;
 
 -- name: CreateType :exec
-INSERT INTO report_type (id, name, system_name)
-VALUES (?, ?, ?);
+INSERT INTO report_type (id, name, system_name, code)
+VALUES (?, ?, ?, ?);
 
 -- name: UpdateType :exec
 UPDATE report_type
-SET name = ?, system_name = ?
+SET name = ?, system_name = ?, code = ?
 WHERE id = ?;
 
 -- name: DeleteType :exec
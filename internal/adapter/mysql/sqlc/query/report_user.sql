@@ -11,6 +11,9 @@ SELECT
     ru.year,
     ru.hours,
     ru.type_id,
+    ru.start_minute,
+    ru.end_minute,
+    ru.night_hours,
     rt.name as type_name,
     rt.system_name as type_system_name
 FROM report_user ru
@@ -18,6 +21,24 @@ INNER JOIN report_type rt ON ru.type_id = rt.id
 WHERE ru.user_id = ? AND ru.month = ? AND ru.year = ?
 ORDER BY ru.day ASC;
 
+-- name: GetReportUserForMonthAll :many
+SELECT
+    ru.id,
+    ru.user_id,
+    ru.day,
+    ru.month,
+    ru.year,
+    ru.hours,
+    ru.type_id,
+    ru.night_hours,
+    rt.name as type_name,
+    rt.system_name as type_system_name,
+    rt.code as type_code
+FROM report_user ru
+INNER JOIN report_type rt ON ru.type_id = rt.id
+WHERE ru.month = ? AND ru.year = ?
+ORDER BY ru.user_id ASC, ru.day ASC;
+
 -- name: GetReportUserById :one
 SELECT
     ru.id,
@@ -27,17 +48,81 @@ SELECT
     ru.year,
     ru.hours,
     ru.type_id,
+    ru.start_minute,
+    ru.end_minute,
+    ru.night_hours,
     rt.name as type_name,
     rt.system_name as type_system_name
 FROM report_user ru
 INNER JOIN report_type rt ON ru.type_id = rt.id
 WHERE ru.id = ?;
 
+-- name: GetReportUserByIdForUpdate :one
+SELECT
+    ru.id,
+    ru.user_id,
+    ru.day,
+    ru.month,
+    ru.year,
+    ru.hours,
+    ru.type_id,
+    ru.start_minute,
+    ru.end_minute,
+    ru.night_hours,
+    rt.name as type_name,
+    rt.system_name as type_system_name
+FROM report_user ru
+INNER JOIN report_type rt ON ru.type_id = rt.id
+WHERE ru.id = ?
+FOR UPDATE;
+
+-- name: GetReportUserForDay :many
+SELECT
+    ru.id,
+    ru.user_id,
+    ru.day,
+    ru.month,
+    ru.year,
+    ru.hours,
+    ru.type_id,
+    ru.start_minute,
+    ru.end_minute,
+    ru.night_hours,
+    rt.name as type_name,
+    rt.system_name as type_system_name
+FROM report_user ru
+INNER JOIN report_type rt ON ru.type_id = rt.id
+WHERE ru.user_id = ? AND ru.day = ? AND ru.month = ? AND ru.year = ?
+ORDER BY rt.name ASC;
+
+-- name: GetReportUserDayTotalHours :one
+SELECT CAST(COALESCE(SUM(hours), 0.0) AS FLOAT) AS total_hours
+FROM report_user
+WHERE user_id = ? AND day = ? AND month = ? AND year = ?;
+
+-- name: GetReportUserStatsByType :many
+SELECT
+    rt.id as type_id,
+    rt.name as type_name,
+    rt.system_name as type_system_name,
+    COUNT(DISTINCT ru.day) as days_count,
+    CAST(COALESCE(SUM(ru.hours), 0.0) AS FLOAT) AS total_hours
+FROM report_user ru
+INNER JOIN report_type rt ON ru.type_id = rt.id
+WHERE ru.user_id = ? AND ru.month = ? AND ru.year = ?
+GROUP BY rt.id, rt.name, rt.system_name
+ORDER BY rt.name ASC;
+
 -- name: GetReportUserTotalHours :one
 SELECT CAST(COALESCE(SUM(hours), 0.0) AS FLOAT) AS total_hours
 FROM report_user
 WHERE user_id = ? AND month = ? AND year = ?;
 
+-- name: GetReportUserNightHours :one
+SELECT CAST(COALESCE(SUM(night_hours), 0.0) AS FLOAT) AS night_hours
+FROM report_user
+WHERE user_id = ? AND month = ? AND year = ?;
+
 -- name: GetReportUserCountByType :one
 SELECT COUNT(DISTINCT day) as days_count
 FROM report_user
@@ -50,12 +135,12 @@ INNER JOIN report_type rt ON ru.type_id = rt.id
 WHERE ru.user_id = ? AND ru.month = ? AND ru.year = ? AND (rt.system_name = 'work' OR rt.system_name = 'weekend');
 
 -- name: CreateReportUser :exec
-INSERT INTO report_user (id, user_id, day, month, year, hours, type_id)
-VALUES (?, ?, ?, ?, ?, ?, ?);
+INSERT INTO report_user (id, user_id, day, month, year, hours, type_id, start_minute, end_minute, night_hours)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: UpdateReportUser :exec
 UPDATE report_user
-SET hours = ?, type_id = ?
+SET hours = ?, type_id = ?, start_minute = ?, end_minute = ?, night_hours = ?
 WHERE id = ?;
 
 -- name: CheckReportUserExists :one
@@ -63,6 +148,22 @@ SELECT COUNT(*) as exists_count
 FROM report_user
 WHERE user_id = ? AND day = ? AND month = ? AND year = ?;
 
+-- name: CheckReportUserTypeExists :one
+SELECT COUNT(*) as exists_count
+FROM report_user
+WHERE user_id = ? AND day = ? AND month = ? AND year = ? AND type_id = ?;
+
+-- name: DeleteReportUserById :exec
+DELETE FROM report_user
+WHERE id = ?;
+
 -- name: DeleteReportUser :exec
 DELETE FROM report_user
 WHERE user_id = ? AND day = ? AND month = ? AND year = ?;
+
+-- name: LockReportUserDay :exec
+-- Блокирует отметки дня до конца транзакции. Блокировка по префиксу индекса
+-- report_user_day_type закрывает и промежуток, так что параллельная вставка в этот день ждет.
+SELECT id FROM report_user
+WHERE user_id = ? AND day = ? AND month = ? AND year = ?
+FOR UPDATE;
@@ -30,12 +30,29 @@ SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as des
 FROM report_vacation
 WHERE user_id = ? AND status = "approved";
 
+-- name: GetVacationsApprovedInRange :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE status = "approved" AND start_date <= ? AND end_date >= ?
+ORDER BY user_id ASC, start_date ASC;
+
+-- name: GetVacationsApprovedStartingOn :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE status = "approved" AND start_date = ?
+ORDER BY user_id ASC;
+
 -- name: GetYearsVacation :many
 SELECT DISTINCT year
 FROM report_vacation
 WHERE user_id = ?
 ORDER BY year DESC;
 
+-- name: CountVacationsByStatus :one
+SELECT COUNT(*) as vacations_count
+FROM report_vacation
+WHERE status = ?;
+
 -- name: CreateVacation :exec
 INSERT INTO report_vacation (id, user_id, start_date, end_date, year,  description, status)
 VALUES (?, ?, ?, ?, ?, ?, ?);
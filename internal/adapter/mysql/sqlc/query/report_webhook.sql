@@ -0,0 +1,72 @@
+-- ============================================
+-- REPORT_WEBHOOK queries
+-- ============================================
+
+-- name: GetWebhooks :many
+SELECT id, url, secret, events, is_active, create_at
+FROM report_webhook
+ORDER BY create_at ASC;
+
+-- name: GetActiveWebhooks :many
+SELECT id, url, secret, events, is_active, create_at
+FROM report_webhook
+WHERE is_active = 1;
+
+-- name: GetWebhookById :one
+SELECT id, url, secret, events, is_active, create_at
+FROM report_webhook
+WHERE id = ?;
+
+-- name: CreateWebhook :exec
+INSERT INTO report_webhook (id, url, secret, events, is_active)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: UpdateWebhook :exec
+UPDATE report_webhook
+SET url = ?, events = ?, is_active = ?
+WHERE id = ?;
+
+-- name: DeleteWebhook :exec
+DELETE FROM report_webhook
+WHERE id = ?;
+
+-- ============================================
+-- REPORT_WEBHOOK_DELIVERY queries
+-- ============================================
+
+-- name: GetWebhookDeliveries :many
+SELECT id, webhook_id, event, payload, status, attempts, response_code, error, next_attempt_at, create_at, update_at
+FROM report_webhook_delivery
+WHERE webhook_id = ?
+ORDER BY create_at DESC
+LIMIT 100;
+
+-- name: GetWebhookDeliveryById :one
+SELECT id, webhook_id, event, payload, status, attempts, response_code, error, next_attempt_at, create_at, update_at
+FROM report_webhook_delivery
+WHERE id = ?;
+
+-- name: CreateWebhookDelivery :exec
+INSERT INTO report_webhook_delivery (id, webhook_id, event, payload, next_attempt_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: UpdateWebhookDelivery :exec
+UPDATE report_webhook_delivery
+SET status = ?, attempts = ?, response_code = ?, error = ?, next_attempt_at = ?
+WHERE id = ?;
+
+-- name: DeleteWebhookDeliveries :exec
+DELETE FROM report_webhook_delivery
+WHERE webhook_id = ?;
+
+-- name: GetDueWebhookDeliveries :many
+SELECT id, webhook_id, event, payload, status, attempts, response_code, error, next_attempt_at, create_at, update_at
+FROM report_webhook_delivery
+WHERE status = 'pending' AND next_attempt_at <= ?
+ORDER BY next_attempt_at ASC
+LIMIT 100;
+
+-- name: ClaimWebhookDelivery :execrows
+UPDATE report_webhook_delivery
+SET next_attempt_at = sqlc.arg(lease_until)
+WHERE id = sqlc.arg(id) AND status = 'pending' AND next_attempt_at <= sqlc.arg(now);
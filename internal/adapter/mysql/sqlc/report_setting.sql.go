@@ -7,8 +7,43 @@ package repo
 
 import (
 	"context"
+	"database/sql"
 )
 
+const getSettingNightWindow = `-- name: GetSettingNightWindow :one
+SELECT night_start_minute, night_end_minute
+FROM report_setting
+WHERE id = 1
+`
+
+type GetSettingNightWindowRow struct {
+	NightStartMinute int32 `json:"nightStartMinute"`
+	NightEndMinute   int32 `json:"nightEndMinute"`
+}
+
+func (q *Queries) GetSettingNightWindow(ctx context.Context) (GetSettingNightWindowRow, error) {
+	row := q.db.QueryRowContext(ctx, getSettingNightWindow)
+	var i GetSettingNightWindowRow
+	err := row.Scan(
+		&i.NightStartMinute,
+		&i.NightEndMinute,
+	)
+	return i, err
+}
+
+const getSettingPayrollLayout = `-- name: GetSettingPayrollLayout :one
+SELECT payroll_layout
+FROM report_setting
+WHERE id = 1
+`
+
+func (q *Queries) GetSettingPayrollLayout(ctx context.Context) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, getSettingPayrollLayout)
+	var payroll_layout sql.NullString
+	err := row.Scan(&payroll_layout)
+	return payroll_layout, err
+}
+
 const getSettingVacationDuration = `-- name: GetSettingVacationDuration :one
 
 SELECT vacation_duration
@@ -25,3 +60,30 @@ func (q *Queries) GetSettingVacationDuration(ctx context.Context) (int32, error)
 	err := row.Scan(&vacation_duration)
 	return vacation_duration, err
 }
+
+const updateSettingNightWindow = `-- name: UpdateSettingNightWindow :exec
+UPDATE report_setting
+SET night_start_minute = ?, night_end_minute = ?
+WHERE id = 1
+`
+
+type UpdateSettingNightWindowParams struct {
+	NightStartMinute int32 `json:"nightStartMinute"`
+	NightEndMinute   int32 `json:"nightEndMinute"`
+}
+
+func (q *Queries) UpdateSettingNightWindow(ctx context.Context, arg UpdateSettingNightWindowParams) error {
+	_, err := q.db.ExecContext(ctx, updateSettingNightWindow, arg.NightStartMinute, arg.NightEndMinute)
+	return err
+}
+
+const updateSettingPayrollLayout = `-- name: UpdateSettingPayrollLayout :exec
+UPDATE report_setting
+SET payroll_layout = ?
+WHERE id = 1
+`
+
+func (q *Queries) UpdateSettingPayrollLayout(ctx context.Context, payrollLayout sql.NullString) error {
+	_, err := q.db.ExecContext(ctx, updateSettingPayrollLayout, payrollLayout)
+	return err
+}
@@ -10,18 +10,24 @@ import (
 )
 
 const createType = `-- name: CreateType :exec
-INSERT INTO report_type (id, name, system_name)
-VALUES (?, ?, ?)
+INSERT INTO report_type (id, name, system_name, code)
+VALUES (?, ?, ?, ?)
 `
 
 type CreateTypeParams struct {
 	ID         string `json:"id"`
 	Name       string `json:"name"`
 	SystemName string `json:"systemName"`
+	Code       string `json:"code"`
 }
 
 func (q *Queries) CreateType(ctx context.Context, arg CreateTypeParams) error {
-	_, err := q.db.ExecContext(ctx, createType, arg.ID, arg.Name, arg.SystemName)
+	_, err := q.db.ExecContext(ctx, createType,
+		arg.ID,
+		arg.Name,
+		arg.SystemName,
+		arg.Code,
+	)
 	return err
 }
 
@@ -36,7 +42,7 @@ func (q *Queries) DeleteType(ctx context.Context, id string) error {
 }
 
 const getTypeAll = `-- name: GetTypeAll :many
-SELECT id, name, system_name
+SELECT id, name, system_name, code
 FROM report_type
 ORDER BY name ASC
 `
@@ -50,7 +56,12 @@ func (q *Queries) GetTypeAll(ctx context.Context) ([]ReportType, error) {
 	var items []ReportType
 	for rows.Next() {
 		var i ReportType
-		if err := rows.Scan(&i.ID, &i.Name, &i.SystemName); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.SystemName,
+			&i.Code,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -66,7 +77,7 @@ func (q *Queries) GetTypeAll(ctx context.Context) ([]ReportType, error) {
 
 const getTypeById = `-- name: GetTypeById :one
 
-SELECT id, name, system_name
+SELECT id, name, system_name, code
 FROM report_type
 WHERE id = ?
 `
@@ -77,12 +88,17 @@ WHERE id = ?
 func (q *Queries) GetTypeById(ctx context.Context, id string) (ReportType, error) {
 	row := q.db.QueryRowContext(ctx, getTypeById, id)
 	var i ReportType
-	err := row.Scan(&i.ID, &i.Name, &i.SystemName)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.SystemName,
+		&i.Code,
+	)
 	return i, err
 }
 
 const getTypeBySystemName = `-- name: GetTypeBySystemName :one
-SELECT id, name, system_name
+SELECT id, name, system_name, code
 FROM report_type
 WHERE system_name = ?
 `
@@ -90,23 +106,34 @@ WHERE system_name = ?
 func (q *Queries) GetTypeBySystemName(ctx context.Context, systemName string) (ReportType, error) {
 	row := q.db.QueryRowContext(ctx, getTypeBySystemName, systemName)
 	var i ReportType
-	err := row.Scan(&i.ID, &i.Name, &i.SystemName)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.SystemName,
+		&i.Code,
+	)
 	return i, err
 }
 
 const updateType = `-- name: UpdateType :exec
 UPDATE report_type
-SET name = ?, system_name = ?
+SET name = ?, system_name = ?, code = ?
 WHERE id = ?
 `
 
 type UpdateTypeParams struct {
 	Name       string `json:"name"`
 	SystemName string `json:"systemName"`
+	Code       string `json:"code"`
 	ID         string `json:"id"`
 }
 
 func (q *Queries) UpdateType(ctx context.Context, arg UpdateTypeParams) error {
-	_, err := q.db.ExecContext(ctx, updateType, arg.Name, arg.SystemName, arg.ID)
+	_, err := q.db.ExecContext(ctx, updateType,
+		arg.Name,
+		arg.SystemName,
+		arg.Code,
+		arg.ID,
+	)
 	return err
 }
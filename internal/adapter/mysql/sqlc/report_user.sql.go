@@ -7,6 +7,7 @@ package repo
 
 import (
 	"context"
+	"database/sql"
 )
 
 const checkReportUserExists = `-- name: CheckReportUserExists :one
@@ -34,19 +35,49 @@ func (q *Queries) CheckReportUserExists(ctx context.Context, arg CheckReportUser
 	return exists_count, err
 }
 
+const checkReportUserTypeExists = `-- name: CheckReportUserTypeExists :one
+SELECT COUNT(*) as exists_count
+FROM report_user
+WHERE user_id = ? AND day = ? AND month = ? AND year = ? AND type_id = ?
+`
+
+type CheckReportUserTypeExistsParams struct {
+	UserID string `json:"userId"`
+	Day    int32  `json:"day"`
+	Month  int32  `json:"month"`
+	Year   int32  `json:"year"`
+	TypeID string `json:"typeId"`
+}
+
+func (q *Queries) CheckReportUserTypeExists(ctx context.Context, arg CheckReportUserTypeExistsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, checkReportUserTypeExists,
+		arg.UserID,
+		arg.Day,
+		arg.Month,
+		arg.Year,
+		arg.TypeID,
+	)
+	var exists_count int64
+	err := row.Scan(&exists_count)
+	return exists_count, err
+}
+
 const createReportUser = `-- name: CreateReportUser :exec
-INSERT INTO report_user (id, user_id, day, month, year, hours, type_id)
-VALUES (?, ?, ?, ?, ?, ?, ?)
+INSERT INTO report_user (id, user_id, day, month, year, hours, type_id, start_minute, end_minute, night_hours)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateReportUserParams struct {
-	ID     string  `json:"id"`
-	UserID string  `json:"userId"`
-	Day    int32   `json:"day"`
-	Month  int32   `json:"month"`
-	Year   int32   `json:"year"`
-	Hours  float64 `json:"hours"`
-	TypeID string  `json:"typeId"`
+	ID          string        `json:"id"`
+	UserID      string        `json:"userId"`
+	Day         int32         `json:"day"`
+	Month       int32         `json:"month"`
+	Year        int32         `json:"year"`
+	Hours       float64       `json:"hours"`
+	TypeID      string        `json:"typeId"`
+	StartMinute sql.NullInt32 `json:"startMinute"`
+	EndMinute   sql.NullInt32 `json:"endMinute"`
+	NightHours  float64       `json:"nightHours"`
 }
 
 func (q *Queries) CreateReportUser(ctx context.Context, arg CreateReportUserParams) error {
@@ -58,6 +89,9 @@ func (q *Queries) CreateReportUser(ctx context.Context, arg CreateReportUserPara
 		arg.Year,
 		arg.Hours,
 		arg.TypeID,
+		arg.StartMinute,
+		arg.EndMinute,
+		arg.NightHours,
 	)
 	return err
 }
@@ -84,6 +118,16 @@ func (q *Queries) DeleteReportUser(ctx context.Context, arg DeleteReportUserPara
 	return err
 }
 
+const deleteReportUserById = `-- name: DeleteReportUserById :exec
+DELETE FROM report_user
+WHERE id = ?
+`
+
+func (q *Queries) DeleteReportUserById(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteReportUserById, id)
+	return err
+}
+
 const getReportUserById = `-- name: GetReportUserById :one
 SELECT
     ru.id,
@@ -93,6 +137,9 @@ SELECT
     ru.year,
     ru.hours,
     ru.type_id,
+    ru.start_minute,
+    ru.end_minute,
+    ru.night_hours,
     rt.name as type_name,
     rt.system_name as type_system_name
 FROM report_user ru
@@ -101,15 +148,18 @@ WHERE ru.id = ?
 `
 
 type GetReportUserByIdRow struct {
-	ID             string  `json:"id"`
-	UserID         string  `json:"userId"`
-	Day            int32   `json:"day"`
-	Month          int32   `json:"month"`
-	Year           int32   `json:"year"`
-	Hours          float64 `json:"hours"`
-	TypeID         string  `json:"typeId"`
-	TypeName       string  `json:"typeName"`
-	TypeSystemName string  `json:"typeSystemName"`
+	ID             string        `json:"id"`
+	UserID         string        `json:"userId"`
+	Day            int32         `json:"day"`
+	Month          int32         `json:"month"`
+	Year           int32         `json:"year"`
+	Hours          float64       `json:"hours"`
+	TypeID         string        `json:"typeId"`
+	StartMinute    sql.NullInt32 `json:"startMinute"`
+	EndMinute      sql.NullInt32 `json:"endMinute"`
+	NightHours     float64       `json:"nightHours"`
+	TypeName       string        `json:"typeName"`
+	TypeSystemName string        `json:"typeSystemName"`
 }
 
 func (q *Queries) GetReportUserById(ctx context.Context, id string) (GetReportUserByIdRow, error) {
@@ -123,6 +173,64 @@ func (q *Queries) GetReportUserById(ctx context.Context, id string) (GetReportUs
 		&i.Year,
 		&i.Hours,
 		&i.TypeID,
+		&i.StartMinute,
+		&i.EndMinute,
+		&i.NightHours,
+		&i.TypeName,
+		&i.TypeSystemName,
+	)
+	return i, err
+}
+
+const getReportUserByIdForUpdate = `-- name: GetReportUserByIdForUpdate :one
+SELECT
+    ru.id,
+    ru.user_id,
+    ru.day,
+    ru.month,
+    ru.year,
+    ru.hours,
+    ru.type_id,
+    ru.start_minute,
+    ru.end_minute,
+    ru.night_hours,
+    rt.name as type_name,
+    rt.system_name as type_system_name
+FROM report_user ru
+INNER JOIN report_type rt ON ru.type_id = rt.id
+WHERE ru.id = ?
+FOR UPDATE
+`
+
+type GetReportUserByIdForUpdateRow struct {
+	ID             string        `json:"id"`
+	UserID         string        `json:"userId"`
+	Day            int32         `json:"day"`
+	Month          int32         `json:"month"`
+	Year           int32         `json:"year"`
+	Hours          float64       `json:"hours"`
+	TypeID         string        `json:"typeId"`
+	StartMinute    sql.NullInt32 `json:"startMinute"`
+	EndMinute      sql.NullInt32 `json:"endMinute"`
+	NightHours     float64       `json:"nightHours"`
+	TypeName       string        `json:"typeName"`
+	TypeSystemName string        `json:"typeSystemName"`
+}
+
+func (q *Queries) GetReportUserByIdForUpdate(ctx context.Context, id string) (GetReportUserByIdForUpdateRow, error) {
+	row := q.db.QueryRowContext(ctx, getReportUserByIdForUpdate, id)
+	var i GetReportUserByIdForUpdateRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Day,
+		&i.Month,
+		&i.Year,
+		&i.Hours,
+		&i.TypeID,
+		&i.StartMinute,
+		&i.EndMinute,
+		&i.NightHours,
 		&i.TypeName,
 		&i.TypeSystemName,
 	)
@@ -174,6 +282,114 @@ func (q *Queries) GetReportUserCountWork(ctx context.Context, arg GetReportUserC
 	return days_count, err
 }
 
+const getReportUserDayTotalHours = `-- name: GetReportUserDayTotalHours :one
+SELECT CAST(COALESCE(SUM(hours), 0.0) AS FLOAT) AS total_hours
+FROM report_user
+WHERE user_id = ? AND day = ? AND month = ? AND year = ?
+`
+
+type GetReportUserDayTotalHoursParams struct {
+	UserID string `json:"userId"`
+	Day    int32  `json:"day"`
+	Month  int32  `json:"month"`
+	Year   int32  `json:"year"`
+}
+
+func (q *Queries) GetReportUserDayTotalHours(ctx context.Context, arg GetReportUserDayTotalHoursParams) (float64, error) {
+	row := q.db.QueryRowContext(ctx, getReportUserDayTotalHours,
+		arg.UserID,
+		arg.Day,
+		arg.Month,
+		arg.Year,
+	)
+	var total_hours float64
+	err := row.Scan(&total_hours)
+	return total_hours, err
+}
+
+const getReportUserForDay = `-- name: GetReportUserForDay :many
+SELECT
+    ru.id,
+    ru.user_id,
+    ru.day,
+    ru.month,
+    ru.year,
+    ru.hours,
+    ru.type_id,
+    ru.start_minute,
+    ru.end_minute,
+    ru.night_hours,
+    rt.name as type_name,
+    rt.system_name as type_system_name
+FROM report_user ru
+INNER JOIN report_type rt ON ru.type_id = rt.id
+WHERE ru.user_id = ? AND ru.day = ? AND ru.month = ? AND ru.year = ?
+ORDER BY rt.name ASC
+`
+
+type GetReportUserForDayParams struct {
+	UserID string `json:"userId"`
+	Day    int32  `json:"day"`
+	Month  int32  `json:"month"`
+	Year   int32  `json:"year"`
+}
+
+type GetReportUserForDayRow struct {
+	ID             string        `json:"id"`
+	UserID         string        `json:"userId"`
+	Day            int32         `json:"day"`
+	Month          int32         `json:"month"`
+	Year           int32         `json:"year"`
+	Hours          float64       `json:"hours"`
+	TypeID         string        `json:"typeId"`
+	StartMinute    sql.NullInt32 `json:"startMinute"`
+	EndMinute      sql.NullInt32 `json:"endMinute"`
+	NightHours     float64       `json:"nightHours"`
+	TypeName       string        `json:"typeName"`
+	TypeSystemName string        `json:"typeSystemName"`
+}
+
+func (q *Queries) GetReportUserForDay(ctx context.Context, arg GetReportUserForDayParams) ([]GetReportUserForDayRow, error) {
+	rows, err := q.db.QueryContext(ctx, getReportUserForDay,
+		arg.UserID,
+		arg.Day,
+		arg.Month,
+		arg.Year,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReportUserForDayRow
+	for rows.Next() {
+		var i GetReportUserForDayRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Day,
+			&i.Month,
+			&i.Year,
+			&i.Hours,
+			&i.TypeID,
+			&i.StartMinute,
+			&i.EndMinute,
+			&i.NightHours,
+			&i.TypeName,
+			&i.TypeSystemName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getReportUserForMonth = `-- name: GetReportUserForMonth :many
 
 SELECT
@@ -184,6 +400,9 @@ SELECT
     ru.year,
     ru.hours,
     ru.type_id,
+    ru.start_minute,
+    ru.end_minute,
+    ru.night_hours,
     rt.name as type_name,
     rt.system_name as type_system_name
 FROM report_user ru
@@ -199,6 +418,84 @@ type GetReportUserForMonthParams struct {
 }
 
 type GetReportUserForMonthRow struct {
+	ID             string        `json:"id"`
+	UserID         string        `json:"userId"`
+	Day            int32         `json:"day"`
+	Month          int32         `json:"month"`
+	Year           int32         `json:"year"`
+	Hours          float64       `json:"hours"`
+	TypeID         string        `json:"typeId"`
+	StartMinute    sql.NullInt32 `json:"startMinute"`
+	EndMinute      sql.NullInt32 `json:"endMinute"`
+	NightHours     float64       `json:"nightHours"`
+	TypeName       string        `json:"typeName"`
+	TypeSystemName string        `json:"typeSystemName"`
+}
+
+// ============================================
+// REPORT_USER queries
+// ============================================
+func (q *Queries) GetReportUserForMonth(ctx context.Context, arg GetReportUserForMonthParams) ([]GetReportUserForMonthRow, error) {
+	rows, err := q.db.QueryContext(ctx, getReportUserForMonth, arg.UserID, arg.Month, arg.Year)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReportUserForMonthRow
+	for rows.Next() {
+		var i GetReportUserForMonthRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Day,
+			&i.Month,
+			&i.Year,
+			&i.Hours,
+			&i.TypeID,
+			&i.StartMinute,
+			&i.EndMinute,
+			&i.NightHours,
+			&i.TypeName,
+			&i.TypeSystemName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReportUserForMonthAll = `-- name: GetReportUserForMonthAll :many
+SELECT
+    ru.id,
+    ru.user_id,
+    ru.day,
+    ru.month,
+    ru.year,
+    ru.hours,
+    ru.type_id,
+    ru.night_hours,
+    rt.name as type_name,
+    rt.system_name as type_system_name,
+    rt.code as type_code
+FROM report_user ru
+INNER JOIN report_type rt ON ru.type_id = rt.id
+WHERE ru.month = ? AND ru.year = ?
+ORDER BY ru.user_id ASC, ru.day ASC
+`
+
+type GetReportUserForMonthAllParams struct {
+	Month int32 `json:"month"`
+	Year  int32 `json:"year"`
+}
+
+type GetReportUserForMonthAllRow struct {
 	ID             string  `json:"id"`
 	UserID         string  `json:"userId"`
 	Day            int32   `json:"day"`
@@ -206,22 +503,21 @@ type GetReportUserForMonthRow struct {
 	Year           int32   `json:"year"`
 	Hours          float64 `json:"hours"`
 	TypeID         string  `json:"typeId"`
+	NightHours     float64 `json:"nightHours"`
 	TypeName       string  `json:"typeName"`
 	TypeSystemName string  `json:"typeSystemName"`
+	TypeCode       string  `json:"typeCode"`
 }
 
-// ============================================
-// REPORT_USER queries
-// ============================================
-func (q *Queries) GetReportUserForMonth(ctx context.Context, arg GetReportUserForMonthParams) ([]GetReportUserForMonthRow, error) {
-	rows, err := q.db.QueryContext(ctx, getReportUserForMonth, arg.UserID, arg.Month, arg.Year)
+func (q *Queries) GetReportUserForMonthAll(ctx context.Context, arg GetReportUserForMonthAllParams) ([]GetReportUserForMonthAllRow, error) {
+	rows, err := q.db.QueryContext(ctx, getReportUserForMonthAll, arg.Month, arg.Year)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetReportUserForMonthRow
+	var items []GetReportUserForMonthAllRow
 	for rows.Next() {
-		var i GetReportUserForMonthRow
+		var i GetReportUserForMonthAllRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
@@ -230,8 +526,86 @@ func (q *Queries) GetReportUserForMonth(ctx context.Context, arg GetReportUserFo
 			&i.Year,
 			&i.Hours,
 			&i.TypeID,
+			&i.NightHours,
 			&i.TypeName,
 			&i.TypeSystemName,
+			&i.TypeCode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReportUserNightHours = `-- name: GetReportUserNightHours :one
+SELECT CAST(COALESCE(SUM(night_hours), 0.0) AS FLOAT) AS night_hours
+FROM report_user
+WHERE user_id = ? AND month = ? AND year = ?
+`
+
+type GetReportUserNightHoursParams struct {
+	UserID string `json:"userId"`
+	Month  int32  `json:"month"`
+	Year   int32  `json:"year"`
+}
+
+func (q *Queries) GetReportUserNightHours(ctx context.Context, arg GetReportUserNightHoursParams) (float64, error) {
+	row := q.db.QueryRowContext(ctx, getReportUserNightHours, arg.UserID, arg.Month, arg.Year)
+	var night_hours float64
+	err := row.Scan(&night_hours)
+	return night_hours, err
+}
+
+const getReportUserStatsByType = `-- name: GetReportUserStatsByType :many
+SELECT
+    rt.id as type_id,
+    rt.name as type_name,
+    rt.system_name as type_system_name,
+    COUNT(DISTINCT ru.day) as days_count,
+    CAST(COALESCE(SUM(ru.hours), 0.0) AS FLOAT) AS total_hours
+FROM report_user ru
+INNER JOIN report_type rt ON ru.type_id = rt.id
+WHERE ru.user_id = ? AND ru.month = ? AND ru.year = ?
+GROUP BY rt.id, rt.name, rt.system_name
+ORDER BY rt.name ASC
+`
+
+type GetReportUserStatsByTypeParams struct {
+	UserID string `json:"userId"`
+	Month  int32  `json:"month"`
+	Year   int32  `json:"year"`
+}
+
+type GetReportUserStatsByTypeRow struct {
+	TypeID         string  `json:"typeId"`
+	TypeName       string  `json:"typeName"`
+	TypeSystemName string  `json:"typeSystemName"`
+	DaysCount      int64   `json:"daysCount"`
+	TotalHours     float64 `json:"totalHours"`
+}
+
+func (q *Queries) GetReportUserStatsByType(ctx context.Context, arg GetReportUserStatsByTypeParams) ([]GetReportUserStatsByTypeRow, error) {
+	rows, err := q.db.QueryContext(ctx, getReportUserStatsByType, arg.UserID, arg.Month, arg.Year)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReportUserStatsByTypeRow
+	for rows.Next() {
+		var i GetReportUserStatsByTypeRow
+		if err := rows.Scan(
+			&i.TypeID,
+			&i.TypeName,
+			&i.TypeSystemName,
+			&i.DaysCount,
+			&i.TotalHours,
 		); err != nil {
 			return nil, err
 		}
@@ -265,19 +639,54 @@ func (q *Queries) GetReportUserTotalHours(ctx context.Context, arg GetReportUser
 	return total_hours, err
 }
 
+const lockReportUserDay = `-- name: LockReportUserDay :exec
+SELECT id FROM report_user
+WHERE user_id = ? AND day = ? AND month = ? AND year = ?
+FOR UPDATE
+`
+
+type LockReportUserDayParams struct {
+	UserID string `json:"userId"`
+	Day    int32  `json:"day"`
+	Month  int32  `json:"month"`
+	Year   int32  `json:"year"`
+}
+
+// Блокирует отметки дня до конца транзакции. Блокировка по префиксу индекса
+// report_user_day_type закрывает и промежуток, так что параллельная вставка в этот день ждет.
+func (q *Queries) LockReportUserDay(ctx context.Context, arg LockReportUserDayParams) error {
+	_, err := q.db.ExecContext(ctx, lockReportUserDay,
+		arg.UserID,
+		arg.Day,
+		arg.Month,
+		arg.Year,
+	)
+	return err
+}
+
 const updateReportUser = `-- name: UpdateReportUser :exec
 UPDATE report_user
-SET hours = ?, type_id = ?
+SET hours = ?, type_id = ?, start_minute = ?, end_minute = ?, night_hours = ?
 WHERE id = ?
 `
 
 type UpdateReportUserParams struct {
-	Hours  float64 `json:"hours"`
-	TypeID string  `json:"typeId"`
-	ID     string  `json:"id"`
+	Hours       float64       `json:"hours"`
+	TypeID      string        `json:"typeId"`
+	StartMinute sql.NullInt32 `json:"startMinute"`
+	EndMinute   sql.NullInt32 `json:"endMinute"`
+	NightHours  float64       `json:"nightHours"`
+	ID          string        `json:"id"`
 }
 
 func (q *Queries) UpdateReportUser(ctx context.Context, arg UpdateReportUserParams) error {
-	_, err := q.db.ExecContext(ctx, updateReportUser, arg.Hours, arg.TypeID, arg.ID)
+	_, err := q.db.ExecContext(ctx, updateReportUser,
+		arg.Hours,
+		arg.TypeID,
+		arg.StartMinute,
+		arg.EndMinute,
+		arg.NightHours,
+		arg.ID,
+	)
 	return err
 }
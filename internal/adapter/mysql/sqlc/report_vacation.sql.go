@@ -11,6 +11,19 @@ import (
 	"time"
 )
 
+const countVacationsByStatus = `-- name: CountVacationsByStatus :one
+SELECT COUNT(*) as vacations_count
+FROM report_vacation
+WHERE status = ?
+`
+
+func (q *Queries) CountVacationsByStatus(ctx context.Context, status ReportVacationStatus) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countVacationsByStatus, status)
+	var vacations_count int64
+	err := row.Scan(&vacations_count)
+	return vacations_count, err
+}
+
 const createVacation = `-- name: CreateVacation :exec
 INSERT INTO report_vacation (id, user_id, start_date, end_date, year,  description, status)
 VALUES (?, ?, ?, ?, ?, ?, ?)
@@ -235,6 +248,111 @@ func (q *Queries) GetVacations(ctx context.Context, userID string) ([]GetVacatio
 	return items, nil
 }
 
+const getVacationsApprovedInRange = `-- name: GetVacationsApprovedInRange :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE status = "approved" AND start_date <= ? AND end_date >= ?
+ORDER BY user_id ASC, start_date ASC
+`
+
+type GetVacationsApprovedInRangeParams struct {
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+}
+
+type GetVacationsApprovedInRangeRow struct {
+	ID          string               `json:"id"`
+	UserID      string               `json:"userId"`
+	StartDate   time.Time            `json:"startDate"`
+	EndDate     time.Time            `json:"endDate"`
+	Year        int32                `json:"year"`
+	Description string               `json:"description"`
+	Status      ReportVacationStatus `json:"status"`
+	CreateAt    time.Time            `json:"createAt"`
+}
+
+func (q *Queries) GetVacationsApprovedInRange(ctx context.Context, arg GetVacationsApprovedInRangeParams) ([]GetVacationsApprovedInRangeRow, error) {
+	rows, err := q.db.QueryContext(ctx, getVacationsApprovedInRange, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetVacationsApprovedInRangeRow
+	for rows.Next() {
+		var i GetVacationsApprovedInRangeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Year,
+			&i.Description,
+			&i.Status,
+			&i.CreateAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getVacationsApprovedStartingOn = `-- name: GetVacationsApprovedStartingOn :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE status = "approved" AND start_date = ?
+ORDER BY user_id ASC
+`
+
+type GetVacationsApprovedStartingOnRow struct {
+	ID          string               `json:"id"`
+	UserID      string               `json:"userId"`
+	StartDate   time.Time            `json:"startDate"`
+	EndDate     time.Time            `json:"endDate"`
+	Year        int32                `json:"year"`
+	Description string               `json:"description"`
+	Status      ReportVacationStatus `json:"status"`
+	CreateAt    time.Time            `json:"createAt"`
+}
+
+func (q *Queries) GetVacationsApprovedStartingOn(ctx context.Context, startDate time.Time) ([]GetVacationsApprovedStartingOnRow, error) {
+	rows, err := q.db.QueryContext(ctx, getVacationsApprovedStartingOn, startDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetVacationsApprovedStartingOnRow
+	for rows.Next() {
+		var i GetVacationsApprovedStartingOnRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Year,
+			&i.Description,
+			&i.Status,
+			&i.CreateAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getVacationsByYear = `-- name: GetVacationsByYear :many
 SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
 FROM report_vacation
@@ -0,0 +1,10 @@
+package repo
+
+// SchemaVersion - версия схемы, которую ожидает приложение (таблица report_schema_version).
+// При изменении migration/schema.sql увеличивается вместе со строкой INSERT в схемах
+// всех СУБД; /readyz сообщает о базе с другой версией.
+//
+// Версия 2: уникальный индекс report_user_day_type (user_id, day, month, year, type_id).
+// Версия 3: таблица закрытых месяцев report_month_close.
+// Версия 4: время следующей попытки доставки вебхука report_webhook_delivery.next_attempt_at.
+const SchemaVersion = 4
@@ -0,0 +1,57 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"iter"
+)
+
+// Streamer - выборки без ограничения размера, которые отдаются построчно по мере
+// чтения *sql.Rows, не собираясь в памяти. Текст запроса тот же, что у метода
+// Querier с именем без приставки Stream. Ошибка выполнения или чтения приходит
+// последней парой итератора; прерванный цикл закрывает *sql.Rows.
+type Streamer interface {
+	StreamAdminVacationsByYear(ctx context.Context, year int32) iter.Seq2[GetAdminVacationsByYearRow, error]
+}
+
+func (q *Queries) StreamAdminVacationsByYear(ctx context.Context, year int32) iter.Seq2[GetAdminVacationsByYearRow, error] {
+	return func(yield func(GetAdminVacationsByYearRow, error) bool) {
+		rows, err := q.db.QueryContext(ctx, getAdminVacationsByYear, year)
+		if err != nil {
+			yield(GetAdminVacationsByYearRow{}, err)
+			return
+		}
+		defer rows.Close()
+
+		scan(rows, yield, func(i *GetAdminVacationsByYearRow) error {
+			return rows.Scan(
+				&i.ID,
+				&i.UserID,
+				&i.StartDate,
+				&i.EndDate,
+				&i.Year,
+				&i.Description,
+				&i.Status,
+				&i.CreateAt,
+			)
+		})
+	}
+}
+
+// scan передает строки rows в yield, пока тот их принимает
+func scan[T any](rows *sql.Rows, yield func(T, error) bool, into func(*T) error) {
+	for rows.Next() {
+		var i T
+		if err := into(&i); err != nil {
+			yield(i, err)
+			return
+		}
+		if !yield(i, nil) {
+			return
+		}
+	}
+	if err := rows.Err(); err != nil {
+		var zero T
+		yield(zero, err)
+	}
+}
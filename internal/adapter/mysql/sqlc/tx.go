@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Tx - транзакция; запросы внутри нее выполняются через методы Querier
+type Tx interface {
+	Querier
+	Commit() error
+	Rollback() error
+}
+
+// TxBeginner открывает транзакции. Сервисы получают его вместе с Querier,
+// чтобы не зависеть от *sql.DB: в тестах его заменяет хранилище в памяти.
+type TxBeginner interface {
+	Begin(ctx context.Context) (Tx, error)
+}
+
+// NewTxBeginner - транзакции поверх *sql.DB с запросами этого пакета
+func NewTxBeginner(db *sql.DB) TxBeginner {
+	return &sqlTxBeginner{db: db}
+}
+
+type sqlTxBeginner struct {
+	db *sql.DB
+}
+
+func (b *sqlTxBeginner) Begin(ctx context.Context) (Tx, error) {
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlTx{Queries: New(tx), tx: tx}, nil
+}
+
+type sqlTx struct {
+	*Queries
+	tx *sql.Tx
+}
+
+func (t *sqlTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *sqlTx) Rollback() error {
+	return t.tx.Rollback()
+}
@@ -0,0 +1,193 @@
+// Команда gen создает методы оберток пакета observe (querier.go) по интерфейсам
+// Querier и Streamer пакета repo. Запускается из каталога пакета через go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// repoDir - пакет repo относительно каталога observe
+const repoDir = "../mysql/sqlc"
+
+type method struct {
+	name    string
+	params  []param
+	results []string
+}
+
+type param struct {
+	name, typ string
+}
+
+func main() {
+	entries, err := os.ReadDir(repoDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	interfaces := make(map[string][]method)
+	imports := map[string]bool{"TimeTrack/internal/adapter/mysql/sqlc": true, "context": true}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(repoDir, name), nil, 0)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if ts.Name.Name != "Querier" && ts.Name.Name != "Streamer" {
+					continue
+				}
+				methods, used := methodsOf(ts.Type.(*ast.InterfaceType))
+				interfaces[ts.Name.Name] = methods
+				for _, imp := range file.Imports {
+					path := strings.Trim(imp.Path.Value, `"`)
+					if used[filepath.Base(path)] {
+						imports[path] = true
+					}
+				}
+			}
+		}
+	}
+	if interfaces["Querier"] == nil || interfaces["Streamer"] == nil {
+		log.Fatalf("Querier or Streamer not found in %s", repoDir)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by go generate (observe/gen). DO NOT EDIT.\n\npackage observe\n\nimport (\n")
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	for _, path := range paths {
+		if strings.HasSuffix(path, "/mysql/sqlc") {
+			fmt.Fprintf(&buf, "\trepo %q\n", path)
+			continue
+		}
+		fmt.Fprintf(&buf, "\t%q\n", path)
+	}
+	buf.WriteString(")\n")
+
+	for _, m := range interfaces["Querier"] {
+		writeQuery(&buf, m)
+	}
+	for _, m := range interfaces["Streamer"] {
+		writeStream(&buf, m)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("format: %v\n%s", err, buf.Bytes())
+	}
+	if err := os.WriteFile("querier.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// methodsOf - методы интерфейса по имени и пакеты, на которые ссылаются их типы
+func methodsOf(it *ast.InterfaceType) ([]method, map[string]bool) {
+	used := make(map[string]bool)
+	var methods []method
+	for _, field := range it.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			continue
+		}
+		m := method{name: field.Names[0].Name}
+		for _, p := range ft.Params.List {
+			for _, name := range p.Names {
+				m.params = append(m.params, param{name: name.Name, typ: typeString(p.Type, used)})
+			}
+		}
+		for _, r := range ft.Results.List {
+			m.results = append(m.results, typeString(r.Type, used))
+		}
+		methods = append(methods, m)
+	}
+	slices.SortFunc(methods, func(a, b method) int { return strings.Compare(a.name, b.name) })
+	return methods, used
+}
+
+// typeString - тип в пакете observe: собственные типы repo получают префикс repo.
+func typeString(expr ast.Expr, used map[string]bool) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return "repo." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		pkg := t.X.(*ast.Ident).Name
+		used[pkg] = true
+		return pkg + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X, used)
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt, used)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key, used) + "]" + typeString(t.Value, used)
+	case *ast.IndexExpr:
+		return typeString(t.X, used) + "[" + typeString(t.Index, used) + "]"
+	case *ast.IndexListExpr:
+		args := make([]string, len(t.Indices))
+		for i, index := range t.Indices {
+			args[i] = typeString(index, used)
+		}
+		return typeString(t.X, used) + "[" + strings.Join(args, ", ") + "]"
+	}
+	log.Fatalf("unsupported type %T", expr)
+	return ""
+}
+
+func signature(m method) (params, args, results string) {
+	p := make([]string, len(m.params))
+	a := make([]string, len(m.params))
+	for i, prm := range m.params {
+		p[i] = prm.name + " " + prm.typ
+		a[i] = prm.name
+	}
+	results = strings.Join(m.results, ", ")
+	if len(m.results) > 1 {
+		results = "(" + results + ")"
+	}
+	return strings.Join(p, ", "), strings.Join(a, ", "), results
+}
+
+func writeQuery(buf *bytes.Buffer, m method) {
+	params, args, results := signature(m)
+	fmt.Fprintf(buf, "\nfunc (q *querier) %s(%s) %s {\n", m.name, params, results)
+	fmt.Fprintf(buf, "\tctx, done := q.observe(ctx, %q)\n", m.name)
+	if len(m.results) == 1 {
+		fmt.Fprintf(buf, "\terr := q.next.%s(%s)\n\tdone(err)\n\treturn err\n}\n", m.name, args)
+		return
+	}
+	fmt.Fprintf(buf, "\tresult, err := q.next.%s(%s)\n\tdone(err)\n\treturn result, err\n}\n", m.name, args)
+}
+
+func writeStream(buf *bytes.Buffer, m method) {
+	params, args, results := signature(m)
+	fmt.Fprintf(buf, "\nfunc (s *streamer) %s(%s) %s {\n", m.name, params, results)
+	fmt.Fprintf(buf, "\treturn rows(ctx, %q, s.observe, func(ctx context.Context) %s {\n", m.name, results)
+	fmt.Fprintf(buf, "\t\treturn s.next.%s(%s)\n\t})\n}\n", m.name, args)
+}
@@ -0,0 +1,90 @@
+// Package observe - обертки запросов repo, которые вызывают наблюдателя вокруг
+// каждого запроса. На них построены длительность запросов в internal/metrics и
+// spans запросов в internal/tracing.
+//
+// Методы оберток (querier.go) генерируются по интерфейсам repo.Querier и
+// repo.Streamer: после изменения запросов sqlc выполните
+// go generate ./internal/adapter/observe.
+package observe
+
+//go:generate go run ./gen
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"iter"
+)
+
+// Observer вызывается перед запросом с его именем sqlc и возвращает контекст запроса
+// и done - обертка вызывает ее с ошибкой запроса, когда он завершился. Для построчной
+// выборки запрос завершается после чтения последней строки.
+type Observer func(ctx context.Context, query string) (context.Context, func(err error))
+
+// Querier - запросы q под наблюдением observe
+func Querier(q repo.Querier, observe Observer) repo.Querier {
+	return &querier{next: q, observe: observe}
+}
+
+// Streamer - построчные выборки s под наблюдением observe
+func Streamer(s repo.Streamer, observe Observer) repo.Streamer {
+	return &streamer{next: s, observe: observe}
+}
+
+// TxBeginner - транзакции, запросы внутри которых наблюдаются так же, как вне их
+func TxBeginner(b repo.TxBeginner, observe Observer) repo.TxBeginner {
+	return &txBeginner{next: b, observe: observe}
+}
+
+// querier - методов столько же, сколько запросов sqlc; без повторной генерации
+// новый запрос здесь не соберется
+type querier struct {
+	next    repo.Querier
+	observe Observer
+}
+
+type streamer struct {
+	next    repo.Streamer
+	observe Observer
+}
+
+type txBeginner struct {
+	next    repo.TxBeginner
+	observe Observer
+}
+
+func (b *txBeginner) Begin(ctx context.Context) (repo.Tx, error) {
+	t, err := b.next.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tx{querier: querier{next: t, observe: b.observe}, tx: t}, nil
+}
+
+type tx struct {
+	querier
+	tx repo.Tx
+}
+
+func (t *tx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *tx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// rows наблюдает построчную выборку next от запроса до последней прочитанной строки
+func rows[T any](ctx context.Context, query string, observe Observer, next func(ctx context.Context) iter.Seq2[T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx, done := observe(ctx, query)
+		var err error
+		for row, rowErr := range next(ctx) {
+			err = rowErr
+			if !yield(row, rowErr) {
+				break
+			}
+		}
+		done(err)
+	}
+}
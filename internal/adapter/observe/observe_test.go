@@ -0,0 +1,95 @@
+package observe
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// observed запоминает имена запросов и ошибки, с которыми они завершились
+type observed struct {
+	queries []string
+	errs    []error
+}
+
+func (o *observed) observe(ctx context.Context, query string) (context.Context, func(err error)) {
+	o.queries = append(o.queries, query)
+	return ctx, func(err error) {
+		o.errs = append(o.errs, err)
+	}
+}
+
+func TestQuerier(t *testing.T) {
+	ctx := context.Background()
+	o := &observed{}
+	q := Querier(memory.New(), o.observe)
+
+	if err := q.CreateType(ctx, repo.CreateTypeParams{ID: "t-work", Name: "Явка", SystemName: "work", Code: "Я"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.GetTypeById(ctx, "missing"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("err = %v, want sql.ErrNoRows", err)
+	}
+
+	if want := []string{"CreateType", "GetTypeById"}; !slices.Equal(o.queries, want) {
+		t.Fatalf("queries = %v, want %v", o.queries, want)
+	}
+	if o.errs[0] != nil || !errors.Is(o.errs[1], sql.ErrNoRows) {
+		t.Fatalf("errors = %v", o.errs)
+	}
+}
+
+func TestTxBeginner(t *testing.T) {
+	ctx := context.Background()
+	o := &observed{}
+	store := memory.New()
+
+	tx, err := TxBeginner(store, o.observe).Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	if _, err := tx.GetTypeAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"GetTypeAll"}; !slices.Equal(o.queries, want) {
+		t.Fatalf("queries = %v, want %v", o.queries, want)
+	}
+}
+
+// TestStreamer - выборка завершается после последней строки, а не при вызове метода
+func TestStreamer(t *testing.T) {
+	ctx := context.Background()
+	o := &observed{}
+	store := memory.New()
+	if err := store.CreateVacation(ctx, repo.CreateVacationParams{ID: "v-1", UserID: "u-1", Year: 2025, Status: repo.ReportVacationStatusApproved}); err != nil {
+		t.Fatal(err)
+	}
+
+	rows := Streamer(store, o.observe).StreamAdminVacationsByYear(ctx, 2025)
+	if len(o.queries) != 0 {
+		t.Fatalf("queries before iteration = %v", o.queries)
+	}
+	count := 0
+	for _, err := range rows {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(o.errs) != 0 {
+			t.Fatal("query finished before the last row")
+		}
+		count++
+	}
+
+	if count != 1 || !slices.Equal(o.queries, []string{"StreamAdminVacationsByYear"}) || len(o.errs) != 1 {
+		t.Fatalf("rows = %d, queries = %v, finished = %d", count, o.queries, len(o.errs))
+	}
+}
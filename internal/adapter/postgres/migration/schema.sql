@@ -0,0 +1,283 @@
+--
+-- Схема для PostgreSQL, повторяет internal/adapter/mysql/migration/schema.sql.
+-- Строки сравниваются и сортируются без учета регистра и диакритики, как при
+-- utf8mb4_0900_ai_ci в MySQL: для этого у текстовых столбцов сортировка ci (ICU).
+-- Столбцы FLOAT MySQL - real, TINYINT(1) - boolean, ENUM - varchar с CHECK.
+--
+CREATE COLLATION IF NOT EXISTS ci (provider = icu, locale = 'und-u-ks-level1', deterministic = false);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_calendar
+--
+CREATE TABLE report_calendar (
+  id varchar(36) COLLATE ci NOT NULL,
+  day integer NOT NULL,
+  month integer NOT NULL,
+  year integer NOT NULL,
+  description varchar(100) COLLATE ci DEFAULT NULL,
+  is_paid_vacation boolean NOT NULL DEFAULT TRUE,
+  type_id varchar(36) COLLATE ci NOT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_standard
+--
+CREATE TABLE report_standard (
+  id varchar(36) COLLATE ci NOT NULL,
+  month integer NOT NULL,
+  year integer NOT NULL,
+  hours integer NOT NULL,
+  gender_id integer NOT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_type
+--
+CREATE TABLE report_type (
+  id varchar(36) COLLATE ci NOT NULL,
+  name varchar(50) COLLATE ci NOT NULL,
+  system_name varchar(50) COLLATE ci NOT NULL,
+  code varchar(4) COLLATE ci NOT NULL DEFAULT ''
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_user
+--
+CREATE TABLE report_user (
+  id varchar(36) COLLATE ci NOT NULL,
+  user_id varchar(36) COLLATE ci NOT NULL,
+  day integer NOT NULL,
+  month integer NOT NULL,
+  year integer NOT NULL,
+  hours real NOT NULL DEFAULT 0.0,
+  type_id varchar(36) COLLATE ci NOT NULL,
+  start_minute integer DEFAULT NULL,
+  end_minute integer DEFAULT NULL,
+  night_hours real NOT NULL DEFAULT 0.0
+);
+--
+-- Уникальный индекс report_user: одна отметка каждого вида за день
+--
+CREATE UNIQUE INDEX report_user_day_type ON report_user (user_id, day, month, year, type_id);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_vacation
+--
+CREATE TABLE report_vacation (
+  id varchar(36) COLLATE ci NOT NULL,
+  user_id varchar(36) COLLATE ci NOT NULL,
+  start_date date NOT NULL,
+  end_date date NOT NULL,
+  year integer NOT NULL,
+  description varchar(100) COLLATE ci,
+  status varchar(13) COLLATE ci NOT NULL DEFAULT 'consideration'
+    CHECK (status IN ('consideration', 'rejected', 'approved', '')),
+  create_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP(0)
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_setting
+--
+CREATE TABLE report_setting (
+  id integer NOT NULL,
+  vacation_duration integer NOT NULL DEFAULT 30,
+  night_start_minute integer NOT NULL DEFAULT 1320,
+  night_end_minute integer NOT NULL DEFAULT 360,
+  payroll_layout text DEFAULT NULL
+);
+
+INSERT INTO report_setting (id) VALUES (1);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_schedule
+--
+CREATE TABLE report_schedule (
+  id varchar(36) COLLATE ci NOT NULL,
+  name varchar(100) COLLATE ci NOT NULL,
+  kind varchar(6) COLLATE ci NOT NULL DEFAULT 'weekly'
+    CHECK (kind IN ('weekly', 'shift')),
+  cycle_length integer NOT NULL DEFAULT 7,
+  cycle_start date DEFAULT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_schedule_day
+--
+CREATE TABLE report_schedule_day (
+  id varchar(36) COLLATE ci NOT NULL,
+  schedule_id varchar(36) COLLATE ci NOT NULL,
+  day_index integer NOT NULL,
+  hours real NOT NULL DEFAULT 0.0
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_user_schedule
+--
+CREATE TABLE report_user_schedule (
+  id varchar(36) COLLATE ci NOT NULL,
+  user_id varchar(36) COLLATE ci NOT NULL,
+  schedule_id varchar(36) COLLATE ci NOT NULL,
+  effective_from date NOT NULL,
+  effective_to date DEFAULT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_shift
+--
+CREATE TABLE report_shift (
+  id varchar(36) COLLATE ci NOT NULL,
+  user_id varchar(36) COLLATE ci NOT NULL,
+  day integer NOT NULL,
+  month integer NOT NULL,
+  year integer NOT NULL,
+  start_minute integer NOT NULL,
+  end_minute integer NOT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_department
+--
+CREATE TABLE report_department (
+  id varchar(36) COLLATE ci NOT NULL,
+  name varchar(100) COLLATE ci NOT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_user_department
+--
+CREATE TABLE report_user_department (
+  user_id varchar(36) COLLATE ci NOT NULL,
+  department_id varchar(36) COLLATE ci NOT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_project
+--
+CREATE TABLE report_project (
+  id varchar(36) COLLATE ci NOT NULL,
+  code varchar(50) COLLATE ci NOT NULL,
+  name varchar(100) COLLATE ci NOT NULL,
+  client varchar(100) COLLATE ci DEFAULT NULL,
+  is_active boolean NOT NULL DEFAULT TRUE
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_task
+--
+CREATE TABLE report_task (
+  id varchar(36) COLLATE ci NOT NULL,
+  project_id varchar(36) COLLATE ci NOT NULL,
+  name varchar(100) COLLATE ci NOT NULL,
+  is_active boolean NOT NULL DEFAULT TRUE
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_allocation
+--
+CREATE TABLE report_allocation (
+  id varchar(36) COLLATE ci NOT NULL,
+  report_id varchar(36) COLLATE ci NOT NULL,
+  project_id varchar(36) COLLATE ci NOT NULL,
+  task_id varchar(36) COLLATE ci DEFAULT NULL,
+  hours real NOT NULL DEFAULT 0.0
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_document_template
+--
+CREATE TABLE report_document_template (
+  kind varchar(50) COLLATE ci NOT NULL,
+  title varchar(200) COLLATE ci NOT NULL,
+  body text COLLATE ci NOT NULL,
+  update_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP(0)
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_webhook
+--
+CREATE TABLE report_webhook (
+  id varchar(36) COLLATE ci NOT NULL,
+  url varchar(500) COLLATE ci NOT NULL,
+  secret varchar(100) COLLATE ci NOT NULL,
+  events varchar(500) COLLATE ci NOT NULL DEFAULT '*',
+  is_active boolean NOT NULL DEFAULT TRUE,
+  create_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP(0)
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_webhook_delivery
+--
+-- update_at обновляется запросом UpdateWebhookDelivery (в MySQL - ON UPDATE CURRENT_TIMESTAMP)
+CREATE TABLE report_webhook_delivery (
+  id varchar(36) COLLATE ci NOT NULL,
+  webhook_id varchar(36) COLLATE ci NOT NULL,
+  event varchar(50) COLLATE ci NOT NULL,
+  payload text COLLATE ci NOT NULL,
+  status varchar(20) COLLATE ci NOT NULL DEFAULT 'pending',
+  attempts integer NOT NULL DEFAULT 0,
+  response_code integer DEFAULT NULL,
+  error varchar(500) COLLATE ci DEFAULT NULL,
+  next_attempt_at timestamptz DEFAULT NULL,
+  create_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP(0),
+  update_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP(0)
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_user_contact
+--
+CREATE TABLE report_user_contact (
+  user_id varchar(36) COLLATE ci NOT NULL,
+  email varchar(255) COLLATE ci NOT NULL,
+  name varchar(100) COLLATE ci NOT NULL DEFAULT '',
+  manager_id varchar(36) COLLATE ci DEFAULT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_chat_link
+--
+CREATE TABLE report_chat_link (
+  chat_id bigint NOT NULL,
+  user_id varchar(36) COLLATE ci NOT NULL,
+  create_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP(0)
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_chat_link_code
+--
+CREATE TABLE report_chat_link_code (
+  code varchar(16) COLLATE ci NOT NULL,
+  user_id varchar(36) COLLATE ci NOT NULL,
+  expire_at timestamptz NOT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_directory_user
+--
+CREATE TABLE report_directory_user (
+  id varchar(36) COLLATE ci NOT NULL,
+  dn varchar(500) COLLATE ci NOT NULL,
+  login varchar(100) COLLATE ci NOT NULL DEFAULT '',
+  name varchar(200) COLLATE ci NOT NULL DEFAULT '',
+  email varchar(255) COLLATE ci NOT NULL DEFAULT '',
+  is_active boolean NOT NULL DEFAULT TRUE,
+  sync_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP(0)
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_month_close
+--
+CREATE TABLE report_month_close (
+  month integer NOT NULL,
+  year integer NOT NULL,
+  close_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP(0)
+);
+CREATE UNIQUE INDEX report_month_close_month ON report_month_close (month, year);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_schema_version
+--
+CREATE TABLE report_schema_version (
+  version integer NOT NULL
+);
+INSERT INTO report_schema_version (version) VALUES (4);
+-- --------------------------------------------------------
@@ -0,0 +1,33 @@
+// Package postgres - хранилище на PostgreSQL с тем же repo.Querier, что и у MySQL.
+//
+// Запросы - query/*.sql с параметрами $1..$n, выполняются через пакет repo
+// (см. internal/adapter/dialect). Схема - migration/schema.sql.
+package postgres
+
+import (
+	"TimeTrack/internal/adapter/dialect"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"database/sql"
+	"embed"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// Driver - имя драйвера database/sql для sql.Open
+const Driver = "pgx"
+
+//go:embed query/*.sql
+var queryFiles embed.FS
+
+// queries - текст запросов PostgreSQL по имени запроса sqlc
+var queries = dialect.MustLoad(queryFiles)
+
+// New возвращает запросы repo (Querier и Streamer) поверх соединения с PostgreSQL
+func New(db *sql.DB) *repo.Queries {
+	return queries.New(db)
+}
+
+// NewTxBeginner - транзакции с запросами PostgreSQL
+func NewTxBeginner(db *sql.DB) repo.TxBeginner {
+	return queries.NewTxBeginner(db)
+}
@@ -0,0 +1,100 @@
+-- ============================================
+-- REPORT_CALENDAR queries
+-- ============================================
+
+-- name: GetCalendarDays :many
+SELECT
+    rc.id,
+    rc.day,
+    rc.month,
+    rc.year,
+    COALESCE(rc.description, '') as description,  -- Возвращаем пустую строку вместо NULL
+    rc.is_paid_vacation,
+    rc.type_id,
+    rt.name as type_name,
+    rt.system_name as type_system_name
+FROM report_calendar rc
+INNER JOIN report_type rt ON rc.type_id = rt.id
+WHERE rc.month = $1 AND rc.year = $2
+ORDER BY rc.day ASC;
+
+-- name: GetCalendarDaysByType :many
+SELECT
+    rc.id,
+    rc.day,
+    rc.month,
+    rc.year,
+    COALESCE(rc.description, '') as description,  -- Возвращаем пустую строку вместо NULL
+    rc.is_paid_vacation,
+    rc.type_id,
+    rt.name as type_name,
+    rt.system_name as type_system_name
+FROM report_calendar rc
+INNER JOIN report_type rt ON rc.type_id = rt.id
+WHERE rc.month = $1 AND rc.year = $2 AND rt.system_name = $3
+ORDER BY rc.day ASC;
+
+-- name: GetCalendarDaysAll :many
+SELECT
+    rc.id,
+    rc.day,
+    rc.month,
+    rc.year,
+    COALESCE(rc.description, '') as description,  -- Возвращаем пустую строку вместо NULL
+    rc.is_paid_vacation,
+    rc.type_id,
+    rt.name as type_name,
+    rt.system_name as type_system_name
+FROM report_calendar rc
+INNER JOIN report_type rt ON rc.type_id = rt.id
+WHERE rc.year = $1
+ORDER BY rc.month ASC, rc.day ASC;
+
+-- name: GetCalendarDaysAllByType :many
+SELECT
+    rc.id,
+    rc.day,
+    rc.month,
+    rc.year,
+    COALESCE(rc.description, '') as description,  -- Возвращаем пустую строку вместо NULL
+    rc.is_paid_vacation,
+    rc.type_id,
+    rt.name as type_name,
+    rt.system_name as type_system_name
+FROM report_calendar rc
+INNER JOIN report_type rt ON rc.type_id = rt.id
+WHERE rc.year = $1 AND rt.system_name = $2
+ORDER BY rc.month ASC, rc.day ASC;
+
+-- name: GetCalendarDay :one
+SELECT
+    rc.id,
+    rc.day,
+    rc.month,
+    rc.year,
+    rc.description,
+    rc.is_paid_vacation,
+    rc.type_id,
+    rt.name as type_name,
+    rt.system_name as type_system_name
+FROM report_calendar rc
+INNER JOIN report_type rt ON rc.type_id = rt.id
+WHERE rc.day = $1 AND rc.month = $2 AND rc.year = $3;
+
+-- name: CreateCalendarDay :exec
+INSERT INTO report_calendar (id, day, month, year, description, is_paid_vacation, type_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: UpdateCalendarDay :exec
+UPDATE report_calendar
+SET description = $1, type_id = $2
+WHERE id = $3;
+
+-- name: DeleteCalendarDay :exec
+DELETE FROM report_calendar
+WHERE id = $1;
+
+-- name: CheckCalendarDayExists :one
+SELECT COUNT(*) as exists_count
+FROM report_calendar
+WHERE day = $1 AND month = $2 AND year = $3;
@@ -0,0 +1,38 @@
+-- ============================================
+-- REPORT_CHAT_LINK queries
+-- ============================================
+
+-- name: GetChatLinks :many
+SELECT chat_id, user_id, create_at
+FROM report_chat_link
+ORDER BY create_at ASC;
+
+-- name: GetChatLinkByChat :one
+SELECT chat_id, user_id, create_at
+FROM report_chat_link
+WHERE chat_id = $1;
+
+-- name: CreateChatLink :exec
+INSERT INTO report_chat_link (chat_id, user_id)
+VALUES ($1, $2);
+
+-- name: DeleteChatLink :exec
+DELETE FROM report_chat_link
+WHERE chat_id = $1;
+
+-- ============================================
+-- REPORT_CHAT_LINK_CODE queries
+-- ============================================
+
+-- name: GetChatLinkCode :one
+SELECT code, user_id, expire_at
+FROM report_chat_link_code
+WHERE code = $1;
+
+-- name: CreateChatLinkCode :exec
+INSERT INTO report_chat_link_code (code, user_id, expire_at)
+VALUES ($1, $2, $3);
+
+-- name: DeleteChatLinkCode :exec
+DELETE FROM report_chat_link_code
+WHERE code = $1 OR expire_at < CURRENT_TIMESTAMP;
@@ -0,0 +1,38 @@
+-- ============================================
+-- REPORT_DEPARTMENT queries
+-- ============================================
+
+-- name: GetDepartments :many
+SELECT id, name
+FROM report_department
+ORDER BY name ASC;
+
+-- name: GetDepartmentById :one
+SELECT id, name
+FROM report_department
+WHERE id = $1;
+
+-- name: CreateDepartment :exec
+INSERT INTO report_department (id, name)
+VALUES ($1, $2);
+
+-- name: GetUserDepartment :one
+SELECT rud.user_id, rud.department_id, rd.name as department_name
+FROM report_user_department rud
+INNER JOIN report_department rd ON rud.department_id = rd.id
+WHERE rud.user_id = $1;
+
+-- name: GetDepartmentUsers :many
+SELECT rud.user_id
+FROM report_user_department rud
+LEFT JOIN report_directory_user rdu ON rdu.id = rud.user_id
+WHERE rud.department_id = $1 AND COALESCE(rdu.is_active, TRUE)
+ORDER BY rud.user_id ASC;
+
+-- name: CreateUserDepartment :exec
+INSERT INTO report_user_department (user_id, department_id)
+VALUES ($1, $2);
+
+-- name: DeleteUserDepartment :exec
+DELETE FROM report_user_department
+WHERE user_id = $1;
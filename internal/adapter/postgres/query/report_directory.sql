@@ -0,0 +1,38 @@
+-- ============================================
+-- REPORT_DIRECTORY_USER queries
+-- ============================================
+
+-- name: GetDirectoryUsers :many
+SELECT id, dn, login, name, email, is_active, sync_at
+FROM report_directory_user
+ORDER BY name ASC;
+
+-- name: GetDirectoryUserById :one
+SELECT id, dn, login, name, email, is_active, sync_at
+FROM report_directory_user
+WHERE id = $1;
+
+-- name: GetDirectoryUserByEmail :one
+SELECT id, dn, login, name, email, is_active, sync_at
+FROM report_directory_user
+WHERE email = $1
+LIMIT 1;
+
+-- name: CreateDirectoryUser :exec
+INSERT INTO report_directory_user (id, dn, login, name, email, is_active)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: UpdateDirectoryUser :exec
+UPDATE report_directory_user
+SET dn = $1, login = $2, name = $3, email = $4, is_active = $5, sync_at = CURRENT_TIMESTAMP(0)
+WHERE id = $6;
+
+-- name: DeactivateDirectoryUser :exec
+UPDATE report_directory_user
+SET is_active = FALSE, sync_at = CURRENT_TIMESTAMP(0)
+WHERE id = $1;
+
+-- name: CheckDirectoryUserInactive :one
+SELECT COUNT(*) as inactive_count
+FROM report_directory_user
+WHERE id = $1 AND NOT is_active;
@@ -0,0 +1,22 @@
+-- ============================================
+-- REPORT_MONTH_CLOSE queries
+-- ============================================
+
+-- name: GetMonthClose :one
+SELECT month, year, close_at
+FROM report_month_close
+WHERE month = $1 AND year = $2;
+
+-- name: GetMonthClosesByYear :many
+SELECT month, year, close_at
+FROM report_month_close
+WHERE year = $1
+ORDER BY month ASC;
+
+-- name: CloseMonth :exec
+INSERT INTO report_month_close (month, year)
+VALUES ($1, $2);
+
+-- name: ReopenMonth :exec
+DELETE FROM report_month_close
+WHERE month = $1 AND year = $2;
@@ -0,0 +1,62 @@
+-- ============================================
+-- REPORT_SCHEDULE queries
+-- ============================================
+
+-- name: GetSchedules :many
+SELECT id, name, kind, cycle_length, cycle_start
+FROM report_schedule
+ORDER BY name ASC;
+
+-- name: GetScheduleById :one
+SELECT id, name, kind, cycle_length, cycle_start
+FROM report_schedule
+WHERE id = $1;
+
+-- name: CreateSchedule :exec
+INSERT INTO report_schedule (id, name, kind, cycle_length, cycle_start)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: DeleteSchedule :exec
+DELETE FROM report_schedule
+WHERE id = $1;
+
+-- name: GetScheduleDays :many
+SELECT id, schedule_id, day_index, hours
+FROM report_schedule_day
+WHERE schedule_id = $1
+ORDER BY day_index ASC;
+
+-- name: CreateScheduleDay :exec
+INSERT INTO report_schedule_day (id, schedule_id, day_index, hours)
+VALUES ($1, $2, $3, $4);
+
+-- name: DeleteScheduleDays :exec
+DELETE FROM report_schedule_day
+WHERE schedule_id = $1;
+
+-- name: GetUserSchedules :many
+SELECT
+    rus.id,
+    rus.user_id,
+    rus.schedule_id,
+    rus.effective_from,
+    rus.effective_to,
+    rs.name as schedule_name,
+    rs.kind as schedule_kind
+FROM report_user_schedule rus
+INNER JOIN report_schedule rs ON rus.schedule_id = rs.id
+WHERE rus.user_id = $1
+ORDER BY rus.effective_from ASC;
+
+-- name: CreateUserSchedule :exec
+INSERT INTO report_user_schedule (id, user_id, schedule_id, effective_from, effective_to)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: DeleteUserSchedule :exec
+DELETE FROM report_user_schedule
+WHERE id = $1;
+
+-- name: CountUserSchedulesBySchedule :one
+SELECT COUNT(*) as users_count
+FROM report_user_schedule
+WHERE schedule_id = $1;
@@ -0,0 +1,28 @@
+-- ============================================
+-- REPORT_SETTING queries
+-- ============================================
+
+-- name: GetSettingVacationDuration :one
+SELECT vacation_duration
+FROM report_setting
+WHERE id = 1;
+
+-- name: GetSettingNightWindow :one
+SELECT night_start_minute, night_end_minute
+FROM report_setting
+WHERE id = 1;
+
+-- name: UpdateSettingNightWindow :exec
+UPDATE report_setting
+SET night_start_minute = $1, night_end_minute = $2
+WHERE id = 1;
+
+-- name: GetSettingPayrollLayout :one
+SELECT payroll_layout
+FROM report_setting
+WHERE id = 1;
+
+-- name: UpdateSettingPayrollLayout :exec
+UPDATE report_setting
+SET payroll_layout = $1
+WHERE id = 1;
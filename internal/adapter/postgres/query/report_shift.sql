@@ -0,0 +1,38 @@
+-- ============================================
+-- REPORT_SHIFT queries
+-- ============================================
+
+-- name: GetShiftsForMonth :many
+SELECT id, user_id, day, month, year, start_minute, end_minute
+FROM report_shift
+WHERE user_id = $1 AND month = $2 AND year = $3
+ORDER BY day ASC;
+
+-- name: GetShiftsForMonthAll :many
+SELECT id, user_id, day, month, year, start_minute, end_minute
+FROM report_shift
+WHERE month = $1 AND year = $2
+ORDER BY day ASC, user_id ASC;
+
+-- name: GetShift :one
+SELECT id, user_id, day, month, year, start_minute, end_minute
+FROM report_shift
+WHERE user_id = $1 AND day = $2 AND month = $3 AND year = $4;
+
+-- name: GetShiftById :one
+SELECT id, user_id, day, month, year, start_minute, end_minute
+FROM report_shift
+WHERE id = $1;
+
+-- name: CreateShift :exec
+INSERT INTO report_shift (id, user_id, day, month, year, start_minute, end_minute)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: UpdateShift :exec
+UPDATE report_shift
+SET start_minute = $1, end_minute = $2
+WHERE id = $3;
+
+-- name: DeleteShift :exec
+DELETE FROM report_shift
+WHERE id = $1;
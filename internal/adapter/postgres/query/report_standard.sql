@@ -0,0 +1,37 @@
+-- ============================================
+-- REPORT_STANDART queries
+-- ============================================
+
+-- name: GetStandard :one
+SELECT id, month, year, hours, gender_id
+FROM report_standard
+WHERE month = $1 AND year = $2 AND gender_id = $3;
+
+-- name: GetStandardByMonth :many
+SELECT id, month, year, hours, gender_id
+FROM report_standard
+WHERE month = $1 AND year = $2;
+
+-- name: GetStandardByYear :many
+SELECT id, month, year, hours, gender_id
+FROM report_standard
+WHERE year = $1
+ORDER BY month ASC;
+
+-- name: CreateStandard :exec
+INSERT INTO report_standard (id, month, year, hours, gender_id)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: UpdateStandard :exec
+UPDATE report_standard
+SET hours = $1
+WHERE id = $2;
+
+-- name: DeleteStandard :exec
+DELETE FROM report_standard
+WHERE id = $1;
+
+-- name: CheckStandard :one
+SELECT COUNT(*) as exists_count
+FROM report_standard
+WHERE month = $1 AND year = $2 AND gender_id = $3;
\ No newline at end of file
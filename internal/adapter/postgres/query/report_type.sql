@@ -0,0 +1,31 @@
+-- ============================================
+-- REPORT_TYPE queries
+-- ============================================
+
+-- name: GetTypeById :one
+SELECT id, name, system_name, code
+FROM report_type
+WHERE id = $1;
+
+-- name: GetTypeBySystemName :one
+SELECT id, name, system_name, code
+FROM report_type
+WHERE system_name = $1;
+
+-- name: GetTypeAll :many
+SELECT id, name, system_name, code
+FROM report_type
+ORDER BY name ASC;
+
+-- name: CreateType :exec
+INSERT INTO report_type (id, name, system_name, code)
+VALUES ($1, $2, $3, $4);
+
+-- name: UpdateType :exec
+UPDATE report_type
+SET name = $1, system_name = $2, code = $3
+WHERE id = $4;
+
+-- name: DeleteType :exec
+DELETE FROM report_type
+WHERE id = $1;
\ No newline at end of file
@@ -0,0 +1,167 @@
+-- ============================================
+-- REPORT_USER queries
+-- ============================================
+
+-- name: GetReportUserForMonth :many
+SELECT
+    ru.id,
+    ru.user_id,
+    ru.day,
+    ru.month,
+    ru.year,
+    ru.hours,
+    ru.type_id,
+    ru.start_minute,
+    ru.end_minute,
+    ru.night_hours,
+    rt.name as type_name,
+    rt.system_name as type_system_name
+FROM report_user ru
+INNER JOIN report_type rt ON ru.type_id = rt.id
+WHERE ru.user_id = $1 AND ru.month = $2 AND ru.year = $3
+ORDER BY ru.day ASC;
+
+-- name: GetReportUserForMonthAll :many
+SELECT
+    ru.id,
+    ru.user_id,
+    ru.day,
+    ru.month,
+    ru.year,
+    ru.hours,
+    ru.type_id,
+    ru.night_hours,
+    rt.name as type_name,
+    rt.system_name as type_system_name,
+    rt.code as type_code
+FROM report_user ru
+INNER JOIN report_type rt ON ru.type_id = rt.id
+WHERE ru.month = $1 AND ru.year = $2
+ORDER BY ru.user_id ASC, ru.day ASC;
+
+-- name: GetReportUserById :one
+SELECT
+    ru.id,
+    ru.user_id,
+    ru.day,
+    ru.month,
+    ru.year,
+    ru.hours,
+    ru.type_id,
+    ru.start_minute,
+    ru.end_minute,
+    ru.night_hours,
+    rt.name as type_name,
+    rt.system_name as type_system_name
+FROM report_user ru
+INNER JOIN report_type rt ON ru.type_id = rt.id
+WHERE ru.id = $1;
+
+-- name: GetReportUserByIdForUpdate :one
+SELECT
+    ru.id,
+    ru.user_id,
+    ru.day,
+    ru.month,
+    ru.year,
+    ru.hours,
+    ru.type_id,
+    ru.start_minute,
+    ru.end_minute,
+    ru.night_hours,
+    rt.name as type_name,
+    rt.system_name as type_system_name
+FROM report_user ru
+INNER JOIN report_type rt ON ru.type_id = rt.id
+WHERE ru.id = $1
+FOR UPDATE;
+
+-- name: GetReportUserForDay :many
+SELECT
+    ru.id,
+    ru.user_id,
+    ru.day,
+    ru.month,
+    ru.year,
+    ru.hours,
+    ru.type_id,
+    ru.start_minute,
+    ru.end_minute,
+    ru.night_hours,
+    rt.name as type_name,
+    rt.system_name as type_system_name
+FROM report_user ru
+INNER JOIN report_type rt ON ru.type_id = rt.id
+WHERE ru.user_id = $1 AND ru.day = $2 AND ru.month = $3 AND ru.year = $4
+ORDER BY rt.name ASC;
+
+-- name: GetReportUserDayTotalHours :one
+SELECT CAST(COALESCE(SUM(CAST(hours AS double precision)), 0) AS real) AS total_hours
+FROM report_user
+WHERE user_id = $1 AND day = $2 AND month = $3 AND year = $4;
+
+-- name: GetReportUserStatsByType :many
+SELECT
+    rt.id as type_id,
+    rt.name as type_name,
+    rt.system_name as type_system_name,
+    COUNT(DISTINCT ru.day) as days_count,
+    CAST(COALESCE(SUM(CAST(ru.hours AS double precision)), 0) AS real) AS total_hours
+FROM report_user ru
+INNER JOIN report_type rt ON ru.type_id = rt.id
+WHERE ru.user_id = $1 AND ru.month = $2 AND ru.year = $3
+GROUP BY rt.id, rt.name, rt.system_name
+ORDER BY rt.name ASC;
+
+-- name: GetReportUserTotalHours :one
+SELECT CAST(COALESCE(SUM(CAST(hours AS double precision)), 0) AS real) AS total_hours
+FROM report_user
+WHERE user_id = $1 AND month = $2 AND year = $3;
+
+-- name: GetReportUserNightHours :one
+SELECT CAST(COALESCE(SUM(CAST(night_hours AS double precision)), 0) AS real) AS night_hours
+FROM report_user
+WHERE user_id = $1 AND month = $2 AND year = $3;
+
+-- name: GetReportUserCountByType :one
+SELECT COUNT(DISTINCT day) as days_count
+FROM report_user
+WHERE user_id = $1 AND month = $2 AND year = $3 AND type_id = $4;
+
+-- name: GetReportUserCountWork :one
+SELECT COUNT(DISTINCT day) as days_count
+FROM report_user ru
+INNER JOIN report_type rt ON ru.type_id = rt.id
+WHERE ru.user_id = $1 AND ru.month = $2 AND ru.year = $3 AND (rt.system_name = 'work' OR rt.system_name = 'weekend');
+
+-- name: CreateReportUser :exec
+INSERT INTO report_user (id, user_id, day, month, year, hours, type_id, start_minute, end_minute, night_hours)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: UpdateReportUser :exec
+UPDATE report_user
+SET hours = $1, type_id = $2, start_minute = $3, end_minute = $4, night_hours = $5
+WHERE id = $6;
+
+-- name: CheckReportUserExists :one
+SELECT COUNT(*) as exists_count
+FROM report_user
+WHERE user_id = $1 AND day = $2 AND month = $3 AND year = $4;
+
+-- name: CheckReportUserTypeExists :one
+SELECT COUNT(*) as exists_count
+FROM report_user
+WHERE user_id = $1 AND day = $2 AND month = $3 AND year = $4 AND type_id = $5;
+
+-- name: DeleteReportUserById :exec
+DELETE FROM report_user
+WHERE id = $1;
+
+-- name: DeleteReportUser :exec
+DELETE FROM report_user
+WHERE user_id = $1 AND day = $2 AND month = $3 AND year = $4;
+
+-- name: LockReportUserDay :exec
+-- Блокирует день сотрудника до конца транзакции. FOR UPDATE не закрывает вставку
+-- новых строк, поэтому день сериализуется рекомендательной блокировкой.
+SELECT pg_advisory_xact_lock(hashtextextended('report_user/' || $1::text || '/' || $2::int || '/' || $3::int || '/' || $4::int, 0));
@@ -0,0 +1,69 @@
+-- ============================================
+-- REPORT_VACATION queries
+-- ============================================
+
+-- name: GetVacations :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE user_id = $1
+ORDER BY create_at DESC;
+
+-- name: GetVacationsByYear :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE user_id = $1 AND year = $2
+ORDER BY create_at DESC;
+
+-- name: GetAdminVacationsByYear :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE year = $1
+ORDER BY create_at DESC;
+
+-- name: GetVacationById :one
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE id = $1;
+
+-- name: GetVacationApproved :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE user_id = $1 AND status = 'approved';
+
+-- name: GetVacationsApprovedInRange :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE status = 'approved' AND start_date <= $1 AND end_date >= $2
+ORDER BY user_id ASC, start_date ASC;
+
+-- name: GetVacationsApprovedStartingOn :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE status = 'approved' AND start_date = $1
+ORDER BY user_id ASC;
+
+-- name: GetYearsVacation :many
+SELECT DISTINCT year
+FROM report_vacation
+WHERE user_id = $1
+ORDER BY year DESC;
+
+-- name: CountVacationsByStatus :one
+SELECT COUNT(*) as vacations_count
+FROM report_vacation
+WHERE status = $1;
+
+-- name: CreateVacation :exec
+INSERT INTO report_vacation (id, user_id, start_date, end_date, year, description, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: UpdateVacationStatus :exec
+UPDATE report_vacation
+SET status = $1
+WHERE id = $2;
+
+-- name: DeleteVacation :exec
+DELETE FROM report_vacation
+WHERE id = $1;
+
+
@@ -0,0 +1,72 @@
+-- ============================================
+-- REPORT_WEBHOOK queries
+-- ============================================
+
+-- name: GetWebhooks :many
+SELECT id, url, secret, events, is_active, create_at
+FROM report_webhook
+ORDER BY create_at ASC;
+
+-- name: GetActiveWebhooks :many
+SELECT id, url, secret, events, is_active, create_at
+FROM report_webhook
+WHERE is_active;
+
+-- name: GetWebhookById :one
+SELECT id, url, secret, events, is_active, create_at
+FROM report_webhook
+WHERE id = $1;
+
+-- name: CreateWebhook :exec
+INSERT INTO report_webhook (id, url, secret, events, is_active)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: UpdateWebhook :exec
+UPDATE report_webhook
+SET url = $1, events = $2, is_active = $3
+WHERE id = $4;
+
+-- name: DeleteWebhook :exec
+DELETE FROM report_webhook
+WHERE id = $1;
+
+-- ============================================
+-- REPORT_WEBHOOK_DELIVERY queries
+-- ============================================
+
+-- name: GetWebhookDeliveries :many
+SELECT id, webhook_id, event, payload, status, attempts, response_code, error, next_attempt_at, create_at, update_at
+FROM report_webhook_delivery
+WHERE webhook_id = $1
+ORDER BY create_at DESC
+LIMIT 100;
+
+-- name: GetWebhookDeliveryById :one
+SELECT id, webhook_id, event, payload, status, attempts, response_code, error, next_attempt_at, create_at, update_at
+FROM report_webhook_delivery
+WHERE id = $1;
+
+-- name: CreateWebhookDelivery :exec
+INSERT INTO report_webhook_delivery (id, webhook_id, event, payload, next_attempt_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: UpdateWebhookDelivery :exec
+UPDATE report_webhook_delivery
+SET status = $1, attempts = $2, response_code = $3, error = $4, next_attempt_at = $5, update_at = CURRENT_TIMESTAMP(0)
+WHERE id = $6;
+
+-- name: DeleteWebhookDeliveries :exec
+DELETE FROM report_webhook_delivery
+WHERE webhook_id = $1;
+
+-- name: GetDueWebhookDeliveries :many
+SELECT id, webhook_id, event, payload, status, attempts, response_code, error, next_attempt_at, create_at, update_at
+FROM report_webhook_delivery
+WHERE status = 'pending' AND next_attempt_at <= $1
+ORDER BY next_attempt_at ASC
+LIMIT 100;
+
+-- name: ClaimWebhookDelivery :execrows
+UPDATE report_webhook_delivery
+SET next_attempt_at = $1
+WHERE id = $2 AND status = 'pending' AND next_attempt_at <= $3;
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"TimeTrack/internal/adapter/dialect"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var placeholder = regexp.MustCompile(`\$(\d+)`)
+
+// TestQueriesMatchMySQL проверяет, что для каждого запроса MySQL есть запрос
+// PostgreSQL с тем же именем, типом результата и числом параметров
+func TestQueriesMatchMySQL(t *testing.T) {
+	mysql := dialect.MustLoad(os.DirFS("../mysql/sqlc"))
+
+	for name, query := range mysql {
+		pg, ok := queries[name]
+		if !ok {
+			t.Errorf("%s: no PostgreSQL query", name)
+			continue
+		}
+
+		if header(pg) != header(query) {
+			t.Errorf("%s: header %q, want %q", name, header(pg), header(query))
+		}
+
+		params := 0
+		for _, m := range placeholder.FindAllStringSubmatch(pg, -1) {
+			n, _ := strconv.Atoi(m[1])
+			params = max(params, n)
+		}
+		if want := dialect.Params(query); params != want {
+			t.Errorf("%s: %d parameters, want %d", name, params, want)
+		}
+	}
+
+	for name := range queries {
+		if _, ok := mysql[name]; !ok {
+			t.Errorf("%s: no MySQL query", name)
+		}
+	}
+}
+
+func header(query string) string {
+	line, _, _ := strings.Cut(query, "\n")
+	return line
+}
@@ -0,0 +1,30 @@
+package sqlite_test
+
+import (
+	"TimeTrack/internal/adapter/contract"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/adapter/sqlite"
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+// TestContract выполняется на новом файле базы во временном каталоге:
+// схема создается при открытии, сервер не нужен
+func TestContract(t *testing.T) {
+	db, err := sqlite.Open(context.Background(), filepath.Join(t.TempDir(), "contract.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	contract.Run(t, func(t *testing.T) contract.Store {
+		contract.Reset(t, db)
+		q := sqlite.New(db)
+		return struct {
+			repo.Querier
+			repo.Streamer
+			repo.TxBeginner
+		}{q, q, sqlite.NewTxBeginner(db)}
+	})
+}
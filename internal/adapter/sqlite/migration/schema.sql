@@ -0,0 +1,305 @@
+--
+-- Схема для SQLite, повторяет internal/adapter/mysql/migration/schema.sql.
+-- Применяется автоматически при открытии базы (sqlite.Open), поэтому все
+-- объекты создаются через IF NOT EXISTS.
+-- Текстовые столбцы используют сортировку ci без учета регистра, как
+-- utf8mb4_0900_ai_ci в MySQL; ее регистрирует пакет internal/adapter/sqlite.
+-- Время хранится текстом в местном часовом поясе, как DATETIME/TIMESTAMP
+-- при loc=Local, DATE - текстом ГГГГ-ММ-ДД.
+-- ENUM - varchar с CHECK и триггерами, которые, как MySQL, приводят значение
+-- к записи из списка ('Approved' сохраняется как 'approved').
+--
+--
+-- Структура таблицы report_calendar
+--
+CREATE TABLE IF NOT EXISTS report_calendar (
+  id varchar(36) COLLATE ci NOT NULL,
+  day int NOT NULL,
+  month int NOT NULL,
+  year int NOT NULL,
+  description varchar(100) COLLATE ci DEFAULT NULL,
+  is_paid_vacation tinyint(1) NOT NULL DEFAULT 1,
+  type_id varchar(36) COLLATE ci NOT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_standard
+--
+CREATE TABLE IF NOT EXISTS report_standard (
+  id varchar(36) COLLATE ci NOT NULL,
+  month int NOT NULL,
+  year int NOT NULL,
+  hours int NOT NULL,
+  gender_id int NOT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_type
+--
+CREATE TABLE IF NOT EXISTS report_type (
+  id varchar(36) COLLATE ci NOT NULL,
+  name varchar(50) COLLATE ci NOT NULL,
+  system_name varchar(50) COLLATE ci NOT NULL,
+  code varchar(4) COLLATE ci NOT NULL DEFAULT ''
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_user
+--
+CREATE TABLE IF NOT EXISTS report_user (
+  id varchar(36) COLLATE ci NOT NULL,
+  user_id varchar(36) COLLATE ci NOT NULL,
+  day int NOT NULL,
+  month int NOT NULL,
+  year int NOT NULL,
+  hours float NOT NULL DEFAULT 0.0,
+  type_id varchar(36) COLLATE ci NOT NULL,
+  start_minute int DEFAULT NULL,
+  end_minute int DEFAULT NULL,
+  night_hours float NOT NULL DEFAULT 0.0
+);
+--
+-- Уникальный индекс report_user: одна отметка каждого вида за день
+--
+CREATE UNIQUE INDEX IF NOT EXISTS report_user_day_type ON report_user (user_id, day, month, year, type_id);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_vacation
+--
+CREATE TABLE IF NOT EXISTS report_vacation (
+  id varchar(36) COLLATE ci NOT NULL,
+  user_id varchar(36) COLLATE ci NOT NULL,
+  start_date date NOT NULL,
+  end_date date NOT NULL,
+  year int NOT NULL,
+  description varchar(100) COLLATE ci,
+  status varchar(13) COLLATE ci NOT NULL DEFAULT 'consideration' CHECK (status IN ('consideration', 'rejected', 'approved', '')),
+  create_at timestamp NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_setting
+--
+CREATE TABLE IF NOT EXISTS report_setting (
+  id int NOT NULL,
+  vacation_duration int NOT NULL DEFAULT 30,
+  night_start_minute int NOT NULL DEFAULT 1320,
+  night_end_minute int NOT NULL DEFAULT 360,
+  payroll_layout text COLLATE ci DEFAULT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_schedule
+--
+CREATE TABLE IF NOT EXISTS report_schedule (
+  id varchar(36) COLLATE ci NOT NULL,
+  name varchar(100) COLLATE ci NOT NULL,
+  kind varchar(6) COLLATE ci NOT NULL DEFAULT 'weekly' CHECK (kind IN ('weekly', 'shift')),
+  cycle_length int NOT NULL DEFAULT 7,
+  cycle_start date DEFAULT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_schedule_day
+--
+CREATE TABLE IF NOT EXISTS report_schedule_day (
+  id varchar(36) COLLATE ci NOT NULL,
+  schedule_id varchar(36) COLLATE ci NOT NULL,
+  day_index int NOT NULL,
+  hours float NOT NULL DEFAULT 0.0
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_user_schedule
+--
+CREATE TABLE IF NOT EXISTS report_user_schedule (
+  id varchar(36) COLLATE ci NOT NULL,
+  user_id varchar(36) COLLATE ci NOT NULL,
+  schedule_id varchar(36) COLLATE ci NOT NULL,
+  effective_from date NOT NULL,
+  effective_to date DEFAULT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_shift
+--
+CREATE TABLE IF NOT EXISTS report_shift (
+  id varchar(36) COLLATE ci NOT NULL,
+  user_id varchar(36) COLLATE ci NOT NULL,
+  day int NOT NULL,
+  month int NOT NULL,
+  year int NOT NULL,
+  start_minute int NOT NULL,
+  end_minute int NOT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_department
+--
+CREATE TABLE IF NOT EXISTS report_department (
+  id varchar(36) COLLATE ci NOT NULL,
+  name varchar(100) COLLATE ci NOT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_user_department
+--
+CREATE TABLE IF NOT EXISTS report_user_department (
+  user_id varchar(36) COLLATE ci NOT NULL,
+  department_id varchar(36) COLLATE ci NOT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_project
+--
+CREATE TABLE IF NOT EXISTS report_project (
+  id varchar(36) COLLATE ci NOT NULL,
+  code varchar(50) COLLATE ci NOT NULL,
+  name varchar(100) COLLATE ci NOT NULL,
+  client varchar(100) COLLATE ci DEFAULT NULL,
+  is_active tinyint(1) NOT NULL DEFAULT 1
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_task
+--
+CREATE TABLE IF NOT EXISTS report_task (
+  id varchar(36) COLLATE ci NOT NULL,
+  project_id varchar(36) COLLATE ci NOT NULL,
+  name varchar(100) COLLATE ci NOT NULL,
+  is_active tinyint(1) NOT NULL DEFAULT 1
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_allocation
+--
+CREATE TABLE IF NOT EXISTS report_allocation (
+  id varchar(36) COLLATE ci NOT NULL,
+  report_id varchar(36) COLLATE ci NOT NULL,
+  project_id varchar(36) COLLATE ci NOT NULL,
+  task_id varchar(36) COLLATE ci DEFAULT NULL,
+  hours float NOT NULL DEFAULT 0.0
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_document_template
+--
+CREATE TABLE IF NOT EXISTS report_document_template (
+  kind varchar(50) COLLATE ci NOT NULL,
+  title varchar(200) COLLATE ci NOT NULL,
+  body text COLLATE ci NOT NULL,
+  update_at timestamp NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_webhook
+--
+CREATE TABLE IF NOT EXISTS report_webhook (
+  id varchar(36) COLLATE ci NOT NULL,
+  url varchar(500) COLLATE ci NOT NULL,
+  secret varchar(100) COLLATE ci NOT NULL,
+  events varchar(500) COLLATE ci NOT NULL DEFAULT '*',
+  is_active tinyint(1) NOT NULL DEFAULT 1,
+  create_at timestamp NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_webhook_delivery
+--
+CREATE TABLE IF NOT EXISTS report_webhook_delivery (
+  id varchar(36) COLLATE ci NOT NULL,
+  webhook_id varchar(36) COLLATE ci NOT NULL,
+  event varchar(50) COLLATE ci NOT NULL,
+  payload text COLLATE ci NOT NULL,
+  status varchar(20) COLLATE ci NOT NULL DEFAULT 'pending',
+  attempts int NOT NULL DEFAULT 0,
+  response_code int DEFAULT NULL,
+  error varchar(500) COLLATE ci DEFAULT NULL,
+  next_attempt_at timestamp DEFAULT NULL,
+  create_at timestamp NOT NULL DEFAULT (datetime('now', 'localtime')),
+  update_at timestamp NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_user_contact
+--
+CREATE TABLE IF NOT EXISTS report_user_contact (
+  user_id varchar(36) COLLATE ci NOT NULL,
+  email varchar(255) COLLATE ci NOT NULL,
+  name varchar(100) COLLATE ci NOT NULL DEFAULT '',
+  manager_id varchar(36) COLLATE ci DEFAULT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_chat_link
+--
+CREATE TABLE IF NOT EXISTS report_chat_link (
+  chat_id bigint NOT NULL,
+  user_id varchar(36) COLLATE ci NOT NULL,
+  create_at timestamp NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_chat_link_code
+--
+CREATE TABLE IF NOT EXISTS report_chat_link_code (
+  code varchar(16) COLLATE ci NOT NULL,
+  user_id varchar(36) COLLATE ci NOT NULL,
+  expire_at datetime NOT NULL
+);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_directory_user
+--
+CREATE TABLE IF NOT EXISTS report_directory_user (
+  id varchar(36) COLLATE ci NOT NULL,
+  dn varchar(500) COLLATE ci NOT NULL,
+  login varchar(100) COLLATE ci NOT NULL DEFAULT '',
+  name varchar(200) COLLATE ci NOT NULL DEFAULT '',
+  email varchar(255) COLLATE ci NOT NULL DEFAULT '',
+  is_active tinyint(1) NOT NULL DEFAULT 1,
+  sync_at timestamp NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+-- --------------------------------------------------------
+--
+-- Значения ENUM: report_vacation.status и report_schedule.kind
+--
+CREATE TRIGGER IF NOT EXISTS report_vacation_status_insert AFTER INSERT ON report_vacation
+WHEN NEW.status <> lower(NEW.status) COLLATE BINARY
+BEGIN
+  UPDATE report_vacation SET status = lower(NEW.status) WHERE rowid = NEW.rowid;
+END;
+CREATE TRIGGER IF NOT EXISTS report_vacation_status_update AFTER UPDATE OF status ON report_vacation
+WHEN NEW.status <> lower(NEW.status) COLLATE BINARY
+BEGIN
+  UPDATE report_vacation SET status = lower(NEW.status) WHERE rowid = NEW.rowid;
+END;
+CREATE TRIGGER IF NOT EXISTS report_schedule_kind_insert AFTER INSERT ON report_schedule
+WHEN NEW.kind <> lower(NEW.kind) COLLATE BINARY
+BEGIN
+  UPDATE report_schedule SET kind = lower(NEW.kind) WHERE rowid = NEW.rowid;
+END;
+--
+-- Структура таблицы report_month_close
+--
+CREATE TABLE IF NOT EXISTS report_month_close (
+  month int NOT NULL,
+  year int NOT NULL,
+  close_at timestamp NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS report_month_close_month ON report_month_close (month, year);
+-- --------------------------------------------------------
+--
+-- Структура таблицы report_schema_version
+--
+CREATE TABLE IF NOT EXISTS report_schema_version (
+  version int NOT NULL
+);
+-- --------------------------------------------------------
+INSERT INTO report_setting (id)
+SELECT 1 WHERE NOT EXISTS (SELECT 1 FROM report_setting);
+INSERT INTO report_schema_version (version)
+SELECT 4 WHERE NOT EXISTS (SELECT 1 FROM report_schema_version);
+-- версия 2: уникальный индекс report_user_day_type, 3: таблица report_month_close,
+-- 4: столбец report_webhook_delivery.next_attempt_at (добавляет sqlite.migrate)
+UPDATE report_schema_version SET version = 4 WHERE version < 4;
@@ -0,0 +1,38 @@
+-- ============================================
+-- REPORT_DIRECTORY_USER queries
+-- ============================================
+
+-- name: GetDirectoryUsers :many
+SELECT id, dn, login, name, email, is_active, sync_at
+FROM report_directory_user
+ORDER BY name ASC;
+
+-- name: GetDirectoryUserById :one
+SELECT id, dn, login, name, email, is_active, sync_at
+FROM report_directory_user
+WHERE id = ?;
+
+-- name: GetDirectoryUserByEmail :one
+SELECT id, dn, login, name, email, is_active, sync_at
+FROM report_directory_user
+WHERE email = ?
+LIMIT 1;
+
+-- name: CreateDirectoryUser :exec
+INSERT INTO report_directory_user (id, dn, login, name, email, is_active)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: UpdateDirectoryUser :exec
+UPDATE report_directory_user
+SET dn = ?, login = ?, name = ?, email = ?, is_active = ?, sync_at = datetime('now', 'localtime')
+WHERE id = ?;
+
+-- name: DeactivateDirectoryUser :exec
+UPDATE report_directory_user
+SET is_active = 0, sync_at = datetime('now', 'localtime')
+WHERE id = ?;
+
+-- name: CheckDirectoryUserInactive :one
+SELECT COUNT(*) as inactive_count
+FROM report_directory_user
+WHERE id = ? AND is_active = 0;
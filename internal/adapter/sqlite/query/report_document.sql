@@ -0,0 +1,21 @@
+-- ============================================
+-- REPORT_DOCUMENT_TEMPLATE queries
+-- ============================================
+
+-- name: GetDocumentTemplates :many
+SELECT kind, title, body, update_at
+FROM report_document_template
+ORDER BY kind ASC;
+
+-- name: GetDocumentTemplate :one
+SELECT kind, title, body, update_at
+FROM report_document_template
+WHERE kind = ?;
+
+-- name: CreateDocumentTemplate :exec
+INSERT INTO report_document_template (kind, title, body)
+VALUES (?, ?, ?);
+
+-- name: DeleteDocumentTemplate :exec
+DELETE FROM report_document_template
+WHERE kind = ?;
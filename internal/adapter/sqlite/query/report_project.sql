@@ -0,0 +1,118 @@
+-- ============================================
+-- REPORT_PROJECT queries
+-- ============================================
+
+-- name: GetProjects :many
+SELECT id, code, name, COALESCE(client, '') as client, is_active
+FROM report_project
+ORDER BY code ASC;
+
+-- name: GetProjectById :one
+SELECT id, code, name, COALESCE(client, '') as client, is_active
+FROM report_project
+WHERE id = ?;
+
+-- name: CreateProject :exec
+INSERT INTO report_project (id, code, name, client, is_active)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: UpdateProject :exec
+UPDATE report_project
+SET name = ?, client = ?, is_active = ?
+WHERE id = ?;
+
+-- name: CheckProjectCodeExists :one
+SELECT COUNT(*) as exists_count
+FROM report_project
+WHERE code = ?;
+
+-- name: GetTasksByProject :many
+SELECT id, project_id, name, is_active
+FROM report_task
+WHERE project_id = ?
+ORDER BY name ASC;
+
+-- name: GetTaskById :one
+SELECT id, project_id, name, is_active
+FROM report_task
+WHERE id = ?;
+
+-- name: CreateTask :exec
+INSERT INTO report_task (id, project_id, name, is_active)
+VALUES (?, ?, ?, ?);
+
+-- name: UpdateTask :exec
+UPDATE report_task
+SET name = ?, is_active = ?
+WHERE id = ?;
+
+-- name: GetAllocationsByReport :many
+SELECT
+    ra.id,
+    ra.report_id,
+    ra.project_id,
+    ra.task_id,
+    ra.hours,
+    rp.code as project_code,
+    rp.name as project_name,
+    COALESCE(rtk.name, '') as task_name
+FROM report_allocation ra
+INNER JOIN report_project rp ON ra.project_id = rp.id
+LEFT JOIN report_task rtk ON ra.task_id = rtk.id
+WHERE ra.report_id = ?
+ORDER BY rp.code ASC;
+
+-- name: CreateAllocation :exec
+INSERT INTO report_allocation (id, report_id, project_id, task_id, hours)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: DeleteAllocationsByReport :exec
+DELETE FROM report_allocation
+WHERE report_id = ?;
+
+-- name: DeleteAllocationsByDay :exec
+DELETE FROM report_allocation
+WHERE report_id IN (
+    SELECT id FROM report_user
+    WHERE user_id = ? AND day = ? AND month = ? AND year = ?
+);
+
+-- name: GetProjectTotalsByMonth :many
+SELECT
+    rp.id as project_id,
+    rp.code as project_code,
+    rp.name as project_name,
+    CAST(COALESCE(SUM(ra.hours), 0.0) AS FLOAT) AS total_hours
+FROM report_allocation ra
+INNER JOIN report_user ru ON ra.report_id = ru.id
+INNER JOIN report_project rp ON ra.project_id = rp.id
+WHERE ru.month = ? AND ru.year = ?
+GROUP BY rp.id, rp.code, rp.name
+ORDER BY rp.code ASC;
+
+-- name: GetProjectTotalsByUser :many
+SELECT
+    rp.id as project_id,
+    rp.code as project_code,
+    rp.name as project_name,
+    CAST(COALESCE(SUM(ra.hours), 0.0) AS FLOAT) AS total_hours
+FROM report_allocation ra
+INNER JOIN report_user ru ON ra.report_id = ru.id
+INNER JOIN report_project rp ON ra.project_id = rp.id
+WHERE ru.user_id = ? AND ru.month = ? AND ru.year = ?
+GROUP BY rp.id, rp.code, rp.name
+ORDER BY rp.code ASC;
+
+-- name: GetProjectTotalsByDepartment :many
+SELECT
+    rp.id as project_id,
+    rp.code as project_code,
+    rp.name as project_name,
+    CAST(COALESCE(SUM(ra.hours), 0.0) AS FLOAT) AS total_hours
+FROM report_allocation ra
+INNER JOIN report_user ru ON ra.report_id = ru.id
+INNER JOIN report_project rp ON ra.project_id = rp.id
+INNER JOIN report_user_department rud ON ru.user_id = rud.user_id
+WHERE rud.department_id = ? AND ru.month = ? AND ru.year = ?
+GROUP BY rp.id, rp.code, rp.name
+ORDER BY rp.code ASC;
@@ -0,0 +1,9 @@
+-- ============================================
+-- REPORT_SCHEMA_VERSION queries
+-- ============================================
+
+-- name: GetSchemaVersion :one
+SELECT version
+FROM report_schema_version
+ORDER BY version DESC
+LIMIT 1;
@@ -0,0 +1,38 @@
+-- ============================================
+-- REPORT_SHIFT queries
+-- ============================================
+
+-- name: GetShiftsForMonth :many
+SELECT id, user_id, day, month, year, start_minute, end_minute
+FROM report_shift
+WHERE user_id = ? AND month = ? AND year = ?
+ORDER BY day ASC;
+
+-- name: GetShiftsForMonthAll :many
+SELECT id, user_id, day, month, year, start_minute, end_minute
+FROM report_shift
+WHERE month = ? AND year = ?
+ORDER BY day ASC, user_id ASC;
+
+-- name: GetShift :one
+SELECT id, user_id, day, month, year, start_minute, end_minute
+FROM report_shift
+WHERE user_id = ? AND day = ? AND month = ? AND year = ?;
+
+-- name: GetShiftById :one
+SELECT id, user_id, day, month, year, start_minute, end_minute
+FROM report_shift
+WHERE id = ?;
+
+-- name: CreateShift :exec
+INSERT INTO report_shift (id, user_id, day, month, year, start_minute, end_minute)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: UpdateShift :exec
+UPDATE report_shift
+SET start_minute = ?, end_minute = ?
+WHERE id = ?;
+
+-- name: DeleteShift :exec
+DELETE FROM report_shift
+WHERE id = ?;
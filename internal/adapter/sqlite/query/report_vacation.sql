@@ -0,0 +1,69 @@
+-- ============================================
+-- REPORT_VACATION queries
+-- ============================================
+
+-- name: GetVacations :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE user_id = ?
+ORDER BY create_at DESC;
+
+-- name: GetVacationsByYear :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE user_id = ? AND year = ?
+ORDER BY create_at DESC;
+
+-- name: GetAdminVacationsByYear :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE year = ?
+ORDER BY create_at DESC;
+
+-- name: GetVacationById :one
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE id = ?;
+
+-- name: GetVacationApproved :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE user_id = ? AND status = 'approved';
+
+-- name: GetVacationsApprovedInRange :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE status = 'approved' AND start_date <= date(?) AND end_date >= date(?)
+ORDER BY user_id ASC, start_date ASC;
+
+-- name: GetVacationsApprovedStartingOn :many
+SELECT id, user_id, start_date, end_date, year, COALESCE(description, '') as description, status, create_at
+FROM report_vacation
+WHERE status = 'approved' AND start_date = date(?)
+ORDER BY user_id ASC;
+
+-- name: GetYearsVacation :many
+SELECT DISTINCT year
+FROM report_vacation
+WHERE user_id = ?
+ORDER BY year DESC;
+
+-- name: CountVacationsByStatus :one
+SELECT COUNT(*) as vacations_count
+FROM report_vacation
+WHERE status = ?;
+
+-- name: CreateVacation :exec
+INSERT INTO report_vacation (id, user_id, start_date, end_date, year,  description, status)
+VALUES (?, ?, date(?), date(?), ?, ?, ?);
+
+-- name: UpdateVacationStatus :exec
+UPDATE report_vacation
+SET status = ?
+WHERE id = ?;
+
+-- name: DeleteVacation :exec
+DELETE FROM report_vacation
+WHERE id = ?;
+
+
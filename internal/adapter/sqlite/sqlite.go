@@ -0,0 +1,150 @@
+// Package sqlite - встроенное хранилище на SQLite с тем же repo.Querier, что и у MySQL:
+// для небольших команд и локальной разработки без сервера БД.
+//
+// Запросы - query/*.sql, выполняются через пакет repo (см. internal/adapter/dialect).
+// Схема migration/schema.sql применяется при каждом открытии базы и создает
+// недостающие таблицы. Драйвер modernc.org/sqlite написан на Go и не требует cgo.
+package sqlite
+
+import (
+	"TimeTrack/internal/adapter/dialect"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"net/url"
+	"strings"
+
+	sqlite "modernc.org/sqlite"
+)
+
+// Driver - имя драйвера database/sql для sql.Open
+const Driver = "sqlite"
+
+// DefaultPath - файл базы, если путь не задан
+const DefaultPath = "time_track.db"
+
+//go:embed query/*.sql
+var queryFiles embed.FS
+
+//go:embed migration/schema.sql
+var schema string
+
+// queries - текст запросов SQLite по имени запроса sqlc
+var queries = dialect.MustLoad(queryFiles)
+
+// defaultParams - параметры соединения, если они не заданы в пути:
+//   - время пишется и читается в местном часовом поясе без смещения, как у MySQL с loc=Local
+//   - транзакции сразу берут блокировку записи, а занятая база ожидается до 5 секунд,
+//     иначе параллельные запросы (и процессы Prefork) получали бы SQLITE_BUSY
+//   - журнал WAL не блокирует чтение во время записи
+var defaultParams = []struct{ key, value string }{
+	{"_time_format", "datetime"},
+	{"_timezone", "Local"},
+	{"_txlock", "immediate"},
+	{"_busy_timeout", "5000"},
+	{"_journal_mode", "WAL"},
+}
+
+func init() {
+	// ci - сравнение строк без учета регистра, как utf8mb4_0900_ai_ci в MySQL
+	sqlite.MustRegisterCollationUtf8("ci", func(left, right string) int {
+		return strings.Compare(strings.ToLower(left), strings.ToLower(right))
+	})
+}
+
+// Open открывает (или создает) файл базы и применяет схему
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	if path == "" {
+		path = DefaultPath
+	}
+
+	db, err := sql.Open(Driver, dsn(path))
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite: %w", err)
+	}
+
+	if err := migrate(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// New возвращает запросы repo (Querier и Streamer) поверх базы SQLite
+func New(db *sql.DB) *repo.Queries {
+	return queries.New(db)
+}
+
+// NewTxBeginner - транзакции с запросами SQLite
+func NewTxBeginner(db *sql.DB) repo.TxBeginner {
+	return queries.NewTxBeginner(db)
+}
+
+// column - столбец, добавленный в таблицу после ее создания: CREATE TABLE IF NOT EXISTS
+// не меняет существующую таблицу, и в базу прежней версии его добавляет migrate.
+// fill заполняет столбец в уже существующих строках.
+type column struct {
+	table, name, definition, fill string
+}
+
+var columns = []column{
+	// версия 4: ожидающие доставки прежней версии повторяются сразу после обновления
+	{"report_webhook_delivery", "next_attempt_at", "timestamp DEFAULT NULL",
+		"UPDATE report_webhook_delivery SET next_attempt_at = datetime('now', 'localtime') WHERE status = 'pending'"},
+}
+
+// migrate создает недостающие таблицы и столбцы одной транзакцией: при первом запуске
+// с Prefork схему применяет один процесс, остальные ждут его и ничего не меняют
+func migrate(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("migrate sqlite: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, schema); err != nil {
+		return fmt.Errorf("migrate sqlite: %w", err)
+	}
+
+	for _, c := range columns {
+		var exists int
+		if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", c.table, c.name).Scan(&exists); err != nil {
+			return fmt.Errorf("migrate sqlite: %s.%s: %w", c.table, c.name, err)
+		}
+		if exists > 0 {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", c.table, c.name, c.definition)); err != nil {
+			return fmt.Errorf("migrate sqlite: %s.%s: %w", c.table, c.name, err)
+		}
+		if _, err := tx.ExecContext(ctx, c.fill); err != nil {
+			return fmt.Errorf("migrate sqlite: %s.%s: %w", c.table, c.name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("migrate sqlite: %w", err)
+	}
+
+	return nil
+}
+
+// dsn дополняет путь к файлу параметрами по умолчанию
+func dsn(path string) string {
+	name, rawQuery, _ := strings.Cut(path, "?")
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return path
+	}
+
+	for _, p := range defaultParams {
+		if !params.Has(p.key) {
+			params.Set(p.key, p.value)
+		}
+	}
+
+	return name + "?" + params.Encode()
+}
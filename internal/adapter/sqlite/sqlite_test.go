@@ -0,0 +1,189 @@
+package sqlite
+
+import (
+	"TimeTrack/internal/adapter/dialect"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func open(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := Open(context.Background(), filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// TestQueriesMatchMySQL проверяет, что для каждого запроса MySQL есть запрос
+// SQLite с тем же именем, типом результата и числом параметров, и что запрос
+// компилируется на схеме
+func TestQueriesMatchMySQL(t *testing.T) {
+	mysql := dialect.MustLoad(os.DirFS("../mysql/sqlc"))
+	db := open(t)
+
+	for name, query := range mysql {
+		lite, ok := queries[name]
+		if !ok {
+			t.Errorf("%s: no SQLite query", name)
+			continue
+		}
+
+		if header(lite) != header(query) {
+			t.Errorf("%s: header %q, want %q", name, header(lite), header(query))
+		}
+		if got, want := strings.Count(lite, "?"), dialect.Params(query); got != want {
+			t.Errorf("%s: %d parameters, want %d", name, got, want)
+		}
+
+		stmt, err := db.Prepare(lite)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		stmt.Close()
+	}
+
+	for name := range queries {
+		if _, ok := mysql[name]; !ok {
+			t.Errorf("%s: no MySQL query", name)
+		}
+	}
+}
+
+// TestOpenTwice - повторное открытие не меняет схему и данные
+func TestOpenTwice(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "twice.db")
+	ctx := context.Background()
+
+	db, err := Open(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := New(db).CreateType(ctx, repo.CreateTypeParams{ID: "t1", Name: "Работа", SystemName: "work", Code: "Я"}); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	db, err = Open(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	types, err := New(db).GetTypeAll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(types) != 1 {
+		t.Fatalf("types = %d, want 1", len(types))
+	}
+
+	var settings int
+	if err := db.QueryRow("SELECT COUNT(*) FROM report_setting").Scan(&settings); err != nil {
+		t.Fatal(err)
+	}
+	if settings != 1 {
+		t.Fatalf("report_setting rows = %d, want 1", settings)
+	}
+}
+
+// TestUpgradeColumns - база прежней версии получает новые столбцы, ожидающие
+// доставки вебхуков - время следующей попытки
+func TestUpgradeColumns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+	ctx := context.Background()
+
+	db, err := sql.Open(Driver, dsn(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`CREATE TABLE report_webhook_delivery (
+  id varchar(36) NOT NULL, webhook_id varchar(36) NOT NULL, event varchar(50) NOT NULL,
+  payload text NOT NULL, status varchar(20) NOT NULL DEFAULT 'pending', attempts int NOT NULL DEFAULT 0,
+  response_code int DEFAULT NULL, error varchar(500) DEFAULT NULL,
+  create_at timestamp NOT NULL DEFAULT (datetime('now', 'localtime')),
+  update_at timestamp NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+INSERT INTO report_webhook_delivery (id, webhook_id, event, payload) VALUES ('d1', 'w1', 'report.created', '{}');
+INSERT INTO report_webhook_delivery (id, webhook_id, event, payload, status) VALUES ('d2', 'w1', 'report.created', '{}', 'success');`); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	db, err = Open(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	due, err := New(db).GetDueWebhookDeliveries(ctx, sql.NullTime{Time: time.Now().Add(time.Minute), Valid: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 1 || due[0].ID != "d1" {
+		t.Fatalf("due deliveries = %+v, want the pending one", due)
+	}
+}
+
+// TestVacationStatusEnum - report_vacation.status ведет себя как ENUM MySQL:
+// значение приводится к записи из списка, чужое значение отклоняется
+func TestVacationStatusEnum(t *testing.T) {
+	db := open(t)
+	q := New(db)
+	ctx := context.Background()
+	day := time.Date(2026, time.July, 1, 0, 0, 0, 0, time.Local)
+
+	create := func(id string, status repo.ReportVacationStatus) error {
+		return q.CreateVacation(ctx, repo.CreateVacationParams{
+			ID: id, UserID: "u1", StartDate: day, EndDate: day.AddDate(0, 0, 13), Year: 2026, Status: status,
+		})
+	}
+
+	if err := create("v1", "Approved"); err != nil {
+		t.Fatal(err)
+	}
+	vacation, err := q.GetVacationById(ctx, "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vacation.Status != repo.ReportVacationStatusApproved {
+		t.Fatalf("status = %q, want approved", vacation.Status)
+	}
+
+	if err := q.UpdateVacationStatus(ctx, repo.UpdateVacationStatusParams{Status: "REJECTED", ID: "v1"}); err != nil {
+		t.Fatal(err)
+	}
+	if vacation, _ = q.GetVacationById(ctx, "v1"); vacation.Status != repo.ReportVacationStatusRejected {
+		t.Fatalf("status = %q, want rejected", vacation.Status)
+	}
+
+	if err := create("v2", "cancelled"); err == nil {
+		t.Fatal("status outside the enum was accepted")
+	}
+	if err := q.UpdateVacationStatus(ctx, repo.UpdateVacationStatusParams{Status: "cancelled", ID: "v1"}); err == nil {
+		t.Fatal("status outside the enum was accepted on update")
+	}
+}
+
+func TestDSN(t *testing.T) {
+	got := dsn("data/team.db?_busy_timeout=100")
+	if !strings.HasPrefix(got, "data/team.db?") || !strings.Contains(got, "_busy_timeout=100") || strings.Contains(got, "_busy_timeout=5000") {
+		t.Fatalf("dsn = %q", got)
+	}
+	if !strings.Contains(got, "_txlock=immediate") {
+		t.Fatalf("dsn = %q, want default _txlock", got)
+	}
+}
+
+func header(query string) string {
+	line, _, _ := strings.Cut(query, "\n")
+	return line
+}
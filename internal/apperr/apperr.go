@@ -0,0 +1,255 @@
+// Package apperr - ошибки приложения с видом (не найдено, конфликт, ошибка проверки,
+// нет доступа...), стабильным кодом для программ и сообщением для людей.
+//
+// Сервисы объявляют доменные ошибки через конструкторы этого пакета
+// (ErrShiftExists = apperr.Conflict("shift_exists", "...")) и по-прежнему могут
+// уточнять их через fmt.Errorf("%w: ...", ErrX). Обработчики возвращают ошибки
+// Fiber, а Handler превращает их в ответ: вид задает статус HTTP, непредвиденные
+// ошибки становятся 500 и пишутся в журнал.
+package apperr
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindInvalid
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindUnprocessable
+	KindUnavailable
+	KindUpstream
+)
+
+// status - статус HTTP для вида ошибки
+func (k Kind) status() int {
+	switch k {
+	case KindInvalid:
+		return http.StatusBadRequest
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindUnprocessable:
+		return http.StatusUnprocessableEntity
+	case KindUnavailable:
+		return http.StatusServiceUnavailable
+	case KindUpstream:
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
+
+// Коды общих ошибок; доменные ошибки задают свои коды
+const (
+	CodeInternal         = "internal_error"
+	CodeValidation       = "validation_failed"
+	CodeInvalidParameter = "invalid_parameter"
+	CodeInvalidBody      = "invalid_body"
+	CodeNotFound         = "not_found"
+)
+
+type Error struct {
+	Kind Kind
+	// Code - стабильный код для программ, например "shift_exists"
+	Code    string
+	Message string
+	// Fields - ошибки отдельных полей запроса
+	Fields []Field
+	// Err - причина; клиенту не показывается
+	Err error
+
+	// status - статус ошибок Fiber, у которых нет своего вида (405, 413...)
+	status int
+}
+
+// Field - ошибка одного поля: имя в запросе (userId, entries[0].hours) и что не так
+type Field struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Status - статус HTTP ответа
+func (e *Error) Status() int {
+	if e.status != 0 {
+		return e.status
+	}
+	return e.Kind.status()
+}
+
+func NotFound(code, message string) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+func Conflict(code, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+func Forbidden(code, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+func Unauthorized(code, message string) *Error {
+	return &Error{Kind: KindUnauthorized, Code: code, Message: message}
+}
+
+// Unprocessable - запрос верный, но данные не позволяют его выполнить
+func Unprocessable(code, message string) *Error {
+	return &Error{Kind: KindUnprocessable, Code: code, Message: message}
+}
+
+// Unavailable - функция не настроена или временно недоступна
+func Unavailable(code, message string) *Error {
+	return &Error{Kind: KindUnavailable, Code: code, Message: message}
+}
+
+// Upstream - ошибка внешней системы (провайдер входа, каталог LDAP)
+func Upstream(code, message string, err error) *Error {
+	return &Error{Kind: KindUpstream, Code: code, Message: message, Err: err}
+}
+
+// Invalid - запрос не прошел проверку
+func Invalid(code, message string, fields ...Field) *Error {
+	return &Error{Kind: KindInvalid, Code: code, Message: message, Fields: fields}
+}
+
+// Validation - ошибки полей запроса одним ответом
+func Validation(fields ...Field) *Error {
+	messages := make([]string, len(fields))
+	for i, f := range fields {
+		messages[i] = f.Field + " " + f.Message
+	}
+	return Invalid(CodeValidation, strings.Join(messages, "; "), fields...)
+}
+
+// InvalidField - ошибка одного поля: InvalidField("userId", "must be a valid UUID")
+func InvalidField(field, message string) *Error {
+	return Validation(Field{Field: field, Message: message})
+}
+
+// Required - обязательное поле или параметр пути не задан
+func Required(field string) *Error {
+	return InvalidField(field, "is required")
+}
+
+// InvalidParam - параметр пути или запроса не разобран или вне допустимых значений
+func InvalidParam(name string) *Error {
+	return Invalid(CodeInvalidParameter, "invalid "+name+" parameter", Field{Field: name, Message: "invalid value"})
+}
+
+// InvalidBody - тело запроса не разобрано
+func InvalidBody(err error) *Error {
+	return &Error{Kind: KindInvalid, Code: CodeInvalidBody, Message: "invalid request body", Err: err}
+}
+
+// Wrap - ошибка сервиса для ответа: доменная ошибка из цепочки err отдается
+// клиенту как есть, остальные - 500 с сообщением message, причина пишется в журнал
+func Wrap(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: KindInternal, Code: CodeInternal, Message: message, Err: err}
+}
+
+// From находит в цепочке err ошибку для ответа. Порядок: доменная *Error, ошибка Fiber,
+// внутренняя ошибка из Wrap, затем sql.ErrNoRows (не найдено). Wrap важнее ErrNoRows:
+// обработчик, обернувший ошибку, считает ее сбоем, даже если внутри нет строки
+// (например, не найден справочник при записи). Отсутствие запрошенной записи
+// обработчик возвращает без Wrap или доменной ошибкой NotFound. Остальные ошибки -
+// внутренние с общим сообщением.
+func From(err error) *Error {
+	var wrapped *Error
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		appErr, ok := e.(*Error)
+		if !ok {
+			continue
+		}
+		if appErr.Kind != KindInternal {
+			return public(err, appErr)
+		}
+		if wrapped == nil {
+			wrapped = appErr
+		}
+	}
+
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &fiberErr):
+		return fromFiber(fiberErr)
+	case wrapped != nil:
+		return wrapped
+	case errors.Is(err, sql.ErrNoRows):
+		return NotFound(CodeNotFound, "not found")
+	}
+	return &Error{Kind: KindInternal, Code: CodeInternal, Message: "internal server error", Err: err}
+}
+
+// public - доменная ошибка с уточнениями: из текста err отбрасываются префиксы
+// вызывающих ("create schedule: "), уточнения после "%w: " сохраняются
+func public(err error, appErr *Error) *Error {
+	message := err.Error()
+	if i := strings.Index(message, appErr.Message); i >= 0 {
+		message = message[i:]
+	} else {
+		message = appErr.Message
+	}
+	if appErr.Err != nil {
+		// причина доменной ошибки (например, ответ провайдера) клиенту не показывается
+		message = appErr.Message
+	}
+
+	out := *appErr
+	out.Message = message
+	return &out
+}
+
+func fromFiber(e *fiber.Error) *Error {
+	kind := KindInternal
+	code := strings.ReplaceAll(strings.ToLower(http.StatusText(e.Code)), " ", "_")
+	switch e.Code {
+	case http.StatusBadRequest:
+		kind = KindInvalid
+	case http.StatusUnauthorized:
+		kind = KindUnauthorized
+	case http.StatusForbidden:
+		kind = KindForbidden
+	case http.StatusNotFound:
+		kind = KindNotFound
+	case http.StatusConflict:
+		kind = KindConflict
+	}
+	if code == "" {
+		code = CodeInternal
+	}
+	return &Error{Kind: kind, Code: code, Message: e.Message, status: e.Code}
+}
+
+// Status - статус HTTP, с которым Handler ответит на err (для метрик и трассировки)
+func Status(err error) int {
+	return From(err).Status()
+}
@@ -0,0 +1,91 @@
+package apperr
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var errExists = Conflict("shift_exists", "shift already planned for this day")
+
+func TestFrom(t *testing.T) {
+	cause := errors.New("connection refused")
+
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    string
+		message string
+	}{
+		{"domain", errExists, http.StatusConflict, "shift_exists", "shift already planned for this day"},
+		{"caller prefix", fmt.Errorf("plan shift: %w", errExists), http.StatusConflict, "shift_exists", "shift already planned for this day"},
+		{"details", fmt.Errorf("plan: %w: 2025-05-05", errExists), http.StatusConflict, "shift_exists", "shift already planned for this day: 2025-05-05"},
+		{"wrapped domain", Wrap(fmt.Errorf("plan: %w", errExists), "failed to plan shift"), http.StatusConflict, "shift_exists", "shift already planned for this day"},
+		{"internal", Wrap(cause, "failed to plan shift"), http.StatusInternalServerError, CodeInternal, "failed to plan shift"},
+		{"unknown", cause, http.StatusInternalServerError, CodeInternal, "internal server error"},
+		{"no rows", fmt.Errorf("get shift: %w", sql.ErrNoRows), http.StatusNotFound, CodeNotFound, "not found"},
+		{"wrapped no rows", Wrap(fmt.Errorf("get type: %w", sql.ErrNoRows), "failed to create report"), http.StatusInternalServerError, CodeInternal, "failed to create report"},
+		{"domain not found", Wrap(fmt.Errorf("get vacation: %w", NotFound("vacation_not_found", "vacation not found")), "failed to render"), http.StatusNotFound, "vacation_not_found", "vacation not found"},
+		{"fiber", fiber.ErrMethodNotAllowed, http.StatusMethodNotAllowed, "method_not_allowed", "Method Not Allowed"},
+		{"upstream", Upstream("directory_unavailable", "failed to sync directory", cause), http.StatusBadGateway, "directory_unavailable", "failed to sync directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := From(tt.err)
+			if e.Status() != tt.status || e.Code != tt.code || e.Message != tt.message {
+				t.Fatalf("From = %d %q %q, want %d %q %q", e.Status(), e.Code, e.Message, tt.status, tt.code, tt.message)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: Handler(slog.New(slog.NewTextHandler(io.Discard, nil)))})
+	app.Post("/shifts", func(c *fiber.Ctx) error {
+		return Validation(
+			Field{Field: "userId", Message: "must be a valid UUID"},
+			Field{Field: "entries[0].hours", Message: "must be between 0 and 24"},
+		)
+	})
+	app.Get("/shifts", func(c *fiber.Ctx) error {
+		return Wrap(errors.New("dial tcp: connection refused"), "failed to get shifts")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/shifts", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body Response
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusBadRequest || body.Code != CodeValidation || len(body.Fields) != 2 {
+		t.Fatalf("validation: %d %+v", resp.StatusCode, body)
+	}
+	if body.Fields[1].Field != "entries[0].hours" {
+		t.Fatalf("fields = %+v", body.Fields)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/shifts", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body = Response{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	// причина не попадает в ответ
+	if resp.StatusCode != http.StatusInternalServerError || body.Code != CodeInternal || body.Message != "failed to get shifts" {
+		t.Fatalf("internal: %d %+v", resp.StatusCode, body)
+	}
+}
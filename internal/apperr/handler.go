@@ -0,0 +1,42 @@
+package apperr
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Response - тело ответа с ошибкой
+type Response struct {
+	// Error - текст статуса HTTP ("Not Found")
+	Error   string  `json:"error"`
+	Code    string  `json:"code"`
+	Message string  `json:"message,omitempty"`
+	Fields  []Field `json:"fields,omitempty"`
+}
+
+// Handler - обработчик ошибок Fiber (fiber.Config.ErrorHandler): отвечает по виду
+// ошибки, внутренние ошибки пишет в журнал с причиной
+func Handler(logger *slog.Logger) fiber.ErrorHandler {
+	return func(c *fiber.Ctx, err error) error {
+		e := From(err)
+		status := e.Status()
+
+		if status >= http.StatusInternalServerError {
+			logger.Error(e.Message,
+				slog.String("method", c.Method()),
+				slog.String("path", c.Path()),
+				slog.String("code", e.Code),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		return c.Status(status).JSON(Response{
+			Error:   http.StatusText(status),
+			Code:    e.Code,
+			Message: e.Message,
+			Fields:  e.Fields,
+		})
+	}
+}
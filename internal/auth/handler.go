@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"TimeTrack/internal/apperr"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// loginCookie - cookie с состоянием входа через провайдера
+const loginCookie = "timetrack_oidc"
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NewHandler(service Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Login перенаправляет пользователя к провайдеру
+func (h *Handler) Login(c *fiber.Ctx) error {
+	login, err := h.service.Begin(c.UserContext())
+	if err != nil {
+		if errors.Is(err, ErrNotConfigured) {
+			return err
+		}
+		return apperr.Upstream("identity_provider_unavailable", "identity provider is unavailable", err)
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     loginCookie,
+		Value:    login.Cookie,
+		Path:     "/v1/auth",
+		MaxAge:   int(loginTTL / time.Second),
+		Secure:   true,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+
+	return c.Redirect(login.URL, http.StatusFound)
+}
+
+// Callback - адрес возврата от провайдера (OIDC_REDIRECT_URL)
+func (h *Handler) Callback(c *fiber.Ctx) error {
+	if providerErr := c.Query("error"); providerErr != "" {
+		return apperr.Unauthorized("login_rejected", providerErr+": "+c.Query("error_description"))
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		return apperr.Required("code")
+	}
+
+	sess, err := h.service.Complete(c.UserContext(), code, c.Query("state"), c.Cookies(loginCookie))
+	c.ClearCookie(loginCookie)
+	if err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			// подробности от провайдера пишутся в журнал, клиенту - только код
+			h.logger.Warn("oidc token rejected", slog.String("error", err.Error()))
+			return ErrInvalidToken
+		}
+		return apperr.Wrap(err, "failed to complete login")
+	}
+
+	if sess.Redirect != "" {
+		return c.Redirect(sess.Redirect, http.StatusFound)
+	}
+
+	return c.JSON(sess)
+}
+
+type meResponse struct {
+	UserID    string    `json:"userId"`
+	Role      string    `json:"role"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Me - сведения о текущей сессии
+func (h *Handler) Me(c *fiber.Ctx) error {
+	claims := FromContext(c)
+	if claims == nil {
+		return ErrSessionRequired
+	}
+
+	return c.JSON(meResponse{
+		UserID:    claims.Subject,
+		Role:      claims.Role,
+		Name:      claims.Name,
+		Email:     claims.Email,
+		ExpiresAt: claims.ExpiresAt.Time,
+	})
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты входа для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/v1/auth/oidc/login", Summary: "Перейти ко входу у провайдера",
+			Status: http.StatusFound, Public: true},
+		{Method: http.MethodGet, Path: "/v1/auth/oidc/callback", Summary: "Возврат от провайдера: токен сессии или переход на FRONTEND_URL",
+			Query: []openapi.Param{
+				{Name: "code", Description: "Код авторизации"},
+				{Name: "state", Description: "Состояние входа"},
+				{Name: "error", Description: "Ошибка провайдера"},
+				{Name: "error_description", Description: "Описание ошибки провайдера"},
+			},
+			Response:  session{},
+			Responses: map[int]any{http.StatusFound: nil},
+			Public:    true},
+		{Method: http.MethodGet, Path: "/v1/auth/me", Summary: "Текущая сессия",
+			Response: meResponse{}},
+	}
+}
@@ -0,0 +1,339 @@
+package auth
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/tracing"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+// loginTTL - сколько ждем возврата пользователя от провайдера
+const loginTTL = 10 * time.Minute
+
+var (
+	ErrNotConfigured = apperr.Unavailable("sso_not_configured", "single sign-on is not configured")
+	ErrInvalidState  = apperr.Invalid("invalid_login_state", "invalid or expired login state")
+	ErrInvalidToken  = apperr.Unauthorized("invalid_token", "identity provider returned an invalid token")
+	ErrUnknownUser   = apperr.Forbidden("unknown_user", "account is not linked to a local user")
+	ErrInactiveUser  = apperr.Forbidden("inactive_user", "user is inactive")
+)
+
+// OIDCConfig - параметры провайдера. UserClaim - claim с UUID локального пользователя;
+// если не задан, пользователь ищется по email в каталоге, затем берется sub. Вход возможен
+// только для активных сотрудников из каталога (report_directory_user).
+// Пользователь получает роль admin, если RoleClaim содержит одну из AdminGroups.
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	UserClaim    string
+	RoleClaim    string
+	AdminGroups  []string
+	// FrontendURL - куда вернуть пользователя с токеном (#token=...); без него ответ - JSON
+	FrontendURL string
+}
+
+type Service interface {
+	Begin(ctx context.Context) (*loginRequest, error)
+	Complete(ctx context.Context, code, state, cookie string) (*session, error)
+}
+
+type service struct {
+	repo     repo.Querier
+	db       repo.TxBeginner
+	cfg      OIDCConfig
+	sessions *Sessions
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+func NewService(repo repo.Querier, db repo.TxBeginner, cfg OIDCConfig, sessions *Sessions) Service {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{oidc.ScopeOpenID, "profile", "email"}
+	}
+	if cfg.RoleClaim == "" {
+		cfg.RoleClaim = "groups"
+	}
+	return &service{repo: repo, db: db, cfg: cfg, sessions: sessions}
+}
+
+// loginRequest - адрес провайдера и значение cookie, связывающей ответ с этим браузером
+type loginRequest struct {
+	URL    string
+	Cookie string
+}
+
+type session struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	UserID    string    `json:"userId"`
+	Role      string    `json:"role"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Redirect  string    `json:"-"`
+}
+
+// loginState хранится в cookie на время входа
+type loginState struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	jwt.RegisteredClaims
+}
+
+// Begin начинает authorization code flow с PKCE
+func (s *service) Begin(ctx context.Context) (_ *loginRequest, err error) {
+	ctx, end := tracing.Start(ctx, "auth.Begin")
+	defer end(&err)
+
+	provider, err := s.oidcProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	state, nonce := randomString(), randomString()
+	verifier := oauth2.GenerateVerifier()
+
+	cookie, err := jwt.NewWithClaims(jwt.SigningMethodHS256, loginState{
+		State:    state,
+		Nonce:    nonce,
+		Verifier: verifier,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(loginTTL)),
+		},
+	}).SignedString(s.stateKey())
+	if err != nil {
+		return nil, fmt.Errorf("sign login state: %w", err)
+	}
+
+	return &loginRequest{
+		URL:    s.oauth2Config(provider).AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)),
+		Cookie: cookie,
+	}, nil
+}
+
+// Complete обменивает код на токены, проверяет ID token по JWKS провайдера
+// и выпускает сессию для найденного локального пользователя
+func (s *service) Complete(ctx context.Context, code, state, cookie string) (_ *session, err error) {
+	ctx, end := tracing.Start(ctx, "auth.Complete")
+	defer end(&err)
+
+	provider, err := s.oidcProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	var login loginState
+	if _, err := jwt.ParseWithClaims(cookie, &login, func(t *jwt.Token) (any, error) {
+		return s.stateKey(), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired()); err != nil {
+		return nil, ErrInvalidState
+	}
+	if subtle.ConstantTimeCompare([]byte(login.State), []byte(state)) != 1 {
+		return nil, ErrInvalidState
+	}
+
+	ctx = oidc.ClientContext(ctx, httpClient)
+	token, err := s.oauth2Config(provider).Exchange(ctx, code, oauth2.VerifierOption(login.Verifier))
+	if err != nil {
+		return nil, fmt.Errorf("%w: exchange code: %v", ErrInvalidToken, err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: id_token is missing", ErrInvalidToken)
+	}
+
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: s.cfg.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(login.Nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	}
+
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	userID, name, err := s.localUser(ctx, idToken.Subject, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	email, _ := claims["email"].(string)
+	if claimName, ok := claims["name"].(string); ok && claimName != "" {
+		name = claimName
+	}
+	role := s.role(claims)
+
+	signed, expiresAt, err := s.sessions.Issue(userID, role, name, email)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := session{
+		Token:     signed,
+		ExpiresAt: expiresAt,
+		UserID:    userID,
+		Role:      role,
+		Name:      name,
+		Email:     email,
+	}
+	if s.cfg.FrontendURL != "" {
+		sess.Redirect = s.cfg.FrontendURL + "#" + url.Values{
+			"token":     {signed},
+			"expiresAt": {expiresAt.Format(time.RFC3339)},
+		}.Encode()
+	}
+
+	return &sess, nil
+}
+
+// localUser сопоставляет учетную запись провайдера с сотрудником
+func (s *service) localUser(ctx context.Context, subject string, claims map[string]any) (string, string, error) {
+	if s.cfg.UserClaim != "" {
+		value, _ := claims[s.cfg.UserClaim].(string)
+		return s.activeUser(ctx, value)
+	}
+
+	// адрес, который провайдер не подтвердил, пользователь мог указать сам: по нему
+	// сотрудник не сопоставляется
+	if email, ok := claims["email"].(string); ok && email != "" && verified(claims) {
+		user, err := s.repo.GetDirectoryUserByEmail(ctx, email)
+		switch {
+		case err == nil:
+			if !user.IsActive {
+				return "", "", ErrInactiveUser
+			}
+			return user.ID, user.Name, nil
+		case !errors.Is(err, sql.ErrNoRows):
+			return "", "", fmt.Errorf("get directory user: %w", err)
+		}
+	}
+
+	return s.activeUser(ctx, subject)
+}
+
+// verified - подтвержден ли адрес: claim email_verified бывает логическим значением
+// или строкой "true"
+func verified(claims map[string]any) bool {
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+// activeUser - сотрудник должен быть в каталоге и быть активным: вход по произвольному
+// UUID из claim провайдера не создает сессию
+func (s *service) activeUser(ctx context.Context, userID string) (string, string, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return "", "", ErrUnknownUser
+	}
+
+	user, err := s.repo.GetDirectoryUserById(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", ErrUnknownUser
+		}
+		return "", "", fmt.Errorf("get directory user: %w", err)
+	}
+	if !user.IsActive {
+		return "", "", ErrInactiveUser
+	}
+
+	return user.ID, user.Name, nil
+}
+
+// role - admin, если claim групп (строка или список) содержит одну из AdminGroups
+func (s *service) role(claims map[string]any) string {
+	var groups []string
+	switch v := claims[s.cfg.RoleClaim].(type) {
+	case string:
+		groups = []string{v}
+	case []any:
+		for _, g := range v {
+			if str, ok := g.(string); ok {
+				groups = append(groups, str)
+			}
+		}
+	}
+
+	for _, g := range groups {
+		if slices.Contains(s.cfg.AdminGroups, g) {
+			return RoleAdmin
+		}
+	}
+	return RoleUser
+}
+
+// httpClient - клиент для обращений к провайдеру
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// oidcProvider загружает discovery-документ при первом входе и кеширует его;
+// при недоступности провайдера сервер стартует, а вход повторит попытку.
+func (s *service) oidcProvider() (*oidc.Provider, error) {
+	if s.cfg.Issuer == "" || s.cfg.ClientID == "" {
+		return nil, ErrNotConfigured
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.provider == nil {
+		// Контекст провайдера используется и для последующей загрузки JWKS,
+		// поэтому он не должен зависеть от запроса
+		provider, err := oidc.NewProvider(oidc.ClientContext(context.Background(), httpClient), s.cfg.Issuer)
+		if err != nil {
+			return nil, fmt.Errorf("discover oidc provider: %w", err)
+		}
+		s.provider = provider
+	}
+
+	return s.provider, nil
+}
+
+func (s *service) oauth2Config(provider *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     s.cfg.ClientID,
+		ClientSecret: s.cfg.ClientSecret,
+		RedirectURL:  s.cfg.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       s.cfg.Scopes,
+	}
+}
+
+// stateKey - отдельный ключ для cookie входа, чтобы ее нельзя было предъявить как сессию
+func (s *service) stateKey() []byte {
+	return append(append([]byte{}, s.sessions.secret...), ":oidc-login"...)
+}
+
+func randomString() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,286 @@
+package auth
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	clientID = "timetrack"
+	keyID    = "key-1"
+
+	ivanID = "0b9c7f5e-4a3d-4c1e-9f7a-2d8e6b1c5a40"
+	olegID = "9a7c3e1b-2d4f-4b8a-9c6e-3f1d5a7b2c80"
+)
+
+// provider - OIDC-провайдер на httptest: discovery, JWKS и выдача ID token.
+// claims дополняют или заменяют стандартные claims выдаваемого токена,
+// signer - ключ подписи (по умолчанию ключ из JWKS).
+type provider struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	signer *rsa.PrivateKey
+	claims jwt.MapClaims
+	nonce  string
+}
+
+func newProvider(t *testing.T) *provider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &provider{key: key, signer: key, claims: jwt.MapClaims{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                p.URL,
+			"authorization_endpoint":                p.URL + "/authorize",
+			"token_endpoint":                        p.URL + "/token",
+			"jwks_uri":                              p.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("code") != "code-1" || r.PostForm.Get("code_verifier") == "" {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     p.idToken(t),
+		})
+	})
+
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+func (p *provider) idToken(t *testing.T) string {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   p.URL,
+		"aud":   clientID,
+		"sub":   "provider-subject",
+		"nonce": p.nonce,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}
+	for k, v := range p.claims {
+		claims[k] = v
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	signed, err := token.SignedString(p.signer)
+	if err != nil {
+		t.Error(err)
+	}
+	return signed
+}
+
+// newTestService - сервис входа через провайдер p; в каталоге активный Иван
+// (ivan@example.com) и отключенный Олег
+func newTestService(t *testing.T, p *provider, cfg OIDCConfig) (Service, *Sessions) {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.New()
+
+	for _, u := range []repo.CreateDirectoryUserParams{
+		{ID: ivanID, Dn: "uid=ivan", Login: "ivan", Name: "Иван Петров", Email: "ivan@example.com", IsActive: true},
+		{ID: olegID, Dn: "uid=oleg", Login: "oleg", Name: "Олег Иванов", Email: "oleg@example.com", IsActive: false},
+	} {
+		if err := store.CreateDirectoryUser(ctx, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg.Issuer = p.URL
+	cfg.ClientID = clientID
+	cfg.ClientSecret = "client-secret"
+	cfg.RedirectURL = "https://timetrack.example.com/v1/auth/oidc/callback"
+	sessions := NewSessions("test-secret", 0)
+	return NewService(store, store, cfg, sessions), sessions
+}
+
+// login проходит вход целиком: Begin, ответ провайдера с nonce из запроса и Complete
+func login(t *testing.T, svc Service, p *provider) (*session, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	req, err := svc.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := u.Query()
+	if query.Get("client_id") != clientID || query.Get("code_challenge_method") != "S256" || query.Get("nonce") == "" {
+		t.Fatalf("authorization URL = %s", req.URL)
+	}
+	p.nonce = query.Get("nonce")
+
+	return svc.Complete(ctx, "code-1", query.Get("state"), req.Cookie)
+}
+
+func TestComplete(t *testing.T) {
+	p := newProvider(t)
+	svc, sessions := newTestService(t, p, OIDCConfig{
+		AdminGroups: []string{"timetrack-admins"},
+		FrontendURL: "https://timetrack.example.com/",
+	})
+	p.claims["email"] = "ivan@example.com"
+	p.claims["email_verified"] = true
+	p.claims["groups"] = []string{"staff", "timetrack-admins"}
+
+	sess, err := login(t, svc, p)
+	if err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if sess.UserID != ivanID || sess.Role != RoleAdmin || sess.Name != "Иван Петров" || sess.Email != "ivan@example.com" {
+		t.Fatalf("session = %+v", sess)
+	}
+
+	claims, err := sessions.Parse(sess.Token)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if claims.Subject != ivanID || claims.Role != RoleAdmin {
+		t.Fatalf("claims = %+v", claims)
+	}
+
+	redirect, err := url.Parse(sess.Redirect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fragment, _ := url.ParseQuery(redirect.Fragment); fragment.Get("token") != sess.Token {
+		t.Fatalf("redirect = %s", sess.Redirect)
+	}
+}
+
+// TestCompleteUserClaim - сотрудник из claim с UUID, без групп администратора - роль user
+func TestCompleteUserClaim(t *testing.T) {
+	p := newProvider(t)
+	svc, _ := newTestService(t, p, OIDCConfig{UserClaim: "employee_id", RoleClaim: "roles", AdminGroups: []string{"admin"}})
+	p.claims["employee_id"] = ivanID
+	p.claims["roles"] = "staff"
+
+	sess, err := login(t, svc, p)
+	if err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if sess.UserID != ivanID || sess.Role != RoleUser || sess.Name != "Иван Петров" || sess.Redirect != "" {
+		t.Fatalf("session = %+v", sess)
+	}
+}
+
+func TestCompleteRejects(t *testing.T) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		cfg    OIDCConfig
+		claims jwt.MapClaims
+		signer *rsa.PrivateKey
+		want   error
+	}{
+		{name: "bad signature", claims: jwt.MapClaims{"sub": ivanID}, signer: otherKey, want: ErrInvalidToken},
+		{name: "wrong audience", claims: jwt.MapClaims{"sub": ivanID, "aud": "another-client"}, want: ErrInvalidToken},
+		{name: "wrong issuer", claims: jwt.MapClaims{"sub": ivanID, "iss": "https://evil.example.com"}, want: ErrInvalidToken},
+		{name: "expired", claims: jwt.MapClaims{"sub": ivanID, "exp": time.Now().Add(-time.Hour).Unix()}, want: ErrInvalidToken},
+		{name: "wrong nonce", claims: jwt.MapClaims{"sub": ivanID, "nonce": "replayed"}, want: ErrInvalidToken},
+		{name: "subject is not a uuid", claims: jwt.MapClaims{}, want: ErrUnknownUser},
+		// UUID, которого нет в каталоге, не дает сессии
+		{name: "unknown user", claims: jwt.MapClaims{"sub": "5d2e8a1f-7c3b-4e9d-8a6f-1b4c7e2d9f30"}, want: ErrUnknownUser},
+		{name: "unknown email", claims: jwt.MapClaims{"email": "guest@example.com", "email_verified": true}, want: ErrUnknownUser},
+		// неподтвержденный адрес не сопоставляется: иначе чужая учетная запись провайдера
+		// с адресом сотрудника получила бы его сессию
+		{name: "unverified email", claims: jwt.MapClaims{"email": "ivan@example.com"}, want: ErrUnknownUser},
+		{name: "email not verified", claims: jwt.MapClaims{"email": "ivan@example.com", "email_verified": false}, want: ErrUnknownUser},
+		{name: "inactive user", claims: jwt.MapClaims{"sub": olegID}, want: ErrInactiveUser},
+		{name: "inactive email", claims: jwt.MapClaims{"email": "oleg@example.com", "email_verified": "true"}, want: ErrInactiveUser},
+		{name: "inactive user claim", cfg: OIDCConfig{UserClaim: "employee_id"}, claims: jwt.MapClaims{"employee_id": olegID}, want: ErrInactiveUser},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newProvider(t)
+			svc, _ := newTestService(t, p, tt.cfg)
+			p.claims = tt.claims
+			if tt.signer != nil {
+				p.signer = tt.signer
+			}
+
+			if _, err := login(t, svc, p); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompleteState(t *testing.T) {
+	p := newProvider(t)
+	svc, _ := newTestService(t, p, OIDCConfig{})
+	ctx := context.Background()
+
+	req, err := svc.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	u, _ := url.Parse(req.URL)
+	state := u.Query().Get("state")
+
+	if _, err := svc.Complete(ctx, "code-1", "other-state", req.Cookie); !errors.Is(err, ErrInvalidState) {
+		t.Fatalf("state mismatch: err = %v", err)
+	}
+	if _, err := svc.Complete(ctx, "code-1", state, req.Cookie+"x"); !errors.Is(err, ErrInvalidState) {
+		t.Fatalf("tampered cookie: err = %v", err)
+	}
+	// токен сессии не принимается как cookie входа: у них разные ключи
+	token, _, err := NewSessions("test-secret", 0).Issue(ivanID, RoleAdmin, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Complete(ctx, "code-1", state, token); !errors.Is(err, ErrInvalidState) {
+		t.Fatalf("session as login cookie: err = %v", err)
+	}
+}
+
+func TestNotConfigured(t *testing.T) {
+	svc := NewService(memory.New(), memory.New(), OIDCConfig{}, NewSessions("test-secret", 0))
+	if _, err := svc.Begin(context.Background()); !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("err = %v, want ErrNotConfigured", err)
+	}
+}
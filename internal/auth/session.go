@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"TimeTrack/internal/apperr"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Роли пользователей
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+const (
+	issuer = "timetrack"
+	// DefaultSessionTTL - срок действия сессии, если SESSION_TTL не задан
+	DefaultSessionTTL = 12 * time.Hour
+	// claimsKey - ключ, под которым middleware сохраняет сессию в c.Locals
+	claimsKey = "auth.claims"
+)
+
+var (
+	ErrInvalidSession  = apperr.Unauthorized("invalid_session", "invalid or expired session token")
+	ErrSessionRequired = apperr.Unauthorized("session_required", "session token is required")
+	ErrBearerScheme    = apperr.Unauthorized("invalid_auth_scheme", "authorization header must use the Bearer scheme")
+	ErrRoleRequired    = apperr.Forbidden("role_required", "session role does not allow this operation")
+)
+
+// Claims - содержимое токена сессии
+type Claims struct {
+	Role  string `json:"role"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Sessions выпускает и проверяет токены сессии (HS256, ключ SECRET_KEY)
+type Sessions struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewSessions(secret string, ttl time.Duration) *Sessions {
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+	return &Sessions{secret: []byte(secret), ttl: ttl}
+}
+
+// Issue выпускает токен для локального пользователя
+func (s *Sessions) Issue(userID, role, name, email string) (string, time.Time, error) {
+	if len(s.secret) == 0 {
+		return "", time.Time{}, errors.New("SECRET_KEY is not set")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		Role:  role,
+		Name:  name,
+		Email: email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+
+	signed, err := token.SignedString(s.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("sign session token: %w", err)
+	}
+
+	return signed, expiresAt, nil
+}
+
+func (s *Sessions) Parse(token string) (*Claims, error) {
+	if len(s.secret) == 0 {
+		return nil, ErrInvalidSession
+	}
+
+	var claims Claims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		return s.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, ErrInvalidSession
+	}
+
+	return &claims, nil
+}
+
+// Middleware проверяет заголовок Authorization: Bearer <токен>. Если required=false,
+// запросы без токена пропускаются (для клиентов, еще не перешедших на вход через SSO),
+// но переданный неверный токен все равно отклоняется. public - префиксы путей без проверки.
+func Middleware(sessions *Sessions, required bool, public ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		for _, prefix := range public {
+			if strings.HasPrefix(c.Path(), prefix) {
+				return c.Next()
+			}
+		}
+
+		header := c.Get(fiber.HeaderAuthorization)
+		if header == "" {
+			if required {
+				return ErrSessionRequired
+			}
+			return c.Next()
+		}
+
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			return ErrBearerScheme
+		}
+
+		claims, err := sessions.Parse(token)
+		if err != nil {
+			return err
+		}
+
+		c.Locals(claimsKey, claims)
+		return c.Next()
+	}
+}
+
+// RequireRole пропускает только сессии с ролью role; ставится после Middleware на маршруты
+// администрирования. В отличие от Middleware, сессия нужна при любом AUTH_REQUIRED:
+// запрос без токена не получает права администратора.
+func RequireRole(role string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := FromContext(c)
+		if claims == nil {
+			return ErrSessionRequired
+		}
+		if claims.Role != role {
+			return ErrRoleRequired
+		}
+		return c.Next()
+	}
+}
+
+// FromContext - сессия текущего запроса или nil
+func FromContext(c *fiber.Ctx) *Claims {
+	claims, _ := c.Locals(claimsKey).(*Claims)
+	return claims
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"TimeTrack/internal/apperr"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TestRequireRole - маршруты администрирования принимают только сессию admin,
+// в том числе когда остальные маршруты доступны без токена
+func TestRequireRole(t *testing.T) {
+	sessions := NewSessions("test-secret", 0)
+	adminToken, _, err := sessions.Issue(ivanID, RoleAdmin, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	userToken, _, err := sessions.Issue(olegID, RoleUser, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		required bool
+		token    string
+		want     int
+	}{
+		{"admin", true, adminToken, http.StatusOK},
+		{"user", true, userToken, http.StatusForbidden},
+		{"no session", true, "", http.StatusUnauthorized},
+		{"admin when not required", false, adminToken, http.StatusOK},
+		{"user when not required", false, userToken, http.StatusForbidden},
+		{"no session when not required", false, "", http.StatusUnauthorized},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: apperr.Handler(slog.New(slog.NewTextHandler(io.Discard, nil)))})
+			app.Use(Middleware(sessions, tt.required))
+			app.Post("/v1/payroll/close/5/2025", RequireRole(RoleAdmin), func(c *fiber.Ctx) error {
+				return c.SendStatus(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/payroll/close/5/2025", nil)
+			if tt.token != "" {
+				req.Header.Set(fiber.HeaderAuthorization, "Bearer "+tt.token)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
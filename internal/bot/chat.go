@@ -0,0 +1,124 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultTelegramURL - адрес Bot API, если TELEGRAM_API_URL не задан
+const defaultTelegramURL = "https://api.telegram.org"
+
+// Chat - мессенджер, через который работает бот. Обновления приходят вебхуком,
+// поэтому от мессенджера нужна только отправка ответов и проверка подлинности запроса.
+type Chat interface {
+	Send(ctx context.Context, chatID int64, text string) error
+	Authorize(token string) bool
+}
+
+// TelegramConfig - параметры Telegram Bot API. APIURL можно направить на локальную
+// заглушку; Secret сверяется с заголовком X-Telegram-Bot-Api-Secret-Token и обязателен
+// вместе с Token.
+type TelegramConfig struct {
+	Token  string
+	APIURL string
+	Secret string
+}
+
+// NewChat выбирает реализацию по конфигурации: без токена ответы только пишутся в лог
+func NewChat(cfg TelegramConfig, logger *slog.Logger) Chat {
+	if cfg.Token == "" {
+		return &logChat{logger: logger}
+	}
+	if cfg.APIURL == "" {
+		cfg.APIURL = defaultTelegramURL
+	}
+	return &telegramChat{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type telegramChat struct {
+	cfg    TelegramConfig
+	client *http.Client
+}
+
+func (t *telegramChat) Send(ctx context.Context, chatID int64, text string) error {
+	body, err := json.Marshal(map[string]any{
+		"chat_id": chatID,
+		"text":    text,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimRight(t.cfg.APIURL, "/"), t.cfg.Token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return fmt.Errorf("send message: status %d: %s", resp.StatusCode, detail)
+	}
+
+	return nil
+}
+
+// Authorize без настроенного секрета отклоняет все запросы: иначе любой мог бы
+// отправлять команды от имени привязанных чатов
+func (t *telegramChat) Authorize(token string) bool {
+	if t.cfg.Secret == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(t.cfg.Secret)) == 1
+}
+
+// logChat - бот без мессенджера: ответы только пишутся в лог
+type logChat struct {
+	logger *slog.Logger
+}
+
+func (l *logChat) Send(ctx context.Context, chatID int64, text string) error {
+	l.logger.Info("chat is not configured, message skipped",
+		slog.Int64("chat_id", chatID),
+		slog.String("text", text),
+	)
+	return nil
+}
+
+// Authorize отклоняет все запросы: без мессенджера обновлениям неоткуда приходить,
+// а принятое обновление позволило бы писать табель от имени привязанного сотрудника
+func (l *logChat) Authorize(token string) bool {
+	return false
+}
+
+// Update - входящее обновление Telegram (используемые поля)
+type Update struct {
+	UpdateID int64    `json:"update_id"`
+	Message  *Message `json:"message"`
+}
+
+type Message struct {
+	MessageID int64 `json:"message_id"`
+	Chat      struct {
+		ID int64 `json:"id"`
+	} `json:"chat"`
+	Text string `json:"text"`
+}
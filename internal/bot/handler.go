@@ -0,0 +1,73 @@
+package bot
+
+import (
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/auth"
+	"log/slog"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// secretHeader - заголовок, в котором Telegram передает секрет вебхука
+const secretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NewHandler(service Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Update принимает обновление от мессенджера. На сбои отвечает 200, чтобы мессенджер
+// не повторял то же сообщение; пользователь уже получил ответ об ошибке.
+func (h *Handler) Update(c *fiber.Ctx) error {
+	if !h.service.Authorize(c.Get(secretHeader)) {
+		return apperr.Unauthorized("invalid_secret_token", "invalid secret token")
+	}
+
+	var update Update
+	if err := c.BodyParser(&update); err != nil {
+		return apperr.InvalidBody(err)
+	}
+
+	if err := h.service.HandleUpdate(c.UserContext(), update); err != nil {
+		h.logger.Error("failed to handle chat update",
+			slog.Int64("update_id", update.UpdateID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return nil
+}
+
+// LinkCode выдает код, который сотрудник отправляет боту командой /link. Код
+// привязывает чат к сотруднику текущей сессии: иначе любой мог бы получить код
+// для чужой учетной записи и отмечать часы от ее имени.
+func (h *Handler) LinkCode(c *fiber.Ctx) error {
+	claims := auth.FromContext(c)
+	if claims == nil {
+		return auth.ErrSessionRequired
+	}
+
+	code, err := h.service.LinkCode(c.UserContext(), claims.Subject)
+	if err != nil {
+		return apperr.Wrap(err, "failed to create link code")
+	}
+
+	return c.Status(http.StatusCreated).JSON(code)
+}
+
+func (h *Handler) Remind(c *fiber.Ctx) error {
+	result, err := h.service.Remind(c.UserContext())
+	if err != nil {
+		return apperr.Wrap(err, "failed to send chat reminders")
+	}
+
+	return c.JSON(result)
+}
@@ -0,0 +1,23 @@
+package bot
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты чат-бота для спецификации OpenAPI. Вебхук мессенджера
+// регистрируется, только если бот подключен к Telegram (telegram).
+func Operations(telegram bool) []openapi.Operation {
+	ops := []openapi.Operation{
+		{Method: http.MethodPost, Path: "/v1/bot/link-code", Summary: "Код привязки чата к сотруднику текущей сессии",
+			Status: http.StatusCreated, Response: repo.ReportChatLinkCode{}},
+		{Method: http.MethodPost, Path: "/v1/bot/remind", Summary: "Напомнить в чате о незаполненном табеле",
+			Response: remindResult{}},
+	}
+	if telegram {
+		ops = append(ops, openapi.Operation{Method: http.MethodPost, Path: "/v1/bot/telegram/update",
+			Summary: "Обновление от мессенджера (проверяется секретный заголовок)", Request: Update{}, Public: true})
+	}
+	return ops
+}
@@ -0,0 +1,322 @@
+package bot
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/report"
+	"TimeTrack/internal/tracing"
+	"TimeTrack/internal/vacation"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// linkCodeTTL - время жизни кода привязки аккаунта
+	linkCodeTTL = 15 * time.Minute
+	// defaultType - тип отметки, если в /hours он не указан
+	defaultType = "work"
+	dateLayout  = "2006-01-02"
+)
+
+const helpText = `Команды:
+/hours 8 [тип] [ГГГГ-ММ-ДД] - отметить часы (по умолчанию тип work и сегодняшний день)
+/vacation 2026-07-01 2026-07-14 [комментарий] - подать заявку на отпуск
+/link КОД - привязать чат к учетной записи
+/unlink - отвязать чат`
+
+type Service interface {
+	HandleUpdate(ctx context.Context, update Update) error
+	Authorize(token string) bool
+	LinkCode(ctx context.Context, userID string) (*repo.ReportChatLinkCode, error)
+	Remind(ctx context.Context) (*remindResult, error)
+}
+
+type service struct {
+	repo      repo.Querier
+	db        repo.TxBeginner
+	chat      Chat
+	reports   report.Service
+	vacations vacation.Service
+	logger    *slog.Logger
+}
+
+func NewService(repo repo.Querier, db repo.TxBeginner, chat Chat, reports report.Service, vacations vacation.Service, logger *slog.Logger) Service {
+	return &service{repo: repo, db: db, chat: chat, reports: reports, vacations: vacations, logger: logger}
+}
+
+// remindResult - итог рассылки напоминаний; Failed - чаты, которым не удалось
+// проверить табель или отправить сообщение
+type remindResult struct {
+	Date   string `json:"date"`
+	Sent   int    `json:"sent"`
+	Failed int    `json:"failed"`
+}
+
+// HandleUpdate выполняет команду из сообщения и отправляет ответ в тот же чат.
+// Ошибки пользователя (неверные аргументы, нет привязки) возвращаются текстом ответа,
+// ошибкой возвращаются только сбои хранилища и мессенджера.
+func (s *service) HandleUpdate(ctx context.Context, update Update) (err error) {
+	ctx, end := tracing.Start(ctx, "bot.HandleUpdate")
+	defer end(&err)
+
+	if update.Message == nil || !strings.HasPrefix(update.Message.Text, "/") {
+		return nil
+	}
+
+	chatID := update.Message.Chat.ID
+	reply, err := s.execute(ctx, chatID, update.Message.Text)
+	if err != nil {
+		s.chat.Send(ctx, chatID, "Не удалось выполнить команду, попробуйте позже.")
+		return err
+	}
+
+	return s.chat.Send(ctx, chatID, reply)
+}
+
+func (s *service) execute(ctx context.Context, chatID int64, text string) (string, error) {
+	args := strings.Fields(text)
+	// В группах команда приходит в виде /hours@BotName
+	command, _, _ := strings.Cut(strings.ToLower(args[0]), "@")
+	args = args[1:]
+
+	switch command {
+	case "/start":
+		if len(args) == 1 {
+			return s.link(ctx, chatID, args[0])
+		}
+		return helpText, nil
+	case "/help":
+		return helpText, nil
+	case "/link":
+		if len(args) != 1 {
+			return "Укажите код: /link КОД", nil
+		}
+		return s.link(ctx, chatID, args[0])
+	}
+
+	userID, err := s.linkedUser(ctx, chatID)
+	if err != nil {
+		return "", err
+	}
+	if userID == "" {
+		return "Чат не привязан к учетной записи. Получите код привязки в веб-интерфейсе и отправьте /link КОД.", nil
+	}
+
+	switch command {
+	case "/unlink":
+		if err := s.repo.DeleteChatLink(ctx, chatID); err != nil {
+			return "", fmt.Errorf("delete chat link: %w", err)
+		}
+		return "Чат отвязан от учетной записи.", nil
+	case "/hours":
+		return s.hours(ctx, userID, args)
+	case "/vacation":
+		return s.vacation(ctx, userID, args)
+	}
+
+	return "Неизвестная команда.\n\n" + helpText, nil
+}
+
+// hours: /hours <часы> [тип] [дата]
+func (s *service) hours(ctx context.Context, userID string, args []string) (string, error) {
+	if len(args) == 0 || len(args) > 3 {
+		return "Формат: /hours 8 [тип] [ГГГГ-ММ-ДД]", nil
+	}
+
+	hours, err := strconv.ParseFloat(strings.Replace(args[0], ",", ".", 1), 64)
+	if err != nil || hours <= 0 || hours > 24 {
+		return "Количество часов должно быть числом от 0 до 24.", nil
+	}
+
+	reportType, date := defaultType, time.Now()
+	for _, arg := range args[1:] {
+		if d, err := time.ParseInLocation(dateLayout, arg, time.Local); err == nil {
+			date = d
+			continue
+		}
+		reportType = arg
+	}
+
+	created, err := s.reports.Create(ctx, report.CreateReportParams{
+		ID:     uuid.NewString(),
+		UserID: userID,
+		Day:    int32(date.Day()),
+		Month:  int32(date.Month()),
+		Year:   int32(date.Year()),
+		Hours:  hours,
+		Type:   reportType,
+	})
+	switch {
+	case errors.Is(err, report.ErrDayHoursExceeded):
+		return "За этот день уже отмечено слишком много часов (больше 24).", nil
+	case errors.Is(err, report.ErrDuplicateType):
+		return "За этот день уже есть отметка такого типа.", nil
+	case errors.Is(err, report.ErrUnknownType):
+		return fmt.Sprintf("Неизвестный тип отметки %q.", reportType), nil
+	case err != nil:
+		return "", err
+	}
+
+	return fmt.Sprintf("Отмечено %s ч (%s) за %s.",
+		strconv.FormatFloat(created.Hours, 'f', -1, 64), created.TypeName, date.Format("02.01.2006")), nil
+}
+
+// vacation: /vacation <начало> <конец> [комментарий]
+func (s *service) vacation(ctx context.Context, userID string, args []string) (string, error) {
+	if len(args) < 2 {
+		return "Формат: /vacation 2026-07-01 2026-07-14 [комментарий]", nil
+	}
+
+	start, err := time.ParseInLocation(dateLayout, args[0], time.Local)
+	if err != nil {
+		return "Дата начала должна быть в формате ГГГГ-ММ-ДД.", nil
+	}
+	end, err := time.ParseInLocation(dateLayout, args[1], time.Local)
+	if err != nil {
+		return "Дата окончания должна быть в формате ГГГГ-ММ-ДД.", nil
+	}
+	if end.Before(start) {
+		return "Дата окончания раньше даты начала.", nil
+	}
+
+	description := strings.Join(args[2:], " ")
+	if utf8.RuneCountInString(description) > vacation.MaxDescription {
+		return fmt.Sprintf("Комментарий должен быть не длиннее %d символов.", vacation.MaxDescription), nil
+	}
+	created, err := s.vacations.Create(ctx, repo.CreateVacationParams{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		StartDate:   start,
+		EndDate:     end,
+		Year:        int32(start.Year()),
+		Description: sql.NullString{String: description, Valid: description != ""},
+		Status:      repo.ReportVacationStatusConsideration,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Заявка на отпуск с %s по %s отправлена на рассмотрение.",
+		created.StartDate.Format("02.01.2006"), created.EndDate.Format("02.01.2006")), nil
+}
+
+func (s *service) link(ctx context.Context, chatID int64, code string) (string, error) {
+	linkCode, err := s.repo.GetChatLinkCode(ctx, strings.ToUpper(code))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && time.Now().After(linkCode.ExpireAt)) {
+		return "Код не найден или устарел. Получите новый код в веб-интерфейсе.", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get link code: %w", err)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := tx.DeleteChatLink(ctx, chatID); err != nil {
+		return "", fmt.Errorf("delete chat link: %w", err)
+	}
+	if err := tx.CreateChatLink(ctx, repo.CreateChatLinkParams{ChatID: chatID, UserID: linkCode.UserID}); err != nil {
+		return "", fmt.Errorf("create chat link: %w", err)
+	}
+	if err := tx.DeleteChatLinkCode(ctx, linkCode.Code); err != nil {
+		return "", fmt.Errorf("delete link code: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("commit: %w", err)
+	}
+
+	return "Чат привязан к учетной записи.\n\n" + helpText, nil
+}
+
+// linkedUser возвращает пустую строку, если чат не привязан
+func (s *service) linkedUser(ctx context.Context, chatID int64) (string, error) {
+	link, err := s.repo.GetChatLinkByChat(ctx, chatID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("get chat link: %w", err)
+	}
+	return link.UserID, nil
+}
+
+func (s *service) Authorize(token string) bool {
+	return s.chat.Authorize(token)
+}
+
+// LinkCode выдает одноразовый код для привязки чата к сотруднику
+func (s *service) LinkCode(ctx context.Context, userID string) (_ *repo.ReportChatLinkCode, err error) {
+	ctx, end := tracing.Start(ctx, "bot.LinkCode", tracing.User(userID))
+	defer end(&err)
+
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("generate link code: %w", err)
+	}
+
+	code := repo.CreateChatLinkCodeParams{
+		Code:     base32.StdEncoding.EncodeToString(b),
+		UserID:   userID,
+		ExpireAt: time.Now().Add(linkCodeTTL),
+	}
+	if err := s.repo.CreateChatLinkCode(ctx, code); err != nil {
+		return nil, fmt.Errorf("create link code: %w", err)
+	}
+
+	return &repo.ReportChatLinkCode{Code: code.Code, UserID: code.UserID, ExpireAt: code.ExpireAt}, nil
+}
+
+// Remind напоминает в привязанные чаты, если за сегодняшний рабочий по графику день
+// нет отметки. Запускается раз в день планировщиком (POST /v1/bot/remind).
+// Сбой проверки табеля одного сотрудника не прерывает рассылку остальным.
+func (s *service) Remind(ctx context.Context) (_ *remindResult, err error) {
+	ctx, end := tracing.Start(ctx, "bot.Remind")
+	defer end(&err)
+
+	links, err := s.repo.GetChatLinks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get chat links: %w", err)
+	}
+
+	now := time.Now()
+	result := remindResult{Date: now.Format(time.DateOnly)}
+
+	for _, link := range links {
+		missing, err := s.reports.MissingDays(ctx, link.UserID, int32(now.Month()), int32(now.Year()))
+		if err != nil {
+			s.logger.Warn("failed to check missing days",
+				slog.String("user_id", link.UserID),
+				slog.Int64("chat_id", link.ChatID),
+				slog.String("error", err.Error()),
+			)
+			result.Failed++
+			continue
+		}
+		if !slices.Contains(*missing, int32(now.Day())) {
+			continue
+		}
+
+		if err := s.chat.Send(ctx, link.ChatID, "Сегодня еще нет отметки в табеле. Отправьте /hours 8, чтобы отметить рабочий день."); err != nil {
+			result.Failed++
+			continue
+		}
+		result.Sent++
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,293 @@
+package bot
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/calendar"
+	"TimeTrack/internal/report"
+	"TimeTrack/internal/schedule"
+	"TimeTrack/internal/shift"
+	"TimeTrack/internal/vacation"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// nop - публикация событий и уведомления, которые тестам не нужны
+type nop struct{}
+
+func (nop) Publish(ctx context.Context, event string, data any)          {}
+func (nop) VacationSubmitted(ctx context.Context, vacationID string)     {}
+func (nop) VacationStatusChanged(ctx context.Context, vacationID string) {}
+
+// chat запоминает ответы бота вместо отправки; err - ошибка каждой отправки
+type chat struct {
+	sent map[int64][]string
+	err  error
+}
+
+func (c *chat) Send(ctx context.Context, chatID int64, text string) error {
+	c.sent[chatID] = append(c.sent[chatID], text)
+	return c.err
+}
+
+func (c *chat) Authorize(token string) bool {
+	return token == "secret"
+}
+
+// reports подменяет MissingDays сервиса табеля: missing - незаполненные дни
+// по сотрудникам, failing - сотрудники, для которых проверка завершается ошибкой
+type reports struct {
+	report.Service
+	missing map[string][]int32
+	failing map[string]bool
+}
+
+func (r *reports) MissingDays(ctx context.Context, userID string, month, year int32) (*[]int32, error) {
+	if r.failing[userID] {
+		return nil, errors.New("schedule is unavailable")
+	}
+	days := r.missing[userID]
+	return &days, nil
+}
+
+const (
+	userID = "0b9c7f5e-4a3d-4c1e-9f7a-2d8e6b1c5a40"
+	chatID = 1001
+)
+
+type testBot struct {
+	Service
+	store   *memory.Store
+	chat    *chat
+	reports *reports
+}
+
+// newTestBot - бот над хранилищем в памяти с настоящими сервисами табеля и отпусков
+func newTestBot(t *testing.T) *testBot {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.New()
+
+	for _, rt := range []repo.CreateTypeParams{
+		{ID: "t-work", Name: "Явка", SystemName: "work", Code: "Я"},
+		{ID: "t-remote", Name: "Удаленная работа", SystemName: "remote", Code: "УР"},
+	} {
+		if err := store.CreateType(ctx, rt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	calendarService := calendar.NewService(store, store, nop{})
+	reportService := report.NewService(store, store, schedule.NewService(store, store, calendarService), shift.NewService(store, store), calendarService, nop{})
+	vacationService := vacation.NewService(store, store, store, nop{}, nop{})
+
+	b := &testBot{
+		store:   store,
+		chat:    &chat{sent: map[int64][]string{}},
+		reports: &reports{Service: reportService, missing: map[string][]int32{}, failing: map[string]bool{}},
+	}
+	b.Service = NewService(store, store, b.chat, b.reports, vacationService, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return b
+}
+
+// send отправляет команду из чата и возвращает ответ бота
+func (b *testBot) send(t *testing.T, chatID int64, text string) string {
+	t.Helper()
+	update := Update{Message: &Message{Text: text}}
+	update.Message.Chat.ID = chatID
+	if err := b.HandleUpdate(context.Background(), update); err != nil {
+		t.Fatalf("%s: %v", text, err)
+	}
+	sent := b.chat.sent[chatID]
+	if len(sent) == 0 {
+		t.Fatalf("%s: no reply", text)
+	}
+	return sent[len(sent)-1]
+}
+
+// link привязывает чат к сотруднику через код из веб-интерфейса
+func (b *testBot) link(t *testing.T, chatID int64, userID string) {
+	t.Helper()
+	code, err := b.LinkCode(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("LinkCode: %v", err)
+	}
+	// код принимается в любом регистре
+	if reply := b.send(t, chatID, "/link "+strings.ToLower(code.Code)); !strings.HasPrefix(reply, "Чат привязан") {
+		t.Fatalf("/link: reply = %q", reply)
+	}
+}
+
+func TestLink(t *testing.T) {
+	b := newTestBot(t)
+	ctx := context.Background()
+
+	if reply := b.send(t, chatID, "/hours 8"); !strings.HasPrefix(reply, "Чат не привязан") {
+		t.Fatalf("unlinked /hours: reply = %q", reply)
+	}
+
+	b.link(t, chatID, userID)
+	link, err := b.store.GetChatLinkByChat(ctx, chatID)
+	if err != nil || link.UserID != userID {
+		t.Fatalf("chat link = %+v, %v", link, err)
+	}
+
+	// код одноразовый
+	code, err := b.LinkCode(ctx, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.send(t, chatID+1, "/start "+code.Code)
+	if reply := b.send(t, chatID+2, "/link "+code.Code); !strings.HasPrefix(reply, "Код не найден") {
+		t.Fatalf("reused code: reply = %q", reply)
+	}
+
+	if reply := b.send(t, chatID, "/unlink"); reply != "Чат отвязан от учетной записи." {
+		t.Fatalf("/unlink: reply = %q", reply)
+	}
+	if reply := b.send(t, chatID, "/vacation 2026-07-01 2026-07-14"); !strings.HasPrefix(reply, "Чат не привязан") {
+		t.Fatalf("/vacation after /unlink: reply = %q", reply)
+	}
+}
+
+func TestLinkExpired(t *testing.T) {
+	b := newTestBot(t)
+
+	if err := b.store.CreateChatLinkCode(context.Background(), repo.CreateChatLinkCodeParams{
+		Code: "EXPIRED1", UserID: userID, ExpireAt: time.Now().Add(-time.Minute),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if reply := b.send(t, chatID, "/link EXPIRED1"); !strings.HasPrefix(reply, "Код не найден или устарел") {
+		t.Fatalf("expired code: reply = %q", reply)
+	}
+	if _, err := b.store.GetChatLinkByChat(context.Background(), chatID); err == nil {
+		t.Fatal("chat linked with an expired code")
+	}
+}
+
+func TestHours(t *testing.T) {
+	b := newTestBot(t)
+	b.link(t, chatID, userID)
+
+	for _, tt := range []struct {
+		command, reply string
+	}{
+		{"/hours 8 2025-05-05", "Отмечено 8 ч (Явка) за 05.05.2025."},
+		{"/hours@TimeTrackBot 2,5 remote 2025-05-06", "Отмечено 2.5 ч (Удаленная работа) за 06.05.2025."},
+		{"/hours 4 work 2025-05-05", "За этот день уже есть отметка такого типа."},
+		{"/hours 20 remote 2025-05-05", "За этот день уже отмечено слишком много часов (больше 24)."},
+		{"/hours 8 overtime 2025-05-07", "Неизвестный тип отметки \"overtime\"."},
+		{"/hours 25", "Количество часов должно быть числом от 0 до 24."},
+		{"/hours", "Формат: /hours 8 [тип] [ГГГГ-ММ-ДД]"},
+	} {
+		if reply := b.send(t, chatID, tt.command); reply != tt.reply {
+			t.Errorf("%s: reply = %q, want %q", tt.command, reply, tt.reply)
+		}
+	}
+
+	reports, err := b.store.GetReportUserForMonth(context.Background(), repo.GetReportUserForMonthParams{UserID: userID, Month: 5, Year: 2025})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("reports = %+v, want two entries", reports)
+	}
+}
+
+func TestVacation(t *testing.T) {
+	b := newTestBot(t)
+	b.link(t, chatID, userID)
+
+	for _, tt := range []struct {
+		command, reply string
+	}{
+		{"/vacation 2026-07-01", "Формат: /vacation 2026-07-01 2026-07-14 [комментарий]"},
+		{"/vacation 01.07.2026 2026-07-14", "Дата начала должна быть в формате ГГГГ-ММ-ДД."},
+		{"/vacation 2026-07-14 2026-07-01", "Дата окончания раньше даты начала."},
+		{"/vacation 2026-07-01 2026-07-14 " + strings.Repeat("море ", 21), "Комментарий должен быть не длиннее 100 символов."},
+		{"/vacation 2026-07-01 2026-07-14 на море", "Заявка на отпуск с 01.07.2026 по 14.07.2026 отправлена на рассмотрение."},
+	} {
+		if reply := b.send(t, chatID, tt.command); reply != tt.reply {
+			t.Errorf("%s: reply = %q, want %q", tt.command, reply, tt.reply)
+		}
+	}
+
+	vacations, err := b.store.GetVacations(context.Background(), userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vacations) != 1 {
+		t.Fatalf("vacations = %+v", vacations)
+	}
+	v := vacations[0]
+	// даты - календарные дни в часовом поясе сервера, как и в /hours
+	if !v.StartDate.Equal(time.Date(2026, time.July, 1, 0, 0, 0, 0, time.Local)) || v.StartDate.Location() != time.Local {
+		t.Fatalf("start = %v, want local midnight", v.StartDate)
+	}
+	if v.Status != repo.ReportVacationStatusConsideration || v.Description != "на море" || v.Year != 2026 {
+		t.Fatalf("vacation = %+v", v)
+	}
+}
+
+// TestRemind - напоминание уходит только в чаты с незаполненным сегодняшним днем;
+// сбой проверки одного сотрудника не прерывает рассылку
+func TestRemind(t *testing.T) {
+	b := newTestBot(t)
+	today := int32(time.Now().Day())
+
+	for i, id := range []string{"u-missing", "u-filled", "u-failing", "u-weekend"} {
+		b.link(t, int64(i+1), id)
+	}
+	b.reports.missing["u-missing"] = []int32{today}
+	b.reports.missing["u-filled"] = []int32{}
+	b.reports.failing["u-failing"] = true
+	b.reports.missing["u-weekend"] = []int32{today}
+	// ответы на /link не считаются
+	clear(b.chat.sent)
+
+	result, err := b.Remind(context.Background())
+	if err != nil {
+		t.Fatalf("Remind: %v", err)
+	}
+	if result.Sent != 2 || result.Failed != 1 || result.Date != time.Now().Format(time.DateOnly) {
+		t.Fatalf("result = %+v", result)
+	}
+	if len(b.chat.sent[1]) != 1 || len(b.chat.sent[4]) != 1 || len(b.chat.sent[2]) != 0 || len(b.chat.sent[3]) != 0 {
+		t.Fatalf("sent = %v", b.chat.sent)
+	}
+	if !strings.HasPrefix(b.chat.sent[1][0], "Сегодня еще нет отметки") {
+		t.Fatalf("reminder = %q", b.chat.sent[1][0])
+	}
+
+	b.chat.err = errors.New("chat is unavailable")
+	result, err = b.Remind(context.Background())
+	if err != nil {
+		t.Fatalf("Remind: %v", err)
+	}
+	if result.Sent != 0 || result.Failed != 3 {
+		t.Fatalf("result with failing chat = %+v", result)
+	}
+}
+
+// TestAuthorize - без Telegram и без секрета вебхук не принимает запросы
+func TestAuthorize(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	if NewChat(TelegramConfig{}, logger).Authorize("") {
+		t.Fatal("chat without telegram accepted a request")
+	}
+	if NewChat(TelegramConfig{Token: "123:token"}, logger).Authorize("") {
+		t.Fatal("telegram chat without a secret accepted a request")
+	}
+	c := NewChat(TelegramConfig{Token: "123:token", Secret: "secret"}, logger)
+	if c.Authorize("") || c.Authorize("wrong") || !c.Authorize("secret") {
+		t.Fatal("telegram chat checks the secret incorrectly")
+	}
+}
@@ -2,10 +2,14 @@ package calendar
 
 import (
 	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/export"
+	"TimeTrack/internal/validate"
 	"database/sql"
-	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -26,21 +30,21 @@ func NewHandler(service Service, logger *slog.Logger) *Handler {
 func (h *Handler) ListMonth(c *fiber.Ctx) error {
 	year, err := c.ParamsInt("year")
 	if err != nil || year < 1900 || year > 2100 {
-		return h.respondError(c, http.StatusBadRequest, "invalid year parameter")
+		return apperr.InvalidParam("year")
 	}
 
 	month, err := c.ParamsInt("month")
 	if err != nil || month < 1 || month > 12 {
-		return h.respondError(c, http.StatusBadRequest, "invalid month parameter")
+		return apperr.InvalidParam("month")
 	}
 
-	calendars, err := h.service.ListMonth(c.Context(), repo.GetCalendarDaysParams{
+	calendars, err := h.service.ListMonth(c.UserContext(), repo.GetCalendarDaysParams{
 		Month: int32(month),
 		Year:  int32(year),
 	})
 
 	if err != nil {
-		return h.respondError(c, http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	return c.JSON(calendars)
@@ -49,55 +53,34 @@ func (h *Handler) ListMonth(c *fiber.Ctx) error {
 func (h *Handler) ListYear(c *fiber.Ctx) error {
 	year, err := c.ParamsInt("year")
 	if err != nil || year < 1900 || year > 2100 {
-		return h.respondError(c, http.StatusBadRequest, "invalid year parameter")
+		return apperr.InvalidParam("year")
 	}
 
-	calendars, err := h.service.ListYear(c.Context(), int32(year))
+	calendars, err := h.service.ListYear(c.UserContext(), int32(year))
 
 	if err != nil {
-		return h.respondError(c, http.StatusBadRequest, err.Error())
+		return err
 	}
 
-	return c.JSON(calendars)
+	return export.Respond(c, h.logger, fmt.Sprintf("calendar-%d", year), calendars)
 }
 
 type createRequest struct {
-	Day            int32          `json:"day"`
-	Month          int32          `json:"month"`
-	Year           int32          `json:"year"`
+	Day            int32          `json:"day" validate:"required,min=1,max=31,date=Month Year"`
+	Month          int32          `json:"month" validate:"required,min=1,max=12"`
+	Year           int32          `json:"year" validate:"required,min=1900,max=2100"`
 	Description    sql.NullString `json:"description"`
 	IsPaidVacation bool           `json:"isPaidVacation"`
-	TypeID         string         `json:"typeId"`
-}
-
-func (r *createRequest) validate() error {
-	if r.Day < 1 || r.Day > 31 {
-		return errors.New("day must be between 1 and 31")
-	}
-	if r.Month < 1 || r.Month > 12 {
-		return errors.New("month must be between 1 and 12")
-	}
-	if r.Year < 1900 || r.Year > 2100 {
-		return errors.New("year must be between 1900 and 2100")
-	}
-	if r.TypeID == "" {
-		return errors.New("typeSystemName is required")
-	}
-	return nil
+	TypeID         string         `json:"typeId" validate:"required"`
 }
 
 func (h *Handler) Create(c *fiber.Ctx) error {
 	var req createRequest
-	if err := c.BodyParser(&req); err != nil {
-		h.logger.Warn("invalid request body", slog.String("error", err.Error()))
-		return h.respondError(c, http.StatusBadRequest, "invalid request body")
+	if err := validate.Body(c, &req); err != nil {
+		return err
 	}
 
-	if err := req.validate(); err != nil {
-		return h.respondError(c, http.StatusBadRequest, err.Error())
-	}
-
-	report, err := h.service.Create(c.Context(), repo.CreateCalendarDayParams{
+	report, err := h.service.Create(c.UserContext(), repo.CreateCalendarDayParams{
 		ID:             uuid.NewString(),
 		Day:            req.Day,
 		Month:          req.Month,
@@ -107,27 +90,97 @@ func (h *Handler) Create(c *fiber.Ctx) error {
 		TypeID:         req.TypeID,
 	})
 	if err != nil {
-		h.logger.Error("failed to create report",
-			slog.Int64("Day", int64(req.Day)),
-			slog.Int64("Month", int64(req.Month)),
-			slog.Int64("Year", int64(req.Year)),
-			slog.String("error", err.Error()),
-		)
-		return h.respondError(c, http.StatusInternalServerError, "failed to create report")
+		return apperr.Wrap(err, "failed to create report")
 	}
 
 	return c.Status(http.StatusCreated).JSON(report)
 }
 
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
+func (h *Handler) Day(c *fiber.Ctx) error {
+	date, err := time.Parse(dateLayout, c.Params("date"))
+	if err != nil {
+		return apperr.InvalidParam("date")
+	}
+
+	day, err := h.service.DayInfo(c.UserContext(), date)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get calendar day")
+	}
+
+	return c.JSON(day)
+}
+
+type expectedHoursResponse struct {
+	Date          string  `json:"date"`
+	ExpectedHours float64 `json:"expectedHours"`
+}
+
+func (h *Handler) ExpectedHours(c *fiber.Ctx) error {
+	date, err := time.Parse(dateLayout, c.Params("date"))
+	if err != nil {
+		return apperr.InvalidParam("date")
+	}
+
+	hours, err := h.service.ExpectedHours(c.UserContext(), date)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get expected hours")
+	}
+
+	return c.JSON(expectedHoursResponse{
+		Date:          date.Format(dateLayout),
+		ExpectedHours: hours,
+	})
+}
+
+func (h *Handler) WorkingDays(c *fiber.Ctx) error {
+	from, err := time.Parse(dateLayout, c.Params("from"))
+	if err != nil {
+		return apperr.InvalidParam("from")
+	}
+
+	to, err := time.Parse(dateLayout, c.Params("to"))
+	if err != nil {
+		return apperr.InvalidParam("to")
+	}
+
+	if to.Before(from) {
+		return apperr.InvalidField("to", "must not be before from")
+	}
+
+	workingDays, err := h.service.WorkingDays(c.UserContext(), from, to)
+	if err != nil {
+		return apperr.Wrap(err, "failed to count working days")
+	}
+
+	return c.JSON(workingDays)
+}
+
+// addWorkingDaysResponse - дата через days рабочих дней после date
+type addWorkingDaysResponse struct {
+	Date   string `json:"date"`
+	Days   int    `json:"days"`
+	Result string `json:"result"`
 }
 
-// respondError - вспомогательный метод для отправки ошибок
-func (h *Handler) respondError(c *fiber.Ctx, status int, message string) error {
-	return c.Status(status).JSON(ErrorResponse{
-		Error:   http.StatusText(status),
-		Message: message,
+func (h *Handler) AddWorkingDays(c *fiber.Ctx) error {
+	date, err := time.Parse(dateLayout, c.Params("date"))
+	if err != nil {
+		return apperr.InvalidParam("date")
+	}
+
+	days, err := c.ParamsInt("days")
+	if err != nil {
+		return apperr.InvalidParam("days")
+	}
+
+	result, err := h.service.AddWorkingDays(c.UserContext(), date, days)
+	if err != nil {
+		return apperr.Wrap(err, "failed to add working days")
+	}
+
+	return c.JSON(addWorkingDaysResponse{
+		Date:   date.Format(dateLayout),
+		Days:   days,
+		Result: result.Format(dateLayout),
 	})
 }
@@ -0,0 +1,27 @@
+package calendar
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты производственного календаря для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/v1/calendar/list/:month/:year", Summary: "Особые дни месяца",
+			Response: []repo.GetCalendarDaysRow{}},
+		{Method: http.MethodGet, Path: "/v1/calendar/list/:year", Summary: "Особые дни года",
+			Response: []repo.GetCalendarDaysAllRow{}, Export: true},
+		{Method: http.MethodPost, Admin: true, Path: "/v1/calendar/create", Summary: "Добавить праздник, перенос или сокращенный день",
+			Request: createRequest{}, Status: http.StatusCreated, Response: repo.GetCalendarDayRow{}},
+		{Method: http.MethodGet, Path: "/v1/calendar/day/:date", Summary: "Вид дня и норма часов",
+			Response: dayInfo{}},
+		{Method: http.MethodGet, Path: "/v1/calendar/hours/:date", Summary: "Норма часов на дату",
+			Response: expectedHoursResponse{}},
+		{Method: http.MethodGet, Path: "/v1/calendar/workdays/:from/:to", Summary: "Рабочие дни и часы за период",
+			Response: workingDaysRange{}},
+		{Method: http.MethodGet, Path: "/v1/calendar/add-workdays/:date/:days", Summary: "Дата через заданное число рабочих дней",
+			Response: addWorkingDaysResponse{}},
+	}
+}
@@ -2,8 +2,10 @@ package calendar
 
 import (
 	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/tracing"
+	"TimeTrack/internal/webhook"
 	"context"
-	"database/sql"
+	"time"
 )
 
 type Service interface {
@@ -11,18 +13,27 @@ type Service interface {
 	ListYear(ctx context.Context, year int32) (*[]repo.GetCalendarDaysAllRow, error)
 	Create(ctx context.Context, prm repo.CreateCalendarDayParams) (*repo.GetCalendarDayRow, error)
 	Delete(ctx context.Context, id string) error
+	DayInfo(ctx context.Context, date time.Time) (*dayInfo, error)
+	MonthDays(ctx context.Context, month, year int32) (*[]dayInfo, error)
+	ExpectedHours(ctx context.Context, date time.Time) (float64, error)
+	WorkingDays(ctx context.Context, from, to time.Time) (*workingDaysRange, error)
+	AddWorkingDays(ctx context.Context, date time.Time, days int) (time.Time, error)
 }
 
 type service struct {
-	repo repo.Querier
-	db   *sql.DB
+	repo   repo.Querier
+	db     repo.TxBeginner
+	events webhook.Publisher
 }
 
-func NewService(repo repo.Querier, db *sql.DB) Service {
-	return &service{repo: repo, db: db}
+func NewService(repo repo.Querier, db repo.TxBeginner, events webhook.Publisher) Service {
+	return &service{repo: repo, db: db, events: events}
 }
 
-func (s *service) ListMonth(ctx context.Context, prm repo.GetCalendarDaysParams) (*[]repo.GetCalendarDaysRow, error) {
+func (s *service) ListMonth(ctx context.Context, prm repo.GetCalendarDaysParams) (_ *[]repo.GetCalendarDaysRow, err error) {
+	ctx, end := tracing.Start(ctx, "calendar.ListMonth", tracing.Month(prm.Month), tracing.Year(prm.Year))
+	defer end(&err)
+
 	calendar, err := s.repo.GetCalendarDays(ctx, prm)
 	if err != nil {
 		return nil, err
@@ -31,7 +42,10 @@ func (s *service) ListMonth(ctx context.Context, prm repo.GetCalendarDaysParams)
 	return &calendar, nil
 }
 
-func (s *service) ListYear(ctx context.Context, year int32) (*[]repo.GetCalendarDaysAllRow, error) {
+func (s *service) ListYear(ctx context.Context, year int32) (_ *[]repo.GetCalendarDaysAllRow, err error) {
+	ctx, end := tracing.Start(ctx, "calendar.ListYear", tracing.Year(year))
+	defer end(&err)
+
 	calendar, err := s.repo.GetCalendarDaysAll(ctx, year)
 	if err != nil {
 		return nil, err
@@ -40,8 +54,11 @@ func (s *service) ListYear(ctx context.Context, year int32) (*[]repo.GetCalendar
 	return &calendar, nil
 }
 
-func (s *service) Create(ctx context.Context, prm repo.CreateCalendarDayParams) (*repo.GetCalendarDayRow, error) {
-	err := s.repo.CreateCalendarDay(ctx, prm)
+func (s *service) Create(ctx context.Context, prm repo.CreateCalendarDayParams) (_ *repo.GetCalendarDayRow, err error) {
+	ctx, end := tracing.Start(ctx, "calendar.Create", tracing.Day(prm.Day), tracing.Month(prm.Month), tracing.Year(prm.Year))
+	defer end(&err)
+
+	err = s.repo.CreateCalendarDay(ctx, prm)
 	if err != nil {
 		return nil, err
 	}
@@ -51,12 +68,18 @@ func (s *service) Create(ctx context.Context, prm repo.CreateCalendarDayParams)
 		return nil, err
 	}
 
+	s.events.Publish(ctx, webhook.EventCalendarCreated, calendar)
+
 	return &calendar, nil
 }
 
-func (s *service) Delete(ctx context.Context, id string) error {
+func (s *service) Delete(ctx context.Context, id string) (err error) {
+	ctx, end := tracing.Start(ctx, "calendar.Delete")
+	defer end(&err)
+
 	if err := s.repo.DeleteCalendarDay(ctx, id); err != nil {
 		return err
 	}
+	s.events.Publish(ctx, webhook.EventCalendarDeleted, map[string]string{"id": id})
 	return nil
 }
@@ -0,0 +1,208 @@
+package calendar
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// events запоминает опубликованные события вместо отправки вебхуков
+type events []string
+
+func (e *events) Publish(ctx context.Context, event string, data any) {
+	*e = append(*e, event)
+}
+
+// newTestService - сервис над хранилищем в памяти с типами дней календаря
+// и праздниками 1-2 мая 2025 и сокращенным 8 мая
+func newTestService(t *testing.T) (Service, *memory.Store, *events) {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.New()
+
+	for _, rt := range []repo.CreateTypeParams{
+		{ID: "t-holiday", Name: "Праздник", SystemName: "holiday", Code: "В"},
+		{ID: "t-shortened", Name: "Сокращенный", SystemName: "shortened", Code: "Я"},
+		{ID: "t-work", Name: "Рабочий", SystemName: "work", Code: "Я"},
+	} {
+		if err := store.CreateType(ctx, rt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	published := &events{}
+	svc := NewService(store, store, published)
+
+	for _, day := range []repo.CreateCalendarDayParams{
+		{ID: "c-0502", Day: 2, Month: 5, Year: 2025, TypeID: "t-holiday"},
+		{ID: "c-0501", Day: 1, Month: 5, Year: 2025, Description: sql.NullString{String: "Праздник Весны и Труда", Valid: true}, TypeID: "t-holiday"},
+		{ID: "c-0508", Day: 8, Month: 5, Year: 2025, TypeID: "t-shortened"},
+	} {
+		if _, err := svc.Create(ctx, day); err != nil {
+			t.Fatalf("Create %s: %v", day.ID, err)
+		}
+	}
+
+	return svc, store, published
+}
+
+func TestCreateJoinsType(t *testing.T) {
+	ctx := context.Background()
+	svc, _, published := newTestService(t)
+
+	day, err := svc.Create(ctx, repo.CreateCalendarDayParams{ID: "c-0612", Day: 12, Month: 6, Year: 2025, TypeID: "t-holiday"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if day.TypeSystemName != "holiday" || day.TypeName != "Праздник" {
+		t.Fatalf("type = %q/%q", day.TypeSystemName, day.TypeName)
+	}
+	if day.Description.Valid {
+		t.Fatalf("description = %+v, want NULL", day.Description)
+	}
+	if n := len(*published); n != 4 || (*published)[n-1] != "calendar.created" {
+		t.Fatalf("events = %v", *published)
+	}
+}
+
+func TestListMonth(t *testing.T) {
+	svc, _, _ := newTestService(t)
+
+	days, err := svc.ListMonth(context.Background(), repo.GetCalendarDaysParams{Month: 5, Year: 2025})
+	if err != nil {
+		t.Fatalf("ListMonth: %v", err)
+	}
+	if len(*days) != 3 {
+		t.Fatalf("len = %d, want 3", len(*days))
+	}
+
+	first := (*days)[0]
+	if first.Day != 1 || first.Description != "Праздник Весны и Труда" {
+		t.Fatalf("first = %+v", first)
+	}
+	if second := (*days)[1]; second.Day != 2 || second.Description != "" {
+		t.Fatalf("second = %+v, want empty description", second)
+	}
+}
+
+func TestMonthDays(t *testing.T) {
+	svc, _, _ := newTestService(t)
+
+	days, err := svc.MonthDays(context.Background(), 5, 2025)
+	if err != nil {
+		t.Fatalf("MonthDays: %v", err)
+	}
+	if len(*days) != 31 {
+		t.Fatalf("len = %d, want 31", len(*days))
+	}
+
+	cases := []struct {
+		day   int
+		kind  DayKind
+		hours float64
+	}{
+		{1, DayKindHoliday, 0},
+		{3, DayKindWeekend, 0},
+		{5, DayKindWork, StandardDayHours},
+		{8, DayKindShortened, ShortenedDayHours},
+	}
+	for _, c := range cases {
+		info := (*days)[c.day-1]
+		if info.Kind != c.kind || info.ExpectedHours != c.hours {
+			t.Errorf("May %d: kind = %s, hours = %v; want %s, %v", c.day, info.Kind, info.ExpectedHours, c.kind, c.hours)
+		}
+	}
+	if !(*days)[0].IsOverride || (*days)[0].Description != "Праздник Весны и Труда" {
+		t.Errorf("May 1 = %+v", (*days)[0])
+	}
+}
+
+func TestWorkingDays(t *testing.T) {
+	svc, _, _ := newTestService(t)
+
+	got, err := svc.WorkingDays(context.Background(),
+		time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("WorkingDays: %v", err)
+	}
+	if got.WorkingDays != 20 || got.ExpectedHours != 159 {
+		t.Fatalf("got %d days / %v hours, want 20 / 159", got.WorkingDays, got.ExpectedHours)
+	}
+
+	if _, err := svc.WorkingDays(context.Background(),
+		time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Fatal("reversed range: want error")
+	}
+
+	// диапазон длиннее maxRangeYears не перебирается
+	_, err = svc.WorkingDays(context.Background(),
+		time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, ErrRangeTooLong) || apperr.Status(err) != http.StatusBadRequest {
+		t.Fatalf("long range: err = %v", err)
+	}
+}
+
+func TestAddWorkingDays(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	ctx := context.Background()
+	from := time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC)
+
+	got, err := svc.AddWorkingDays(ctx, from, 1)
+	if err != nil {
+		t.Fatalf("AddWorkingDays: %v", err)
+	}
+	if want := time.Date(2025, 5, 5, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("got %s, want %s", got.Format(dateLayout), want.Format(dateLayout))
+	}
+
+	got, err = svc.AddWorkingDays(ctx, time.Date(2025, 5, 5, 0, 0, 0, 0, time.UTC), -1)
+	if err != nil {
+		t.Fatalf("AddWorkingDays: %v", err)
+	}
+	if !got.Equal(from) {
+		t.Fatalf("got %s, want %s", got.Format(dateLayout), from.Format(dateLayout))
+	}
+
+	// дальше maxWorkdaySearch дней - ошибка запроса, а не сбой сервера
+	_, err = svc.AddWorkingDays(ctx, from, maxWorkdaySearch+1)
+	if !errors.Is(err, ErrWorkdayNotFound) || apperr.Status(apperr.Wrap(err, "failed")) != http.StatusUnprocessableEntity {
+		t.Fatalf("too many days: err = %v", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	svc, store, published := newTestService(t)
+	ctx := context.Background()
+
+	if err := svc.Delete(ctx, "c-0501"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if (*published)[len(*published)-1] != "calendar.deleted" {
+		t.Fatalf("events = %v", *published)
+	}
+
+	info, err := svc.DayInfo(ctx, time.Date(2025, 5, 1, 15, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("DayInfo: %v", err)
+	}
+	if info.Kind != DayKindWork || info.IsOverride {
+		t.Fatalf("May 1 after delete = %+v", info)
+	}
+
+	days, err := store.GetCalendarDaysAll(ctx, 2025)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(days) != 2 {
+		t.Fatalf("len = %d, want 2", len(days))
+	}
+}
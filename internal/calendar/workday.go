@@ -0,0 +1,260 @@
+package calendar
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/tracing"
+	"context"
+	"fmt"
+	"time"
+)
+
+// DayKind - тип дня в производственном календаре
+type DayKind string
+
+const (
+	DayKindWork      DayKind = "work"
+	DayKindWeekend   DayKind = "weekend"
+	DayKindHoliday   DayKind = "holiday"
+	DayKindShortened DayKind = "shortened"
+)
+
+const (
+	// StandardDayHours - норма часов обычного рабочего дня
+	StandardDayHours = 8.0
+	// ShortenedDayHours - норма часов сокращённого (предпраздничного) дня
+	ShortenedDayHours = 7.0
+
+	// maxWorkdaySearch ограничивает перебор дней, чтобы не зациклиться на календаре без рабочих дней
+	maxWorkdaySearch = 366 * 10
+	// maxRangeYears ограничивает диапазон WorkingDays: каждый год - запрос к базе и перебор дней
+	maxRangeYears = 10
+)
+
+const dateLayout = "2006-01-02"
+
+var (
+	ErrWorkdayNotFound = apperr.Unprocessable("workday_not_found", "working day not found")
+	ErrRangeTooLong    = apperr.InvalidField("to", fmt.Sprintf("must be within %d years of from", maxRangeYears))
+)
+
+// IsWorking сообщает, является ли день рабочим
+func (k DayKind) IsWorking() bool {
+	return k == DayKindWork || k == DayKindShortened
+}
+
+// Hours возвращает норму часов для дня данного типа
+func (k DayKind) Hours() float64 {
+	switch k {
+	case DayKindWork:
+		return StandardDayHours
+	case DayKindShortened:
+		return ShortenedDayHours
+	default:
+		return 0
+	}
+}
+
+type dayInfo struct {
+	Date          string  `json:"date"`
+	Kind          DayKind `json:"kind"`
+	IsWorking     bool    `json:"isWorking"`
+	ExpectedHours float64 `json:"expectedHours"`
+	Description   string  `json:"description"`
+	IsOverride    bool    `json:"isOverride"`
+}
+
+type workingDaysRange struct {
+	From          string  `json:"from"`
+	To            string  `json:"to"`
+	WorkingDays   int32   `json:"workingDays"`
+	ExpectedHours float64 `json:"expectedHours"`
+}
+
+// workCalendar - пятидневка Пн–Пт с переопределениями из report_calendar.
+// Переопределения подгружаются по годам по мере необходимости.
+type workCalendar struct {
+	repo      repo.Querier
+	years     map[int]bool
+	overrides map[string]repo.GetCalendarDaysAllRow
+}
+
+func newWorkCalendar(r repo.Querier) *workCalendar {
+	return &workCalendar{
+		repo:      r,
+		years:     make(map[int]bool),
+		overrides: make(map[string]repo.GetCalendarDaysAllRow),
+	}
+}
+
+func (w *workCalendar) loadYear(ctx context.Context, year int) error {
+	if w.years[year] {
+		return nil
+	}
+
+	days, err := w.repo.GetCalendarDaysAll(ctx, int32(year))
+	if err != nil {
+		return fmt.Errorf("get calendar days for %d: %w", year, err)
+	}
+
+	for _, d := range days {
+		key := fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+		w.overrides[key] = d
+	}
+	w.years[year] = true
+
+	return nil
+}
+
+// day определяет тип дня и описание из календаря (если день переопределён)
+func (w *workCalendar) day(ctx context.Context, date time.Time) (DayKind, string, error) {
+	info, err := w.info(ctx, date)
+	if err != nil {
+		return "", "", err
+	}
+
+	return info.Kind, info.Description, nil
+}
+
+func (w *workCalendar) info(ctx context.Context, date time.Time) (*dayInfo, error) {
+	if err := w.loadYear(ctx, date.Year()); err != nil {
+		return nil, err
+	}
+
+	info := dayInfo{Date: date.Format(dateLayout), Kind: DayKindWork}
+
+	switch date.Weekday() {
+	case time.Saturday, time.Sunday:
+		info.Kind = DayKindWeekend
+	}
+
+	if override, exists := w.overrides[info.Date]; exists {
+		switch kind := DayKind(override.TypeSystemName); kind {
+		case DayKindWork, DayKindWeekend, DayKindHoliday, DayKindShortened:
+			info.Kind = kind
+			info.Description = override.Description
+			info.IsOverride = true
+		}
+	}
+
+	info.IsWorking = info.Kind.IsWorking()
+	info.ExpectedHours = info.Kind.Hours()
+
+	return &info, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (s *service) DayInfo(ctx context.Context, date time.Time) (_ *dayInfo, err error) {
+	ctx, end := tracing.Start(ctx, "calendar.DayInfo")
+	defer end(&err)
+
+	return newWorkCalendar(s.repo).info(ctx, truncateDay(date))
+}
+
+// MonthDays возвращает сведения о каждом дне месяца
+func (s *service) MonthDays(ctx context.Context, month, year int32) (_ *[]dayInfo, err error) {
+	ctx, end := tracing.Start(ctx, "calendar.MonthDays", tracing.Month(month), tracing.Year(year))
+	defer end(&err)
+
+	cal := newWorkCalendar(s.repo)
+
+	first := time.Date(int(year), time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	days := make([]dayInfo, 0, 31)
+
+	for d := first; d.Month() == first.Month(); d = d.AddDate(0, 0, 1) {
+		info, err := cal.info(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, *info)
+	}
+
+	return &days, nil
+}
+
+func (s *service) ExpectedHours(ctx context.Context, date time.Time) (_ float64, err error) {
+	ctx, end := tracing.Start(ctx, "calendar.ExpectedHours")
+	defer end(&err)
+
+	kind, _, err := newWorkCalendar(s.repo).day(ctx, truncateDay(date))
+	if err != nil {
+		return 0, err
+	}
+
+	return kind.Hours(), nil
+}
+
+// WorkingDays считает рабочие дни и норму часов в диапазоне [from, to] включительно
+func (s *service) WorkingDays(ctx context.Context, from, to time.Time) (_ *workingDaysRange, err error) {
+	ctx, end := tracing.Start(ctx, "calendar.WorkingDays")
+	defer end(&err)
+
+	from, to = truncateDay(from), truncateDay(to)
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid range: %s is before %s", to.Format(dateLayout), from.Format(dateLayout))
+	}
+	if to.After(from.AddDate(maxRangeYears, 0, 0)) {
+		return nil, ErrRangeTooLong
+	}
+
+	cal := newWorkCalendar(s.repo)
+
+	result := workingDaysRange{
+		From: from.Format(dateLayout),
+		To:   to.Format(dateLayout),
+	}
+
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		kind, _, err := cal.day(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+
+		if kind.IsWorking() {
+			result.WorkingDays++
+			result.ExpectedHours += kind.Hours()
+		}
+	}
+
+	return &result, nil
+}
+
+// AddWorkingDays прибавляет к дате n рабочих дней (при отрицательном n - отнимает).
+// Сама исходная дата не учитывается.
+func (s *service) AddWorkingDays(ctx context.Context, date time.Time, days int) (_ time.Time, err error) {
+	ctx, end := tracing.Start(ctx, "calendar.AddWorkingDays")
+	defer end(&err)
+
+	date = truncateDay(date)
+	if days == 0 {
+		return date, nil
+	}
+
+	step := 1
+	if days < 0 {
+		step, days = -1, -days
+	}
+
+	cal := newWorkCalendar(s.repo)
+
+	for i := 0; i < maxWorkdaySearch; i++ {
+		date = date.AddDate(0, 0, step)
+
+		kind, _, err := cal.day(ctx, date)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if kind.IsWorking() {
+			days--
+			if days == 0 {
+				return date, nil
+			}
+		}
+	}
+
+	return time.Time{}, ErrWorkdayNotFound
+}
@@ -0,0 +1,42 @@
+// Package closing - проверка закрытия месяца для расчета зарплаты (payroll.Close).
+// После закрытия не меняется ничего, что попадает в выгрузку: отметки табеля,
+// отпуска, распределение часов по проектам и смены.
+package closing
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrMonthClosed = apperr.Conflict("month_closed", "month is closed for payroll")
+
+// CheckMonthOpen возвращает ErrMonthClosed, если месяц закрыт для расчета зарплаты.
+// Изменения проверяют это внутри своей транзакции: q - транзакция.
+func CheckMonthOpen(ctx context.Context, q repo.Querier, month, year int32) error {
+	_, err := q.GetMonthClose(ctx, repo.GetMonthCloseParams{Month: month, Year: year})
+	switch {
+	case err == nil:
+		return ErrMonthClosed
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	default:
+		return fmt.Errorf("get month close: %w", err)
+	}
+}
+
+// CheckRangeOpen проверяет CheckMonthOpen каждый месяц периода from - to (например, отпуска)
+func CheckRangeOpen(ctx context.Context, q repo.Querier, from, to time.Time) error {
+	first := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for m := first; !m.After(last); m = m.AddDate(0, 1, 0) {
+		if err := CheckMonthOpen(ctx, q, int32(m.Month()), int32(m.Year())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
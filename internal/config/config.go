@@ -0,0 +1,246 @@
+// Package config - настройки сервиса.
+//
+// Значение каждой настройки берется из (по возрастанию приоритета): значения по
+// умолчанию, файла настроек (.env, формат KEY=VALUE), переменной окружения с тем
+// же именем и флага командной строки (-db-max-open-conns для DB_MAX_OPEN_CONNS;
+// секреты флагами не передаются). Ошибки собираются в один отчет, чтобы их можно
+// было исправить за один запуск.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// DefaultFile - файл настроек, если не задан -config или CONFIG_FILE.
+// Отсутствие файла по умолчанию не ошибка: все можно задать окружением.
+const DefaultFile = ".env"
+
+type Config struct {
+	Addr    string
+	TLS     TLSConfig
+	Prefork bool
+	// ShutdownTimeout - сколько ждать начатые запросы после SIGTERM, 0 - без ограничения
+	ShutdownTimeout time.Duration
+	LogLevel        slog.Level
+	// TracingEndpoint - коллектор OTLP/HTTP для spans, пусто - без трассировки
+	TracingEndpoint string
+	CORS            CORSConfig
+	DB              DBConfig
+	PdfFont         string
+	SMTP            SMTPConfig
+	Telegram        TelegramConfig
+	Webhook         WebhookConfig
+	LDAP            LDAPConfig
+	Auth            AuthConfig
+}
+
+// TLSConfig - сертификат HTTPS; Enabled=false - обычный HTTP (например, за обратным прокси)
+type TLSConfig struct {
+	Enabled  bool
+	CertFile string
+	KeyFile  string
+}
+
+// CORSConfig - источники, которым разрешены запросы из браузера; "*" - любые
+type CORSConfig struct {
+	AllowOrigins []string
+}
+
+// DBConfig - подключение к базе и пул соединений (0 - без ограничения)
+type DBConfig struct {
+	// Driver - mysql, postgres или sqlite
+	Driver string
+	DSN    string
+	// SqlitePath - файл базы SQLite, по умолчанию sqlite.DefaultPath
+	SqlitePath string
+	// ConnectTimeout - сколько ждать базу при запуске, прежде чем завершиться с ошибкой
+	ConnectTimeout  time.Duration
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// SMTPConfig - почтовые уведомления; без Addr письма только пишутся в лог
+type SMTPConfig struct {
+	Addr     string
+	Username string
+	Password string
+	From     string
+}
+
+// TelegramConfig - бот; без Token ответы бота только пишутся в лог
+type TelegramConfig struct {
+	Token  string
+	APIURL string
+	Secret string
+}
+
+// WebhookConfig - подписки на события; AllowPrivate разрешает адреса подписчиков
+// во внутренней сети (loopback, частные и link-local)
+type WebhookConfig struct {
+	AllowPrivate bool
+}
+
+// LDAPConfig - каталог сотрудников; SyncInterval 0 отключает синхронизацию по расписанию
+type LDAPConfig struct {
+	URL            string
+	BindDN         string
+	Password       string
+	BaseDN         string
+	Filter         string
+	IDAttr         string
+	LoginAttr      string
+	DepartmentAttr string
+	SyncInterval   time.Duration
+}
+
+// AuthConfig - вход через OIDC-провайдера и токены сессии (ключ Secret).
+// Required=false пропускает запросы без токена, пока клиенты переходят на SSO.
+type AuthConfig struct {
+	Secret     string
+	SessionTTL time.Duration
+	Required   bool
+	OIDC       OIDCConfig
+}
+
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	UserClaim    string
+	RoleClaim    string
+	AdminGroups  []string
+	FrontendURL  string
+}
+
+// Error - отчет обо всех ошибках настроек
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Load собирает и проверяет настройки. args - аргументы без имени программы:
+// флаги идут до подкоманды, остаток (подкоманда и ее флаги) возвращается вторым
+// значением. На -h печатает список настроек и возвращает flag.ErrHelp.
+func Load(args []string) (*Config, []string, error) {
+	flags := flag.NewFlagSet("timetrack", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	file := flags.String("config", "", "файл настроек KEY=VALUE (CONFIG_FILE, по умолчанию "+DefaultFile+")")
+
+	for _, s := range settings {
+		if !s.secret {
+			flags.Var(&flagValue{value: s.def, isBool: s.isBool}, s.flag(), s.key+": "+s.usage)
+		}
+	}
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			printUsage(os.Stderr, flags)
+			return nil, nil, err
+		}
+		return nil, nil, &Error{Problems: []string{err.Error() + " (список настроек: -h)"}}
+	}
+
+	fromFlags := make(map[string]string)
+	flags.Visit(func(f *flag.Flag) {
+		if v, ok := f.Value.(*flagValue); ok {
+			fromFlags[strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))] = v.value
+		}
+	})
+
+	path, explicit := *file, *file != ""
+	if !explicit {
+		path, explicit = os.LookupEnv("CONFIG_FILE")
+	}
+	if path == "" {
+		path = DefaultFile
+	}
+
+	var problems []string
+	fromFile, err := godotenv.Read(path)
+	if err != nil && (explicit || !errors.Is(err, fs.ErrNotExist)) {
+		problems = append(problems, fmt.Sprintf("config file %s: %v", path, err))
+	}
+
+	cfg := &Config{}
+	for _, s := range settings {
+		value, source := s.def, "default"
+		if v, ok := fromFile[s.key]; ok {
+			value, source = v, path
+		}
+		if v, ok := os.LookupEnv(s.key); ok {
+			value, source = v, "environment"
+		}
+		if v, ok := fromFlags[s.key]; ok {
+			value, source = v, "flag -"+s.flag()
+		}
+
+		if err := s.set(cfg, strings.TrimSpace(value)); err != nil {
+			if s.secret {
+				problems = append(problems, fmt.Sprintf("%s (%s): %v", s.key, source, err))
+			} else {
+				problems = append(problems, fmt.Sprintf("%s=%q (%s): %v", s.key, value, source, err))
+			}
+		}
+	}
+
+	problems = append(problems, cfg.validate(flags.NArg() == 0)...)
+	if len(problems) > 0 {
+		return nil, nil, &Error{Problems: problems}
+	}
+
+	return cfg, flags.Args(), nil
+}
+
+// flagValue - строковое значение флага; для логических настроек допускается -prefork без значения
+type flagValue struct {
+	value  string
+	isBool bool
+}
+
+func (f *flagValue) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.value
+}
+
+func (f *flagValue) Set(value string) error {
+	f.value = value
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	return f.isBool
+}
+
+func printUsage(w io.Writer, flags *flag.FlagSet) {
+	fmt.Fprintln(w, "Usage: timetrack [flags] [import|remind|sync-ldap [command flags]]")
+	fmt.Fprintln(w, "Каждую настройку можно задать в файле настроек, переменной окружения с тем же именем или флагом:")
+	flags.SetOutput(w)
+	flags.PrintDefaults()
+	flags.SetOutput(io.Discard)
+
+	fmt.Fprintln(w, "Секреты задаются только в файле или окружении:")
+	for _, s := range settings {
+		if s.secret {
+			fmt.Fprintf(w, "  %s\n    \t%s\n", s.key, s.usage)
+		}
+	}
+}
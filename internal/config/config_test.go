@@ -0,0 +1,163 @@
+package config
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setup - окружение без настроек сервиса, файл настроек во временном каталоге
+// и обычный HTTP, чтобы не нужны были файлы сертификата
+func setup(t *testing.T, file string) {
+	t.Helper()
+	for _, s := range settings {
+		if v, ok := os.LookupEnv(s.key); ok {
+			t.Setenv(s.key, v)
+			os.Unsetenv(s.key)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "test.env")
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("TLS_ENABLED", "false")
+}
+
+func problems(t *testing.T, err error) []string {
+	t.Helper()
+	var cfgErr *Error
+	if !errors.As(err, &cfgErr) {
+		t.Fatalf("err = %v, want *Error", err)
+	}
+	return cfgErr.Problems
+}
+
+func TestLoadDefaults(t *testing.T) {
+	setup(t, "DB_STRING=user@/db\n")
+
+	cfg, args, err := Load(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(args) != 0 {
+		t.Fatalf("args = %v", args)
+	}
+	if cfg.Addr != ":8080" || !cfg.Prefork || cfg.LogLevel != slog.LevelInfo {
+		t.Fatalf("addr %q, prefork %v, log level %v", cfg.Addr, cfg.Prefork, cfg.LogLevel)
+	}
+	if cfg.DB.Driver != "mysql" || cfg.DB.MaxOpenConns != 10 || cfg.DB.MaxIdleConns != 5 || cfg.DB.ConnMaxLifetime != 5*time.Minute {
+		t.Fatalf("db = %+v", cfg.DB)
+	}
+	if len(cfg.CORS.AllowOrigins) != 1 || cfg.CORS.AllowOrigins[0] != "*" {
+		t.Fatalf("cors = %v", cfg.CORS.AllowOrigins)
+	}
+	if cfg.ShutdownTimeout != 30*time.Second || cfg.DB.ConnectTimeout != 5*time.Second {
+		t.Fatalf("shutdown timeout %v, connect timeout %v", cfg.ShutdownTimeout, cfg.DB.ConnectTimeout)
+	}
+}
+
+// TestLoadPrecedence - флаг важнее окружения, окружение важнее файла
+func TestLoadPrecedence(t *testing.T) {
+	setup(t, "DB_STRING=user@/db\nADDR=file:1\nLOG_LEVEL=debug\nDB_MAX_OPEN_CONNS=30\nCORS_ALLOW_ORIGINS=https://a.example.com, https://b.example.com\n")
+	t.Setenv("ADDR", "env:2")
+	t.Setenv("DB_MAX_OPEN_CONNS", "40")
+
+	cfg, args, err := Load([]string{"-db-max-open-conns", "50", "-prefork=false", "import", "-file", "x.csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Addr != "env:2" {
+		t.Fatalf("addr = %q, want value from environment", cfg.Addr)
+	}
+	if cfg.DB.MaxOpenConns != 50 {
+		t.Fatalf("max open conns = %d, want value from flag", cfg.DB.MaxOpenConns)
+	}
+	if cfg.LogLevel != slog.LevelDebug || cfg.Prefork {
+		t.Fatalf("log level %v, prefork %v", cfg.LogLevel, cfg.Prefork)
+	}
+	if len(cfg.CORS.AllowOrigins) != 2 {
+		t.Fatalf("cors = %v", cfg.CORS.AllowOrigins)
+	}
+	if strings.Join(args, " ") != "import -file x.csv" {
+		t.Fatalf("args = %v", args)
+	}
+}
+
+// TestLoadReport - все ошибки сразу, секреты в отчет не попадают
+func TestLoadReport(t *testing.T) {
+	setup(t, "DB_DRIVER=oracle\nSECRET_KEY=\n")
+	t.Setenv("DB_MAX_OPEN_CONNS", "many")
+	t.Setenv("LOG_LEVEL", "loud")
+	t.Setenv("CORS_ALLOW_ORIGINS", "timetrack.example.com")
+	t.Setenv("AUTH_REQUIRED", "true")
+	t.Setenv("SMTP_PASSWORD", "hunter2")
+	t.Setenv("TELEGRAM_TOKEN", "123:token")
+
+	_, _, err := Load(nil)
+	got := problems(t, err)
+
+	for _, want := range []string{"DB_DRIVER", "DB_MAX_OPEN_CONNS=\"many\" (environment)", "LOG_LEVEL", "CORS_ALLOW_ORIGINS", "SECRET_KEY", "TELEGRAM_SECRET"} {
+		found := false
+		for _, p := range got {
+			found = found || strings.Contains(p, want)
+		}
+		if !found {
+			t.Errorf("report has no %q:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "hunter2") || strings.Contains(err.Error(), "123:token") {
+		t.Errorf("report contains a secret:\n%v", err)
+	}
+}
+
+// TestLoadTLS - файлы сертификата проверяются только для сервера
+func TestLoadTLS(t *testing.T) {
+	setup(t, "DB_STRING=user@/db\n")
+	dir := t.TempDir()
+	t.Setenv("TLS_ENABLED", "true")
+	t.Setenv("TLS_CERT_FILE", filepath.Join(dir, "cert.pem"))
+	t.Setenv("TLS_KEY_FILE", filepath.Join(dir, "key.pem"))
+
+	_, _, err := Load(nil)
+	if got := problems(t, err); len(got) != 2 {
+		t.Fatalf("problems = %v, want missing certificate and key", got)
+	}
+
+	if _, _, err := Load([]string{"sync-ldap"}); err != nil {
+		t.Fatalf("subcommand: %v", err)
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	setup(t, "")
+	t.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.env"))
+	t.Setenv("DB_DRIVER", "sqlite")
+
+	_, _, err := Load(nil)
+	if got := problems(t, err); len(got) != 1 || !strings.Contains(got[0], "missing.env") {
+		t.Fatalf("problems = %v, want missing file", got)
+	}
+
+	// файл по умолчанию необязателен
+	os.Unsetenv("CONFIG_FILE")
+	t.Chdir(t.TempDir())
+	if _, _, err := Load(nil); err != nil {
+		t.Fatalf("without %s: %v", DefaultFile, err)
+	}
+}
+
+func TestLoadUnknownFlag(t *testing.T) {
+	setup(t, "")
+
+	_, _, err := Load([]string{"-db-password", "x"})
+	if got := problems(t, err); len(got) != 1 || !strings.Contains(got[0], "db-password") {
+		t.Fatalf("problems = %v", got)
+	}
+}
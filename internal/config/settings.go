@@ -0,0 +1,229 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// setting - одна настройка: имя переменной (и ключ в файле), значение по умолчанию
+// и разбор значения в поле Config
+type setting struct {
+	key   string
+	def   string
+	usage string
+	// secret - не принимается флагом, значение не выводится в отчете об ошибках
+	secret bool
+	isBool bool
+	set    func(c *Config, value string) error
+}
+
+// flag - имя флага: DB_MAX_OPEN_CONNS -> db-max-open-conns
+func (s setting) flag() string {
+	return strings.ToLower(strings.ReplaceAll(s.key, "_", "-"))
+}
+
+var settings = []setting{
+	text("ADDR", ":8080", "адрес HTTP-сервера", func(c *Config) *string { return &c.Addr }),
+	boolean("TLS_ENABLED", "true", "HTTPS; false - обычный HTTP", func(c *Config) *bool { return &c.TLS.Enabled }),
+	text("TLS_CERT_FILE", "cert.pem", "сертификат HTTPS", func(c *Config) *string { return &c.TLS.CertFile }),
+	text("TLS_KEY_FILE", "key.pem", "закрытый ключ HTTPS", func(c *Config) *string { return &c.TLS.KeyFile }),
+	boolean("PREFORK", "true", "несколько процессов на одном порту (Fiber Prefork)", func(c *Config) *bool { return &c.Prefork }),
+	duration("SHUTDOWN_TIMEOUT", "30s", "сколько ждать завершения начатых запросов при остановке; 0 - без ограничения", func(c *Config) *time.Duration { return &c.ShutdownTimeout }),
+	{key: "LOG_LEVEL", def: "info", usage: "уровень журнала: debug, info, warn или error", set: func(c *Config, value string) error {
+		if err := c.LogLevel.UnmarshalText([]byte(value)); err != nil {
+			return errors.New("want debug, info, warn or error")
+		}
+		return nil
+	}},
+	list("CORS_ALLOW_ORIGINS", "*", "источники для CORS через запятую, например https://timetrack.example.com; * - любые", func(c *Config) *[]string { return &c.CORS.AllowOrigins }),
+
+	text("DB_DRIVER", "mysql", "СУБД: mysql, postgres или sqlite", func(c *Config) *string { return &c.DB.Driver }),
+	secret(text("DB_STRING", "", "строка подключения MySQL или PostgreSQL", func(c *Config) *string { return &c.DB.DSN })),
+	text("SQLITE_PATH", "", "файл базы SQLite (по умолчанию time_track.db)", func(c *Config) *string { return &c.DB.SqlitePath }),
+	duration("DB_CONNECT_TIMEOUT", "5s", "сколько ждать базу при запуске, прежде чем завершиться с ошибкой", func(c *Config) *time.Duration { return &c.DB.ConnectTimeout }),
+	integer("DB_MAX_OPEN_CONNS", "10", "соединений с базой на процесс, 0 - без ограничения", func(c *Config) *int { return &c.DB.MaxOpenConns }),
+	integer("DB_MAX_IDLE_CONNS", "5", "простаивающих соединений в пуле", func(c *Config) *int { return &c.DB.MaxIdleConns }),
+	duration("DB_CONN_MAX_LIFETIME", "5m", "время жизни соединения, 0 - без ограничения", func(c *Config) *time.Duration { return &c.DB.ConnMaxLifetime }),
+	duration("DB_CONN_MAX_IDLE_TIME", "1m", "время простоя соединения до закрытия, 0 - без ограничения", func(c *Config) *time.Duration { return &c.DB.ConnMaxIdleTime }),
+
+	text("TRACING_ENDPOINT", "", "коллектор OpenTelemetry (OTLP/HTTP), например http://localhost:4318; пусто - трассировка отключена", func(c *Config) *string { return &c.TracingEndpoint }),
+
+	text("PDF_FONT", "", "TTF-шрифт с кириллицей для печатных документов; пусто - встроенный Go Regular", func(c *Config) *string { return &c.PdfFont }),
+
+	text("SMTP_ADDR", "", "SMTP-сервер для уведомлений; пусто - письма только пишутся в лог", func(c *Config) *string { return &c.SMTP.Addr }),
+	text("SMTP_USER", "", "пользователь SMTP", func(c *Config) *string { return &c.SMTP.Username }),
+	secret(text("SMTP_PASSWORD", "", "пароль SMTP", func(c *Config) *string { return &c.SMTP.Password })),
+	text("SMTP_FROM", "", "адрес отправителя", func(c *Config) *string { return &c.SMTP.From }),
+
+	secret(text("TELEGRAM_TOKEN", "", "токен Telegram-бота; пусто - ответы бота только пишутся в лог", func(c *Config) *string { return &c.Telegram.Token })),
+	text("TELEGRAM_API_URL", "", "адрес Bot API (для локальной заглушки)", func(c *Config) *string { return &c.Telegram.APIURL }),
+	secret(text("TELEGRAM_SECRET", "", "секрет вебхука бота; обязателен вместе с TELEGRAM_TOKEN", func(c *Config) *string { return &c.Telegram.Secret })),
+
+	boolean("WEBHOOK_ALLOW_PRIVATE", "false", "разрешить подписки на loopback, частные и link-local адреса (подписчики во внутренней сети)", func(c *Config) *bool { return &c.Webhook.AllowPrivate }),
+
+	text("LDAP_URL", "", "сервер LDAP/AD; пусто - синхронизация отключена", func(c *Config) *string { return &c.LDAP.URL }),
+	text("LDAP_BIND_DN", "", "учетная запись для чтения каталога", func(c *Config) *string { return &c.LDAP.BindDN }),
+	secret(text("LDAP_PASSWORD", "", "пароль LDAP", func(c *Config) *string { return &c.LDAP.Password })),
+	text("LDAP_BASE_DN", "", "ветка каталога с сотрудниками", func(c *Config) *string { return &c.LDAP.BaseDN }),
+	text("LDAP_FILTER", "", "фильтр сотрудников", func(c *Config) *string { return &c.LDAP.Filter }),
+	text("LDAP_ID_ATTR", "", "атрибут идентификатора", func(c *Config) *string { return &c.LDAP.IDAttr }),
+	text("LDAP_LOGIN_ATTR", "", "атрибут логина", func(c *Config) *string { return &c.LDAP.LoginAttr }),
+	text("LDAP_DEPARTMENT_ATTR", "", "атрибут отдела", func(c *Config) *string { return &c.LDAP.DepartmentAttr }),
+	duration("LDAP_SYNC_INTERVAL", "0", "период синхронизации, например 1h; 0 - только вручную", func(c *Config) *time.Duration { return &c.LDAP.SyncInterval }),
+
+	secret(text("SECRET_KEY", "", "ключ подписи токенов сессии", func(c *Config) *string { return &c.Auth.Secret })),
+	duration("SESSION_TTL", "0", "срок действия сессии; 0 - по умолчанию (12h)", func(c *Config) *time.Duration { return &c.Auth.SessionTTL }),
+	boolean("AUTH_REQUIRED", "false", "отклонять запросы без токена сессии", func(c *Config) *bool { return &c.Auth.Required }),
+	text("OIDC_ISSUER", "", "провайдер OpenID Connect", func(c *Config) *string { return &c.Auth.OIDC.Issuer }),
+	text("OIDC_CLIENT_ID", "", "клиент OIDC", func(c *Config) *string { return &c.Auth.OIDC.ClientID }),
+	secret(text("OIDC_CLIENT_SECRET", "", "секрет клиента OIDC", func(c *Config) *string { return &c.Auth.OIDC.ClientSecret })),
+	text("OIDC_REDIRECT_URL", "", "адрес возврата после входа", func(c *Config) *string { return &c.Auth.OIDC.RedirectURL }),
+	list("OIDC_SCOPES", "", "области доступа через запятую или пробел", func(c *Config) *[]string { return &c.Auth.OIDC.Scopes }),
+	text("OIDC_USER_CLAIM", "", "claim с идентификатором сотрудника", func(c *Config) *string { return &c.Auth.OIDC.UserClaim }),
+	text("OIDC_ROLE_CLAIM", "", "claim с группами", func(c *Config) *string { return &c.Auth.OIDC.RoleClaim }),
+	list("OIDC_ADMIN_GROUPS", "", "группы с ролью admin", func(c *Config) *[]string { return &c.Auth.OIDC.AdminGroups }),
+	text("OIDC_FRONTEND_URL", "", "страница фронтенда, которой передается токен", func(c *Config) *string { return &c.Auth.OIDC.FrontendURL }),
+}
+
+func text(key, def, usage string, field func(c *Config) *string) setting {
+	return setting{key: key, def: def, usage: usage, set: func(c *Config, value string) error {
+		*field(c) = value
+		return nil
+	}}
+}
+
+func boolean(key, def, usage string, field func(c *Config) *bool) setting {
+	return setting{key: key, def: def, usage: usage, isBool: true, set: func(c *Config, value string) error {
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("want true or false")
+		}
+		*field(c) = v
+		return nil
+	}}
+}
+
+func integer(key, def, usage string, field func(c *Config) *int) setting {
+	return setting{key: key, def: def, usage: usage, set: func(c *Config, value string) error {
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("want an integer")
+		}
+		if v < 0 {
+			return errors.New("must not be negative")
+		}
+		*field(c) = v
+		return nil
+	}}
+}
+
+func duration(key, def, usage string, field func(c *Config) *time.Duration) setting {
+	return setting{key: key, def: def, usage: usage, set: func(c *Config, value string) error {
+		if value == "" {
+			*field(c) = 0
+			return nil
+		}
+		v, err := time.ParseDuration(value)
+		if err != nil {
+			return errors.New("want a duration such as 30s, 5m or 1h")
+		}
+		if v < 0 {
+			return errors.New("must not be negative")
+		}
+		*field(c) = v
+		return nil
+	}}
+}
+
+// list - значения через запятую или пробел
+func list(key, def, usage string, field func(c *Config) *[]string) setting {
+	return setting{key: key, def: def, usage: usage, set: func(c *Config, value string) error {
+		*field(c) = strings.FieldsFunc(value, func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+		return nil
+	}}
+}
+
+func secret(s setting) setting {
+	s.secret = true
+	return s
+}
+
+// validate проверяет сочетания настроек после разбора. Файлы сертификата
+// нужны только серверу (server=true), подкоманды их не требуют.
+func (c *Config) validate(server bool) []string {
+	var problems []string
+	add := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if c.Addr == "" {
+		add("ADDR: must not be empty")
+	}
+
+	if server && c.TLS.Enabled {
+		for _, file := range []struct{ key, path string }{{"TLS_CERT_FILE", c.TLS.CertFile}, {"TLS_KEY_FILE", c.TLS.KeyFile}} {
+			if file.path == "" {
+				add("%s: must not be empty when TLS_ENABLED=true", file.key)
+			} else if _, err := os.Stat(file.path); err != nil {
+				add("%s: %v (set TLS_ENABLED=false for plain HTTP)", file.key, err)
+			}
+		}
+	}
+
+	for _, origin := range c.CORS.AllowOrigins {
+		if origin == "*" {
+			if len(c.CORS.AllowOrigins) > 1 {
+				add("CORS_ALLOW_ORIGINS: * cannot be combined with other origins")
+			}
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" || u.RawQuery != "" {
+			add("CORS_ALLOW_ORIGINS: %q is not an origin like https://timetrack.example.com", origin)
+		}
+	}
+
+	switch c.DB.Driver {
+	case "mysql", "postgres":
+		if c.DB.DSN == "" {
+			add("DB_STRING: must be set for DB_DRIVER=%s", c.DB.Driver)
+		}
+	case "sqlite":
+	default:
+		add("DB_DRIVER: %q is not one of mysql, postgres, sqlite", c.DB.Driver)
+	}
+
+	if c.DB.ConnectTimeout == 0 {
+		add("DB_CONNECT_TIMEOUT: must be greater than 0")
+	}
+
+	if c.DB.MaxOpenConns > 0 && c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		add("DB_MAX_IDLE_CONNS: %d is greater than DB_MAX_OPEN_CONNS=%d", c.DB.MaxIdleConns, c.DB.MaxOpenConns)
+	}
+
+	if c.LDAP.SyncInterval > 0 && c.LDAP.URL == "" {
+		add("LDAP_SYNC_INTERVAL: requires LDAP_URL")
+	}
+
+	if c.Telegram.Token != "" && c.Telegram.Secret == "" {
+		add("TELEGRAM_SECRET: must be set when TELEGRAM_TOKEN is set")
+	}
+
+	if c.Auth.Secret == "" {
+		if c.Auth.Required {
+			add("SECRET_KEY: must be set when AUTH_REQUIRED=true")
+		}
+		if c.Auth.OIDC.Issuer != "" {
+			add("SECRET_KEY: must be set when OIDC_ISSUER is set")
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,119 @@
+package department
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NewHandler(service Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) List(c *fiber.Ctx) error {
+	departments, err := h.service.List(c.UserContext())
+	if err != nil {
+		return apperr.Wrap(err, "failed to get departments")
+	}
+
+	return c.JSON(departments)
+}
+
+type createRequest struct {
+	Name string `json:"name"`
+}
+
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req createRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperr.InvalidBody(err)
+	}
+
+	if req.Name == "" {
+		return apperr.Required("name")
+	}
+
+	department, err := h.service.Create(c.UserContext(), repo.CreateDepartmentParams{
+		ID:   uuid.NewString(),
+		Name: req.Name,
+	})
+	if err != nil {
+		return apperr.Wrap(err, "failed to create department")
+	}
+
+	return c.Status(http.StatusCreated).JSON(department)
+}
+
+func (h *Handler) Users(c *fiber.Ctx) error {
+	departmentID := c.Params("department")
+	if departmentID == "" {
+		return apperr.Required("department")
+	}
+
+	users, err := h.service.Users(c.UserContext(), departmentID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get department users")
+	}
+
+	return c.JSON(users)
+}
+
+func (h *Handler) UserDepartment(c *fiber.Ctx) error {
+	userID := c.Params("user")
+	if userID == "" {
+		return apperr.Required("user")
+	}
+
+	department, err := h.service.UserDepartment(c.UserContext(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperr.NotFound("department_not_assigned", "user has no department")
+		}
+		return apperr.Wrap(err, "failed to get user department")
+	}
+
+	return c.JSON(department)
+}
+
+type assignRequest struct {
+	UserID       string `json:"userId"`
+	DepartmentID string `json:"departmentId"`
+}
+
+func (h *Handler) Assign(c *fiber.Ctx) error {
+	var req assignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperr.InvalidBody(err)
+	}
+
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		return apperr.InvalidField("userId", "must be a valid UUID")
+	}
+	if _, err := uuid.Parse(req.DepartmentID); err != nil {
+		return apperr.InvalidField("departmentId", "must be a valid UUID")
+	}
+
+	department, err := h.service.Assign(c.UserContext(), repo.CreateUserDepartmentParams(req))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperr.NotFound("department_not_found", "department not found")
+		}
+		return apperr.Wrap(err, "failed to assign department")
+	}
+
+	return c.JSON(department)
+}
@@ -0,0 +1,23 @@
+package department
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты отделов для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/v1/department/list", Summary: "Отделы",
+			Response: []repo.ReportDepartment{}},
+		{Method: http.MethodPost, Path: "/v1/department/create", Summary: "Создать отдел",
+			Request: createRequest{}, Status: http.StatusCreated, Response: repo.ReportDepartment{}},
+		{Method: http.MethodPost, Path: "/v1/department/assign", Summary: "Перевести сотрудника в отдел",
+			Request: assignRequest{}, Response: repo.GetUserDepartmentRow{}},
+		{Method: http.MethodGet, Path: "/v1/department/user/:user", Summary: "Отдел сотрудника",
+			Response: repo.GetUserDepartmentRow{}},
+		{Method: http.MethodGet, Path: "/v1/department/users/:department", Summary: "Сотрудники отдела",
+			Response: []string{}},
+	}
+}
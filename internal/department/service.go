@@ -0,0 +1,106 @@
+package department
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/tracing"
+	"context"
+	"fmt"
+)
+
+type Service interface {
+	List(ctx context.Context) (*[]repo.ReportDepartment, error)
+	Create(ctx context.Context, prm repo.CreateDepartmentParams) (*repo.ReportDepartment, error)
+	Users(ctx context.Context, departmentID string) (*[]string, error)
+	UserDepartment(ctx context.Context, userID string) (*repo.GetUserDepartmentRow, error)
+	Assign(ctx context.Context, prm repo.CreateUserDepartmentParams) (*repo.GetUserDepartmentRow, error)
+}
+
+type service struct {
+	repo repo.Querier
+	db   repo.TxBeginner
+}
+
+func NewService(repo repo.Querier, db repo.TxBeginner) Service {
+	return &service{repo: repo, db: db}
+}
+
+func (s *service) List(ctx context.Context) (_ *[]repo.ReportDepartment, err error) {
+	ctx, end := tracing.Start(ctx, "department.List")
+	defer end(&err)
+
+	departments, err := s.repo.GetDepartments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get departments: %w", err)
+	}
+
+	return &departments, nil
+}
+
+func (s *service) Create(ctx context.Context, prm repo.CreateDepartmentParams) (_ *repo.ReportDepartment, err error) {
+	ctx, end := tracing.Start(ctx, "department.Create")
+	defer end(&err)
+
+	if err := s.repo.CreateDepartment(ctx, prm); err != nil {
+		return nil, fmt.Errorf("create department: %w", err)
+	}
+
+	department, err := s.repo.GetDepartmentById(ctx, prm.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get department: %w", err)
+	}
+
+	return &department, nil
+}
+
+func (s *service) Users(ctx context.Context, departmentID string) (_ *[]string, err error) {
+	ctx, end := tracing.Start(ctx, "department.Users")
+	defer end(&err)
+
+	users, err := s.repo.GetDepartmentUsers(ctx, departmentID)
+	if err != nil {
+		return nil, fmt.Errorf("get department users: %w", err)
+	}
+
+	return &users, nil
+}
+
+func (s *service) UserDepartment(ctx context.Context, userID string) (_ *repo.GetUserDepartmentRow, err error) {
+	ctx, end := tracing.Start(ctx, "department.UserDepartment", tracing.User(userID))
+	defer end(&err)
+
+	department, err := s.repo.GetUserDepartment(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user department: %w", err)
+	}
+
+	return &department, nil
+}
+
+// Assign переводит сотрудника в отдел (сотрудник состоит только в одном отделе)
+func (s *service) Assign(ctx context.Context, prm repo.CreateUserDepartmentParams) (_ *repo.GetUserDepartmentRow, err error) {
+	ctx, end := tracing.Start(ctx, "department.Assign", tracing.User(prm.UserID))
+	defer end(&err)
+
+	if _, err := s.repo.GetDepartmentById(ctx, prm.DepartmentID); err != nil {
+		return nil, fmt.Errorf("get department: %w", err)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := tx.DeleteUserDepartment(ctx, prm.UserID); err != nil {
+		return nil, fmt.Errorf("delete user department: %w", err)
+	}
+	if err := tx.CreateUserDepartment(ctx, prm); err != nil {
+		return nil, fmt.Errorf("create user department: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return s.UserDepartment(ctx, prm.UserID)
+}
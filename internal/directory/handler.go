@@ -0,0 +1,43 @@
+package directory
+
+import (
+	"TimeTrack/internal/apperr"
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NewHandler(service Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Users(c *fiber.Ctx) error {
+	users, err := h.service.Users(c.UserContext())
+	if err != nil {
+		return apperr.Wrap(err, "failed to get directory users")
+	}
+
+	return c.JSON(users)
+}
+
+// Sync запускает синхронизацию вне расписания
+func (h *Handler) Sync(c *fiber.Ctx) error {
+	result, err := h.service.Sync(c.UserContext())
+	if err != nil {
+		if errors.Is(err, ErrNotConfigured) || errors.Is(err, ErrEmptyDirectory) {
+			return err
+		}
+		return apperr.Upstream("directory_unavailable", "failed to sync directory", err)
+	}
+
+	return c.JSON(result)
+}
@@ -0,0 +1,151 @@
+package directory
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/google/uuid"
+)
+
+// Entry - сотрудник из каталога
+type Entry struct {
+	DN         string
+	ID         string
+	Login      string
+	Name       string
+	Email      string
+	Department string
+	ManagerDN  string
+	Disabled   bool
+}
+
+// Directory - источник сотрудников. Реализацию можно заменить заглушкой или
+// направить на локальный LDAP-сервер.
+type Directory interface {
+	Entries(ctx context.Context) ([]Entry, error)
+}
+
+// LDAPConfig - параметры подключения. Значения атрибутов по умолчанию рассчитаны на OpenLDAP;
+// для Active Directory: IDAttribute=objectGUID, LoginAttribute=sAMAccountName, DepartmentAttribute=department.
+type LDAPConfig struct {
+	URL                 string
+	BindDN              string
+	Password            string
+	BaseDN              string
+	Filter              string
+	IDAttribute         string
+	LoginAttribute      string
+	DepartmentAttribute string
+}
+
+const (
+	defaultFilter        = "(objectClass=inetOrgPerson)"
+	defaultIDAttribute   = "entryUUID"
+	defaultLoginAttr     = "uid"
+	defaultDepartmentAtt = "ou"
+
+	// adAccountDisable - флаг ACCOUNTDISABLE в userAccountControl Active Directory
+	adAccountDisable = 0x2
+	pageSize         = 500
+)
+
+// NewLDAP возвращает nil, если URL не задан: синхронизация отключена
+func NewLDAP(cfg LDAPConfig) Directory {
+	if cfg.URL == "" {
+		return nil
+	}
+	if cfg.Filter == "" {
+		cfg.Filter = defaultFilter
+	}
+	if cfg.IDAttribute == "" {
+		cfg.IDAttribute = defaultIDAttribute
+	}
+	if cfg.LoginAttribute == "" {
+		cfg.LoginAttribute = defaultLoginAttr
+	}
+	if cfg.DepartmentAttribute == "" {
+		cfg.DepartmentAttribute = defaultDepartmentAtt
+	}
+	return &ldapDirectory{cfg: cfg}
+}
+
+type ldapDirectory struct {
+	cfg LDAPConfig
+}
+
+func (d *ldapDirectory) Entries(ctx context.Context) ([]Entry, error) {
+	conn, err := ldap.DialURL(d.cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("connect to ldap: %w", err)
+	}
+	defer conn.Close()
+	conn.SetTimeout(30 * time.Second)
+
+	if d.cfg.BindDN != "" {
+		if err := conn.Bind(d.cfg.BindDN, d.cfg.Password); err != nil {
+			return nil, fmt.Errorf("bind to ldap: %w", err)
+		}
+	}
+
+	req := ldap.NewSearchRequest(
+		d.cfg.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		d.cfg.Filter,
+		[]string{
+			d.cfg.IDAttribute, d.cfg.LoginAttribute, d.cfg.DepartmentAttribute,
+			"cn", "displayName", "mail", "manager", "userAccountControl",
+		},
+		nil,
+	)
+
+	result, err := conn.SearchWithPaging(req, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("search ldap: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(result.Entries))
+	for _, e := range result.Entries {
+		entry := Entry{
+			DN:         e.DN,
+			ID:         d.id(e),
+			Login:      e.GetAttributeValue(d.cfg.LoginAttribute),
+			Name:       e.GetAttributeValue("displayName"),
+			Email:      e.GetAttributeValue("mail"),
+			Department: e.GetAttributeValue(d.cfg.DepartmentAttribute),
+			ManagerDN:  e.GetAttributeValue("manager"),
+		}
+		if entry.Name == "" {
+			entry.Name = e.GetAttributeValue("cn")
+		}
+		if uac, err := strconv.Atoi(e.GetAttributeValue("userAccountControl")); err == nil {
+			entry.Disabled = uac&adAccountDisable != 0
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// id - идентификатор сотрудника в виде UUID. objectGUID в AD хранится в двоичном виде,
+// первые три группы - little-endian.
+func (d *ldapDirectory) id(e *ldap.Entry) string {
+	if !strings.EqualFold(d.cfg.IDAttribute, "objectGUID") {
+		return strings.ToLower(e.GetAttributeValue(d.cfg.IDAttribute))
+	}
+
+	b := e.GetRawAttributeValue(d.cfg.IDAttribute)
+	if len(b) != 16 {
+		return ""
+	}
+
+	var u uuid.UUID
+	u[0], u[1], u[2], u[3] = b[3], b[2], b[1], b[0]
+	u[4], u[5] = b[5], b[4]
+	u[6], u[7] = b[7], b[6]
+	copy(u[8:], b[8:])
+	return u.String()
+}
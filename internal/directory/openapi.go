@@ -0,0 +1,17 @@
+package directory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты справочника сотрудников для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/v1/directory/users", Summary: "Сотрудники из каталога",
+			Response: []repo.ReportDirectoryUser{}},
+		{Method: http.MethodPost, Admin: true, Path: "/v1/directory/sync", Summary: "Синхронизировать справочник с каталогом",
+			Response: syncResult{}},
+	}
+}
@@ -0,0 +1,225 @@
+package directory
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/tracing"
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotConfigured  = apperr.Unavailable("directory_not_configured", "directory sync is not configured")
+	ErrEmptyDirectory = apperr.Conflict("empty_directory", "directory returned no users, sync aborted")
+)
+
+type Service interface {
+	Users(ctx context.Context) (*[]repo.ReportDirectoryUser, error)
+	Sync(ctx context.Context) (*syncResult, error)
+}
+
+type service struct {
+	repo      repo.Querier
+	db        repo.TxBeginner
+	directory Directory
+}
+
+// NewService - directory может быть nil, тогда Sync возвращает ErrNotConfigured
+func NewService(repo repo.Querier, db repo.TxBeginner, directory Directory) Service {
+	return &service{repo: repo, db: db, directory: directory}
+}
+
+// syncResult - итог синхронизации
+type syncResult struct {
+	Total       int `json:"total"`
+	Created     int `json:"created"`
+	Updated     int `json:"updated"`
+	Deactivated int `json:"deactivated"`
+	Skipped     int `json:"skipped"`
+	Departments int `json:"departments"`
+}
+
+func (s *service) Users(ctx context.Context) (_ *[]repo.ReportDirectoryUser, err error) {
+	ctx, end := tracing.Start(ctx, "directory.Users")
+	defer end(&err)
+
+	users, err := s.repo.GetDirectoryUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get directory users: %w", err)
+	}
+
+	return &users, nil
+}
+
+// Sync переносит сотрудников из каталога в report_directory_user одной транзакцией.
+// Отделы создаются по названию, адрес и руководитель попадают в контакты для уведомлений.
+// Сотрудники, которых больше нет в каталоге или чья учетная запись отключена, помечаются неактивными.
+func (s *service) Sync(ctx context.Context) (_ *syncResult, err error) {
+	ctx, end := tracing.Start(ctx, "directory.Sync")
+	defer end(&err)
+
+	if s.directory == nil {
+		return nil, ErrNotConfigured
+	}
+
+	entries, err := s.directory.Entries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrEmptyDirectory
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	existing, err := tx.GetDirectoryUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get directory users: %w", err)
+	}
+	known := make(map[string]repo.ReportDirectoryUser, len(existing))
+	for _, u := range existing {
+		known[u.ID] = u
+	}
+
+	departments, err := tx.GetDepartments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get departments: %w", err)
+	}
+	departmentIDs := make(map[string]string, len(departments))
+	for _, d := range departments {
+		departmentIDs[d.Name] = d.ID
+	}
+
+	result := syncResult{Total: len(entries)}
+
+	valid := make([]Entry, 0, len(entries))
+	userIDs := make(map[string]string, len(entries))
+	for _, e := range entries {
+		if _, err := uuid.Parse(e.ID); err != nil {
+			result.Skipped++
+			continue
+		}
+		valid = append(valid, e)
+		userIDs[e.DN] = e.ID
+	}
+
+	seen := make(map[string]bool, len(valid))
+	for _, e := range valid {
+		seen[e.ID] = true
+
+		if _, ok := known[e.ID]; ok {
+			if err := tx.UpdateDirectoryUser(ctx, repo.UpdateDirectoryUserParams{
+				Dn:       e.DN,
+				Login:    e.Login,
+				Name:     e.Name,
+				Email:    e.Email,
+				IsActive: !e.Disabled,
+				ID:       e.ID,
+			}); err != nil {
+				return nil, fmt.Errorf("update directory user: %w", err)
+			}
+			result.Updated++
+		} else {
+			if err := tx.CreateDirectoryUser(ctx, repo.CreateDirectoryUserParams{
+				ID:       e.ID,
+				Dn:       e.DN,
+				Login:    e.Login,
+				Name:     e.Name,
+				Email:    e.Email,
+				IsActive: !e.Disabled,
+			}); err != nil {
+				return nil, fmt.Errorf("create directory user: %w", err)
+			}
+			result.Created++
+		}
+
+		// Отдел и контакт заменяются целиком: если в каталоге их больше нет,
+		// прежние значения удаляются
+		if err := tx.DeleteUserDepartment(ctx, e.ID); err != nil {
+			return nil, fmt.Errorf("delete user department: %w", err)
+		}
+		if e.Department != "" {
+			departmentID, ok := departmentIDs[e.Department]
+			if !ok {
+				departmentID = uuid.NewString()
+				if err := tx.CreateDepartment(ctx, repo.CreateDepartmentParams{ID: departmentID, Name: e.Department}); err != nil {
+					return nil, fmt.Errorf("create department: %w", err)
+				}
+				departmentIDs[e.Department] = departmentID
+				result.Departments++
+			}
+
+			if err := tx.CreateUserDepartment(ctx, repo.CreateUserDepartmentParams{UserID: e.ID, DepartmentID: departmentID}); err != nil {
+				return nil, fmt.Errorf("create user department: %w", err)
+			}
+		}
+
+		if err := tx.DeleteUserContact(ctx, e.ID); err != nil {
+			return nil, fmt.Errorf("delete user contact: %w", err)
+		}
+		if e.Email != "" {
+			managerID, ok := userIDs[e.ManagerDN]
+			if err := tx.CreateUserContact(ctx, repo.CreateUserContactParams{
+				UserID:    e.ID,
+				Email:     e.Email,
+				Name:      e.Name,
+				ManagerID: sql.NullString{String: managerID, Valid: ok},
+			}); err != nil {
+				return nil, fmt.Errorf("create user contact: %w", err)
+			}
+		}
+	}
+
+	for _, u := range existing {
+		if seen[u.ID] || !u.IsActive {
+			continue
+		}
+		if err := tx.DeactivateDirectoryUser(ctx, u.ID); err != nil {
+			return nil, fmt.Errorf("deactivate directory user: %w", err)
+		}
+		result.Deactivated++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Schedule запускает синхронизацию каждые interval до отмены ctx.
+// Выполняется фоновой задачей приложения, при Prefork - только в главном процессе.
+func Schedule(ctx context.Context, s Service, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.Sync(ctx)
+		if err != nil {
+			logger.Error("directory sync failed", slog.String("error", err.Error()))
+		} else {
+			logger.Info("directory sync finished",
+				slog.Int("total", result.Total),
+				slog.Int("created", result.Created),
+				slog.Int("updated", result.Updated),
+				slog.Int("deactivated", result.Deactivated),
+				slog.Int("skipped", result.Skipped),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
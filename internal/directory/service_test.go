@@ -0,0 +1,166 @@
+package directory
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+// directory - каталог с заданным списком сотрудников вместо LDAP
+type directory struct {
+	entries []Entry
+	err     error
+}
+
+func (d *directory) Entries(ctx context.Context) ([]Entry, error) {
+	return d.entries, d.err
+}
+
+const (
+	ivanID = "0b9c7f5e-4a3d-4c1e-9f7a-2d8e6b1c5a40"
+	annaID = "5d2e8a1f-7c3b-4e9d-8a6f-1b4c7e2d9f30"
+	olegID = "9a7c3e1b-2d4f-4b8a-9c6e-3f1d5a7b2c80"
+)
+
+func entries() []Entry {
+	return []Entry{
+		{DN: "uid=ivan,ou=people", ID: ivanID, Login: "ivan", Name: "Иван Петров", Email: "ivan@example.com", Department: "Разработка", ManagerDN: "uid=anna,ou=people"},
+		{DN: "uid=anna,ou=people", ID: annaID, Login: "anna", Name: "Анна Смирнова", Email: "anna@example.com", Department: "Разработка"},
+		{DN: "uid=oleg,ou=people", ID: olegID, Login: "oleg", Name: "Олег Иванов", Department: "Бухгалтерия", ManagerDN: "uid=boss,ou=people"},
+		{DN: "uid=guest,ou=people", ID: "guest", Login: "guest", Name: "Гость"},
+	}
+}
+
+func newTestService(t *testing.T) (Service, *memory.Store, *directory) {
+	t.Helper()
+	store := memory.New()
+	dir := &directory{entries: entries()}
+	return NewService(store, store, dir), store, dir
+}
+
+func mustSync(t *testing.T, svc Service) syncResult {
+	t.Helper()
+	result, err := svc.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	return *result
+}
+
+func TestSync(t *testing.T) {
+	svc, store, _ := newTestService(t)
+	ctx := context.Background()
+
+	result := mustSync(t, svc)
+	if want := (syncResult{Total: 4, Created: 3, Skipped: 1, Departments: 2}); result != want {
+		t.Fatalf("result = %+v, want %+v", result, want)
+	}
+
+	user, err := store.GetDirectoryUserById(ctx, ivanID)
+	if err != nil || user.Login != "ivan" || !user.IsActive {
+		t.Fatalf("user = %+v, %v", user, err)
+	}
+
+	ivan, _ := store.GetUserDepartment(ctx, ivanID)
+	anna, _ := store.GetUserDepartment(ctx, annaID)
+	oleg, _ := store.GetUserDepartment(ctx, olegID)
+	if ivan.DepartmentName != "Разработка" || ivan.DepartmentID != anna.DepartmentID || oleg.DepartmentName != "Бухгалтерия" {
+		t.Fatalf("departments = %+v, %+v, %+v", ivan, anna, oleg)
+	}
+
+	// руководитель находится по DN, неизвестный руководитель не записывается
+	contact, err := store.GetUserContact(ctx, ivanID)
+	if err != nil || contact.Email != "ivan@example.com" || contact.ManagerID != (sql.NullString{String: annaID, Valid: true}) {
+		t.Fatalf("contact = %+v, %v", contact, err)
+	}
+	if contact, _ := store.GetUserContact(ctx, annaID); contact.ManagerID.Valid {
+		t.Fatalf("contact without a manager: %+v", contact)
+	}
+	// без адреса контакт не создается
+	if _, err := store.GetUserContact(ctx, olegID); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("contact without email: err = %v", err)
+	}
+
+	// повторная синхронизация обновляет, а не дублирует; отделы не создаются заново
+	if result := mustSync(t, svc); result.Created != 0 || result.Updated != 3 || result.Departments != 0 {
+		t.Fatalf("second sync = %+v", result)
+	}
+	departments, err := store.GetDepartments(ctx)
+	if err != nil || len(departments) != 2 {
+		t.Fatalf("departments = %+v, %v", departments, err)
+	}
+}
+
+// TestSyncClears - отдел и адрес, удаленные в каталоге, удаляются и у сотрудника
+func TestSyncClears(t *testing.T) {
+	svc, store, dir := newTestService(t)
+	ctx := context.Background()
+	mustSync(t, svc)
+
+	dir.entries[0].Department = ""
+	dir.entries[0].Email = ""
+	dir.entries[2].Department = "Финансы"
+	mustSync(t, svc)
+
+	if _, err := store.GetUserDepartment(ctx, ivanID); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("department left after removal: err = %v", err)
+	}
+	if _, err := store.GetUserContact(ctx, ivanID); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("contact left after email removal: err = %v", err)
+	}
+	if oleg, err := store.GetUserDepartment(ctx, olegID); err != nil || oleg.DepartmentName != "Финансы" {
+		t.Fatalf("moved user department = %+v, %v", oleg, err)
+	}
+}
+
+// TestSyncDeactivates - пропавшие из каталога и отключенные учетные записи неактивны
+func TestSyncDeactivates(t *testing.T) {
+	svc, store, dir := newTestService(t)
+	ctx := context.Background()
+	mustSync(t, svc)
+
+	dir.entries = dir.entries[:2]
+	dir.entries[1].Disabled = true
+	if result := mustSync(t, svc); result.Deactivated != 1 || result.Updated != 2 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	for _, id := range []string{annaID, olegID} {
+		user, err := store.GetDirectoryUserById(ctx, id)
+		if err != nil || user.IsActive {
+			t.Fatalf("user %s = %+v, %v, want inactive", id, user, err)
+		}
+	}
+	if user, _ := store.GetDirectoryUserById(ctx, ivanID); !user.IsActive {
+		t.Fatal("present user deactivated")
+	}
+
+	// уже неактивный сотрудник не считается повторно
+	if result := mustSync(t, svc); result.Deactivated != 0 {
+		t.Fatalf("repeated sync = %+v", result)
+	}
+}
+
+func TestSyncErrors(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+
+	if _, err := NewService(store, store, nil).Sync(ctx); !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("no directory: err = %v, want ErrNotConfigured", err)
+	}
+	if _, err := NewService(store, store, &directory{}).Sync(ctx); !errors.Is(err, ErrEmptyDirectory) {
+		t.Fatalf("empty directory: err = %v, want ErrEmptyDirectory", err)
+	}
+
+	unavailable := errors.New("ldap is unavailable")
+	if _, err := NewService(store, store, &directory{err: unavailable}).Sync(ctx); !errors.Is(err, unavailable) {
+		t.Fatalf("failing directory: err = %v", err)
+	}
+
+	users, err := store.GetDirectoryUsers(ctx)
+	if err != nil || len(users) != 0 {
+		t.Fatalf("users after failed syncs = %+v, %v", users, err)
+	}
+}
@@ -0,0 +1,97 @@
+package document
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NewHandler(service Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// VacationPDF отдает заявление на отпуск (и лист согласования) в PDF.
+// ФИО сотрудника берется из справочника пользователей.
+func (h *Handler) VacationPDF(c *fiber.Ctx) error {
+	vacationID := c.Params("id")
+	if vacationID == "" {
+		return apperr.Required("vacation")
+	}
+
+	var buf bytes.Buffer
+	if err := h.service.VacationPDF(c.UserContext(), vacationID, &buf); err != nil {
+		return apperr.Wrap(err, "failed to render vacation document")
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="vacation-%s.pdf"`, vacationID))
+	return c.Send(buf.Bytes())
+}
+
+func (h *Handler) Templates(c *fiber.Ctx) error {
+	templates, err := h.service.Templates(c.UserContext())
+	if err != nil {
+		return apperr.Wrap(err, "failed to get document templates")
+	}
+
+	return c.JSON(templates)
+}
+
+func (h *Handler) Template(c *fiber.Ctx) error {
+	kind := c.Params("kind")
+
+	template, err := h.service.Template(c.UserContext(), kind)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get document template")
+	}
+
+	return c.JSON(template)
+}
+
+type saveTemplateRequest struct {
+	Kind  string `json:"kind"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+func (h *Handler) SaveTemplate(c *fiber.Ctx) error {
+	var req saveTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperr.InvalidBody(err)
+	}
+
+	template, err := h.service.SaveTemplate(c.UserContext(), repo.CreateDocumentTemplateParams(req))
+	if err != nil {
+		if errors.Is(err, ErrUnknownKind) {
+			// вид из тела запроса - ошибка проверки, а не отсутствующий ресурс
+			return apperr.InvalidField("kind", ErrUnknownKind.Message)
+		}
+		return apperr.Wrap(err, "failed to save document template")
+	}
+
+	return c.JSON(template)
+}
+
+func (h *Handler) ResetTemplate(c *fiber.Ctx) error {
+	kind := c.Params("kind")
+
+	template, err := h.service.ResetTemplate(c.UserContext(), kind)
+	if err != nil {
+		return apperr.Wrap(err, "failed to reset document template")
+	}
+
+	return c.JSON(template)
+}
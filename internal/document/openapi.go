@@ -0,0 +1,22 @@
+package document
+
+import (
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты печатных форм для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/v1/vacation/:id/document.pdf", Summary: "Заявление на отпуск в PDF",
+			Content: openapi.MIMEPDF},
+		{Method: http.MethodGet, Admin: true, Path: "/v1/document/template/list", Summary: "Шаблоны документов",
+			Response: []documentTemplate{}},
+		{Method: http.MethodGet, Admin: true, Path: "/v1/document/template/:kind", Summary: "Шаблон документа",
+			Response: documentTemplate{}},
+		{Method: http.MethodPost, Admin: true, Path: "/v1/document/template/update", Summary: "Сохранить шаблон документа",
+			Request: saveTemplateRequest{}, Response: documentTemplate{}},
+		{Method: http.MethodDelete, Admin: true, Path: "/v1/document/template/:kind", Summary: "Вернуть шаблон по умолчанию",
+			Response: documentTemplate{}},
+	}
+}
@@ -0,0 +1,61 @@
+package document
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// defaultFont - шрифт Go Regular (лицензия в font/LICENSE) с кириллицей;
+// используется, если в настройках не указан свой
+//
+//go:embed font/Go-Regular.ttf
+var defaultFont []byte
+
+// fontFamily - имя, под которым в PDF регистрируется TTF-шрифт с кириллицей
+const fontFamily = "main"
+
+// page - страница документа после подстановки данных в шаблон
+type page struct {
+	Title string
+	Body  string
+}
+
+// writePDF выводит страницы в один PDF формата A4 шрифтом из fontPath
+// или встроенным, если путь пуст
+func writePDF(w io.Writer, fontPath string, pages []page) error {
+	font := defaultFont
+	if fontPath != "" {
+		var err error
+		if font, err = os.ReadFile(fontPath); err != nil {
+			return fmt.Errorf("read font: %w", err)
+		}
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(25, 20, 15)
+	pdf.AddUTF8FontFromBytes(fontFamily, "", font)
+	if err := pdf.Error(); err != nil {
+		return fmt.Errorf("load font %q: %w", fontPath, err)
+	}
+
+	for _, p := range pages {
+		pdf.AddPage()
+
+		pdf.SetFont(fontFamily, "", 14)
+		pdf.MultiCell(0, 8, p.Title, "", "C", false)
+		pdf.Ln(8)
+
+		pdf.SetFont(fontFamily, "", 12)
+		pdf.MultiCell(0, 6, p.Body, "", "L", false)
+	}
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("write pdf: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package document
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/tracing"
+	"TimeTrack/internal/vacation"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// dateLayout - формат дат в печатных документах
+const dateLayout = "02.01.2006"
+
+type Service interface {
+	Templates(ctx context.Context) (*[]documentTemplate, error)
+	Template(ctx context.Context, kind string) (*documentTemplate, error)
+	SaveTemplate(ctx context.Context, prm repo.CreateDocumentTemplateParams) (*documentTemplate, error)
+	ResetTemplate(ctx context.Context, kind string) (*documentTemplate, error)
+	VacationPDF(ctx context.Context, vacationID string, w io.Writer) error
+}
+
+type service struct {
+	repo      repo.Querier
+	db        repo.TxBeginner
+	vacations vacation.Service
+	fontPath  string
+}
+
+// NewService - fontPath указывает на TTF-шрифт с поддержкой кириллицы, пустой - встроенный шрифт
+func NewService(repo repo.Querier, db repo.TxBeginner, vacations vacation.Service, fontPath string) Service {
+	return &service{repo: repo, db: db, vacations: vacations, fontPath: fontPath}
+}
+
+func (s *service) Templates(ctx context.Context) (_ *[]documentTemplate, err error) {
+	ctx, end := tracing.Start(ctx, "document.Templates")
+	defer end(&err)
+
+	kinds := make([]string, 0, len(defaultTemplates))
+	for kind := range defaultTemplates {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	templates := make([]documentTemplate, 0, len(kinds))
+	for _, kind := range kinds {
+		t, err := s.Template(ctx, kind)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *t)
+	}
+
+	return &templates, nil
+}
+
+// Template возвращает сохраненный шаблон или шаблон по умолчанию
+func (s *service) Template(ctx context.Context, kind string) (_ *documentTemplate, err error) {
+	ctx, end := tracing.Start(ctx, "document.Template")
+	defer end(&err)
+
+	def, ok := defaultTemplates[kind]
+	if !ok {
+		return nil, ErrUnknownKind
+	}
+
+	stored, err := s.repo.GetDocumentTemplate(ctx, kind)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			def.IsDefault = true
+			return &def, nil
+		}
+		return nil, fmt.Errorf("get document template: %w", err)
+	}
+
+	return &documentTemplate{
+		Kind:  stored.Kind,
+		Title: stored.Title,
+		Body:  stored.Body,
+	}, nil
+}
+
+func (s *service) SaveTemplate(ctx context.Context, prm repo.CreateDocumentTemplateParams) (_ *documentTemplate, err error) {
+	ctx, end := tracing.Start(ctx, "document.SaveTemplate")
+	defer end(&err)
+
+	t := documentTemplate{Kind: prm.Kind, Title: prm.Title, Body: prm.Body}
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := tx.DeleteDocumentTemplate(ctx, prm.Kind); err != nil {
+		return nil, fmt.Errorf("delete document template: %w", err)
+	}
+	if err := tx.CreateDocumentTemplate(ctx, prm); err != nil {
+		return nil, fmt.Errorf("create document template: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return s.Template(ctx, prm.Kind)
+}
+
+// ResetTemplate удаляет сохраненный шаблон, возвращая шаблон по умолчанию
+func (s *service) ResetTemplate(ctx context.Context, kind string) (_ *documentTemplate, err error) {
+	ctx, end := tracing.Start(ctx, "document.ResetTemplate")
+	defer end(&err)
+
+	if _, ok := defaultTemplates[kind]; !ok {
+		return nil, ErrUnknownKind
+	}
+
+	if err := s.repo.DeleteDocumentTemplate(ctx, kind); err != nil {
+		return nil, fmt.Errorf("delete document template: %w", err)
+	}
+
+	return s.Template(ctx, kind)
+}
+
+// VacationPDF выводит заявление на отпуск, а для согласованного отпуска - еще и лист согласования.
+// ФИО сотрудника берется из справочника пользователей, без записи в нем печатается ID пользователя.
+func (s *service) VacationPDF(ctx context.Context, vacationID string, w io.Writer) (err error) {
+	ctx, end := tracing.Start(ctx, "document.VacationPDF")
+	defer end(&err)
+
+	v, err := s.vacations.Get(ctx, vacationID)
+	if err != nil {
+		return fmt.Errorf("get vacation: %w", err)
+	}
+
+	employee, err := s.employee(ctx, v.UserID)
+	if err != nil {
+		return err
+	}
+
+	holidays := make([]string, 0, len(v.Holidays))
+	for _, h := range v.Holidays {
+		line := fmt.Sprintf("%02d.%02d.%d", h.Day, h.Month, h.Year)
+		if h.Description != "" {
+			line += " - " + h.Description
+		}
+		if !h.IsPaidVacation {
+			line += " (не входит в число дней отпуска)"
+		}
+		holidays = append(holidays, line)
+	}
+
+	data := documentData{
+		Employee:    employee,
+		UserID:      v.UserID,
+		StartDate:   v.StartDate.Format(dateLayout),
+		EndDate:     v.EndDate.Format(dateLayout),
+		Days:        v.CountDay,
+		Holidays:    holidays,
+		Description: v.Description,
+		Status:      string(v.Status),
+		CreatedAt:   v.CreateAt.Format(dateLayout),
+		Today:       time.Now().Format(dateLayout),
+	}
+
+	kinds := []string{KindVacationApplication}
+	if v.Status == repo.ReportVacationStatusApproved {
+		kinds = append(kinds, KindVacationApproval)
+	}
+
+	pages := make([]page, 0, len(kinds))
+	for _, kind := range kinds {
+		t, err := s.Template(ctx, kind)
+		if err != nil {
+			return err
+		}
+
+		title, body, err := t.render(data)
+		if err != nil {
+			return fmt.Errorf("render %s: %w", kind, err)
+		}
+		pages = append(pages, page{Title: title, Body: body})
+	}
+
+	return writePDF(w, s.fontPath, pages)
+}
+
+// employee - ФИО пользователя из справочника или его ID, если записи нет
+func (s *service) employee(ctx context.Context, userID string) (string, error) {
+	user, err := s.repo.GetDirectoryUserById(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return userID, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get directory user: %w", err)
+	}
+	if user.Name == "" {
+		return userID, nil
+	}
+	return user.Name, nil
+}
@@ -0,0 +1,80 @@
+package document
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/vacation"
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+// nop - публикация событий и уведомления, которые тестам не нужны
+type nop struct{}
+
+func (nop) Publish(ctx context.Context, event string, data any)          {}
+func (nop) VacationSubmitted(ctx context.Context, vacationID string)     {}
+func (nop) VacationStatusChanged(ctx context.Context, vacationID string) {}
+
+const userID = "0b9c7f5e-4a3d-4c1e-9f7a-2d8e6b1c5a40"
+
+// newTestService - сервис документов со встроенным шрифтом над хранилищем в памяти
+// с согласованным отпуском v-1 пользователя userID
+func newTestService(t *testing.T) (*service, *memory.Store) {
+	t.Helper()
+	store := memory.New()
+
+	vacations := vacation.NewService(store, store, store, nop{}, nop{})
+	if _, err := vacations.Create(context.Background(), repo.CreateVacationParams{
+		ID:        "v-1",
+		UserID:    userID,
+		StartDate: time.Date(2025, time.July, 7, 0, 0, 0, 0, time.Local),
+		EndDate:   time.Date(2025, time.July, 20, 0, 0, 0, 0, time.Local),
+		Year:      2025,
+		Status:    repo.ReportVacationStatusApproved,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewService(store, store, vacations, "").(*service), store
+}
+
+// TestVacationPDF - документ выводится встроенным шрифтом без внешнего файла
+func TestVacationPDF(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	var buf bytes.Buffer
+	if err := svc.VacationPDF(context.Background(), "v-1", &buf); err != nil {
+		t.Fatalf("VacationPDF: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Fatalf("output is not a PDF: %q", buf.Bytes()[:min(buf.Len(), 16)])
+	}
+}
+
+// TestEmployee - ФИО из справочника пользователей, без записи - ID пользователя
+func TestEmployee(t *testing.T) {
+	svc, store := newTestService(t)
+	ctx := context.Background()
+
+	name, err := svc.employee(ctx, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != userID {
+		t.Fatalf("employee without directory entry = %q, want user ID", name)
+	}
+
+	if err := store.CreateDirectoryUser(ctx, repo.CreateDirectoryUserParams{
+		ID: userID, Login: "ivanov", Name: "Иванов Иван Иванович", Email: "ivanov@example.com", IsActive: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if name, err = svc.employee(ctx, userID); err != nil {
+		t.Fatal(err)
+	}
+	if name != "Иванов Иван Иванович" {
+		t.Fatalf("employee = %q, want directory name", name)
+	}
+}
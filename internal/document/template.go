@@ -0,0 +1,128 @@
+package document
+
+import (
+	"TimeTrack/internal/apperr"
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+const (
+	KindVacationApplication = "vacation_application"
+	KindVacationApproval    = "vacation_approval"
+)
+
+var (
+	ErrUnknownKind     = apperr.NotFound("unknown_document_kind", "unknown document kind")
+	ErrInvalidTemplate = apperr.Invalid("invalid_template", "invalid document template")
+)
+
+// documentTemplate - шаблон документа: заголовок и текст в синтаксисе text/template
+type documentTemplate struct {
+	Kind      string `json:"kind"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+// defaultTemplates - шаблоны, используемые пока администратор не сохранил свои
+var defaultTemplates = map[string]documentTemplate{
+	KindVacationApplication: {
+		Kind:  KindVacationApplication,
+		Title: "Заявление на ежегодный оплачиваемый отпуск",
+		Body: `Прошу предоставить мне ежегодный оплачиваемый отпуск с {{.StartDate}} по {{.EndDate}} продолжительностью {{.Days}} календарных дней.
+{{if .Holidays}}
+В период отпуска входят нерабочие праздничные дни:
+{{range .Holidays}}  - {{.}}
+{{end}}{{end}}{{if .Description}}
+Комментарий: {{.Description}}
+{{end}}
+Сотрудник: {{.Employee}}
+Дата заявления: {{.CreatedAt}}
+
+Подпись: ____________________`,
+	},
+	KindVacationApproval: {
+		Kind:  KindVacationApproval,
+		Title: "Лист согласования отпуска",
+		Body: `Заявление сотрудника {{.Employee}} на ежегодный оплачиваемый отпуск с {{.StartDate}} по {{.EndDate}} ({{.Days}} дн.) согласовано.
+
+Дата заявления: {{.CreatedAt}}
+Дата печати: {{.Today}}
+
+Руководитель: ____________________
+
+Отдел кадров: ____________________`,
+	},
+}
+
+// documentData - данные, доступные в шаблонах документов по отпуску
+type documentData struct {
+	Employee    string
+	UserID      string
+	StartDate   string
+	EndDate     string
+	Days        int16
+	Holidays    []string
+	Description string
+	Status      string
+	CreatedAt   string
+	Today       string
+}
+
+// sampleData - данные для проверки шаблона при сохранении
+var sampleData = documentData{
+	Employee:    "Иванов Иван Иванович",
+	UserID:      "00000000-0000-0000-0000-000000000000",
+	StartDate:   "01.07.2025",
+	EndDate:     "14.07.2025",
+	Days:        14,
+	Holidays:    []string{"04.07.2025 - Пример праздника"},
+	Description: "Пример комментария",
+	Status:      "approved",
+	CreatedAt:   "01.06.2025",
+	Today:       "02.06.2025",
+}
+
+// render подставляет данные в шаблон и возвращает заголовок и текст документа
+func (t documentTemplate) render(data documentData) (string, string, error) {
+	title, err := execute(t.Kind+".title", t.Title, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	body, err := execute(t.Kind+".body", t.Body, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	return title, body, nil
+}
+
+// validate проверяет, что шаблон разбирается и применяется к данным документа
+func (t documentTemplate) validate() error {
+	if _, ok := defaultTemplates[t.Kind]; !ok {
+		return ErrUnknownKind
+	}
+	if t.Title == "" || t.Body == "" {
+		return fmt.Errorf("%w: title and body are required", ErrInvalidTemplate)
+	}
+	if _, _, err := t.render(sampleData); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidTemplate, err.Error())
+	}
+	return nil
+}
+
+func execute(name, text string, data documentData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,343 @@
+package export
+
+import (
+	"TimeTrack/internal/apperr"
+	"bufio"
+	"database/sql/driver"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Format - формат ответа списочного запроса
+type Format string
+
+const (
+	FormatJSON   Format = "json"
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+const (
+	mimeCSV    = "text/csv"
+	mimeNDJSON = "application/x-ndjson"
+)
+
+// flushEvery - через сколько строк выгрузка сбрасывается клиенту
+const flushEvery = 256
+
+// Negotiate определяет формат по параметру ?format= или заголовку Accept.
+// Параметр запроса имеет приоритет; по умолчанию - JSON.
+func Negotiate(c *fiber.Ctx) (Format, error) {
+	switch strings.ToLower(c.Query("format")) {
+	case "":
+	case "json":
+		return FormatJSON, nil
+	case "csv":
+		return FormatCSV, nil
+	case "ndjson", "jsonl":
+		return FormatNDJSON, nil
+	default:
+		return "", apperr.Invalid("unsupported_format",
+			fmt.Sprintf("unsupported format %q, expected json, csv or ndjson", c.Query("format")),
+			apperr.Field{Field: "format", Message: "must be json, csv or ndjson"})
+	}
+
+	switch c.Accepts(fiber.MIMEApplicationJSON, mimeCSV, mimeNDJSON, "application/jsonl") {
+	case mimeCSV:
+		return FormatCSV, nil
+	case mimeNDJSON, "application/jsonl":
+		return FormatNDJSON, nil
+	default:
+		return FormatJSON, nil
+	}
+}
+
+// Respond отдает уже загруженный список в запрошенном формате. data - срез (или указатель
+// на срез) структур; столбцы CSV и ключи NDJSON берутся из json-тегов полей. Для выборок
+// без ограничения размера - Stream. name - имя файла без расширения; logger получает
+// ошибку, оборвавшую уже начатую выгрузку.
+func Respond(c *fiber.Ctx, logger *slog.Logger, name string, data any) error {
+	format, err := Negotiate(c)
+	if err != nil {
+		return err
+	}
+
+	rows := reflect.Indirect(reflect.ValueOf(data))
+	if format == FormatJSON || rows.Kind() != reflect.Slice {
+		return c.JSON(data)
+	}
+
+	return stream(c, logger, format, name, rows.Type().Elem(), func(yield func(any, error) bool) {
+		for i := range rows.Len() {
+			if !yield(rows.Index(i).Interface(), nil) {
+				return
+			}
+		}
+	})
+}
+
+// Stream отдает строки rows в запрошенном формате по мере чтения, не собирая их в памяти;
+// JSON - массив. Ошибка до первой строки возвращается как обычно; после нее статус уже
+// отправлен, и ошибка обрывает ответ (JSON остается незакрытым массивом) и пишется в logger.
+func Stream[T any](c *fiber.Ctx, logger *slog.Logger, name string, rows iter.Seq2[T, error]) error {
+	format, err := Negotiate(c)
+	if err != nil {
+		return err
+	}
+
+	return stream(c, logger, format, name, reflect.TypeFor[T](), func(yield func(any, error) bool) {
+		for row, err := range rows {
+			if !yield(row, err) {
+				return
+			}
+		}
+	})
+}
+
+// stream читает первую строку в обработчике, чтобы вернуть ошибку запроса до ответа,
+// а остальные - при записи тела. Статус к тому времени отправлен, поэтому ошибка
+// чтения или записи только обрывает тело, и о ней сообщает logger.
+func stream(c *fiber.Ctx, logger *slog.Logger, format Format, name string, elem reflect.Type, rows iter.Seq2[any, error]) error {
+	next, stop := iter.Pull2(rows)
+	first, err, ok := next()
+	if err != nil {
+		stop()
+		return err
+	}
+
+	rest := func(yield func(any, error) bool) {
+		defer stop()
+		for row, err, ok := first, error(nil), ok; ok; row, err, ok = next() {
+			if !yield(row, err) || err != nil {
+				return
+			}
+		}
+	}
+
+	var file string
+	var write func(w *bufio.Writer) error
+	switch format {
+	case FormatCSV:
+		file = name + ".csv"
+		c.Attachment(file)
+		c.Set(fiber.HeaderContentType, mimeCSV+"; charset=utf-8")
+		write = func(w *bufio.Writer) error { return writeCSV(w, elem, rest) }
+	case FormatNDJSON:
+		file = name + ".ndjson"
+		c.Attachment(file)
+		c.Set(fiber.HeaderContentType, mimeNDJSON)
+		write = func(w *bufio.Writer) error { return writeNDJSON(w, rest) }
+	default:
+		file = name + ".json"
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		write = func(w *bufio.Writer) error { return writeJSON(w, rest) }
+	}
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := write(w); err != nil {
+			logger.Error("export interrupted",
+				slog.String("export", file),
+				slog.String("error", err.Error()),
+			)
+		}
+	})
+
+	return nil
+}
+
+func writeCSV(w *bufio.Writer, elem reflect.Type, rows iter.Seq2[any, error]) error {
+	cw := csv.NewWriter(w)
+	columns := columnsOf(elem)
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.name
+	}
+	cw.Write(header)
+
+	record := make([]string, len(columns))
+	i := 0
+	for value, err := range rows {
+		if err != nil {
+			cw.Flush()
+			return fmt.Errorf("read rows: %w", err)
+		}
+		row := reflect.Indirect(reflect.ValueOf(value))
+		for j, col := range columns {
+			record[j] = cell(row.FieldByIndex(col.index))
+		}
+		cw.Write(record)
+
+		if i++; i%flushEvery == 0 {
+			cw.Flush()
+			if err := w.Flush(); err != nil {
+				return fmt.Errorf("write: %w", err)
+			}
+		}
+	}
+
+	cw.Flush()
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	return nil
+}
+
+func writeNDJSON(w *bufio.Writer, rows iter.Seq2[any, error]) error {
+	enc := json.NewEncoder(w)
+	i := 0
+	for row, err := range rows {
+		if err != nil {
+			return fmt.Errorf("read rows: %w", err)
+		}
+		if err := enc.Encode(row); err != nil {
+			return fmt.Errorf("encode row: %w", err)
+		}
+
+		if i++; i%flushEvery == 0 {
+			if err := w.Flush(); err != nil {
+				return fmt.Errorf("write: %w", err)
+			}
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	return nil
+}
+
+// writeJSON выводит строки массивом; при ошибке чтения закрывающая скобка не пишется
+func writeJSON(w *bufio.Writer, rows iter.Seq2[any, error]) error {
+	w.WriteByte('[')
+	i := 0
+	for row, err := range rows {
+		if err != nil {
+			return fmt.Errorf("read rows: %w", err)
+		}
+		b, err := json.Marshal(row)
+		if err != nil {
+			return fmt.Errorf("encode row: %w", err)
+		}
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		w.Write(b)
+
+		if i++; i%flushEvery == 0 {
+			if err := w.Flush(); err != nil {
+				return fmt.Errorf("write: %w", err)
+			}
+		}
+	}
+
+	w.WriteByte(']')
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	return nil
+}
+
+type column struct {
+	name  string
+	index []int
+}
+
+// columnsOf - экспортируемые поля структуры в порядке объявления с именами из json-тегов
+func columnsOf(t reflect.Type) []column {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return []column{{name: "value"}}
+	}
+
+	columns := make([]column, 0, t.NumField())
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		columns = append(columns, column{name: name, index: f.Index})
+	}
+
+	return columns
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// cell - значение поля в виде строки CSV
+func cell(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return ""
+		}
+		return cell(v.Elem())
+	case reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return ""
+		}
+	}
+
+	// sql.NullString, sql.NullInt32 и т.п.
+	if valuer, ok := v.Interface().(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil || value == nil {
+			return ""
+		}
+		return cell(reflect.ValueOf(value))
+	}
+
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+			return t.Format(time.DateOnly)
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+
+	// Вложенные списки и структуры выгружаются как JSON в одной ячейке
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"iter"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type item struct {
+	ID    string  `json:"id"`
+	Hours float64 `json:"hours"`
+}
+
+// items - строки выборки; err приходит после них, как ошибка чтения *sql.Rows
+func items(err error, rows ...item) iter.Seq2[item, error] {
+	return func(yield func(item, error) bool) {
+		for _, row := range rows {
+			if !yield(row, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(item{}, err)
+		}
+	}
+}
+
+// get - ответ маршрута, который отдает rows через Stream, и записанный им журнал
+func get(t *testing.T, rows iter.Seq2[item, error], query string) (int, string, string) {
+	t.Helper()
+	var log bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&log, nil))
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		return Stream(c, logger, "items", rows)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/"+query, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body), log.String()
+}
+
+func TestStream(t *testing.T) {
+	rows := items(nil, item{ID: "a", Hours: 8}, item{ID: "b", Hours: 1.5})
+
+	for query, want := range map[string]string{
+		"":               `[{"id":"a","hours":8},{"id":"b","hours":1.5}]`,
+		"?format=csv":    "id,hours\na,8\nb,1.5\n",
+		"?format=ndjson": "{\"id\":\"a\",\"hours\":8}\n{\"id\":\"b\",\"hours\":1.5}\n",
+	} {
+		status, body, log := get(t, rows, query)
+		if status != fiber.StatusOK || body != want || log != "" {
+			t.Errorf("%q: %d %q, want %q; log %q", query, status, body, want, log)
+		}
+	}
+
+	if _, body, _ := get(t, items(nil), ""); body != "[]" {
+		t.Errorf("empty = %q, want []", body)
+	}
+}
+
+// TestStreamError - ошибка до первой строки возвращается обработчику,
+// после нее ответ обрывается, а ошибка с именем выгрузки пишется в журнал
+func TestStreamError(t *testing.T) {
+	failed := errors.New("connection reset")
+
+	if status, _, _ := get(t, items(failed), ""); status != fiber.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", status)
+	}
+
+	for query, want := range map[string]struct{ body, export string }{
+		"":               {`[{"id":"a","hours":8}`, "items.json"},
+		"?format=csv":    {"id,hours\na,8\n", "items.csv"},
+		"?format=ndjson": {"{\"id\":\"a\",\"hours\":8}\n", "items.ndjson"},
+	} {
+		status, body, log := get(t, items(failed, item{ID: "a", Hours: 8}), query)
+		if status != fiber.StatusOK || body != want.body {
+			t.Errorf("%q: interrupted = %d %q, want %q", query, status, body, want.body)
+		}
+		if !strings.Contains(log, "export interrupted") || !strings.Contains(log, "export="+want.export) || !strings.Contains(log, "connection reset") {
+			t.Errorf("%q: log = %q", query, log)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// readyTimeout - сколько /readyz ждет ответа базы
+const readyTimeout = 2 * time.Second
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NewHandler(service Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// StatusResponse - ответ /healthz и /readyz
+type StatusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Live - процесс жив и принимает запросы; база не проверяется,
+// чтобы недоступная СУБД не приводила к перезапуску сервиса
+func (h *Handler) Live(c *fiber.Ctx) error {
+	return c.JSON(StatusResponse{Status: "ok"})
+}
+
+// Ready - сервис может обслуживать запросы: база доступна и схема нужной версии
+func (h *Handler) Ready(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), readyTimeout)
+	defer cancel()
+
+	if err := h.service.Ready(ctx); err != nil {
+		h.logger.Warn("not ready", slog.String("error", err.Error()))
+		return c.Status(http.StatusServiceUnavailable).JSON(StatusResponse{
+			Status: "unavailable",
+			Error:  err.Error(),
+		})
+	}
+
+	return c.JSON(StatusResponse{Status: "ok"})
+}
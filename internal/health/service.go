@@ -0,0 +1,45 @@
+package health
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrSchemaVersion = errors.New("database schema version mismatch")
+
+type Service interface {
+	// Ready проверяет, что база доступна и ее схема той версии, которую ждет код
+	Ready(ctx context.Context) error
+}
+
+// Pinger - проверка соединения с базой (*sql.DB)
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+type service struct {
+	repo repo.Querier
+	db   Pinger
+}
+
+func NewService(repo repo.Querier, db Pinger) Service {
+	return &service{repo: repo, db: db}
+}
+
+func (s *service) Ready(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("ping database: %w", err)
+	}
+
+	version, err := s.repo.GetSchemaVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("get schema version: %w", err)
+	}
+	if version != repo.SchemaVersion {
+		return fmt.Errorf("%w: database has %d, want %d", ErrSchemaVersion, version, repo.SchemaVersion)
+	}
+
+	return nil
+}
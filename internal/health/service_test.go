@@ -0,0 +1,41 @@
+package health
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"errors"
+	"testing"
+)
+
+type pinger struct{ err error }
+
+func (p pinger) PingContext(ctx context.Context) error { return p.err }
+
+// outdated - база со схемой предыдущей версии
+type outdated struct{ *memory.Store }
+
+func (outdated) GetSchemaVersion(ctx context.Context) (int32, error) {
+	return repo.SchemaVersion - 1, nil
+}
+
+func TestReady(t *testing.T) {
+	if err := NewService(memory.New(), pinger{}).Ready(context.Background()); err != nil {
+		t.Fatalf("Ready: %v", err)
+	}
+}
+
+func TestReadyDatabaseDown(t *testing.T) {
+	down := errors.New("connection refused")
+	err := NewService(memory.New(), pinger{err: down}).Ready(context.Background())
+	if !errors.Is(err, down) {
+		t.Fatalf("err = %v, want %v", err, down)
+	}
+}
+
+func TestReadySchemaVersion(t *testing.T) {
+	err := NewService(outdated{memory.New()}, pinger{}).Ready(context.Background())
+	if !errors.Is(err, ErrSchemaVersion) {
+		t.Fatalf("err = %v, want ErrSchemaVersion", err)
+	}
+}
@@ -0,0 +1,113 @@
+package importer
+
+import (
+	"TimeTrack/internal/apperr"
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NewHandler(service Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Import принимает CSV в поле формы file или в теле запроса.
+// Параметры: dryRun=true - только проверка, batch - размер пачки.
+func (h *Handler) Import(c *fiber.Ctx) error {
+	batch := c.QueryInt("batch", DefaultBatchSize)
+	if batch < 1 || batch > 10000 {
+		return apperr.InvalidField("batch", "must be between 1 and 10000")
+	}
+
+	file, err := upload(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.service.Import(c.UserContext(), file, Options{
+		DryRun:    c.QueryBool("dryRun", false),
+		BatchSize: batch,
+	})
+	if err != nil {
+		if result != nil {
+			// Часть пачек уже записана - возвращаем, сколько именно
+			h.logger.Error("failed to import timesheets", slog.String("error", err.Error()))
+			return c.Status(http.StatusInternalServerError).JSON(result)
+		}
+		return apperr.Wrap(err, "failed to import timesheets")
+	}
+
+	if len(result.Errors) > 0 {
+		return c.Status(http.StatusUnprocessableEntity).JSON(result)
+	}
+
+	return c.JSON(result)
+}
+
+// upload - файл из поля формы file или тело запроса. Тело читается потоком
+// (StreamRequestBody сервера) и не копируется в память целиком; больше MaxFileSize
+// байт не читается.
+func upload(c *fiber.Ctx) (io.Reader, error) {
+	if c.Request().Header.ContentLength() > MaxFileSize {
+		return nil, ErrFileTooLarge
+	}
+
+	var body io.Reader = c.Context().RequestBodyStream()
+	if body == nil {
+		body = bytes.NewReader(c.Body())
+	}
+	body = &limitedReader{r: body, n: MaxFileSize}
+
+	boundary := string(c.Request().Header.MultipartFormBoundary())
+	if boundary == "" {
+		return body, nil
+	}
+
+	form := multipart.NewReader(body, boundary)
+	for {
+		part, err := form.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, apperr.Required("file")
+		}
+		if errors.Is(err, ErrFileTooLarge) {
+			return nil, err
+		}
+		if err != nil {
+			return nil, apperr.Invalid("invalid_upload", "failed to read uploaded file")
+		}
+		if part.FormName() == "file" {
+			return part, nil
+		}
+	}
+}
+
+// limitedReader отдает не больше n байт и возвращает ErrFileTooLarge, если данных больше
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	if int64(n) > l.n {
+		return int(l.n), ErrFileTooLarge
+	}
+	l.n -= int64(n)
+	return n, err
+}
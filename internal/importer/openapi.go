@@ -0,0 +1,20 @@
+package importer
+
+import (
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршрут импорта табеля для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodPost, Path: "/v1/report/import", Summary: "Импорт отметок из CSV (поле формы file или тело запроса)",
+			Query: []openapi.Param{
+				{Name: "dryRun", Description: "Только проверить файл", Type: "boolean"},
+				{Name: "batch", Description: "Размер пачки записи, 1-10000", Type: "integer"},
+			},
+			RequestContent: []string{"multipart/form-data", openapi.MIMECSV},
+			Response:       Result{},
+			Responses:      map[int]any{http.StatusUnprocessableEntity: Result{}}},
+	}
+}
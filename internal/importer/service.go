@@ -0,0 +1,429 @@
+package importer
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/closing"
+	"TimeTrack/internal/report"
+	"TimeTrack/internal/tracing"
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultBatchSize - количество строк, записываемых в одной транзакции
+	DefaultBatchSize = 500
+	// MaxFileSize - наибольший размер файла, загружаемого через API: история
+	// табеля за несколько лет
+	MaxFileSize = 256 << 20
+)
+
+var (
+	ErrEmptyFile     = apperr.Invalid("empty_file", "csv file is empty")
+	ErrInvalidCSV    = apperr.Invalid("invalid_csv", "malformed csv")
+	ErrMissingColumn = apperr.Invalid("missing_column", "required column is missing")
+	ErrFileTooLarge  = apperr.Invalid("file_too_large", fmt.Sprintf("csv file is larger than %d MB", MaxFileSize>>20))
+)
+
+// dateLayouts - поддерживаемые форматы даты в файле
+var dateLayouts = []string{"2006-01-02", "02.01.2006"}
+
+// columnAliases - допустимые названия столбцов заголовка
+var columnAliases = map[string]string{
+	"user":             "user",
+	"user_id":          "user",
+	"userid":           "user",
+	"date":             "date",
+	"hours":            "hours",
+	"type":             "type",
+	"type_system_name": "type",
+	"typesystemname":   "type",
+}
+
+type Service interface {
+	Import(ctx context.Context, r io.Reader, opts Options) (*Result, error)
+}
+
+type service struct {
+	repo repo.Querier
+	db   repo.TxBeginner
+}
+
+func NewService(repo repo.Querier, db repo.TxBeginner) Service {
+	return &service{repo: repo, db: db}
+}
+
+// Options - параметры импорта. При DryRun файл только проверяется.
+type Options struct {
+	DryRun    bool
+	BatchSize int
+}
+
+// RowError - ошибка в строке файла (Line - номер строки с учетом заголовка)
+type RowError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// Result - итог импорта. Если при проверке файла есть ошибки, ни одна строка не
+// записывается. Если табель изменили после проверки, импорт останавливается на пачке
+// с конфликтующей строкой: Imported - сколько строк записано до нее.
+type Result struct {
+	Total    int        `json:"total"`
+	Valid    int        `json:"valid"`
+	Imported int        `json:"imported"`
+	Batches  int        `json:"batches"`
+	DryRun   bool       `json:"dryRun"`
+	Errors   []RowError `json:"errors"`
+}
+
+// rowInvalid - ошибка данных строки; попадает в отчет, не прерывая проверку файла
+type rowInvalid struct {
+	msg string
+}
+
+func (e *rowInvalid) Error() string {
+	return e.msg
+}
+
+func invalid(format string, args ...any) error {
+	return &rowInvalid{msg: fmt.Sprintf(format, args...)}
+}
+
+func isInvalid(err error) bool {
+	var target *rowInvalid
+	return errors.As(err, &target)
+}
+
+// Import проверяет весь файл, а затем записывает отметки пачками по BatchSize строк.
+// Файл читается по записям: в памяти остаются только проверенные отметки.
+func (s *service) Import(ctx context.Context, r io.Reader, opts Options) (_ *Result, err error) {
+	ctx, end := tracing.Start(ctx, "importer.Import")
+	defer end(&err)
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+
+	reader, err := newReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrEmptyFile
+	}
+	if err != nil {
+		return nil, readError(err)
+	}
+	columns, err := headerColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{DryRun: opts.DryRun, Errors: []RowError{}}
+	types := make(map[string]repo.ReportType)
+	days := newDays(s.repo)
+	seen := make(map[string]int)
+	var rows []repo.CreateReportUserParams
+	var lines []int
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, readError(err)
+		}
+		line, _ := reader.FieldPos(0)
+		result.Total++
+
+		row, err := s.parseRow(ctx, record, columns, types)
+		if err != nil {
+			if !isInvalid(err) {
+				return nil, err
+			}
+			result.Errors = append(result.Errors, RowError{Line: line, Error: err.Error()})
+			continue
+		}
+
+		key := fmt.Sprintf("%s|%d-%d-%d|%s", row.UserID, row.Year, row.Month, row.Day, row.TypeID)
+		if first, ok := seen[key]; ok {
+			result.Errors = append(result.Errors, RowError{Line: line, Error: fmt.Sprintf("duplicate of line %d", first)})
+			continue
+		}
+		seen[key] = line
+
+		if err := days.check(ctx, row); err != nil {
+			if !isInvalid(err) {
+				return nil, err
+			}
+			result.Errors = append(result.Errors, RowError{Line: line, Error: err.Error()})
+			continue
+		}
+
+		rows = append(rows, row)
+		lines = append(lines, line)
+	}
+
+	result.Valid = len(rows)
+	if len(result.Errors) > 0 || opts.DryRun {
+		return result, nil
+	}
+
+	for start := 0; start < len(rows); start += opts.BatchSize {
+		end := min(start+opts.BatchSize, len(rows))
+
+		line, err := s.writeBatch(ctx, rows[start:end], lines[start:end])
+		if isInvalid(err) {
+			// табель изменили после проверки файла: записанные пачки остаются,
+			// остальные не записываются
+			result.Errors = append(result.Errors, RowError{Line: line, Error: err.Error()})
+			return result, nil
+		}
+		if err != nil {
+			return result, fmt.Errorf("write batch %d: %w", result.Batches+1, err)
+		}
+
+		result.Batches++
+		result.Imported += end - start
+	}
+
+	return result, nil
+}
+
+func (s *service) parseRow(ctx context.Context, record []string, columns map[string]int, types map[string]repo.ReportType) (repo.CreateReportUserParams, error) {
+	var row repo.CreateReportUserParams
+
+	value := func(column string) string {
+		idx := columns[column]
+		if idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	userID := value("user")
+	if _, err := uuid.Parse(userID); err != nil {
+		return row, invalid("user must be a valid UUID")
+	}
+
+	date, err := parseDate(value("date"))
+	if err != nil {
+		return row, err
+	}
+
+	hours, err := strconv.ParseFloat(strings.Replace(value("hours"), ",", ".", 1), 64)
+	if err != nil || hours <= 0 || hours > report.MaxDayHours {
+		return row, invalid("hours must be a number between 0 and %g", report.MaxDayHours)
+	}
+
+	systemName := value("type")
+	reportType, ok := types[systemName]
+	if !ok {
+		reportType, err = s.repo.GetTypeBySystemName(ctx, systemName)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return row, invalid("unknown type %q", systemName)
+			}
+			return row, fmt.Errorf("get report type %q: %w", systemName, err)
+		}
+		types[systemName] = reportType
+	}
+
+	return repo.CreateReportUserParams{
+		ID:     uuid.NewString(),
+		UserID: userID,
+		Day:    int32(date.Day()),
+		Month:  int32(date.Month()),
+		Year:   int32(date.Year()),
+		Hours:  hours,
+		TypeID: reportType.ID,
+	}, nil
+}
+
+// monthKey - месяц сотрудника
+type monthKey struct {
+	userID      string
+	month, year int32
+}
+
+// month - отметки месяца сотрудника по дням: сохраненные и уже проверенные в файле
+type month struct {
+	hours map[int32]float64
+	types map[int32][]string
+}
+
+// days проверяет строки против закрытых месяцев, сохраненных отметок дня и суммы часов
+// за день. Месяц сотрудника загружается одним запросом при первой строке за него,
+// открытость месяца проверяется один раз.
+type days struct {
+	repo   repo.Querier
+	closed map[[2]int32]bool
+	months map[monthKey]*month
+}
+
+func newDays(repo repo.Querier) *days {
+	return &days{
+		repo:   repo,
+		closed: make(map[[2]int32]bool),
+		months: make(map[monthKey]*month),
+	}
+}
+
+func (d *days) check(ctx context.Context, row repo.CreateReportUserParams) error {
+	period := [2]int32{row.Month, row.Year}
+	closed, ok := d.closed[period]
+	if !ok {
+		err := closing.CheckMonthOpen(ctx, d.repo, row.Month, row.Year)
+		if err != nil && !errors.Is(err, closing.ErrMonthClosed) {
+			return err
+		}
+		closed = err != nil
+		d.closed[period] = closed
+	}
+	if closed {
+		return invalid("month %02d.%d is closed for payroll", row.Month, row.Year)
+	}
+
+	m, err := d.month(ctx, monthKey{userID: row.UserID, month: row.Month, year: row.Year})
+	if err != nil {
+		return err
+	}
+	if slices.Contains(m.types[row.Day], row.TypeID) {
+		return invalid("day already has an entry of this type")
+	}
+	if m.hours[row.Day]+row.Hours > report.MaxDayHours {
+		return invalid("total hours for the day exceed %g", report.MaxDayHours)
+	}
+
+	m.hours[row.Day] += row.Hours
+	m.types[row.Day] = append(m.types[row.Day], row.TypeID)
+	return nil
+}
+
+func (d *days) month(ctx context.Context, key monthKey) (*month, error) {
+	if m, ok := d.months[key]; ok {
+		return m, nil
+	}
+
+	reports, err := d.repo.GetReportUserForMonth(ctx, repo.GetReportUserForMonthParams{
+		UserID: key.userID,
+		Month:  key.month,
+		Year:   key.year,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get month reports: %w", err)
+	}
+
+	m := &month{hours: make(map[int32]float64), types: make(map[int32][]string)}
+	for _, r := range reports {
+		m.hours[r.Day] += r.Hours
+		m.types[r.Day] = append(m.types[r.Day], r.TypeID)
+	}
+	d.months[key] = m
+	return m, nil
+}
+
+// writeBatch записывает пачку в одной транзакции. Если после проверки файла месяц
+// закрыли или другой запрос сохранил отметку того же вида за тот же день, возвращает
+// ошибку строки (rowInvalid) и ее номер в файле.
+func (s *service) writeBatch(ctx context.Context, rows []repo.CreateReportUserParams, lines []int) (int, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	checked := make(map[[2]int32]bool)
+	for i, row := range rows {
+		if period := [2]int32{row.Month, row.Year}; !checked[period] {
+			err := closing.CheckMonthOpen(ctx, tx, row.Month, row.Year)
+			if errors.Is(err, closing.ErrMonthClosed) {
+				return lines[i], invalid("month %02d.%d is closed for payroll", row.Month, row.Year)
+			}
+			if err != nil {
+				return 0, err
+			}
+			checked[period] = true
+		}
+	}
+
+	for i, row := range rows {
+		if err := tx.CreateReportUser(ctx, row); err != nil {
+			if repo.IsDuplicateKey(err) {
+				return lines[i], invalid("day already has an entry of this type")
+			}
+			return 0, fmt.Errorf("create user report: %w", err)
+		}
+	}
+
+	return 0, tx.Commit()
+}
+
+// newReader читает CSV по записям; разделитель (',' или ';') определяется по заголовку
+func newReader(r io.Reader) (*csv.Reader, error) {
+	buffered := bufio.NewReader(r)
+	header, err := buffered.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("read csv: %w", err)
+	}
+
+	// Excel сохраняет CSV в UTF-8 с BOM
+	header = strings.TrimPrefix(header, "\ufeff")
+
+	reader := csv.NewReader(io.MultiReader(strings.NewReader(header), buffered))
+	reader.FieldsPerRecord = -1
+	if strings.Count(header, ";") > strings.Count(header, ",") {
+		reader.Comma = ';'
+	}
+	return reader, nil
+}
+
+// readError - ошибка разбора CSV или чтения файла
+func readError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return fmt.Errorf("%w: %s", ErrInvalidCSV, err.Error())
+	}
+	return fmt.Errorf("read csv: %w", err)
+}
+
+func headerColumns(header []string) (map[string]int, error) {
+	columns := make(map[string]int)
+	for i, name := range header {
+		if column, ok := columnAliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[column] = i
+		}
+	}
+
+	for _, column := range []string{"user", "date", "hours", "type"} {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingColumn, column)
+		}
+	}
+
+	return columns, nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, invalid("invalid date %q, expected YYYY-MM-DD or DD.MM.YYYY", value)
+}
@@ -0,0 +1,240 @@
+package importer
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	ivanID = "0b9c7f5e-4a3d-4c1e-9f7a-2d8e6b1c5a40"
+	annaID = "5d2e8a1f-7c3b-4e9d-8a6f-1b4c7e2d9f30"
+)
+
+// counting считает загрузки отметок месяца
+type counting struct {
+	*memory.Store
+	monthLoads int
+}
+
+func (c *counting) GetReportUserForMonth(ctx context.Context, arg repo.GetReportUserForMonthParams) ([]repo.GetReportUserForMonthRow, error) {
+	c.monthLoads++
+	return c.Store.GetReportUserForMonth(ctx, arg)
+}
+
+// racing перед каждой транзакцией выполняет before - запись другого запроса,
+// сделанную после проверки файла
+type racing struct {
+	*memory.Store
+	before func()
+}
+
+func (r *racing) Begin(ctx context.Context) (repo.Tx, error) {
+	if r.before != nil {
+		r.before()
+		r.before = nil
+	}
+	return r.Store.Begin(ctx)
+}
+
+// newTestStore - хранилище с видами отметок work и remote и отметкой Ивана за 05.05.2025
+func newTestStore(t *testing.T) *memory.Store {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.New()
+
+	for _, rt := range []repo.CreateTypeParams{
+		{ID: "t-work", Name: "Явка", SystemName: "work", Code: "Я"},
+		{ID: "t-remote", Name: "Удаленная работа", SystemName: "remote", Code: "УР"},
+	} {
+		if err := store.CreateType(ctx, rt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.CreateReportUser(ctx, repo.CreateReportUserParams{
+		ID: "r-1", UserID: ivanID, Day: 5, Month: 5, Year: 2025, Hours: 8, TypeID: "t-work",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func monthReports(t *testing.T, store *memory.Store, userID string, month, year int32) []repo.GetReportUserForMonthRow {
+	t.Helper()
+	reports, err := store.GetReportUserForMonth(context.Background(), repo.GetReportUserForMonthParams{UserID: userID, Month: month, Year: year})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reports
+}
+
+func TestImport(t *testing.T) {
+	store := newTestStore(t)
+	db := &counting{Store: store}
+	svc := NewService(db, store)
+
+	// BOM и разделитель ';' от Excel, дата в обоих форматах, запятая в часах
+	file := "\ufeffuser_id;date;hours;type\n" +
+		ivanID + ";2025-05-06;8;work\n" +
+		ivanID + ";07.05.2025;7,5;work\n" +
+		ivanID + ";2025-05-07;0,5;remote\n" +
+		annaID + ";2025-05-06;8;work\n" +
+		ivanID + ";2025-06-02;8;work\n"
+
+	result, err := svc.Import(context.Background(), strings.NewReader(file), Options{BatchSize: 2})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	want := &Result{Total: 5, Valid: 5, Imported: 5, Batches: 3, Errors: []RowError{}}
+	if !reflect.DeepEqual(result, want) {
+		t.Fatalf("result = %+v, want %+v", result, want)
+	}
+
+	if reports := monthReports(t, store, ivanID, 5, 2025); len(reports) != 4 {
+		t.Fatalf("Ivan's May = %+v, want the existing entry and three imported", reports)
+	}
+	if reports := monthReports(t, store, annaID, 5, 2025); len(reports) != 1 || reports[0].Hours != 8 {
+		t.Fatalf("Anna's May = %+v", reports)
+	}
+	// отметки месяца сотрудника загружаются один раз, а не на каждую строку
+	if db.monthLoads != 3 {
+		t.Fatalf("month loads = %d, want one per user and month", db.monthLoads)
+	}
+}
+
+// TestImportErrors - ошибки собираются по всем строкам с номерами строк файла,
+// и ни одна строка не записывается
+func TestImportErrors(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	if err := store.CloseMonth(ctx, repo.CloseMonthParams{Month: 4, Year: 2025}); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewService(store, store)
+
+	file := "user,date,hours,type,comment\n" +
+		ivanID + ",2025-05-06,8,work,ok\n" +
+		"ivan,2025-05-06,8,work,\n" +
+		ivanID + ",2025-13-01,8,work,\n" +
+		ivanID + ",2025-05-08,25,work,\n" +
+		ivanID + ",2025-05-08,8,overtime,\n" +
+		ivanID + ",2025-05-06,4,work,\n" +
+		ivanID + ",2025-05-05,4,work,\n" +
+		ivanID + ",2025-05-05,17,remote,\n" +
+		ivanID + ",2025-04-01,8,work,\"многострочный\nкомментарий\"\n" +
+		ivanID + ",2025-05-09,8,work,ok\n"
+
+	result, err := svc.Import(ctx, strings.NewReader(file), Options{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	want := []RowError{
+		{Line: 3, Error: "user must be a valid UUID"},
+		{Line: 4, Error: `invalid date "2025-13-01", expected YYYY-MM-DD or DD.MM.YYYY`},
+		{Line: 5, Error: "hours must be a number between 0 and 24"},
+		{Line: 6, Error: `unknown type "overtime"`},
+		{Line: 7, Error: "duplicate of line 2"},
+		{Line: 8, Error: "day already has an entry of this type"},
+		{Line: 9, Error: "total hours for the day exceed 24"},
+		{Line: 10, Error: "month 04.2025 is closed for payroll"},
+	}
+	if !reflect.DeepEqual(result.Errors, want) {
+		t.Fatalf("errors = %+v\nwant %+v", result.Errors, want)
+	}
+	if result.Total != 10 || result.Valid != 2 || result.Imported != 0 || result.Batches != 0 {
+		t.Fatalf("result = %+v", result)
+	}
+	if reports := monthReports(t, store, ivanID, 5, 2025); len(reports) != 1 {
+		t.Fatalf("reports written despite errors: %+v", reports)
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	store := newTestStore(t)
+	svc := NewService(store, store)
+
+	file := "user,date,hours,type\n" + ivanID + ",2025-05-06,8,work\n"
+	result, err := svc.Import(context.Background(), strings.NewReader(file), Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if !result.DryRun || result.Valid != 1 || result.Imported != 0 || len(result.Errors) != 0 {
+		t.Fatalf("result = %+v", result)
+	}
+	if reports := monthReports(t, store, ivanID, 5, 2025); len(reports) != 1 {
+		t.Fatalf("dry run wrote reports: %+v", reports)
+	}
+}
+
+// TestImportRace - отметка, которую другой запрос сохранил после проверки файла,
+// становится ошибкой строки, а не ошибкой сервера; записанные пачки остаются
+func TestImportRace(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	db := &racing{Store: store}
+	svc := NewService(store, db)
+
+	file := "user,date,hours,type\n" +
+		ivanID + ",2025-05-06,8,work\n" +
+		ivanID + ",2025-05-07,8,work\n" +
+		ivanID + ",2025-05-08,8,work\n"
+
+	db.before = func() {
+		if err := store.CreateReportUser(ctx, repo.CreateReportUserParams{
+			ID: "r-2", UserID: ivanID, Day: 7, Month: 5, Year: 2025, Hours: 4, TypeID: "t-work",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	result, err := svc.Import(ctx, strings.NewReader(file), Options{BatchSize: 3})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if want := []RowError{{Line: 3, Error: "day already has an entry of this type"}}; !reflect.DeepEqual(result.Errors, want) {
+		t.Fatalf("errors = %+v, want %+v", result.Errors, want)
+	}
+	if result.Imported != 0 || result.Batches != 0 {
+		t.Fatalf("result = %+v", result)
+	}
+	// пачка откатилась целиком: остались только исходная и параллельная отметки
+	if reports := monthReports(t, store, ivanID, 5, 2025); len(reports) != 2 {
+		t.Fatalf("reports = %+v", reports)
+	}
+
+	// месяц закрыли после проверки файла
+	db.before = func() {
+		if err := store.CloseMonth(ctx, repo.CloseMonthParams{Month: 5, Year: 2025}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	file = "user,date,hours,type\n" + annaID + ",2025-05-06,8,work\n"
+	result, err = svc.Import(ctx, strings.NewReader(file), Options{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if want := []RowError{{Line: 2, Error: "month 05.2025 is closed for payroll"}}; !reflect.DeepEqual(result.Errors, want) {
+		t.Fatalf("errors = %+v, want %+v", result.Errors, want)
+	}
+}
+
+func TestImportFile(t *testing.T) {
+	svc := NewService(memory.New(), memory.New())
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name, file string
+		want       error
+	}{
+		{"empty", "", ErrEmptyFile},
+		{"missing column", "user,date,hours\n", ErrMissingColumn},
+		{"malformed", "user,date,hours,type\n\"unclosed,2025-05-06,8,work\n", ErrInvalidCSV},
+	} {
+		if _, err := svc.Import(ctx, strings.NewReader(tt.file), Options{}); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// businessTimeout - сколько опрос /metrics ждет запросы бизнес-показателей
+const businessTimeout = 2 * time.Second
+
+// business - показатели, которые считаются запросом к базе при каждом опросе
+type business struct {
+	repo             repo.Querier
+	pendingVacations *prometheus.Desc
+}
+
+// NewBusiness - бизнес-показатели: заявления на отпуск, ожидающие решения
+func NewBusiness(repo repo.Querier) prometheus.Collector {
+	return &business{
+		repo: repo,
+		pendingVacations: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "vacations_pending"),
+			"Vacation requests awaiting approval.",
+			nil, nil,
+		),
+	}
+}
+
+func (b *business) Describe(ch chan<- *prometheus.Desc) {
+	ch <- b.pendingVacations
+}
+
+func (b *business) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), businessTimeout)
+	defer cancel()
+
+	pending, err := b.repo.CountVacationsByStatus(ctx, repo.ReportVacationStatusConsideration)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(b.pendingVacations, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(b.pendingVacations, prometheus.GaugeValue, float64(pending))
+}
@@ -0,0 +1,141 @@
+// Package metrics - метрики Prometheus для /metrics: HTTP-запросы по маршрутам,
+// пул соединений с базой, длительность запросов repo.Querier и бизнес-показатели.
+//
+// Метрики собираются в каждом процессе отдельно: при Prefork каждый опрос
+// /metrics попадает в один из дочерних процессов.
+package metrics
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/adapter/observe"
+	"TimeTrack/internal/apperr"
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "timetrack"
+
+type Metrics struct {
+	registry *prometheus.Registry
+	requests *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	queries  *prometheus.HistogramVec
+}
+
+// New регистрирует метрики процесса, HTTP и запросов к базе; статистика пула db
+// (*sql.DB.Stats) публикуется как go_sql_*{db_name="timetrack"}
+func New(db *sql.DB) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route and status.",
+		}, []string{"method", "route", "status"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		queries: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Database query latency by query name and result (ok, no_rows, error).",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"query", "result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(db, namespace),
+		m.requests,
+		m.latency,
+		m.queries,
+	)
+
+	return m
+}
+
+// Register добавляет сборщик, например бизнес-показатели (см. NewBusiness)
+func (m *Metrics) Register(c prometheus.Collector) {
+	m.registry.MustRegister(c)
+}
+
+// Handler - GET /metrics в формате Prometheus. Ошибка одного сборщика (например,
+// недоступная база для бизнес-показателей) не мешает отдать остальные метрики.
+func (m *Metrics) Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		ErrorHandling: promhttp.ContinueOnError,
+	}))
+}
+
+// Middleware считает запросы и их длительность. Маршрут берется из шаблона
+// (/v1/report/list/:user/:month/:year), а не из пути, чтобы число рядов не
+// зависело от идентификаторов в URL.
+func (m *Metrics) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			// ответ с ошибкой пишет обработчик ошибок Fiber уже после middleware
+			status = apperr.Status(err)
+		}
+
+		labels := prometheus.Labels{
+			"method": c.Method(),
+			"route":  c.Route().Path,
+			"status": strconv.Itoa(status),
+		}
+		m.requests.With(labels).Inc()
+		m.latency.With(labels).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+// Querier оборачивает запросы к базе, измеряя длительность каждого
+func (m *Metrics) Querier(q repo.Querier) repo.Querier {
+	return observe.Querier(q, m.observe)
+}
+
+// Streamer - то же для построчных выборок: длительность - до последней прочитанной строки
+func (m *Metrics) Streamer(s repo.Streamer) repo.Streamer {
+	return observe.Streamer(s, m.observe)
+}
+
+// TxBeginner - то же для запросов внутри транзакций
+func (m *Metrics) TxBeginner(b repo.TxBeginner) repo.TxBeginner {
+	return observe.TxBeginner(b, m.observe)
+}
+
+// observe - observe.Observer, измеряющий длительность запроса
+func (m *Metrics) observe(ctx context.Context, query string) (context.Context, func(err error)) {
+	start := time.Now()
+	return ctx, func(err error) {
+		m.observeQuery(query, start, err)
+	}
+}
+
+func (m *Metrics) observeQuery(name string, start time.Time, err error) {
+	result := "ok"
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		result = "no_rows"
+	case err != nil:
+		result = "error"
+	}
+	m.queries.WithLabelValues(name, result).Observe(time.Since(start).Seconds())
+}
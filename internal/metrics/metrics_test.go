@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/adapter/sqlite"
+	"context"
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newMetrics(t *testing.T) *Metrics {
+	t.Helper()
+	db, err := sqlite.Open(context.Background(), filepath.Join(t.TempDir(), "metrics.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return New(db)
+}
+
+// scrape - ответ /metrics
+func scrape(t *testing.T, app *fiber.App) string {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+// TestMiddleware - запросы считаются по шаблону маршрута, а не по пути
+func TestMiddleware(t *testing.T) {
+	m := newMetrics(t)
+	app := fiber.New()
+	app.Use(m.Middleware())
+	app.Get("/metrics", m.Handler())
+	app.Get("/v1/report/list/:user", func(c *fiber.Ctx) error { return c.SendString("ok") })
+	app.Get("/v1/fail", func(c *fiber.Ctx) error { return fiber.ErrConflict })
+
+	for _, path := range []string{"/v1/report/list/u-1", "/v1/report/list/u-2", "/v1/fail"} {
+		if _, err := app.Test(httptest.NewRequest("GET", path, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := testutil.ToFloat64(m.requests.WithLabelValues("GET", "/v1/report/list/:user", "200")); got != 2 {
+		t.Fatalf("requests = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.requests.WithLabelValues("GET", "/v1/fail", "409")); got != 1 {
+		t.Fatalf("failed requests = %v, want 1", got)
+	}
+
+	body := scrape(t, app)
+	for _, want := range []string{"timetrack_http_request_duration_seconds_bucket", `go_sql_max_open_connections{db_name="timetrack"}`, "go_goroutines"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/metrics has no %s", want)
+		}
+	}
+}
+
+func TestQuerier(t *testing.T) {
+	m := newMetrics(t)
+	store := memory.New()
+	q := m.Querier(store)
+	ctx := context.Background()
+
+	if _, err := q.GetVacationById(ctx, "missing"); err == nil {
+		t.Fatal("want sql.ErrNoRows")
+	}
+
+	tx, err := m.TxBeginner(store).Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.CreateType(ctx, repo.CreateTypeParams{ID: "t1", Name: "Работа", SystemName: "work", Code: "Я"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := testutil.CollectAndCount(m.queries, "timetrack_db_query_duration_seconds"); got != 2 {
+		t.Fatalf("query series = %d, want 2", got)
+	}
+	for _, labels := range [][2]string{{"GetVacationById", "no_rows"}, {"CreateType", "ok"}} {
+		if n := histogramCount(t, m, labels[0], labels[1]); n != 1 {
+			t.Errorf("%s %s: %d observations, want 1", labels[0], labels[1], n)
+		}
+	}
+}
+
+func histogramCount(t *testing.T, m *Metrics, query, result string) uint64 {
+	t.Helper()
+	families, err := m.registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range families {
+		if f.GetName() != "timetrack_db_query_duration_seconds" {
+			continue
+		}
+		for _, metric := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range metric.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["query"] == query && labels["result"] == result {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
+
+func TestBusiness(t *testing.T) {
+	store := memory.New()
+	ctx := context.Background()
+	day := time.Date(2026, time.July, 1, 0, 0, 0, 0, time.Local)
+	for i, status := range []repo.ReportVacationStatus{repo.ReportVacationStatusConsideration, repo.ReportVacationStatusConsideration, repo.ReportVacationStatusApproved} {
+		if err := store.CreateVacation(ctx, repo.CreateVacationParams{
+			ID: string(rune('a' + i)), UserID: "u1", StartDate: day, EndDate: day, Year: 2026, Status: status,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := `
+# HELP timetrack_vacations_pending Vacation requests awaiting approval.
+# TYPE timetrack_vacations_pending gauge
+timetrack_vacations_pending 2
+`
+	if err := testutil.CollectAndCompare(NewBusiness(store), strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,78 @@
+package notify
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/validate"
+	"database/sql"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NewHandler(service Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Contact(c *fiber.Ctx) error {
+	userID := c.Params("user")
+	if userID == "" {
+		return apperr.Required("user")
+	}
+
+	contact, err := h.service.Contact(c.UserContext(), userID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get user contact")
+	}
+
+	return c.JSON(contact)
+}
+
+type contactRequest struct {
+	UserID    string `json:"userId" validate:"required,uuid"`
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	ManagerID string `json:"managerId,omitempty" validate:"omitempty,uuid,nefield=UserID"`
+}
+
+func (h *Handler) SetContact(c *fiber.Ctx) error {
+	var req contactRequest
+	if err := validate.Body(c, &req); err != nil {
+		return err
+	}
+
+	contact, err := h.service.SetContact(c.UserContext(), repo.CreateUserContactParams{
+		UserID:    req.UserID,
+		Email:     req.Email,
+		Name:      req.Name,
+		ManagerID: sql.NullString{String: req.ManagerID, Valid: req.ManagerID != ""},
+	})
+	if err != nil {
+		return apperr.Wrap(err, "failed to set user contact")
+	}
+
+	return c.JSON(contact)
+}
+
+// Remind рассылает напоминания об отпусках, начинающихся через ?days= дней (по умолчанию 3)
+func (h *Handler) Remind(c *fiber.Ctx) error {
+	days := c.QueryInt("days", DefaultRemindDays)
+	if days < 0 || days > 365 {
+		return apperr.InvalidField("days", "must be between 0 and 365")
+	}
+
+	result, err := h.service.Remind(c.UserContext(), days)
+	if err != nil {
+		return apperr.Wrap(err, "failed to send vacation reminders")
+	}
+
+	return c.JSON(result)
+}
@@ -0,0 +1,20 @@
+package notify
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты уведомлений для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/v1/notify/contact/:user", Summary: "Контакт сотрудника для уведомлений",
+			Response: repo.ReportUserContact{}},
+		{Method: http.MethodPost, Path: "/v1/notify/contact", Summary: "Задать контакт сотрудника",
+			Request: contactRequest{}, Response: repo.ReportUserContact{}},
+		{Method: http.MethodPost, Path: "/v1/notify/remind", Summary: "Напомнить о незаполненном табеле",
+			Query:    []openapi.Param{{Name: "days", Description: "За сколько последних дней искать пропуски", Type: "integer"}},
+			Response: remindResult{}},
+	}
+}
@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Message - письмо в виде обычного текста
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Sender - способ доставки писем. В тестах и локально вместо SMTP можно
+// подставить любой сервер, принимающий SMTP (например, MailHog), или свою реализацию.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTPConfig - параметры SMTP-сервера. Если Addr пуст, письма только пишутся в лог.
+type SMTPConfig struct {
+	Addr     string
+	Username string
+	Password string
+	From     string
+}
+
+// NewSender выбирает отправителя по конфигурации
+func NewSender(cfg SMTPConfig, logger *slog.Logger) Sender {
+	if cfg.Addr == "" {
+		return &logSender{logger: logger}
+	}
+	return &smtpSender{cfg: cfg}
+}
+
+type smtpSender struct {
+	cfg SMTPConfig
+}
+
+func (s *smtpSender) Send(ctx context.Context, msg Message) error {
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		host, _, err := net.SplitHostPort(s.cfg.Addr)
+		if err != nil {
+			return fmt.Errorf("parse smtp address: %w", err)
+		}
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, host)
+	}
+
+	body, err := s.cfg.build(msg)
+	if err != nil {
+		return fmt.Errorf("build message: %w", err)
+	}
+
+	if err := smtp.SendMail(s.cfg.Addr, auth, s.cfg.From, msg.To, body); err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+
+	return nil
+}
+
+// build собирает письмо: заголовки в кодировке MIME, тело - quoted-printable UTF-8
+func (cfg SMTPConfig) build(msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", cfg.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(msg.Body, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// logSender - отправитель без SMTP: письма только пишутся в лог
+type logSender struct {
+	logger *slog.Logger
+}
+
+func (s *logSender) Send(ctx context.Context, msg Message) error {
+	s.logger.Info("mail is not configured, message skipped",
+		slog.String("to", strings.Join(msg.To, ", ")),
+		slog.String("subject", msg.Subject),
+	)
+	return nil
+}
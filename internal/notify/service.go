@@ -0,0 +1,273 @@
+package notify
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/tracing"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"time"
+)
+
+// DefaultRemindDays - за сколько дней до начала отпуска отправляется напоминание
+const DefaultRemindDays = 3
+
+var (
+	ErrContactNotFound = apperr.NotFound("contact_not_found", "contact not found")
+	ErrInvalidEmail    = apperr.Invalid("invalid_email", "invalid email address")
+)
+
+// VacationNotifier - уведомления, которые отправляет сервис отпусков.
+// Письма уходят в фоне, ошибки доставки только логируются.
+type VacationNotifier interface {
+	VacationSubmitted(ctx context.Context, vacationID string)
+	VacationStatusChanged(ctx context.Context, vacationID string)
+}
+
+type Service interface {
+	VacationNotifier
+	Remind(ctx context.Context, days int) (*remindResult, error)
+	Contact(ctx context.Context, userID string) (*repo.ReportUserContact, error)
+	SetContact(ctx context.Context, prm repo.CreateUserContactParams) (*repo.ReportUserContact, error)
+}
+
+type service struct {
+	repo   repo.Querier
+	db     repo.TxBeginner
+	sender Sender
+	logger *slog.Logger
+}
+
+func NewService(repo repo.Querier, db repo.TxBeginner, sender Sender, logger *slog.Logger) Service {
+	return &service{repo: repo, db: db, sender: sender, logger: logger}
+}
+
+// remindResult - итог рассылки напоминаний
+type remindResult struct {
+	Date    string `json:"date"`
+	Sent    int    `json:"sent"`
+	Skipped int    `json:"skipped"`
+	Failed  int    `json:"failed"`
+}
+
+// VacationSubmitted уведомляет руководителя сотрудника о новой заявке
+func (s *service) VacationSubmitted(ctx context.Context, vacationID string) {
+	ctx, end := tracing.Start(ctx, "notify.VacationSubmitted")
+	defer end(nil)
+
+	vacation, err := s.repo.GetVacationById(ctx, vacationID)
+	if err != nil {
+		s.fail(KindVacationSubmitted, vacationID, fmt.Errorf("get vacation: %w", err))
+		return
+	}
+
+	employee, err := s.contact(ctx, vacation.UserID)
+	if err != nil {
+		s.fail(KindVacationSubmitted, vacationID, err)
+		return
+	}
+	if employee == nil || !employee.ManagerID.Valid {
+		s.skip(KindVacationSubmitted, vacationID, "manager is not set")
+		return
+	}
+
+	manager, err := s.contact(ctx, employee.ManagerID.String)
+	if err != nil {
+		s.fail(KindVacationSubmitted, vacationID, err)
+		return
+	}
+	if manager == nil {
+		s.skip(KindVacationSubmitted, vacationID, "manager has no contact")
+		return
+	}
+
+	data := vacationData(vacation.StartDate, vacation.EndDate, vacation.Description)
+	data.Employee = displayName(employee, vacation.UserID)
+	data.Manager = manager.Name
+
+	s.sendAsync(KindVacationSubmitted, vacationID, manager.Email, data)
+}
+
+// VacationStatusChanged уведомляет сотрудника о согласовании или отклонении заявки
+func (s *service) VacationStatusChanged(ctx context.Context, vacationID string) {
+	ctx, end := tracing.Start(ctx, "notify.VacationStatusChanged")
+	defer end(nil)
+
+	vacation, err := s.repo.GetVacationById(ctx, vacationID)
+	if err != nil {
+		s.fail("vacation_status", vacationID, fmt.Errorf("get vacation: %w", err))
+		return
+	}
+
+	var kind string
+	switch vacation.Status {
+	case repo.ReportVacationStatusApproved:
+		kind = KindVacationApproved
+	case repo.ReportVacationStatusRejected:
+		kind = KindVacationRejected
+	default:
+		return
+	}
+
+	employee, err := s.contact(ctx, vacation.UserID)
+	if err != nil {
+		s.fail(kind, vacationID, err)
+		return
+	}
+	if employee == nil {
+		s.skip(kind, vacationID, "employee has no contact")
+		return
+	}
+
+	data := vacationData(vacation.StartDate, vacation.EndDate, vacation.Description)
+	data.Employee = displayName(employee, vacation.UserID)
+
+	s.sendAsync(kind, vacationID, employee.Email, data)
+}
+
+// Remind отправляет напоминания сотрудникам, чей согласованный отпуск начинается через days дней.
+// Запускается раз в день планировщиком (команда remind или POST /v1/notify/remind).
+func (s *service) Remind(ctx context.Context, days int) (_ *remindResult, err error) {
+	ctx, end := tracing.Start(ctx, "notify.Remind")
+	defer end(&err)
+
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), now.Day()+days, 0, 0, 0, 0, time.Local)
+
+	vacations, err := s.repo.GetVacationsApprovedStartingOn(ctx, date)
+	if err != nil {
+		return nil, fmt.Errorf("get upcoming vacations: %w", err)
+	}
+
+	result := remindResult{Date: date.Format(time.DateOnly)}
+	for _, vacation := range vacations {
+		employee, err := s.contact(ctx, vacation.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if employee == nil {
+			result.Skipped++
+			continue
+		}
+
+		data := vacationData(vacation.StartDate, vacation.EndDate, vacation.Description)
+		data.Employee = displayName(employee, vacation.UserID)
+		data.DaysLeft = days
+
+		if err := s.send(ctx, KindVacationReminder, employee.Email, data); err != nil {
+			s.fail(KindVacationReminder, vacation.ID, err)
+			result.Failed++
+			continue
+		}
+		result.Sent++
+	}
+
+	return &result, nil
+}
+
+func (s *service) Contact(ctx context.Context, userID string) (_ *repo.ReportUserContact, err error) {
+	ctx, end := tracing.Start(ctx, "notify.Contact", tracing.User(userID))
+	defer end(&err)
+
+	contact, err := s.contact(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if contact == nil {
+		return nil, ErrContactNotFound
+	}
+	return contact, nil
+}
+
+// SetContact заменяет адрес и руководителя сотрудника
+func (s *service) SetContact(ctx context.Context, prm repo.CreateUserContactParams) (_ *repo.ReportUserContact, err error) {
+	ctx, end := tracing.Start(ctx, "notify.SetContact", tracing.User(prm.UserID))
+	defer end(&err)
+
+	if _, err := mail.ParseAddress(prm.Email); err != nil {
+		return nil, ErrInvalidEmail
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := tx.DeleteUserContact(ctx, prm.UserID); err != nil {
+		return nil, fmt.Errorf("delete user contact: %w", err)
+	}
+	if err := tx.CreateUserContact(ctx, prm); err != nil {
+		return nil, fmt.Errorf("create user contact: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return s.Contact(ctx, prm.UserID)
+}
+
+// contact возвращает nil, если адрес сотрудника не задан
+func (s *service) contact(ctx context.Context, userID string) (*repo.ReportUserContact, error) {
+	contact, err := s.repo.GetUserContact(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get user contact: %w", err)
+	}
+	return &contact, nil
+}
+
+func (s *service) send(ctx context.Context, kind, to string, data messageData) error {
+	msg, err := render(kind, []string{to}, data)
+	if err != nil {
+		return err
+	}
+	return s.sender.Send(ctx, *msg)
+}
+
+// sendAsync отправляет письмо в фоне, не задерживая ответ на запрос
+func (s *service) sendAsync(kind, vacationID, to string, data messageData) {
+	go func() {
+		if err := s.send(context.Background(), kind, to, data); err != nil {
+			s.fail(kind, vacationID, err)
+		}
+	}()
+}
+
+func (s *service) fail(kind, vacationID string, err error) {
+	s.logger.Warn("failed to send notification",
+		slog.String("kind", kind),
+		slog.String("vacation_id", vacationID),
+		slog.String("error", err.Error()),
+	)
+}
+
+func (s *service) skip(kind, vacationID, reason string) {
+	s.logger.Info("notification skipped",
+		slog.String("kind", kind),
+		slog.String("vacation_id", vacationID),
+		slog.String("reason", reason),
+	)
+}
+
+func vacationData(start, end time.Time, description string) messageData {
+	return messageData{
+		Start:       start.Format(dateLayout),
+		End:         end.Format(dateLayout),
+		Description: description,
+	}
+}
+
+func displayName(contact *repo.ReportUserContact, userID string) string {
+	if contact != nil && contact.Name != "" {
+		return contact.Name
+	}
+	return userID
+}
@@ -0,0 +1,203 @@
+package notify
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sender запоминает письма вместо отправки; err - ошибка каждой отправки
+type sender struct {
+	sent chan Message
+	err  error
+}
+
+func (s *sender) Send(ctx context.Context, msg Message) error {
+	s.sent <- msg
+	return s.err
+}
+
+// next ждет письмо, отправленное в фоне
+func (s *sender) next(t *testing.T) Message {
+	t.Helper()
+	select {
+	case msg := <-s.sent:
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("no message sent")
+		return Message{}
+	}
+}
+
+// none проверяет, что писем не было. Пропущенные уведомления не запускают
+// фоновую отправку, поэтому ждать не нужно.
+func (s *sender) none(t *testing.T) {
+	t.Helper()
+	select {
+	case msg := <-s.sent:
+		t.Fatalf("unexpected message to %v: %q", msg.To, msg.Subject)
+	default:
+	}
+}
+
+// newTestService - сервис уведомлений над хранилищем в памяти: у сотрудника u-1
+// есть адрес и руководитель m-1, у сотрудника u-2 нет руководителя, у u-3 нет адреса
+func newTestService(t *testing.T) (Service, *memory.Store, *sender) {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.New()
+
+	for _, c := range []repo.CreateUserContactParams{
+		{UserID: "u-1", Email: "ivan@example.com", Name: "Иван Петров", ManagerID: sql.NullString{String: "m-1", Valid: true}},
+		{UserID: "m-1", Email: "anna@example.com", Name: "Анна Смирнова"},
+		{UserID: "u-2", Email: "oleg@example.com", Name: "Олег Иванов"},
+	} {
+		if err := store.CreateUserContact(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mail := &sender{sent: make(chan Message, 10)}
+	return NewService(store, store, mail, slog.New(slog.NewTextHandler(io.Discard, nil))), store, mail
+}
+
+func createVacation(t *testing.T, store *memory.Store, id, userID string, start time.Time, status repo.ReportVacationStatus) {
+	t.Helper()
+	if err := store.CreateVacation(context.Background(), repo.CreateVacationParams{
+		ID:          id,
+		UserID:      userID,
+		StartDate:   start,
+		EndDate:     start.AddDate(0, 0, 13),
+		Year:        int32(start.Year()),
+		Description: sql.NullString{String: "на море", Valid: true},
+		Status:      status,
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+var july7 = time.Date(2025, time.July, 7, 0, 0, 0, 0, time.Local)
+
+// TestVacationSubmitted - заявка приходит руководителю сотрудника
+func TestVacationSubmitted(t *testing.T) {
+	svc, store, mail := newTestService(t)
+	ctx := context.Background()
+	createVacation(t, store, "v-1", "u-1", july7, repo.ReportVacationStatusConsideration)
+
+	svc.VacationSubmitted(ctx, "v-1")
+	msg := mail.next(t)
+	if len(msg.To) != 1 || msg.To[0] != "anna@example.com" {
+		t.Fatalf("to = %v, want the manager", msg.To)
+	}
+	if msg.Subject != "Заявка на отпуск: Иван Петров" {
+		t.Fatalf("subject = %q", msg.Subject)
+	}
+	for _, want := range []string{"Здравствуйте, Анна Смирнова!", "Иван Петров подал(а) заявку на отпуск с 07.07.2025 по 20.07.2025.", "Комментарий: на море"} {
+		if !strings.Contains(msg.Body, want) {
+			t.Errorf("body does not contain %q:\n%s", want, msg.Body)
+		}
+	}
+
+	// без руководителя и без адреса письмо не отправляется
+	createVacation(t, store, "v-2", "u-2", july7, repo.ReportVacationStatusConsideration)
+	createVacation(t, store, "v-3", "u-3", july7, repo.ReportVacationStatusConsideration)
+	svc.VacationSubmitted(ctx, "v-2")
+	svc.VacationSubmitted(ctx, "v-3")
+	mail.none(t)
+}
+
+// TestVacationStatusChanged - решение по заявке приходит сотруднику
+func TestVacationStatusChanged(t *testing.T) {
+	svc, store, mail := newTestService(t)
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		status  repo.ReportVacationStatus
+		subject string
+		body    string
+	}{
+		{repo.ReportVacationStatusApproved, "Отпуск согласован", "Ваш отпуск с 07.07.2025 по 20.07.2025 согласован."},
+		{repo.ReportVacationStatusRejected, "Отпуск отклонен", "Ваша заявка на отпуск с 07.07.2025 по 20.07.2025 отклонена."},
+	} {
+		id := "v-" + string(tt.status)
+		createVacation(t, store, id, "u-1", july7, tt.status)
+
+		svc.VacationStatusChanged(ctx, id)
+		msg := mail.next(t)
+		if len(msg.To) != 1 || msg.To[0] != "ivan@example.com" {
+			t.Fatalf("%s: to = %v, want the employee", tt.status, msg.To)
+		}
+		if msg.Subject != tt.subject || !strings.HasPrefix(msg.Body, "Здравствуйте, Иван Петров!") || !strings.Contains(msg.Body, tt.body) {
+			t.Fatalf("%s: message = %q\n%s", tt.status, msg.Subject, msg.Body)
+		}
+	}
+
+	// заявка на рассмотрении не уведомляет
+	createVacation(t, store, "v-consideration", "u-1", july7, repo.ReportVacationStatusConsideration)
+	svc.VacationStatusChanged(ctx, "v-consideration")
+	mail.none(t)
+}
+
+// TestRemind - напоминание получают сотрудники с адресом, чей отпуск начинается через days дней
+func TestRemind(t *testing.T) {
+	svc, store, mail := newTestService(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), now.Day()+DefaultRemindDays, 0, 0, 0, 0, time.Local)
+	createVacation(t, store, "v-1", "u-1", start, repo.ReportVacationStatusApproved)
+	createVacation(t, store, "v-3", "u-3", start, repo.ReportVacationStatusApproved)
+	createVacation(t, store, "v-consideration", "u-2", start, repo.ReportVacationStatusConsideration)
+	createVacation(t, store, "v-later", "u-2", start.AddDate(0, 0, 1), repo.ReportVacationStatusApproved)
+
+	result, err := svc.Remind(ctx, DefaultRemindDays)
+	if err != nil {
+		t.Fatalf("Remind: %v", err)
+	}
+	if result.Sent != 1 || result.Skipped != 1 || result.Failed != 0 || result.Date != start.Format(time.DateOnly) {
+		t.Fatalf("result = %+v", result)
+	}
+
+	msg := mail.next(t)
+	if len(msg.To) != 1 || msg.To[0] != "ivan@example.com" || msg.Subject != "Напоминание об отпуске" {
+		t.Fatalf("message to %v: %q", msg.To, msg.Subject)
+	}
+	want := "Ваш отпуск начинается " + start.Format(dateLayout) + " (через 3 дн.) и продлится по " + start.AddDate(0, 0, 13).Format(dateLayout) + "."
+	if !strings.Contains(msg.Body, want) {
+		t.Fatalf("body does not contain %q:\n%s", want, msg.Body)
+	}
+	mail.none(t)
+
+	// ошибка отправки считается, но не прерывает рассылку
+	mail.err = errors.New("smtp is down")
+	result, err = svc.Remind(ctx, DefaultRemindDays)
+	if err != nil {
+		t.Fatalf("Remind: %v", err)
+	}
+	if result.Sent != 0 || result.Failed != 1 {
+		t.Fatalf("result with failing sender = %+v", result)
+	}
+}
+
+func TestRender(t *testing.T) {
+	msg, err := render(KindVacationSubmitted, []string{"anna@example.com"}, messageData{
+		Employee: "Иван Петров", Start: "07.07.2025", End: "20.07.2025",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(msg.Body, "Комментарий") || !strings.HasPrefix(msg.Body, "Здравствуйте!") {
+		t.Fatalf("body without manager and comment:\n%s", msg.Body)
+	}
+
+	if _, err := render("vacation_unknown", nil, messageData{}); err == nil {
+		t.Fatal("unknown kind rendered")
+	}
+}
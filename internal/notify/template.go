@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// Виды уведомлений
+const (
+	KindVacationSubmitted = "vacation_submitted"
+	KindVacationApproved  = "vacation_approved"
+	KindVacationRejected  = "vacation_rejected"
+	KindVacationReminder  = "vacation_reminder"
+)
+
+// dateLayout - формат дат в письмах
+const dateLayout = "02.01.2006"
+
+// messageData - поля, доступные в шаблонах писем
+type messageData struct {
+	Employee    string
+	Manager     string
+	Start       string
+	End         string
+	Description string
+	DaysLeft    int
+}
+
+type messageTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+func newTemplate(kind, subject, body string) messageTemplate {
+	return messageTemplate{
+		subject: template.Must(template.New(kind + "_subject").Option("missingkey=error").Parse(subject)),
+		body:    template.Must(template.New(kind).Option("missingkey=error").Parse(body)),
+	}
+}
+
+var templates = map[string]messageTemplate{
+	KindVacationSubmitted: newTemplate(KindVacationSubmitted,
+		"Заявка на отпуск: {{.Employee}}",
+		`Здравствуйте{{if .Manager}}, {{.Manager}}{{end}}!
+
+{{.Employee}} подал(а) заявку на отпуск с {{.Start}} по {{.End}}.
+{{- if .Description}}
+Комментарий: {{.Description}}
+{{- end}}
+
+Заявка ожидает рассмотрения.
+`),
+	KindVacationApproved: newTemplate(KindVacationApproved,
+		"Отпуск согласован",
+		`Здравствуйте, {{.Employee}}!
+
+Ваш отпуск с {{.Start}} по {{.End}} согласован.
+`),
+	KindVacationRejected: newTemplate(KindVacationRejected,
+		"Отпуск отклонен",
+		`Здравствуйте, {{.Employee}}!
+
+Ваша заявка на отпуск с {{.Start}} по {{.End}} отклонена.
+`),
+	KindVacationReminder: newTemplate(KindVacationReminder,
+		"Напоминание об отпуске",
+		`Здравствуйте, {{.Employee}}!
+
+Ваш отпуск начинается {{.Start}} (через {{.DaysLeft}} дн.) и продлится по {{.End}}.
+`),
+}
+
+// render собирает письмо по шаблону вида kind
+func render(kind string, to []string, data messageData) (*Message, error) {
+	t, ok := templates[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown notification kind %q", kind)
+	}
+
+	var subject, body bytes.Buffer
+	if err := t.subject.Execute(&subject, data); err != nil {
+		return nil, fmt.Errorf("render %s subject: %w", kind, err)
+	}
+	if err := t.body.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("render %s body: %w", kind, err)
+	}
+
+	return &Message{To: to, Subject: subject.String(), Body: body.String()}, nil
+}
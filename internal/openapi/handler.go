@@ -0,0 +1,87 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Адреса спецификации и документации
+const (
+	SpecPath = "/v1/openapi.json"
+	DocsPath = "/v1/docs"
+)
+
+// swaggerUI - Swagger UI для страницы документации. Версия зафиксирована точно:
+// опубликованные версии на unpkg не меняются, а политика CSP страницы разрешает
+// скрипты и стили только из этого каталога.
+const swaggerUI = "https://unpkg.com/swagger-ui-dist@5.17.14/"
+
+// Operations - маршруты самой документации
+func Operations() []Operation {
+	return []Operation{
+		{Method: http.MethodGet, Path: SpecPath, Summary: "Спецификация OpenAPI этого API", Content: fiber.MIMEApplicationJSON, Public: true},
+		{Method: http.MethodGet, Path: DocsPath, Summary: "Документация (Swagger UI)", Content: MIMEHTML, Public: true},
+	}
+}
+
+// Handler отдает спецификацию; документ сериализуется один раз
+func Handler(doc *openapi3.T) (fiber.Handler, error) {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal openapi: %w", err)
+	}
+
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(body)
+	}, nil
+}
+
+// Docs - страница Swagger UI для спецификации по адресу specURL. Ресурсы CDN
+// загружаются без учетных данных (crossorigin), а Content-Security-Policy
+// разрешает из встроенных скриптов только запуск Swagger UI - по его хешу.
+func Docs(specURL string) fiber.Handler {
+	// json.Marshal экранирует <, > и &, поэтому адрес не закроет тег script
+	url, _ := json.Marshal(specURL)
+	script := fmt.Sprintf(`window.ui = SwaggerUIBundle({url: %s, dom_id: "#swagger-ui"});`, url)
+	page := fmt.Sprintf(`<!doctype html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>TimeTrack API</title>
+<link rel="stylesheet" href="%[1]sswagger-ui.css" crossorigin="anonymous">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="%[1]sswagger-ui-bundle.js" crossorigin="anonymous"></script>
+<script>%[2]s</script>
+</body>
+</html>
+`, swaggerUI, script)
+	policy := docsPolicy(script)
+
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentSecurityPolicy, policy)
+		c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.SendString(page)
+	}
+}
+
+// docsPolicy - CSP страницы документации: скрипты и стили только из каталога
+// Swagger UI и встроенный скрипт script, запросы - к самому сервису
+func docsPolicy(script string) string {
+	sum := sha256.Sum256([]byte(script))
+	return "default-src 'none'; " +
+		"script-src " + swaggerUI + " 'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'; " +
+		"style-src " + swaggerUI + "; " +
+		"img-src 'self' data:; " +
+		"connect-src 'self'; " +
+		"base-uri 'none'; form-action 'none'; frame-ancestors 'none'"
+}
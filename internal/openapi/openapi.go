@@ -0,0 +1,449 @@
+// Package openapi собирает спецификацию OpenAPI 3 для /v1 из описаний маршрутов,
+// которые каждый пакет отдает функцией Operations рядом со своим обработчиком.
+//
+// Схемы тел строятся отражением по тем же типам, которые обработчики разбирают
+// и отдают (теги json), поэтому поля в спецификации не расходятся с кодом. Схемы
+// объектов закрыты (additionalProperties: false): контрактные тесты ловят и лишние,
+// и пропавшие поля. В ответах обязательны поля без omitempty; в запросах с тегами
+// validate (internal/validate) - поля с правилом required, а uuid, min, max и oneof
+// переходят в format, границы и enum.
+package openapi
+
+import (
+	"TimeTrack/internal/apperr"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+)
+
+// Типы содержимого, кроме JSON
+const (
+	MIMECSV    = "text/csv"
+	MIMENDJSON = "application/x-ndjson"
+	MIMEPDF    = "application/pdf"
+	MIMEXLSX   = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	MIMEHTML   = "text/html"
+	MIMEText   = "text/plain"
+)
+
+// Operation - описание маршрута
+type Operation struct {
+	Method string
+	// Path - путь в записи Fiber: /v1/report/list/:user/:month/:year
+	Path    string
+	Summary string
+	// Query - параметры строки запроса
+	Query []Param
+	// Request - значение типа тела запроса JSON; nil - без тела
+	Request any
+	// RequestContent - типы тела запроса не в JSON (text/csv, multipart/form-data)
+	RequestContent []string
+	// Status - статус успешного ответа, по умолчанию 200
+	Status int
+	// Response - значение типа тела успешного ответа JSON; nil - без тела
+	Response any
+	// Content - тип тела успешного ответа не в JSON (application/pdf)
+	Content string
+	// Export - ответ в формате по ?format= или Accept: JSON, CSV или NDJSON (export.Respond)
+	Export bool
+	// Responses - другие документированные ответы со своим телом JSON (например, 422 импорта)
+	Responses map[int]any
+	// Public - маршрут доступен без сессии
+	Public bool
+	// Admin - маршрут доступен только сессии с ролью admin (auth.RequireRole)
+	Admin bool
+}
+
+// Param - параметр строки запроса
+type Param struct {
+	Name        string
+	Description string
+	// Type - тип JSON Schema: string (по умолчанию), integer, boolean
+	Type string
+}
+
+// integerParams - параметры пути с числовыми значениями; остальные - строки
+var integerParams = map[string]bool{"day": true, "month": true, "year": true, "days": true}
+
+// dateParams - параметры пути с датой в формате 2006-01-02
+var dateParams = map[string]bool{"date": true, "from": true, "to": true}
+
+// New собирает спецификацию из описаний маршрутов
+func New(version string, operations ...[]Operation) (*openapi3.T, error) {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info: &openapi3.Info{
+			Title:       "TimeTrack API",
+			Description: "Табель учета рабочего времени, отпуска, графики и смены.",
+			Version:     version,
+		},
+		Paths: openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{},
+			SecuritySchemes: openapi3.SecuritySchemes{
+				"session": &openapi3.SecuritySchemeRef{Value: openapi3.NewJWTSecurityScheme().
+					WithDescription("Токен сессии после входа через /v1/auth/oidc/login; обязателен при AUTH_REQUIRED=true")},
+			},
+		},
+		// без AUTH_REQUIRED запросы без токена тоже принимаются
+		Security: openapi3.SecurityRequirements{{"session": []string{}}, {}},
+	}
+
+	g := &generator{schemas: doc.Components.Schemas}
+	errorSchema, err := g.schema(apperr.Response{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range operations {
+		for _, op := range group {
+			operation, err := g.operation(op, errorSchema)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", op.Method, op.Path, err)
+			}
+			doc.AddOperation(Path(op.Path), op.Method, operation)
+		}
+	}
+
+	return doc, nil
+}
+
+// Path переводит путь Fiber в путь OpenAPI: /list/:user/:year.xlsx -> /list/{user}/{year}.xlsx
+func Path(fiberPath string) string {
+	segments := strings.Split(fiberPath, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			name, ext, _ := strings.Cut(name, ".")
+			segments[i] = "{" + name + "}"
+			if ext != "" {
+				segments[i] += "." + ext
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// pathParams - имена параметров пути Fiber по порядку
+func pathParams(fiberPath string) []string {
+	var names []string
+	for _, segment := range strings.Split(fiberPath, "/") {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			name, _, _ = strings.Cut(name, ".")
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// tag - раздел документации по первому сегменту после /v1: /v1/report/... -> report
+func tag(fiberPath string) string {
+	segments := strings.Split(strings.TrimPrefix(fiberPath, "/v1/"), "/")
+	return segments[0]
+}
+
+type generator struct {
+	schemas openapi3.Schemas
+}
+
+func (g *generator) operation(op Operation, errorSchema *openapi3.SchemaRef) (*openapi3.Operation, error) {
+	operation := openapi3.NewOperation()
+	operation.Summary = op.Summary
+	operation.Tags = []string{tag(op.Path)}
+	operation.OperationID = strings.ToLower(op.Method) + strings.ReplaceAll(Path(op.Path), "/", "_")
+	if op.Public {
+		operation.Security = &openapi3.SecurityRequirements{}
+	}
+	if op.Admin {
+		operation.Description = "Требуется роль admin."
+	}
+
+	for _, name := range pathParams(op.Path) {
+		schema := openapi3.NewStringSchema()
+		switch {
+		case integerParams[name]:
+			schema = openapi3.NewInt32Schema()
+		case dateParams[name]:
+			schema = openapi3.NewStringSchema().WithFormat("date")
+		}
+		operation.AddParameter(openapi3.NewPathParameter(name).WithSchema(schema))
+	}
+	for _, p := range op.Query {
+		schema := openapi3.NewStringSchema()
+		switch p.Type {
+		case "integer":
+			schema = openapi3.NewIntegerSchema()
+		case "boolean":
+			schema = openapi3.NewBoolSchema()
+		}
+		operation.AddParameter(openapi3.NewQueryParameter(p.Name).WithDescription(p.Description).WithSchema(schema))
+	}
+	if op.Export {
+		operation.AddParameter(openapi3.NewQueryParameter("format").
+			WithDescription("Формат ответа; без него - по заголовку Accept, по умолчанию JSON").
+			WithSchema(openapi3.NewStringSchema().WithEnum("json", "csv", "ndjson", "jsonl")))
+	}
+
+	if op.Request != nil || len(op.RequestContent) > 0 {
+		body := openapi3.NewRequestBody().WithRequired(true)
+		content := openapi3.NewContent()
+		if op.Request != nil {
+			schema, err := g.schema(op.Request)
+			if err != nil {
+				return nil, err
+			}
+			content = openapi3.NewContentWithJSONSchemaRef(schema)
+		}
+		for _, mime := range op.RequestContent {
+			content[mime] = openapi3.NewMediaType()
+		}
+		operation.RequestBody = &openapi3.RequestBodyRef{Value: body.WithContent(content)}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success, err := g.response(status, op.Response, op.Content)
+	if err != nil {
+		return nil, err
+	}
+	if op.Export {
+		success.Content[MIMECSV] = openapi3.NewMediaType()
+		success.Content[MIMENDJSON] = openapi3.NewMediaType()
+	}
+	operation.AddResponse(status, success)
+
+	statuses := make([]int, 0, len(op.Responses))
+	for status := range op.Responses {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+	for _, status := range statuses {
+		response, err := g.response(status, op.Responses[status], "")
+		if err != nil {
+			return nil, err
+		}
+		operation.AddResponse(status, response)
+	}
+
+	operation.Responses.Set("default", &openapi3.ResponseRef{Value: openapi3.NewResponse().
+		WithDescription("Ошибка (internal/apperr)").
+		WithJSONSchemaRef(errorSchema)})
+
+	return operation, nil
+}
+
+func (g *generator) response(status int, body any, content string) (*openapi3.Response, error) {
+	response := openapi3.NewResponse().WithDescription(http.StatusText(status))
+	switch {
+	case content != "":
+		response.Content = openapi3.NewContent()
+		response.Content[content] = openapi3.NewMediaType()
+	case body != nil:
+		schema, err := g.schema(body)
+		if err != nil {
+			return nil, err
+		}
+		response.Content = openapi3.NewContentWithJSONSchemaRef(schema)
+	default:
+		response.Content = openapi3.NewContent()
+	}
+	return response, nil
+}
+
+// schema - схема типа value; именованные структуры попадают в components/schemas
+func (g *generator) schema(value any) (*openapi3.SchemaRef, error) {
+	return openapi3gen.NewSchemaRefForValue(value, g.schemas,
+		openapi3gen.UseAllExportedFields(),
+		openapi3gen.CreateComponentSchemas(openapi3gen.ExportComponentSchemasOptions{
+			ExportComponentSchemas: true,
+			ExportTopLevelSchema:   true,
+		}),
+		openapi3gen.CreateTypeNameGenerator(typeName),
+		openapi3gen.SchemaCustomizer(customize),
+	)
+}
+
+// typeName - имя схемы с пакетом, чтобы createRequest из разных пакетов не смешивались:
+// report.createRequest, sqlc.ReportVacation
+func typeName(t reflect.Type) string {
+	if t.Name() == "" {
+		return ""
+	}
+	return path.Base(t.PkgPath()) + "." + t.Name()
+}
+
+// customize закрывает объекты, отмечает обязательные поля, переносит правила validate
+// и разрешает null там, где encoding/json его выдает (nil-срезы и карты)
+func customize(name string, t reflect.Type, tag reflect.StructTag, schema *openapi3.Schema) error {
+	if err := applyRules(tag.Get("validate"), t, schema); err != nil {
+		return fmt.Errorf("field %s: %w", name, err)
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map:
+		schema.Nullable = true
+		// openapi3gen передает элементам тег поля целиком, и правила самого среза
+		// (min=1 - хотя бы один элемент) попадают в схему элемента; простые элементы
+		// строятся заново без правил
+		if err := plainItems(t, schema); err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+	case reflect.Struct:
+		// time.Time и структуры без полей схема описывает не объектом
+		if schema.Properties == nil {
+			return nil
+		}
+		closed := false
+		schema.AdditionalProperties = openapi3.AdditionalProperties{Has: &closed}
+		schema.Required = requiredFields(t)
+	}
+	return nil
+}
+
+// plainItems заменяет схему простых элементов (чисел, строк) среза или карты схемой
+// без правил validate. Структуры - ссылки на компоненты, их схема не меняется.
+func plainItems(t reflect.Type, schema *openapi3.Schema) error {
+	items := &schema.Items
+	if t.Kind() == reflect.Map {
+		items = &schema.AdditionalProperties.Schema
+	}
+	switch t.Elem().Kind() {
+	case reflect.Struct, reflect.Interface, reflect.Pointer:
+		return nil
+	}
+	if *items == nil {
+		return nil
+	}
+
+	plain, err := openapi3gen.NewSchemaRefForValue(reflect.Zero(t.Elem()).Interface(), nil)
+	if err != nil {
+		return err
+	}
+	*items = plain
+	return nil
+}
+
+// applyRules переносит в схему поля правила validate, которые выражаются в JSON Schema;
+// правила после dive относятся к элементам и здесь не учитываются
+func applyRules(rules string, t reflect.Type, schema *openapi3.Schema) error {
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		if name == "dive" {
+			return nil
+		}
+
+		switch name {
+		case "uuid", "uuid4":
+			schema.Format = "uuid"
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, value)
+			}
+		case "min", "max":
+			n, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				return fmt.Errorf("%s=%s: %w", name, param, err)
+			}
+			setBound(schema, t.Kind(), name == "min", n)
+		}
+	}
+	return nil
+}
+
+// setBound - граница min/max: для чисел значение, для строк длина, для срезов число элементов
+func setBound(schema *openapi3.Schema, kind reflect.Kind, lower bool, n float64) {
+	switch kind {
+	case reflect.String:
+		if lower {
+			schema.MinLength = uint64(n)
+		} else {
+			schema.MaxLength = openapi3.Ptr(uint64(n))
+		}
+	case reflect.Slice, reflect.Map:
+		if lower {
+			schema.MinItems = uint64(n)
+		} else {
+			schema.MaxItems = openapi3.Ptr(uint64(n))
+		}
+	default:
+		if lower {
+			schema.Min = &n
+		} else {
+			schema.Max = &n
+		}
+	}
+}
+
+// requiredFields - обязательные поля структуры. Если у полей есть теги validate
+// (тело запроса), обязательны поля с правилом required; иначе (ответ) - поля,
+// которые encoding/json выводит всегда, то есть без omitempty.
+func requiredFields(t reflect.Type) []string {
+	if hasRules(t) {
+		return requiredByRules(t)
+	}
+
+	var names []string
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		jsonTag, hasTag := field.Tag.Lookup("json")
+		name, options, _ := strings.Cut(jsonTag, ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && !hasTag && field.Type.Kind() == reflect.Struct {
+			names = append(names, requiredFields(field.Type)...)
+			continue
+		}
+		if strings.Contains(options, "omitempty") || strings.Contains(options, "omitzero") {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// hasRules - есть ли у полей структуры теги validate
+func hasRules(t reflect.Type) bool {
+	for i := range t.NumField() {
+		if _, ok := t.Field(i).Tag.Lookup("validate"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredByRules - поля с правилом validate required
+func requiredByRules(t reflect.Type) []string {
+	var names []string
+	for i := range t.NumField() {
+		field := t.Field(i)
+		rules := strings.Split(field.Tag.Get("validate"), ",")
+		if !field.IsExported() || !slices.Contains(rules, "required") {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
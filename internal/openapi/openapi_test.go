@@ -0,0 +1,151 @@
+package openapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestPath(t *testing.T) {
+	for fiberPath, want := range map[string]string{
+		"/v1/type/list":                       "/v1/type/list",
+		"/v1/report/list/:user/:month/:year":  "/v1/report/list/{user}/{month}/{year}",
+		"/v1/report/export/:month/:year.xlsx": "/v1/report/export/{month}/{year}.xlsx",
+		"/v1/vacation/:id/document.pdf":       "/v1/vacation/{id}/document.pdf",
+	} {
+		if got := Path(fiberPath); got != want {
+			t.Errorf("Path(%q) = %q, want %q", fiberPath, got, want)
+		}
+	}
+}
+
+type row struct {
+	ID      string    `json:"id"`
+	Comment *string   `json:"comment,omitempty"`
+	Tags    []string  `json:"tags"`
+	At      time.Time `json:"at"`
+}
+
+type request struct {
+	UserID string    `json:"userId" validate:"required,uuid"`
+	Hours  float64   `json:"hours" validate:"min=0,max=24"`
+	Status string    `json:"status" validate:"omitempty,oneof=open closed"`
+	Rows   []row     `json:"rows" validate:"max=10,dive"`
+	Limits []float64 `json:"limits" validate:"required,min=1"`
+}
+
+// schema - схема компонента name из спецификации с одной операцией
+func schema(t *testing.T, op Operation, name string) *openapi3.Schema {
+	t.Helper()
+	doc, err := New("test", []Operation{op})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.Validate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ref := loaded.Components.Schemas[name]
+	if ref == nil {
+		t.Fatalf("no schema %s in %v", name, loaded.Components.Schemas)
+	}
+	return ref.Value
+}
+
+// TestResponseSchema - в ответе обязательны поля без omitempty, срезы допускают null
+func TestResponseSchema(t *testing.T) {
+	s := schema(t, Operation{Method: http.MethodGet, Path: "/v1/row/:id", Response: row{}}, "openapi.row")
+
+	if want := []string{"id", "tags", "at"}; !reflect.DeepEqual(s.Required, want) {
+		t.Errorf("required = %v, want %v", s.Required, want)
+	}
+	if s.AdditionalProperties.Has == nil || *s.AdditionalProperties.Has {
+		t.Errorf("additionalProperties = %+v, want false", s.AdditionalProperties)
+	}
+	if !s.Properties["tags"].Value.Nullable || !s.Properties["comment"].Value.Nullable {
+		t.Errorf("tags and comment must be nullable")
+	}
+	if s.Properties["at"].Value.Format != "date-time" {
+		t.Errorf("at format = %q", s.Properties["at"].Value.Format)
+	}
+}
+
+// TestRequestSchema - в запросе обязательность и границы берутся из тегов validate
+func TestRequestSchema(t *testing.T) {
+	s := schema(t, Operation{Method: http.MethodPost, Path: "/v1/request", Request: request{}}, "openapi.request")
+
+	if want := []string{"userId", "limits"}; !reflect.DeepEqual(s.Required, want) {
+		t.Errorf("required = %v, want %v", s.Required, want)
+	}
+	if f := s.Properties["userId"].Value.Format; f != "uuid" {
+		t.Errorf("userId format = %q", f)
+	}
+	hours := s.Properties["hours"].Value
+	if hours.Min == nil || *hours.Min != 0 || hours.Max == nil || *hours.Max != 24 {
+		t.Errorf("hours bounds = %v..%v", hours.Min, hours.Max)
+	}
+	if enum := s.Properties["status"].Value.Enum; len(enum) != 2 || enum[0] != "open" {
+		t.Errorf("status enum = %v", enum)
+	}
+	if rows := s.Properties["rows"].Value; rows.MaxItems == nil || *rows.MaxItems != 10 {
+		t.Errorf("rows maxItems = %v", rows.MaxItems)
+	}
+	// min=1 у среза - число элементов, а не граница каждого элемента
+	if limits := s.Properties["limits"].Value; limits.MinItems != 1 || limits.Items.Value.Min != nil {
+		t.Errorf("limits minItems = %d, items min = %v", limits.MinItems, limits.Items.Value.Min)
+	}
+}
+
+// TestDocs - Swagger UI точной версии, а CSP пропускает только встроенный запуск по хешу
+func TestDocs(t *testing.T) {
+	app := fiber.New()
+	app.Get(DocsPath, Docs(SpecPath))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, DocsPath, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(body)
+
+	if !strings.Contains(page, `src="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui-bundle.js" crossorigin="anonymous"`) {
+		t.Errorf("page does not load a pinned Swagger UI:\n%s", page)
+	}
+	_, script, _ := strings.Cut(page, "<script>")
+	script, _, _ = strings.Cut(script, "</script>")
+	if !strings.Contains(script, `"/v1/openapi.json"`) {
+		t.Errorf("inline script = %q", script)
+	}
+
+	sum := sha256.Sum256([]byte(script))
+	policy := resp.Header.Get(fiber.HeaderContentSecurityPolicy)
+	for _, want := range []string{"default-src 'none'", "'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'", "frame-ancestors 'none'"} {
+		if !strings.Contains(policy, want) {
+			t.Errorf("policy %q does not contain %q", policy, want)
+		}
+	}
+	if strings.Contains(policy, "unsafe-inline") {
+		t.Errorf("policy allows inline scripts: %q", policy)
+	}
+}
@@ -0,0 +1,140 @@
+package payroll
+
+import (
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/export"
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NewHandler(service Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// List - показатели за закрытый месяц; норма - по графику каждого сотрудника
+func (h *Handler) List(c *fiber.Ctx) error {
+	month, year, err := monthYearParams(c)
+	if err != nil {
+		return err
+	}
+
+	rows, err := h.service.Rows(c.UserContext(), month, year)
+	if err != nil {
+		return apperr.Wrap(err, "failed to build payroll")
+	}
+
+	return export.Respond(c, h.logger, fmt.Sprintf("payroll-%d-%02d", year, month), rows)
+}
+
+// Export отдает файл для расчетной системы по настроенной раскладке
+func (h *Handler) Export(c *fiber.Ctx) error {
+	month, year, err := monthYearParams(c)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	layout, err := h.service.Export(c.UserContext(), month, year, &buf)
+	if err != nil {
+		return apperr.Wrap(err, "failed to export payroll")
+	}
+
+	c.Attachment(fmt.Sprintf("payroll-%d-%02d.%s", year, month, layout.Extension()))
+	c.Set(fiber.HeaderContentType, "text/plain; charset=utf-8")
+	if layout.Format == FormatCSV {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	}
+	return c.Send(buf.Bytes())
+}
+
+func (h *Handler) GetLayout(c *fiber.Ctx) error {
+	layout, err := h.service.Layout(c.UserContext())
+	if err != nil {
+		return apperr.Wrap(err, "failed to get payroll layout")
+	}
+
+	return c.JSON(layout)
+}
+
+func (h *Handler) SetLayout(c *fiber.Ctx) error {
+	var req Layout
+	if err := c.BodyParser(&req); err != nil {
+		return apperr.InvalidBody(err)
+	}
+
+	layout, err := h.service.SetLayout(c.UserContext(), req)
+	if err != nil {
+		return apperr.Wrap(err, "failed to update payroll layout")
+	}
+
+	return c.JSON(layout)
+}
+
+// Months - закрытые месяцы года
+func (h *Handler) Months(c *fiber.Ctx) error {
+	year, err := c.ParamsInt("year")
+	if err != nil || year < 1900 || year > 2100 {
+		return apperr.InvalidParam("year")
+	}
+
+	months, err := h.service.Months(c.UserContext(), int32(year))
+	if err != nil {
+		return apperr.Wrap(err, "failed to get closed months")
+	}
+
+	return c.JSON(months)
+}
+
+// Close закрывает месяц для изменения отметок и открывает расчет зарплаты за него
+func (h *Handler) Close(c *fiber.Ctx) error {
+	month, year, err := monthYearParams(c)
+	if err != nil {
+		return err
+	}
+
+	closed, err := h.service.Close(c.UserContext(), month, year)
+	if err != nil {
+		return apperr.Wrap(err, "failed to close month")
+	}
+
+	return c.Status(http.StatusCreated).JSON(closed)
+}
+
+func (h *Handler) Reopen(c *fiber.Ctx) error {
+	month, year, err := monthYearParams(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.Reopen(c.UserContext(), month, year); err != nil {
+		return apperr.Wrap(err, "failed to reopen month")
+	}
+
+	return nil
+}
+
+func monthYearParams(c *fiber.Ctx) (int32, int32, error) {
+	month, err := c.ParamsInt("month")
+	if err != nil || month < 1 || month > 12 {
+		return 0, 0, apperr.InvalidParam("month")
+	}
+
+	year, err := c.ParamsInt("year")
+	if err != nil || year < 1900 || year > 2100 {
+		return 0, 0, apperr.InvalidParam("year")
+	}
+
+	return int32(month), int32(year), nil
+}
@@ -0,0 +1,203 @@
+package payroll
+
+import (
+	"TimeTrack/internal/apperr"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatFixed = "fixed"
+)
+
+var (
+	ErrInvalidLayout = apperr.Invalid("invalid_layout", "invalid payroll layout")
+	ErrValueTooWide  = apperr.Unprocessable("value_too_wide", "value does not fit into column width")
+)
+
+// Layout - формат файла для расчетной системы: CSV или строки фиксированной ширины
+type Layout struct {
+	Format    string   `json:"format"`
+	Delimiter string   `json:"delimiter"`
+	Header    bool     `json:"header"`
+	Columns   []Column `json:"columns"`
+}
+
+// Column - столбец файла. Width и Align используются только в формате fixed.
+type Column struct {
+	Field    string `json:"field"`
+	Title    string `json:"title"`
+	Width    int    `json:"width"`
+	Align    string `json:"align"`
+	Decimals int    `json:"decimals"`
+}
+
+// fields - значения, которые можно вывести в файл
+var fields = map[string]func(r payrollRow, decimals int) string{
+	"user_id":              func(r payrollRow, _ int) string { return r.UserID },
+	"month":                func(r payrollRow, _ int) string { return strconv.Itoa(int(r.Month)) },
+	"year":                 func(r payrollRow, _ int) string { return strconv.Itoa(int(r.Year)) },
+	"worked_hours":         func(r payrollRow, d int) string { return formatHours(r.WorkedHours, d) },
+	"norm_hours":           func(r payrollRow, d int) string { return formatHours(r.NormHours, d) },
+	"overtime_hours":       func(r payrollRow, d int) string { return formatHours(r.OvertimeHours, d) },
+	"night_hours":          func(r payrollRow, d int) string { return formatHours(r.NightHours, d) },
+	"holiday_hours":        func(r payrollRow, d int) string { return formatHours(r.HolidayHours, d) },
+	"sick_days":            func(r payrollRow, _ int) string { return strconv.Itoa(r.SickDays) },
+	"paid_vacation_days":   func(r payrollRow, _ int) string { return strconv.Itoa(r.PaidVacationDays) },
+	"unpaid_vacation_days": func(r payrollRow, _ int) string { return strconv.Itoa(r.UnpaidVacationDays) },
+}
+
+// fieldOrder - порядок столбцов в раскладке по умолчанию
+var fieldOrder = []string{
+	"user_id", "month", "year",
+	"worked_hours", "norm_hours", "overtime_hours", "night_hours", "holiday_hours",
+	"sick_days", "paid_vacation_days", "unpaid_vacation_days",
+}
+
+// defaultLayout - раскладка, пока администратор не настроил свою
+func defaultLayout() Layout {
+	layout := Layout{Format: FormatCSV, Delimiter: ";", Header: true}
+	for _, field := range fieldOrder {
+		layout.Columns = append(layout.Columns, Column{Field: field, Title: field, Decimals: 2})
+	}
+	return layout
+}
+
+func (l *Layout) validate() error {
+	if len(l.Columns) == 0 {
+		return fmt.Errorf("%w: at least one column is required", ErrInvalidLayout)
+	}
+
+	switch l.Format {
+	case FormatCSV:
+		if utf8.RuneCountInString(l.Delimiter) != 1 {
+			return fmt.Errorf("%w: delimiter must be a single character", ErrInvalidLayout)
+		}
+	case FormatFixed:
+	default:
+		return fmt.Errorf("%w: format must be %q or %q", ErrInvalidLayout, FormatCSV, FormatFixed)
+	}
+
+	for i, col := range l.Columns {
+		if _, ok := fields[col.Field]; !ok {
+			return fmt.Errorf("%w: columns[%d]: unknown field %q", ErrInvalidLayout, i, col.Field)
+		}
+		if col.Decimals < 0 || col.Decimals > 4 {
+			return fmt.Errorf("%w: columns[%d]: decimals must be between 0 and 4", ErrInvalidLayout, i)
+		}
+		if l.Format == FormatFixed && col.Width <= 0 {
+			return fmt.Errorf("%w: columns[%d]: width is required for fixed format", ErrInvalidLayout, i)
+		}
+		if col.Align != "" && col.Align != "left" && col.Align != "right" {
+			return fmt.Errorf("%w: columns[%d]: align must be left or right", ErrInvalidLayout, i)
+		}
+	}
+
+	return nil
+}
+
+// Extension - расширение файла выгрузки
+func (l *Layout) Extension() string {
+	if l.Format == FormatFixed {
+		return "txt"
+	}
+	return "csv"
+}
+
+func (l *Layout) write(w io.Writer, rows []payrollRow) error {
+	if l.Format == FormatFixed {
+		return l.writeFixed(w, rows)
+	}
+	return l.writeCSV(w, rows)
+}
+
+func (l *Layout) writeCSV(w io.Writer, rows []payrollRow) error {
+	cw := csv.NewWriter(w)
+	cw.Comma, _ = utf8.DecodeRuneInString(l.Delimiter)
+
+	record := make([]string, len(l.Columns))
+
+	if l.Header {
+		for i, col := range l.Columns {
+			record[i] = col.title()
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range rows {
+		for i, col := range l.Columns {
+			record[i] = fields[col.Field](row, col.Decimals)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (l *Layout) writeFixed(w io.Writer, rows []payrollRow) error {
+	var line strings.Builder
+
+	if l.Header {
+		for _, col := range l.Columns {
+			title := []rune(col.title())
+			if len(title) > col.Width {
+				title = title[:col.Width]
+			}
+			line.WriteString(col.pad(string(title)))
+		}
+		line.WriteString("\n")
+	}
+
+	for _, row := range rows {
+		for _, col := range l.Columns {
+			value := fields[col.Field](row, col.Decimals)
+			if utf8.RuneCountInString(value) > col.Width {
+				return fmt.Errorf("%w: %s = %q, width %d", ErrValueTooWide, col.Field, value, col.Width)
+			}
+			line.WriteString(col.pad(value))
+		}
+		line.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, line.String())
+	return err
+}
+
+func (c Column) title() string {
+	if c.Title != "" {
+		return c.Title
+	}
+	return c.Field
+}
+
+// pad дополняет значение пробелами до ширины столбца; числа по умолчанию прижимаются вправо
+func (c Column) pad(value string) string {
+	padding := strings.Repeat(" ", c.Width-utf8.RuneCountInString(value))
+
+	align := c.Align
+	if align == "" {
+		align = "left"
+		if c.Field != "user_id" {
+			align = "right"
+		}
+	}
+
+	if align == "right" {
+		return padding + value
+	}
+	return value + padding
+}
+
+func formatHours(hours float64, decimals int) string {
+	return strconv.FormatFloat(hours, 'f', decimals, 64)
+}
@@ -0,0 +1,26 @@
+package payroll
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты выгрузки в расчетную систему для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/v1/payroll/list/:month/:year", Summary: "Показатели для расчета зарплаты за месяц",
+			Response: []payrollRow{}, Export: true},
+		{Method: http.MethodGet, Path: "/v1/payroll/export/:month/:year", Summary: "Файл для расчетной системы по раскладке",
+			Content: openapi.MIMECSV},
+		{Method: http.MethodGet, Path: "/v1/payroll/layout", Summary: "Раскладка файла выгрузки",
+			Response: Layout{}},
+		{Method: http.MethodPost, Admin: true, Path: "/v1/payroll/layout", Summary: "Задать раскладку файла выгрузки",
+			Request: Layout{}, Response: Layout{}},
+		{Method: http.MethodGet, Path: "/v1/payroll/months/:year", Summary: "Закрытые месяцы года",
+			Response: []repo.ReportMonthClose{}},
+		{Method: http.MethodPost, Admin: true, Path: "/v1/payroll/close/:month/:year", Summary: "Закрыть месяц для изменения отметок",
+			Status: http.StatusCreated, Response: repo.ReportMonthClose{}},
+		{Method: http.MethodDelete, Admin: true, Path: "/v1/payroll/close/:month/:year", Summary: "Снова открыть месяц"},
+	}
+}
@@ -0,0 +1,293 @@
+package payroll
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/calendar"
+	"TimeTrack/internal/schedule"
+	"TimeTrack/internal/tracing"
+	"TimeTrack/internal/vacation"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Системные имена типов отметок, учитываемых в расчете
+const (
+	medicalType = "medical"
+	unpaidType  = "unpaid"
+)
+
+var (
+	ErrMonthNotClosed     = apperr.Conflict("month_not_closed", "payroll is available only for a closed month")
+	ErrMonthNotEnded      = apperr.Conflict("month_not_ended", "month has not ended yet")
+	ErrMonthAlreadyClosed = apperr.Conflict("month_already_closed", "month is already closed")
+)
+
+// workTypes - типы отметок, часы которых считаются отработанными
+var workTypes = map[string]bool{
+	"work":    true,
+	"weekend": true,
+}
+
+type Service interface {
+	Rows(ctx context.Context, month, year int32) (*[]payrollRow, error)
+	Export(ctx context.Context, month, year int32, w io.Writer) (*Layout, error)
+	Layout(ctx context.Context) (*Layout, error)
+	SetLayout(ctx context.Context, layout Layout) (*Layout, error)
+	Months(ctx context.Context, year int32) (*[]repo.ReportMonthClose, error)
+	Close(ctx context.Context, month, year int32) (*repo.ReportMonthClose, error)
+	Reopen(ctx context.Context, month, year int32) error
+}
+
+type service struct {
+	repo      repo.Querier
+	db        repo.TxBeginner
+	calendar  calendar.Service
+	schedules schedule.Service
+	vacations vacation.Service
+}
+
+func NewService(repo repo.Querier, db repo.TxBeginner, calendar calendar.Service, schedules schedule.Service, vacations vacation.Service) Service {
+	return &service{repo: repo, db: db, calendar: calendar, schedules: schedules, vacations: vacations}
+}
+
+// payrollRow - показатели сотрудника за месяц для расчета зарплаты
+type payrollRow struct {
+	UserID             string  `json:"userId"`
+	Month              int32   `json:"month"`
+	Year               int32   `json:"year"`
+	WorkedHours        float64 `json:"workedHours"`
+	NormHours          float64 `json:"normHours"`
+	OvertimeHours      float64 `json:"overtimeHours"`
+	NightHours         float64 `json:"nightHours"`
+	HolidayHours       float64 `json:"holidayHours"`
+	SickDays           int     `json:"sickDays"`
+	PaidVacationDays   int     `json:"paidVacationDays"`
+	UnpaidVacationDays int     `json:"unpaidVacationDays"`
+}
+
+// Rows собирает показатели всех сотрудников, у которых в месяце есть отметки или отпуск.
+// Месяц должен быть закрыт (Close): после закрытия отметки за него не меняются.
+// Норма - часы по графику сотрудника (schedule.ExpectedMonth), переработка - сверх нормы.
+func (s *service) Rows(ctx context.Context, month, year int32) (_ *[]payrollRow, err error) {
+	ctx, end := tracing.Start(ctx, "payroll.Rows", tracing.Month(month), tracing.Year(year))
+	defer end(&err)
+
+	_, err = s.repo.GetMonthClose(ctx, repo.GetMonthCloseParams{Month: month, Year: year})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrMonthNotClosed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get month close: %w", err)
+	}
+
+	calendarDays, err := s.calendar.MonthDays(ctx, month, year)
+	if err != nil {
+		return nil, fmt.Errorf("get calendar month: %w", err)
+	}
+
+	holidays := make(map[int32]bool)
+	for i, d := range *calendarDays {
+		if d.Kind == calendar.DayKindHoliday {
+			holidays[int32(i+1)] = true
+		}
+	}
+
+	reports, err := s.repo.GetReportUserForMonthAll(ctx, repo.GetReportUserForMonthAllParams{
+		Month: month,
+		Year:  year,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get month reports: %w", err)
+	}
+
+	vacationDays, err := s.vacations.ApprovedDaysInMonth(ctx, month, year)
+	if err != nil {
+		return nil, fmt.Errorf("get vacation days: %w", err)
+	}
+
+	users := make(map[string]*payrollRow)
+	row := func(userID string) *payrollRow {
+		r, ok := users[userID]
+		if !ok {
+			r = &payrollRow{UserID: userID, Month: month, Year: year}
+			users[userID] = r
+		}
+		return r
+	}
+
+	sickDays := make(map[string]map[int32]bool)
+	unpaidDays := make(map[string]map[int32]bool)
+	markDay := func(days map[string]map[int32]bool, userID string, day int32) {
+		if days[userID] == nil {
+			days[userID] = make(map[int32]bool)
+		}
+		days[userID][day] = true
+	}
+
+	for _, r := range reports {
+		p := row(r.UserID)
+
+		switch {
+		case workTypes[r.TypeSystemName]:
+			p.WorkedHours += r.Hours
+			p.NightHours += r.NightHours
+			if holidays[r.Day] {
+				p.HolidayHours += r.Hours
+			}
+		case r.TypeSystemName == medicalType:
+			markDay(sickDays, r.UserID, r.Day)
+		case r.TypeSystemName == unpaidType:
+			markDay(unpaidDays, r.UserID, r.Day)
+		}
+	}
+
+	for userID, days := range vacationDays {
+		row(userID).PaidVacationDays = int(days)
+	}
+
+	rows := make([]payrollRow, 0, len(users))
+	for userID, p := range users {
+		expected, err := s.schedules.ExpectedMonth(ctx, userID, month, year)
+		if err != nil {
+			return nil, fmt.Errorf("get expected hours: %w", err)
+		}
+		p.NormHours = expected.TotalHours
+		p.SickDays = len(sickDays[userID])
+		p.UnpaidVacationDays = len(unpaidDays[userID])
+		if p.NormHours > 0 && p.WorkedHours > p.NormHours {
+			p.OvertimeHours = p.WorkedHours - p.NormHours
+		}
+		rows = append(rows, *p)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].UserID < rows[j].UserID
+	})
+
+	return &rows, nil
+}
+
+// Export записывает показатели за месяц в файл по настроенной раскладке
+func (s *service) Export(ctx context.Context, month, year int32, w io.Writer) (_ *Layout, err error) {
+	ctx, end := tracing.Start(ctx, "payroll.Export", tracing.Month(month), tracing.Year(year))
+	defer end(&err)
+
+	layout, err := s.Layout(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Rows(ctx, month, year)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := layout.write(w, *rows); err != nil {
+		return nil, err
+	}
+
+	return layout, nil
+}
+
+// Layout возвращает сохраненную раскладку файла или раскладку по умолчанию
+func (s *service) Layout(ctx context.Context) (_ *Layout, err error) {
+	ctx, end := tracing.Start(ctx, "payroll.Layout")
+	defer end(&err)
+
+	stored, err := s.repo.GetSettingPayrollLayout(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get payroll layout: %w", err)
+	}
+
+	layout := defaultLayout()
+	if stored.Valid && stored.String != "" {
+		layout = Layout{}
+		if err := json.Unmarshal([]byte(stored.String), &layout); err != nil {
+			return nil, fmt.Errorf("decode payroll layout: %w", err)
+		}
+	}
+
+	return &layout, nil
+}
+
+func (s *service) SetLayout(ctx context.Context, layout Layout) (_ *Layout, err error) {
+	ctx, end := tracing.Start(ctx, "payroll.SetLayout")
+	defer end(&err)
+
+	if err := layout.validate(); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(layout)
+	if err != nil {
+		return nil, fmt.Errorf("encode payroll layout: %w", err)
+	}
+
+	if err := s.repo.UpdateSettingPayrollLayout(ctx, sql.NullString{String: string(data), Valid: true}); err != nil {
+		return nil, fmt.Errorf("update payroll layout: %w", err)
+	}
+
+	return &layout, nil
+}
+
+// Months возвращает закрытые месяцы года
+func (s *service) Months(ctx context.Context, year int32) (_ *[]repo.ReportMonthClose, err error) {
+	ctx, end := tracing.Start(ctx, "payroll.Months", tracing.Year(year))
+	defer end(&err)
+
+	months, err := s.repo.GetMonthClosesByYear(ctx, year)
+	if err != nil {
+		return nil, fmt.Errorf("get closed months: %w", err)
+	}
+	if months == nil {
+		months = []repo.ReportMonthClose{}
+	}
+
+	return &months, nil
+}
+
+// Close закрывает закончившийся месяц: отметки за него больше не меняются,
+// а показатели для расчета зарплаты становятся доступны
+func (s *service) Close(ctx context.Context, month, year int32) (_ *repo.ReportMonthClose, err error) {
+	ctx, end := tracing.Start(ctx, "payroll.Close", tracing.Month(month), tracing.Year(year))
+	defer end(&err)
+
+	ended := time.Date(int(year), time.Month(month)+1, 1, 0, 0, 0, 0, time.Local)
+	if time.Now().Before(ended) {
+		return nil, ErrMonthNotEnded
+	}
+
+	err = s.repo.CloseMonth(ctx, repo.CloseMonthParams{Month: month, Year: year})
+	if repo.IsDuplicateKey(err) {
+		return nil, ErrMonthAlreadyClosed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("close month: %w", err)
+	}
+
+	closed, err := s.repo.GetMonthClose(ctx, repo.GetMonthCloseParams{Month: month, Year: year})
+	if err != nil {
+		return nil, fmt.Errorf("get month close: %w", err)
+	}
+
+	return &closed, nil
+}
+
+// Reopen снова открывает месяц для изменения отметок
+func (s *service) Reopen(ctx context.Context, month, year int32) (err error) {
+	ctx, end := tracing.Start(ctx, "payroll.Reopen", tracing.Month(month), tracing.Year(year))
+	defer end(&err)
+
+	if err := s.repo.ReopenMonth(ctx, repo.ReopenMonthParams{Month: month, Year: year}); err != nil {
+		return fmt.Errorf("reopen month: %w", err)
+	}
+
+	return nil
+}
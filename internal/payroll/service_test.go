@@ -0,0 +1,233 @@
+package payroll
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/calendar"
+	"TimeTrack/internal/closing"
+	"TimeTrack/internal/schedule"
+	"TimeTrack/internal/shift"
+	"TimeTrack/internal/vacation"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// nop - публикация событий и уведомления, которые тестам не нужны
+type nop struct{}
+
+func (nop) Publish(ctx context.Context, event string, data any)          {}
+func (nop) VacationSubmitted(ctx context.Context, vacationID string)     {}
+func (nop) VacationStatusChanged(ctx context.Context, vacationID string) {}
+
+const userID = "0b9c7f5e-4a3d-4c1e-9f7a-2d8e6b1c5a40"
+
+// newTestService - сервис расчета над хранилищем в памяти; у userID в мае 2025 года
+// рабочая отметка с ночными часами и больничный, у которого ночные часы не учитываются
+func newTestService(t *testing.T) (Service, *memory.Store) {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.New()
+
+	for _, rt := range []repo.CreateTypeParams{
+		{ID: "t-work", Name: "Явка", SystemName: "work", Code: "Я"},
+		{ID: "t-medical", Name: "Больничный", SystemName: "medical", Code: "Б"},
+	} {
+		if err := store.CreateType(ctx, rt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, r := range []repo.CreateReportUserParams{
+		{ID: "r-1", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 8, TypeID: "t-work", NightHours: 2},
+		{ID: "r-2", UserID: userID, Day: 6, Month: 5, Year: 2025, Hours: 8, TypeID: "t-medical", NightHours: 3},
+	} {
+		if err := store.CreateReportUser(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	calendarService := calendar.NewService(store, store, nop{})
+	schedules := schedule.NewService(store, store, calendarService)
+	vacations := vacation.NewService(store, store, store, nop{}, nop{})
+	return NewService(store, store, calendarService, schedules, vacations), store
+}
+
+// TestRowsClosedMonth - показатели доступны только после закрытия месяца
+func TestRowsClosedMonth(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	if _, err := svc.Rows(ctx, 5, 2025); !errors.Is(err, ErrMonthNotClosed) {
+		t.Fatalf("open month: err = %v, want ErrMonthNotClosed", err)
+	}
+
+	if _, err := svc.Close(ctx, 5, 2025); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	rows, err := svc.Rows(ctx, 5, 2025)
+	if err != nil {
+		t.Fatalf("closed month: %v", err)
+	}
+	if len(*rows) != 1 {
+		t.Fatalf("rows = %+v", *rows)
+	}
+	if row := (*rows)[0]; row.WorkedHours != 8 || row.NightHours != 2 || row.SickDays != 1 {
+		t.Fatalf("row = %+v, want night hours of the work entry only", row)
+	}
+
+	if err := svc.Reopen(ctx, 5, 2025); err != nil {
+		t.Fatalf("Reopen: %v", err)
+	}
+	if _, err := svc.Rows(ctx, 5, 2025); !errors.Is(err, ErrMonthNotClosed) {
+		t.Fatalf("reopened month: err = %v, want ErrMonthNotClosed", err)
+	}
+}
+
+// TestRowsNorm - норма и переработка считаются по графику каждого сотрудника
+func TestRowsNorm(t *testing.T) {
+	svc, store := newTestService(t)
+	ctx := context.Background()
+	schedules := schedule.NewService(store, store, calendar.NewService(store, store, nop{}))
+
+	// у второго сотрудника неполный день: 4 часа с понедельника по пятницу
+	const partTimeID = "5d2e8a1f-7c3b-4e9d-8a6f-1b4c7e2d9f30"
+	if _, err := schedules.Create(ctx, schedule.CreateScheduleParams{
+		ID: "s-half", Name: "Полставки", Kind: repo.ReportScheduleKindWeekly, Hours: []float64{4, 4, 4, 4, 4, 0, 0},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := schedules.Assign(ctx, repo.CreateUserScheduleParams{
+		ID: "a-1", UserID: partTimeID, ScheduleID: "s-half", EffectiveFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for day := int32(12); day <= 23; day++ {
+		if err := store.CreateReportUser(ctx, repo.CreateReportUserParams{
+			ID: fmt.Sprintf("r-half-%d", day), UserID: partTimeID, Day: day, Month: 5, Year: 2025, Hours: 8, TypeID: "t-work",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	full, err := schedules.ExpectedMonth(ctx, userID, 5, 2025)
+	if err != nil {
+		t.Fatal(err)
+	}
+	half, err := schedules.ExpectedMonth(ctx, partTimeID, 5, 2025)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if half.TotalHours*2 != full.TotalHours {
+		t.Fatalf("part-time norm = %g, full norm = %g", half.TotalHours, full.TotalHours)
+	}
+
+	if _, err := svc.Close(ctx, 5, 2025); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	rows, err := svc.Rows(ctx, 5, 2025)
+	if err != nil {
+		t.Fatalf("Rows: %v", err)
+	}
+	norms := make(map[string]payrollRow)
+	for _, row := range *rows {
+		norms[row.UserID] = row
+	}
+	if row := norms[userID]; row.NormHours != full.TotalHours || row.OvertimeHours != 0 {
+		t.Errorf("full-time row = %+v, want norm %g", row, full.TotalHours)
+	}
+	if row := norms[partTimeID]; row.NormHours != half.TotalHours || row.OvertimeHours != 96-half.TotalHours {
+		t.Errorf("part-time row = %+v, want norm %g and overtime above it", row, half.TotalHours)
+	}
+}
+
+// TestClosedMonthWrites - после закрытия месяца не меняются отпуска, смены
+// и распределение по проектам, которые входят в выгрузку
+func TestClosedMonthWrites(t *testing.T) {
+	svc, store := newTestService(t)
+	ctx := context.Background()
+
+	vacations := vacation.NewService(store, store, store, nop{}, nop{})
+	shifts := shift.NewService(store, store)
+
+	if _, err := vacations.Create(ctx, repo.CreateVacationParams{
+		ID: "v-1", UserID: userID, StartDate: time.Date(2025, 4, 28, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC),
+		Year: 2025, Status: repo.ReportVacationStatusConsideration,
+	}); err != nil {
+		t.Fatalf("create vacation: %v", err)
+	}
+	if _, err := shifts.Plan(ctx, repo.CreateShiftParams{ID: "sh-1", UserID: userID, Day: 12, Month: 5, Year: 2025, StartMinute: 540, EndMinute: 1080}); err != nil {
+		t.Fatalf("plan shift: %v", err)
+	}
+
+	if _, err := svc.Close(ctx, 5, 2025); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	for name, write := range map[string]func() error{
+		"create vacation": func() error {
+			_, err := vacations.Create(ctx, repo.CreateVacationParams{
+				ID: "v-2", UserID: userID, StartDate: time.Date(2025, 4, 28, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 5, 4, 0, 0, 0, 0, time.UTC),
+				Year: 2025, Status: repo.ReportVacationStatusConsideration,
+			})
+			return err
+		},
+		"plan shift": func() error {
+			_, err := shifts.Plan(ctx, repo.CreateShiftParams{ID: "sh-2", UserID: userID, Day: 13, Month: 5, Year: 2025, StartMinute: 540, EndMinute: 1080})
+			return err
+		},
+		"update shift": func() error {
+			_, err := shifts.Update(ctx, repo.UpdateShiftParams{ID: "sh-1", StartMinute: 600, EndMinute: 1080})
+			return err
+		},
+		"delete shift": func() error { return shifts.Delete(ctx, "sh-1") },
+	} {
+		if err := write(); !errors.Is(err, closing.ErrMonthClosed) {
+			t.Errorf("%s: err = %v, want ErrMonthClosed", name, err)
+		}
+	}
+
+	// апрель открыт: отпуск в нем можно согласовать и удалить
+	if err := vacations.ChangeStatus(ctx, repo.UpdateVacationStatusParams{ID: "v-1", Status: repo.ReportVacationStatusApproved}); err != nil {
+		t.Fatalf("approve vacation in an open month: %v", err)
+	}
+	if _, err := svc.Close(ctx, 4, 2025); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := vacations.ChangeStatus(ctx, repo.UpdateVacationStatusParams{ID: "v-1", Status: repo.ReportVacationStatusRejected}); !errors.Is(err, closing.ErrMonthClosed) {
+		t.Errorf("change status: err = %v, want ErrMonthClosed", err)
+	}
+	if err := vacations.Delete(ctx, "v-1"); !errors.Is(err, closing.ErrMonthClosed) {
+		t.Errorf("delete vacation: err = %v, want ErrMonthClosed", err)
+	}
+}
+
+func TestClose(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	if _, err := svc.Close(ctx, int32(now.Month()), int32(now.Year())); !errors.Is(err, ErrMonthNotEnded) {
+		t.Fatalf("current month: err = %v, want ErrMonthNotEnded", err)
+	}
+
+	closed, err := svc.Close(ctx, 4, 2025)
+	if err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if closed.Month != 4 || closed.Year != 2025 || closed.CloseAt.IsZero() {
+		t.Fatalf("closed = %+v", closed)
+	}
+	if _, err := svc.Close(ctx, 4, 2025); !errors.Is(err, ErrMonthAlreadyClosed) {
+		t.Fatalf("close twice: err = %v, want ErrMonthAlreadyClosed", err)
+	}
+
+	months, err := svc.Months(ctx, 2025)
+	if err != nil {
+		t.Fatalf("Months: %v", err)
+	}
+	if len(*months) != 1 || (*months)[0].Month != 4 {
+		t.Fatalf("months = %+v", *months)
+	}
+}
@@ -0,0 +1,284 @@
+package project
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/validate"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NewHandler(service Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) List(c *fiber.Ctx) error {
+	projects, err := h.service.List(c.UserContext())
+	if err != nil {
+		return apperr.Wrap(err, "failed to get projects")
+	}
+
+	return c.JSON(projects)
+}
+
+type createRequest struct {
+	Code   string `json:"code" validate:"required"`
+	Name   string `json:"name" validate:"required"`
+	Client string `json:"client"`
+}
+
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req createRequest
+	if err := validate.Body(c, &req); err != nil {
+		return err
+	}
+
+	project, err := h.service.Create(c.UserContext(), repo.CreateProjectParams{
+		ID:       uuid.NewString(),
+		Code:     req.Code,
+		Name:     req.Name,
+		Client:   sql.NullString{String: req.Client, Valid: req.Client != ""},
+		IsActive: true,
+	})
+	if err != nil {
+		return apperr.Wrap(err, "failed to create project")
+	}
+
+	return c.Status(http.StatusCreated).JSON(project)
+}
+
+type updateRequest struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Client   string `json:"client"`
+	IsActive bool   `json:"isActive"`
+}
+
+func (h *Handler) Update(c *fiber.Ctx) error {
+	var req updateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperr.InvalidBody(err)
+	}
+
+	if _, err := uuid.Parse(req.ID); err != nil {
+		return apperr.InvalidField("id", "must be a valid UUID")
+	}
+	if req.Name == "" {
+		return apperr.Required("name")
+	}
+
+	project, err := h.service.Update(c.UserContext(), repo.UpdateProjectParams{
+		Name:     req.Name,
+		Client:   sql.NullString{String: req.Client, Valid: req.Client != ""},
+		IsActive: req.IsActive,
+		ID:       req.ID,
+	})
+	if err != nil {
+		return apperr.Wrap(err, "failed to update project")
+	}
+
+	return c.JSON(project)
+}
+
+func (h *Handler) Tasks(c *fiber.Ctx) error {
+	projectID := c.Params("project")
+	if projectID == "" {
+		return apperr.Required("project")
+	}
+
+	tasks, err := h.service.Tasks(c.UserContext(), projectID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get tasks")
+	}
+
+	return c.JSON(tasks)
+}
+
+type createTaskRequest struct {
+	ProjectID string `json:"projectId"`
+	Name      string `json:"name"`
+}
+
+func (h *Handler) CreateTask(c *fiber.Ctx) error {
+	var req createTaskRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperr.InvalidBody(err)
+	}
+
+	if _, err := uuid.Parse(req.ProjectID); err != nil {
+		return apperr.InvalidField("projectId", "must be a valid UUID")
+	}
+	if req.Name == "" {
+		return apperr.Required("name")
+	}
+
+	task, err := h.service.CreateTask(c.UserContext(), repo.CreateTaskParams{
+		ID:        uuid.NewString(),
+		ProjectID: req.ProjectID,
+		Name:      req.Name,
+		IsActive:  true,
+	})
+	if err != nil {
+		return apperr.Wrap(err, "failed to create task")
+	}
+
+	return c.Status(http.StatusCreated).JSON(task)
+}
+
+type updateTaskRequest struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	IsActive bool   `json:"isActive"`
+}
+
+func (h *Handler) UpdateTask(c *fiber.Ctx) error {
+	var req updateTaskRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperr.InvalidBody(err)
+	}
+
+	if _, err := uuid.Parse(req.ID); err != nil {
+		return apperr.InvalidField("id", "must be a valid UUID")
+	}
+	if req.Name == "" {
+		return apperr.Required("name")
+	}
+
+	task, err := h.service.UpdateTask(c.UserContext(), repo.UpdateTaskParams{
+		Name:     req.Name,
+		IsActive: req.IsActive,
+		ID:       req.ID,
+	})
+	if err != nil {
+		return apperr.Wrap(err, "failed to update task")
+	}
+
+	return c.JSON(task)
+}
+
+func (h *Handler) Allocations(c *fiber.Ctx) error {
+	reportID := c.Params("report")
+	if reportID == "" {
+		return apperr.Required("report")
+	}
+
+	allocations, err := h.service.Allocations(c.UserContext(), reportID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get allocations")
+	}
+
+	return c.JSON(allocations)
+}
+
+func (h *Handler) Allocate(c *fiber.Ctx) error {
+	var req AllocateParams
+	if err := c.BodyParser(&req); err != nil {
+		return apperr.InvalidBody(err)
+	}
+
+	if _, err := uuid.Parse(req.ReportID); err != nil {
+		return apperr.InvalidField("reportId", "must be a valid UUID")
+	}
+	for i, a := range req.Allocations {
+		if _, err := uuid.Parse(a.ProjectID); err != nil {
+			return apperr.InvalidField(fmt.Sprintf("allocations[%d].projectId", i), "must be a valid UUID")
+		}
+		if a.Hours <= 0 || a.Hours > 24 {
+			return apperr.InvalidField(fmt.Sprintf("allocations[%d].hours", i), "must be between 0 and 24")
+		}
+	}
+
+	allocations, err := h.service.Allocate(c.UserContext(), req)
+	if err != nil {
+		return apperr.Wrap(err, "failed to allocate hours")
+	}
+
+	return c.JSON(allocations)
+}
+
+func (h *Handler) TotalsByMonth(c *fiber.Ctx) error {
+	month, year, err := monthYearParams(c)
+	if err != nil {
+		return err
+	}
+
+	totals, err := h.service.TotalsByMonth(c.UserContext(), month, year)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get project totals")
+	}
+
+	return c.JSON(totals)
+}
+
+func (h *Handler) TotalsByUser(c *fiber.Ctx) error {
+	userID := c.Params("user")
+	if userID == "" {
+		return apperr.Required("user")
+	}
+
+	month, year, err := monthYearParams(c)
+	if err != nil {
+		return err
+	}
+
+	totals, err := h.service.TotalsByUser(c.UserContext(), repo.GetProjectTotalsByUserParams{
+		UserID: userID,
+		Month:  month,
+		Year:   year,
+	})
+	if err != nil {
+		return apperr.Wrap(err, "failed to get project totals")
+	}
+
+	return c.JSON(totals)
+}
+
+func (h *Handler) TotalsByDepartment(c *fiber.Ctx) error {
+	departmentID := c.Params("department")
+	if departmentID == "" {
+		return apperr.Required("department")
+	}
+
+	month, year, err := monthYearParams(c)
+	if err != nil {
+		return err
+	}
+
+	totals, err := h.service.TotalsByDepartment(c.UserContext(), repo.GetProjectTotalsByDepartmentParams{
+		DepartmentID: departmentID,
+		Month:        month,
+		Year:         year,
+	})
+	if err != nil {
+		return apperr.Wrap(err, "failed to get project totals")
+	}
+
+	return c.JSON(totals)
+}
+
+func monthYearParams(c *fiber.Ctx) (int32, int32, error) {
+	month, err := c.ParamsInt("month")
+	if err != nil || month < 1 || month > 12 {
+		return 0, 0, apperr.InvalidParam("month")
+	}
+
+	year, err := c.ParamsInt("year")
+	if err != nil || year < 1900 || year > 2100 {
+		return 0, 0, apperr.InvalidParam("year")
+	}
+
+	return int32(month), int32(year), nil
+}
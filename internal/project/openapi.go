@@ -0,0 +1,35 @@
+package project
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты проектов и распределения часов для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/v1/project/list", Summary: "Проекты",
+			Response: []repo.GetProjectsRow{}},
+		{Method: http.MethodPost, Admin: true, Path: "/v1/project/create", Summary: "Создать проект",
+			Request: createRequest{}, Status: http.StatusCreated, Response: repo.GetProjectByIdRow{}},
+		{Method: http.MethodPost, Path: "/v1/project/update", Summary: "Изменить проект",
+			Request: updateRequest{}, Response: repo.GetProjectByIdRow{}},
+		{Method: http.MethodGet, Path: "/v1/project/tasks/:project", Summary: "Задачи проекта",
+			Response: []repo.ReportTask{}},
+		{Method: http.MethodPost, Path: "/v1/project/task/create", Summary: "Создать задачу",
+			Request: createTaskRequest{}, Status: http.StatusCreated, Response: repo.ReportTask{}},
+		{Method: http.MethodPost, Path: "/v1/project/task/update", Summary: "Изменить задачу",
+			Request: updateTaskRequest{}, Response: repo.ReportTask{}},
+		{Method: http.MethodGet, Path: "/v1/project/allocation/:report", Summary: "Распределение часов отметки по проектам",
+			Response: []repo.GetAllocationsByReportRow{}},
+		{Method: http.MethodPost, Path: "/v1/project/allocate", Summary: "Распределить часы отметки по проектам",
+			Request: AllocateParams{}, Response: []repo.GetAllocationsByReportRow{}},
+		{Method: http.MethodGet, Path: "/v1/project/totals/:month/:year", Summary: "Часы по проектам за месяц",
+			Response: []repo.GetProjectTotalsByMonthRow{}},
+		{Method: http.MethodGet, Path: "/v1/project/totals/user/:user/:month/:year", Summary: "Часы сотрудника по проектам за месяц",
+			Response: []repo.GetProjectTotalsByUserRow{}},
+		{Method: http.MethodGet, Path: "/v1/project/totals/department/:department/:month/:year", Summary: "Часы отдела по проектам за месяц",
+			Response: []repo.GetProjectTotalsByDepartmentRow{}},
+	}
+}
@@ -0,0 +1,300 @@
+package project
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/closing"
+	"TimeTrack/internal/tracing"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrProjectCodeExists  = apperr.Conflict("project_code_exists", "project code already exists")
+	ErrProjectInactive    = apperr.Invalid("project_inactive", "project is inactive")
+	ErrTaskMismatch       = apperr.Invalid("task_mismatch", "task does not belong to project or is inactive")
+	ErrNotWorkEntry       = apperr.Invalid("not_work_entry", "only work entries can be allocated to projects")
+	ErrAllocationExceeded = apperr.Invalid("allocation_exceeded", "allocated hours exceed hours of the entry")
+	ErrProjectNotFound    = apperr.NotFound("project_not_found", "project not found")
+	ErrTaskNotFound       = apperr.NotFound("task_not_found", "task not found")
+	ErrReportNotFound     = apperr.NotFound("report_not_found", "report entry not found")
+)
+
+// workTypes - типы отметок, часы которых распределяются по проектам
+var workTypes = map[string]bool{
+	"work":    true,
+	"weekend": true,
+}
+
+type Service interface {
+	List(ctx context.Context) (*[]repo.GetProjectsRow, error)
+	Create(ctx context.Context, prm repo.CreateProjectParams) (*repo.GetProjectByIdRow, error)
+	Update(ctx context.Context, prm repo.UpdateProjectParams) (*repo.GetProjectByIdRow, error)
+	Tasks(ctx context.Context, projectID string) (*[]repo.ReportTask, error)
+	CreateTask(ctx context.Context, prm repo.CreateTaskParams) (*repo.ReportTask, error)
+	UpdateTask(ctx context.Context, prm repo.UpdateTaskParams) (*repo.ReportTask, error)
+	Allocations(ctx context.Context, reportID string) (*[]repo.GetAllocationsByReportRow, error)
+	Allocate(ctx context.Context, prm AllocateParams) (*[]repo.GetAllocationsByReportRow, error)
+	TotalsByMonth(ctx context.Context, month, year int32) (*[]repo.GetProjectTotalsByMonthRow, error)
+	TotalsByUser(ctx context.Context, prm repo.GetProjectTotalsByUserParams) (*[]repo.GetProjectTotalsByUserRow, error)
+	TotalsByDepartment(ctx context.Context, prm repo.GetProjectTotalsByDepartmentParams) (*[]repo.GetProjectTotalsByDepartmentRow, error)
+}
+
+type service struct {
+	repo repo.Querier
+	db   repo.TxBeginner
+}
+
+func NewService(repo repo.Querier, db repo.TxBeginner) Service {
+	return &service{repo: repo, db: db}
+}
+
+type Allocation struct {
+	ProjectID string  `json:"projectId"`
+	TaskID    string  `json:"taskId"`
+	Hours     float64 `json:"hours"`
+}
+
+// AllocateParams - распределение часов отметки табеля по проектам (заменяет существующее)
+type AllocateParams struct {
+	ReportID    string       `json:"reportId"`
+	Allocations []Allocation `json:"allocations"`
+}
+
+func (s *service) List(ctx context.Context) (_ *[]repo.GetProjectsRow, err error) {
+	ctx, end := tracing.Start(ctx, "project.List")
+	defer end(&err)
+
+	projects, err := s.repo.GetProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get projects: %w", err)
+	}
+
+	return &projects, nil
+}
+
+func (s *service) Create(ctx context.Context, prm repo.CreateProjectParams) (_ *repo.GetProjectByIdRow, err error) {
+	ctx, end := tracing.Start(ctx, "project.Create")
+	defer end(&err)
+
+	exists, err := s.repo.CheckProjectCodeExists(ctx, prm.Code)
+	if err != nil {
+		return nil, fmt.Errorf("check project code: %w", err)
+	}
+	if exists > 0 {
+		return nil, ErrProjectCodeExists
+	}
+
+	if err := s.repo.CreateProject(ctx, prm); err != nil {
+		return nil, fmt.Errorf("create project: %w", err)
+	}
+
+	return s.getProject(ctx, prm.ID)
+}
+
+func (s *service) Update(ctx context.Context, prm repo.UpdateProjectParams) (_ *repo.GetProjectByIdRow, err error) {
+	ctx, end := tracing.Start(ctx, "project.Update")
+	defer end(&err)
+
+	if err := s.repo.UpdateProject(ctx, prm); err != nil {
+		return nil, fmt.Errorf("update project: %w", err)
+	}
+
+	return s.getProject(ctx, prm.ID)
+}
+
+func (s *service) Tasks(ctx context.Context, projectID string) (_ *[]repo.ReportTask, err error) {
+	ctx, end := tracing.Start(ctx, "project.Tasks")
+	defer end(&err)
+
+	tasks, err := s.repo.GetTasksByProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("get tasks: %w", err)
+	}
+
+	return &tasks, nil
+}
+
+func (s *service) CreateTask(ctx context.Context, prm repo.CreateTaskParams) (_ *repo.ReportTask, err error) {
+	ctx, end := tracing.Start(ctx, "project.CreateTask")
+	defer end(&err)
+
+	if _, err := s.getProject(ctx, prm.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateTask(ctx, prm); err != nil {
+		return nil, fmt.Errorf("create task: %w", err)
+	}
+
+	return s.getTask(ctx, prm.ID)
+}
+
+func (s *service) UpdateTask(ctx context.Context, prm repo.UpdateTaskParams) (_ *repo.ReportTask, err error) {
+	ctx, end := tracing.Start(ctx, "project.UpdateTask")
+	defer end(&err)
+
+	if err := s.repo.UpdateTask(ctx, prm); err != nil {
+		return nil, fmt.Errorf("update task: %w", err)
+	}
+
+	return s.getTask(ctx, prm.ID)
+}
+
+func (s *service) Allocations(ctx context.Context, reportID string) (_ *[]repo.GetAllocationsByReportRow, err error) {
+	ctx, end := tracing.Start(ctx, "project.Allocations")
+	defer end(&err)
+
+	allocations, err := s.repo.GetAllocationsByReport(ctx, reportID)
+	if err != nil {
+		return nil, fmt.Errorf("get allocations: %w", err)
+	}
+
+	return &allocations, nil
+}
+
+func (s *service) Allocate(ctx context.Context, prm AllocateParams) (_ *[]repo.GetAllocationsByReportRow, err error) {
+	ctx, end := tracing.Start(ctx, "project.Allocate")
+	defer end(&err)
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// отметка читается под блокировкой: изменение ее часов или вида ждет конца
+	// транзакции, и распределение не превысит часы, с которыми оно проверено
+	report, err := tx.GetReportUserByIdForUpdate(ctx, prm.ReportID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrReportNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get user day report: %w", err)
+	}
+
+	if !workTypes[report.TypeSystemName] {
+		return nil, ErrNotWorkEntry
+	}
+
+	// распределение по проектам входит в выгрузку закрытого месяца
+	if err := closing.CheckMonthOpen(ctx, tx, report.Month, report.Year); err != nil {
+		return nil, err
+	}
+
+	var total float64
+	for _, a := range prm.Allocations {
+		total += a.Hours
+
+		project, err := s.getProject(ctx, a.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if !project.IsActive {
+			return nil, ErrProjectInactive
+		}
+
+		if a.TaskID != "" {
+			task, err := s.getTask(ctx, a.TaskID)
+			if err != nil {
+				return nil, err
+			}
+			if task.ProjectID != a.ProjectID || !task.IsActive {
+				return nil, ErrTaskMismatch
+			}
+		}
+	}
+
+	if total > report.Hours {
+		return nil, ErrAllocationExceeded
+	}
+
+	if err := tx.DeleteAllocationsByReport(ctx, prm.ReportID); err != nil {
+		return nil, fmt.Errorf("delete allocations: %w", err)
+	}
+
+	for _, a := range prm.Allocations {
+		if err := tx.CreateAllocation(ctx, repo.CreateAllocationParams{
+			ID:        uuid.NewString(),
+			ReportID:  prm.ReportID,
+			ProjectID: a.ProjectID,
+			TaskID:    sql.NullString{String: a.TaskID, Valid: a.TaskID != ""},
+			Hours:     a.Hours,
+		}); err != nil {
+			return nil, fmt.Errorf("create allocation: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return s.Allocations(ctx, prm.ReportID)
+}
+
+func (s *service) TotalsByMonth(ctx context.Context, month, year int32) (_ *[]repo.GetProjectTotalsByMonthRow, err error) {
+	ctx, end := tracing.Start(ctx, "project.TotalsByMonth", tracing.Month(month), tracing.Year(year))
+	defer end(&err)
+
+	totals, err := s.repo.GetProjectTotalsByMonth(ctx, repo.GetProjectTotalsByMonthParams{
+		Month: month,
+		Year:  year,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get project totals: %w", err)
+	}
+
+	return &totals, nil
+}
+
+func (s *service) TotalsByUser(ctx context.Context, prm repo.GetProjectTotalsByUserParams) (_ *[]repo.GetProjectTotalsByUserRow, err error) {
+	ctx, end := tracing.Start(ctx, "project.TotalsByUser", tracing.User(prm.UserID), tracing.Month(prm.Month), tracing.Year(prm.Year))
+	defer end(&err)
+
+	totals, err := s.repo.GetProjectTotalsByUser(ctx, prm)
+	if err != nil {
+		return nil, fmt.Errorf("get user project totals: %w", err)
+	}
+
+	return &totals, nil
+}
+
+func (s *service) TotalsByDepartment(ctx context.Context, prm repo.GetProjectTotalsByDepartmentParams) (_ *[]repo.GetProjectTotalsByDepartmentRow, err error) {
+	ctx, end := tracing.Start(ctx, "project.TotalsByDepartment", tracing.Month(prm.Month), tracing.Year(prm.Year))
+	defer end(&err)
+
+	totals, err := s.repo.GetProjectTotalsByDepartment(ctx, prm)
+	if err != nil {
+		return nil, fmt.Errorf("get department project totals: %w", err)
+	}
+
+	return &totals, nil
+}
+
+func (s *service) getProject(ctx context.Context, id string) (*repo.GetProjectByIdRow, error) {
+	project, err := s.repo.GetProjectById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrProjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get project: %w", err)
+	}
+
+	return &project, nil
+}
+
+func (s *service) getTask(ctx context.Context, id string) (*repo.ReportTask, error) {
+	task, err := s.repo.GetTaskById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get task: %w", err)
+	}
+
+	return &task, nil
+}
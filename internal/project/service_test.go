@@ -0,0 +1,242 @@
+package project
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/closing"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+const (
+	userID = "0b9c7f5e-4a3d-4c1e-9f7a-2d8e6b1c5a40"
+	annaID = "5d2e8a1f-7c3b-4e9d-8a6f-1b4c7e2d9f30"
+)
+
+// newTestService - сервис проектов над хранилищем в памяти с видами отметок work
+// и medical, рабочей отметкой Ивана r-1 на 8 часов за 05.05.2025, активными
+// проектами p-1 (с задачей k-1) и p-2 и закрытым проектом p-3
+func newTestService(t *testing.T) (Service, *memory.Store) {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.New()
+
+	for _, rt := range []repo.CreateTypeParams{
+		{ID: "t-work", Name: "Явка", SystemName: "work", Code: "Я"},
+		{ID: "t-medical", Name: "Больничный", SystemName: "medical", Code: "Б"},
+	} {
+		if err := store.CreateType(ctx, rt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.CreateReportUser(ctx, repo.CreateReportUserParams{
+		ID: "r-1", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 8, TypeID: "t-work",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewService(store, store)
+	for _, p := range []repo.CreateProjectParams{
+		{ID: "p-1", Code: "P1", Name: "Проект", IsActive: true},
+		{ID: "p-2", Code: "P2", Name: "Внедрение", IsActive: true},
+		{ID: "p-3", Code: "P3", Name: "Архив"},
+	} {
+		if _, err := svc.Create(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := svc.CreateTask(ctx, repo.CreateTaskParams{ID: "k-1", ProjectID: "p-1", Name: "Задача", IsActive: true}); err != nil {
+		t.Fatal(err)
+	}
+	return svc, store
+}
+
+// TestNotFound - сервис сам сообщает, чего не нашлось, ошибками NotFound
+func TestNotFound(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	for name, tt := range map[string]struct {
+		call func() error
+		want error
+	}{
+		"update project": {func() error {
+			_, err := svc.Update(ctx, repo.UpdateProjectParams{ID: "missing", Name: "Проект"})
+			return err
+		}, ErrProjectNotFound},
+		"create task": {func() error {
+			_, err := svc.CreateTask(ctx, repo.CreateTaskParams{ID: "k-2", ProjectID: "missing", Name: "Задача"})
+			return err
+		}, ErrProjectNotFound},
+		"update task": {func() error {
+			_, err := svc.UpdateTask(ctx, repo.UpdateTaskParams{ID: "missing", Name: "Задача"})
+			return err
+		}, ErrTaskNotFound},
+		"allocate to a missing report": {func() error {
+			_, err := svc.Allocate(ctx, AllocateParams{ReportID: "missing", Allocations: []Allocation{{ProjectID: "p-1", Hours: 1}}})
+			return err
+		}, ErrReportNotFound},
+		"allocate to a missing project": {func() error {
+			_, err := svc.Allocate(ctx, AllocateParams{ReportID: "r-1", Allocations: []Allocation{{ProjectID: "missing", Hours: 1}}})
+			return err
+		}, ErrProjectNotFound},
+		"allocate to a missing task": {func() error {
+			_, err := svc.Allocate(ctx, AllocateParams{ReportID: "r-1", Allocations: []Allocation{{ProjectID: "p-1", TaskID: "missing", Hours: 1}}})
+			return err
+		}, ErrTaskNotFound},
+	} {
+		err := tt.call()
+		if !errors.Is(err, tt.want) || apperr.Status(apperr.Wrap(err, "failed")) != http.StatusNotFound {
+			t.Errorf("%s: err = %v, want %v", name, err, tt.want)
+		}
+	}
+}
+
+// allocated - распределение отметки reportID: проект - часы
+func allocated(t *testing.T, svc Service, reportID string) map[string]float64 {
+	t.Helper()
+	allocations, err := svc.Allocations(context.Background(), reportID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hours := make(map[string]float64)
+	for _, a := range *allocations {
+		hours[a.ProjectID] += a.Hours
+	}
+	return hours
+}
+
+func TestAllocate(t *testing.T) {
+	svc, store := newTestService(t)
+	ctx := context.Background()
+
+	allocations, err := svc.Allocate(ctx, AllocateParams{ReportID: "r-1", Allocations: []Allocation{
+		{ProjectID: "p-1", TaskID: "k-1", Hours: 5},
+		{ProjectID: "p-2", Hours: 3},
+	}})
+	if err != nil {
+		t.Fatalf("Allocate: %v", err)
+	}
+	if len(*allocations) != 2 {
+		t.Fatalf("allocations = %+v", *allocations)
+	}
+
+	// новое распределение заменяет прежнее
+	if _, err := svc.Allocate(ctx, AllocateParams{ReportID: "r-1", Allocations: []Allocation{{ProjectID: "p-1", Hours: 2}}}); err != nil {
+		t.Fatalf("Allocate again: %v", err)
+	}
+	if got := allocated(t, svc, "r-1"); len(got) != 1 || got["p-1"] != 2 {
+		t.Fatalf("replaced allocation = %v", got)
+	}
+
+	if err := store.CreateReportUser(ctx, repo.CreateReportUserParams{
+		ID: "r-2", UserID: userID, Day: 6, Month: 5, Year: 2025, Hours: 8, TypeID: "t-medical",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tt := range map[string]struct {
+		prm  AllocateParams
+		want error
+	}{
+		"more than the entry": {AllocateParams{ReportID: "r-1", Allocations: []Allocation{{ProjectID: "p-1", Hours: 6}, {ProjectID: "p-2", Hours: 3}}}, ErrAllocationExceeded},
+		"inactive project":    {AllocateParams{ReportID: "r-1", Allocations: []Allocation{{ProjectID: "p-3", Hours: 1}}}, ErrProjectInactive},
+		"task of another":     {AllocateParams{ReportID: "r-1", Allocations: []Allocation{{ProjectID: "p-2", TaskID: "k-1", Hours: 1}}}, ErrTaskMismatch},
+		"sick leave":          {AllocateParams{ReportID: "r-2", Allocations: []Allocation{{ProjectID: "p-1", Hours: 1}}}, ErrNotWorkEntry},
+	} {
+		if _, err := svc.Allocate(ctx, tt.prm); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", name, err, tt.want)
+		}
+	}
+	if got := allocated(t, svc, "r-1"); len(got) != 1 || got["p-1"] != 2 {
+		t.Fatalf("rejected allocations changed the entry: %v", got)
+	}
+
+	// распределение закрытого месяца не меняется
+	if err := store.CloseMonth(ctx, repo.CloseMonthParams{Month: 5, Year: 2025}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Allocate(ctx, AllocateParams{ReportID: "r-1", Allocations: []Allocation{{ProjectID: "p-2", Hours: 8}}}); !errors.Is(err, closing.ErrMonthClosed) {
+		t.Fatalf("closed month: err = %v, want ErrMonthClosed", err)
+	}
+	if got := allocated(t, svc, "r-1"); len(got) != 1 || got["p-1"] != 2 {
+		t.Fatalf("allocation of a closed month changed: %v", got)
+	}
+}
+
+// TestTotals - часы по проектам за месяц: всего, по сотруднику и по подразделению
+func TestTotals(t *testing.T) {
+	svc, store := newTestService(t)
+	ctx := context.Background()
+
+	if err := store.CreateReportUser(ctx, repo.CreateReportUserParams{
+		ID: "r-3", UserID: annaID, Day: 6, Month: 5, Year: 2025, Hours: 8, TypeID: "t-work",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateDepartment(ctx, repo.CreateDepartmentParams{ID: "d-1", Name: "Разработка"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateUserDepartment(ctx, repo.CreateUserDepartmentParams{UserID: userID, DepartmentID: "d-1"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, prm := range []AllocateParams{
+		{ReportID: "r-1", Allocations: []Allocation{{ProjectID: "p-1", TaskID: "k-1", Hours: 5}, {ProjectID: "p-2", Hours: 3}}},
+		{ReportID: "r-3", Allocations: []Allocation{{ProjectID: "p-1", Hours: 4}}},
+	} {
+		if _, err := svc.Allocate(ctx, prm); err != nil {
+			t.Fatalf("Allocate %s: %v", prm.ReportID, err)
+		}
+	}
+
+	month, err := svc.TotalsByMonth(ctx, 5, 2025)
+	if err != nil {
+		t.Fatalf("TotalsByMonth: %v", err)
+	}
+	byUser, err := svc.TotalsByUser(ctx, repo.GetProjectTotalsByUserParams{UserID: userID, Month: 5, Year: 2025})
+	if err != nil {
+		t.Fatalf("TotalsByUser: %v", err)
+	}
+	byDepartment, err := svc.TotalsByDepartment(ctx, repo.GetProjectTotalsByDepartmentParams{DepartmentID: "d-1", Month: 5, Year: 2025})
+	if err != nil {
+		t.Fatalf("TotalsByDepartment: %v", err)
+	}
+
+	totals := func(rows []repo.GetProjectTotalsByMonthRow) map[string]float64 {
+		hours := make(map[string]float64)
+		for _, r := range rows {
+			hours[r.ProjectID] = r.TotalHours
+		}
+		return hours
+	}
+	for name, tt := range map[string]struct {
+		got  map[string]float64
+		want map[string]float64
+	}{
+		"month":      {totals(*month), map[string]float64{"p-1": 9, "p-2": 3}},
+		"user":       {totals(convert(*byUser)), map[string]float64{"p-1": 5, "p-2": 3}},
+		"department": {totals(convert(*byDepartment)), map[string]float64{"p-1": 5, "p-2": 3}},
+	} {
+		if len(tt.got) != len(tt.want) || tt.got["p-1"] != tt.want["p-1"] || tt.got["p-2"] != tt.want["p-2"] {
+			t.Errorf("%s totals = %v, want %v", name, tt.got, tt.want)
+		}
+	}
+
+	june, err := svc.TotalsByMonth(ctx, 6, 2025)
+	if err != nil || len(*june) != 0 {
+		t.Fatalf("June totals = %v, %v", june, err)
+	}
+}
+
+// convert приводит итоги по сотруднику и подразделению к строкам итогов месяца:
+// sqlc создает для каждого запроса свой тип с теми же полями
+func convert[T repo.GetProjectTotalsByUserRow | repo.GetProjectTotalsByDepartmentRow](rows []T) []repo.GetProjectTotalsByMonthRow {
+	result := make([]repo.GetProjectTotalsByMonthRow, len(rows))
+	for i, r := range rows {
+		result[i] = repo.GetProjectTotalsByMonthRow(r)
+	}
+	return result
+}
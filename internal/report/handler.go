@@ -2,7 +2,12 @@ package report
 
 import (
 	repo "TimeTrack/internal/adapter/mysql/sqlc"
-	"errors"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/export"
+	"TimeTrack/internal/shift"
+	"TimeTrack/internal/validate"
+	"bytes"
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -22,12 +27,6 @@ func NewHandler(service Service, logger *slog.Logger) *Handler {
 	}
 }
 
-// ErrorResponse представляет стандартный формат ошибки
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
-}
-
 // SuccessResponse представляет стандартный формат успешного ответа
 type SuccessResponse struct {
 	Message string      `json:"message"`
@@ -37,17 +36,17 @@ type SuccessResponse struct {
 func (h *Handler) List(c *fiber.Ctx) error {
 	userID := c.Params("user")
 	if userID == "" {
-		return h.respondError(c, http.StatusBadRequest, "user ID is required")
+		return apperr.Required("user")
 	}
 
 	month, err := c.ParamsInt("month")
 	if err != nil || month < 1 || month > 12 {
-		return h.respondError(c, http.StatusBadRequest, "invalid month parameter")
+		return apperr.InvalidParam("month")
 	}
 
 	year, err := c.ParamsInt("year")
 	if err != nil || year < 1900 || year > 2100 {
-		return h.respondError(c, http.StatusBadRequest, "invalid year parameter")
+		return apperr.InvalidParam("year")
 	}
 
 	prm := repo.GetReportUserForMonthParams{
@@ -56,110 +55,127 @@ func (h *Handler) List(c *fiber.Ctx) error {
 		Year:   int32(year),
 	}
 
-	report, err := h.service.List(c.Context(), prm)
+	report, err := h.service.List(c.UserContext(), prm)
 	if err != nil {
-		h.logger.Error("failed to list reports",
-			slog.String("user_id", userID),
-			slog.Int("month", month),
-			slog.Int("year", year),
-			slog.String("error", err.Error()),
-		)
-		return h.respondError(c, http.StatusInternalServerError, "failed to retrieve reports")
+		return apperr.Wrap(err, "failed to retrieve reports")
 	}
 
-	return c.JSON(report)
+	return export.Respond(c, h.logger, fmt.Sprintf("report-%s-%d-%02d", userID, year, month), report)
 }
 
 func (h *Handler) MonthStats(c *fiber.Ctx) error {
 	userID := c.Params("user")
 	if userID == "" {
-		return h.respondError(c, http.StatusBadRequest, "user ID is required")
+		return apperr.Required("user")
 	}
 
 	month, err := c.ParamsInt("month")
 	if err != nil || month < 1 || month > 12 {
-		return h.respondError(c, http.StatusBadRequest, "invalid month parameter")
+		return apperr.InvalidParam("month")
 	}
 
 	year, err := c.ParamsInt("year")
 	if err != nil || year < 1900 || year > 2100 {
-		return h.respondError(c, http.StatusBadRequest, "invalid year parameter")
+		return apperr.InvalidParam("year")
 	}
 
-	monthStats, err := h.service.MonthStats(c.Context(), userID, int32(month), int32(year))
+	monthStats, err := h.service.MonthStats(c.UserContext(), userID, int32(month), int32(year))
 	if err != nil {
-		h.logger.Error("failed to list reports",
-			slog.String("user_id", userID),
-			slog.Int("month", month),
-			slog.Int("year", year),
-			slog.String("error", err.Error()),
-		)
-		return h.respondError(c, http.StatusInternalServerError, "failed to retrieve reports")
+		return apperr.Wrap(err, "failed to retrieve reports")
 	}
 
 	return c.JSON(monthStats)
 }
 
-type createRequest struct {
-	UserID string  `json:"userId" validate:"required,uuid"`
-	Day    int32   `json:"day" validate:"required,min=1,max=31"`
-	Month  int32   `json:"month" validate:"required,min=1,max=12"`
-	Year   int32   `json:"year" validate:"required,min=1900,max=2100"`
-	Hours  float64 `json:"hours" validate:"required,min=0,max=24"`
-	Type   string  `json:"typeSystemName" validate:"required"`
-}
+func (h *Handler) MissingDays(c *fiber.Ctx) error {
+	userID := c.Params("user")
+	if userID == "" {
+		return apperr.Required("user")
+	}
 
-func (r *createRequest) validate() error {
-	if r.UserID == "" {
-		return errors.New("userId is required")
+	month, err := c.ParamsInt("month")
+	if err != nil || month < 1 || month > 12 {
+		return apperr.InvalidParam("month")
 	}
-	if _, err := uuid.Parse(r.UserID); err != nil {
-		return errors.New("userId must be a valid UUID")
+
+	year, err := c.ParamsInt("year")
+	if err != nil || year < 1900 || year > 2100 {
+		return apperr.InvalidParam("year")
 	}
-	if r.Day < 1 || r.Day > 31 {
-		return errors.New("day must be between 1 and 31")
+
+	days, err := h.service.MissingDays(c.UserContext(), userID, int32(month), int32(year))
+	if err != nil {
+		return apperr.Wrap(err, "failed to get missing days")
 	}
-	if r.Month < 1 || r.Month > 12 {
-		return errors.New("month must be between 1 and 12")
+
+	return c.JSON(days)
+}
+
+// Export отдает табель команды за месяц в формате XLSX.
+// Норма берется для пола из параметра gender (по умолчанию 1).
+func (h *Handler) Export(c *fiber.Ctx) error {
+	month, err := c.ParamsInt("month")
+	if err != nil || month < 1 || month > 12 {
+		return apperr.InvalidParam("month")
 	}
-	if r.Year < 1900 || r.Year > 2100 {
-		return errors.New("year must be between 1900 and 2100")
+
+	year, err := c.ParamsInt("year")
+	if err != nil || year < 1900 || year > 2100 {
+		return apperr.InvalidParam("year")
 	}
-	if r.Hours < 0 || r.Hours > 24 {
-		return errors.New("hours must be between 0 and 24")
+
+	gender := c.QueryInt("gender", 1)
+
+	sheet, err := h.service.Timesheet(c.UserContext(), int32(month), int32(year), int32(gender))
+	if err != nil {
+		return apperr.Wrap(err, "failed to build timesheet")
 	}
-	if r.Type == "" {
-		return errors.New("typeSystemName is required")
+
+	var buf bytes.Buffer
+	if err := sheet.WriteXLSX(&buf); err != nil {
+		return apperr.Wrap(err, "failed to render timesheet")
 	}
-	return nil
+
+	c.Set(fiber.HeaderContentType, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	c.Attachment(fmt.Sprintf("timesheet-%d-%02d.xlsx", year, month))
+	return c.Send(buf.Bytes())
+}
+
+type createRequest struct {
+	UserID string  `json:"userId" validate:"required,uuid"`
+	Day    int32   `json:"day" validate:"required,min=1,max=31,date=Month Year"`
+	Month  int32   `json:"month" validate:"required,min=1,max=12"`
+	Year   int32   `json:"year" validate:"required,min=1900,max=2100"`
+	Hours  float64 `json:"hours" validate:"min=0,max=24"`
+	Type   string  `json:"typeSystemName" validate:"required"`
+	Start  string  `json:"start"`
+	End    string  `json:"end"`
 }
 
 func (h *Handler) Create(c *fiber.Ctx) error {
 	var req createRequest
-	if err := c.BodyParser(&req); err != nil {
-		h.logger.Warn("invalid request body", slog.String("error", err.Error()))
-		return h.respondError(c, http.StatusBadRequest, "invalid request body")
-	}
-
-	if err := req.validate(); err != nil {
-		return h.respondError(c, http.StatusBadRequest, err.Error())
+	if err := validate.Body(c, &req); err != nil {
+		return err
 	}
 
-	report, err := h.service.Create(c.Context(), CreateReportParams{
-		ID:     uuid.NewString(),
-		UserID: req.UserID,
-		Day:    req.Day,
-		Month:  req.Month,
-		Year:   req.Year,
-		Hours:  req.Hours,
-		Type:   req.Type,
+	start, end, err := parseInterval("", req.Start, req.End)
+	if err != nil {
+		return err
+	}
+
+	report, err := h.service.Create(c.UserContext(), CreateReportParams{
+		ID:          uuid.NewString(),
+		UserID:      req.UserID,
+		Day:         req.Day,
+		Month:       req.Month,
+		Year:        req.Year,
+		Hours:       req.Hours,
+		Type:        req.Type,
+		StartMinute: start,
+		EndMinute:   end,
 	})
 	if err != nil {
-		h.logger.Error("failed to create report",
-			slog.String("user_id", req.UserID),
-			slog.String("error", err.Error()),
-		)
-		return h.respondError(c, http.StatusInternalServerError, "failed to create report")
+		return apperr.Wrap(err, "failed to create report")
 	}
 
 	return c.Status(http.StatusCreated).JSON(report)
@@ -167,44 +183,32 @@ func (h *Handler) Create(c *fiber.Ctx) error {
 
 type updateRequest struct {
 	ID    string  `json:"id" validate:"required,uuid"`
-	Hours float64 `json:"hours" validate:"required,min=0,max=24"`
+	Hours float64 `json:"hours" validate:"min=0,max=24"`
 	Type  string  `json:"typeSystemName" validate:"required"`
-}
-
-func (r *updateRequest) validate() error {
-	if r.ID == "" {
-		return errors.New("id is required")
-	}
-	if _, err := uuid.Parse(r.ID); err != nil {
-		return errors.New("id must be a valid UUID")
-	}
-	if r.Hours < 0 || r.Hours > 24 {
-		return errors.New("hours must be between 0 and 24")
-	}
-	if r.Type == "" {
-		return errors.New("typeSystemName is required")
-	}
-	return nil
+	Start string  `json:"start"`
+	End   string  `json:"end"`
 }
 
 func (h *Handler) Update(c *fiber.Ctx) error {
 	var req updateRequest
-	if err := c.BodyParser(&req); err != nil {
-		h.logger.Warn("invalid request body", slog.String("error", err.Error()))
-		return h.respondError(c, http.StatusBadRequest, "invalid request body")
+	if err := validate.Body(c, &req); err != nil {
+		return err
 	}
 
-	if err := req.validate(); err != nil {
-		return h.respondError(c, http.StatusBadRequest, err.Error())
+	start, end, err := parseInterval("", req.Start, req.End)
+	if err != nil {
+		return err
 	}
 
-	report, err := h.service.Update(c.Context(), UpdateReportParams(req))
+	report, err := h.service.Update(c.UserContext(), UpdateReportParams{
+		ID:          req.ID,
+		Hours:       req.Hours,
+		Type:        req.Type,
+		StartMinute: start,
+		EndMinute:   end,
+	})
 	if err != nil {
-		h.logger.Error("failed to update report",
-			slog.String("report_id", req.ID),
-			slog.String("error", err.Error()),
-		)
-		return h.respondError(c, http.StatusInternalServerError, "failed to update report")
+		return apperr.Wrap(err, "failed to update report")
 	}
 
 	return c.JSON(report)
@@ -213,39 +217,32 @@ func (h *Handler) Update(c *fiber.Ctx) error {
 func (h *Handler) Delete(c *fiber.Ctx) error {
 	userID := c.Params("user")
 	if userID == "" {
-		return h.respondError(c, http.StatusBadRequest, "user ID is required")
+		return apperr.Required("user")
 	}
 
 	month, err := c.ParamsInt("month")
 	if err != nil || month < 1 || month > 12 {
-		return h.respondError(c, http.StatusBadRequest, "invalid month parameter")
+		return apperr.InvalidParam("month")
 	}
 
 	year, err := c.ParamsInt("year")
 	if err != nil || year < 1900 || year > 2100 {
-		return h.respondError(c, http.StatusBadRequest, "invalid year parameter")
+		return apperr.InvalidParam("year")
 	}
 
 	day, err := c.ParamsInt("day")
 	if err != nil || day < 1 || day > 31 {
-		return h.respondError(c, http.StatusBadRequest, "invalid day parameter")
+		return apperr.InvalidParam("day")
 	}
 
-	err = h.service.Delete(c.Context(), repo.DeleteReportUserParams{
+	err = h.service.Delete(c.UserContext(), repo.DeleteReportUserParams{
 		UserID: userID,
 		Day:    int32(day),
 		Month:  int32(month),
 		Year:   int32(year),
 	})
 	if err != nil {
-		h.logger.Error("failed to delete report",
-			slog.String("user_id", userID),
-			slog.Int("day", day),
-			slog.Int("month", month),
-			slog.Int("year", year),
-			slog.String("error", err.Error()),
-		)
-		return h.respondError(c, http.StatusInternalServerError, "failed to delete report")
+		return apperr.Wrap(err, "failed to delete report")
 	}
 
 	return c.JSON(SuccessResponse{
@@ -253,10 +250,133 @@ func (h *Handler) Delete(c *fiber.Ctx) error {
 	})
 }
 
-// respondError - вспомогательный метод для отправки ошибок
-func (h *Handler) respondError(c *fiber.Ctx, status int, message string) error {
-	return c.Status(status).JSON(ErrorResponse{
-		Error:   http.StatusText(status),
-		Message: message,
+func (h *Handler) Day(c *fiber.Ctx) error {
+	prm, err := dayParams(c)
+	if err != nil {
+		return err
+	}
+
+	entries, err := h.service.Day(c.UserContext(), prm)
+	if err != nil {
+		return apperr.Wrap(err, "failed to retrieve day report")
+	}
+
+	return c.JSON(entries)
+}
+
+type dayEntryRequest struct {
+	Hours float64 `json:"hours" validate:"min=0,max=24"`
+	Type  string  `json:"typeSystemName" validate:"required"`
+	Start string  `json:"start"`
+	End   string  `json:"end"`
+}
+
+type setDayRequest struct {
+	UserID  string            `json:"userId" validate:"required,uuid"`
+	Day     int32             `json:"day" validate:"required,min=1,max=31,date=Month Year"`
+	Month   int32             `json:"month" validate:"required,min=1,max=12"`
+	Year    int32             `json:"year" validate:"required,min=1900,max=2100"`
+	Entries []dayEntryRequest `json:"entries" validate:"dive"`
+}
+
+func (h *Handler) SetDay(c *fiber.Ctx) error {
+	var req setDayRequest
+	if err := validate.Body(c, &req); err != nil {
+		return err
+	}
+
+	entries := make([]DayEntry, len(req.Entries))
+	for i, e := range req.Entries {
+		start, end, err := parseInterval(fmt.Sprintf("entries[%d].", i), e.Start, e.End)
+		if err != nil {
+			return err
+		}
+
+		entries[i] = DayEntry{
+			Hours:       e.Hours,
+			Type:        e.Type,
+			StartMinute: start,
+			EndMinute:   end,
+		}
+	}
+
+	day, err := h.service.SetDay(c.UserContext(), SetDayParams{
+		UserID:  req.UserID,
+		Day:     req.Day,
+		Month:   req.Month,
+		Year:    req.Year,
+		Entries: entries,
 	})
+	if err != nil {
+		return apperr.Wrap(err, "failed to set day report")
+	}
+
+	return c.JSON(day)
+}
+
+func (h *Handler) DeleteEntry(c *fiber.Ctx) error {
+	entryID := c.Params("entry")
+	if entryID == "" {
+		return apperr.Required("entry")
+	}
+
+	if err := h.service.DeleteEntry(c.UserContext(), entryID); err != nil {
+		return apperr.Wrap(err, "failed to delete report entry")
+	}
+
+	return c.JSON(SuccessResponse{
+		Message: "Report entry deleted successfully",
+	})
+}
+
+func dayParams(c *fiber.Ctx) (repo.GetReportUserForDayParams, error) {
+	userID := c.Params("user")
+	if userID == "" {
+		return repo.GetReportUserForDayParams{}, apperr.Required("user")
+	}
+
+	day, err := c.ParamsInt("day")
+	if err != nil || day < 1 || day > 31 {
+		return repo.GetReportUserForDayParams{}, apperr.InvalidParam("day")
+	}
+
+	month, err := c.ParamsInt("month")
+	if err != nil || month < 1 || month > 12 {
+		return repo.GetReportUserForDayParams{}, apperr.InvalidParam("month")
+	}
+
+	year, err := c.ParamsInt("year")
+	if err != nil || year < 1900 || year > 2100 {
+		return repo.GetReportUserForDayParams{}, apperr.InvalidParam("year")
+	}
+
+	return repo.GetReportUserForDayParams{
+		UserID: userID,
+		Day:    int32(day),
+		Month:  int32(month),
+		Year:   int32(year),
+	}, nil
+}
+
+// parseInterval разбирает необязательное время начала и конца работы ("HH:MM");
+// prefix - путь к полям в ошибке проверки ("entries[0].")
+func parseInterval(prefix, start, end string) (*int32, *int32, error) {
+	if start == "" && end == "" {
+		return nil, nil, nil
+	}
+	if start == "" || end == "" {
+		return nil, nil, apperr.InvalidField(prefix+"end", "must be set together with start")
+	}
+
+	startMinute, err := shift.ParseClock(start)
+	if err != nil {
+		return nil, nil, apperr.InvalidField(prefix+"start", "must be a time in HH:MM format")
+	}
+
+	endMinute, err := shift.ParseClock(end)
+	if err != nil {
+		return nil, nil, apperr.InvalidField(prefix+"end", "must be a time in HH:MM format")
+	}
+
+	return &startMinute, &endMinute, nil
 }
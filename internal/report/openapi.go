@@ -0,0 +1,33 @@
+package report
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты табеля для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/v1/report/list/:user/:month/:year", Summary: "Отметки сотрудника за месяц",
+			Response: []repo.GetReportUserForMonthRow{}, Export: true},
+		{Method: http.MethodGet, Path: "/v1/report/monthstats/:user/:month/:year", Summary: "Итоги месяца: часы по видам, норма, переработка",
+			Response: monthStats{}},
+		{Method: http.MethodGet, Path: "/v1/report/missing/:user/:month/:year", Summary: "Рабочие дни месяца без отметок",
+			Response: []int32{}},
+		{Method: http.MethodGet, Path: "/v1/report/export/:month/:year.xlsx", Summary: "Табель за месяц в XLSX",
+			Query: []openapi.Param{{Name: "gender", Description: "Норма часов для пола (1 или 2)", Type: "integer"}}, Content: openapi.MIMEXLSX},
+		{Method: http.MethodPost, Path: "/v1/report/create", Summary: "Отметить часы за день",
+			Request: createRequest{}, Status: http.StatusCreated, Response: ReportResponse{}},
+		{Method: http.MethodPost, Path: "/v1/report/update", Summary: "Изменить отметку",
+			Request: updateRequest{}, Response: ReportResponse{}},
+		{Method: http.MethodDelete, Path: "/v1/report/delete/:user/:day/:month/:year", Summary: "Удалить отметки дня",
+			Response: SuccessResponse{}},
+		{Method: http.MethodGet, Path: "/v1/report/day/:user/:day/:month/:year", Summary: "Отметки дня",
+			Response: []repo.GetReportUserForDayRow{}},
+		{Method: http.MethodPost, Path: "/v1/report/day", Summary: "Заменить все отметки дня",
+			Request: setDayRequest{}, Response: []repo.GetReportUserForDayRow{}},
+		{Method: http.MethodDelete, Path: "/v1/report/delete-entry/:entry", Summary: "Удалить одну отметку",
+			Response: SuccessResponse{}},
+	}
+}
@@ -2,9 +2,32 @@ package report
 
 import (
 	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/calendar"
+	"TimeTrack/internal/closing"
+	"TimeTrack/internal/project"
+	"TimeTrack/internal/schedule"
+	"TimeTrack/internal/shift"
+	"TimeTrack/internal/tracing"
+	"TimeTrack/internal/webhook"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxDayHours - предельная сумма часов всех отметок за один день
+const MaxDayHours = 24.0
+
+var (
+	ErrDayHoursExceeded = apperr.Invalid("day_hours_exceeded", "total hours for the day exceed 24")
+	ErrDuplicateType    = apperr.Conflict("duplicate_type", "day already has an entry of this type")
+	ErrNotFound         = apperr.NotFound("report_not_found", "report entry not found")
+	ErrUnknownType      = apperr.Invalid("unknown_type", "unknown report type",
+		apperr.Field{Field: "typeSystemName", Message: "unknown report type"})
 )
 
 type Service interface {
@@ -13,15 +36,24 @@ type Service interface {
 	Update(ctx context.Context, prm UpdateReportParams) (*ReportResponse, error)
 	Delete(ctx context.Context, prm repo.DeleteReportUserParams) error
 	MonthStats(ctx context.Context, userID string, month, year int32) (*monthStats, error)
+	MissingDays(ctx context.Context, userID string, month, year int32) (*[]int32, error)
+	Day(ctx context.Context, prm repo.GetReportUserForDayParams) (*[]repo.GetReportUserForDayRow, error)
+	SetDay(ctx context.Context, prm SetDayParams) (*[]repo.GetReportUserForDayRow, error)
+	DeleteEntry(ctx context.Context, id string) error
+	Timesheet(ctx context.Context, month, year, genderID int32) (*timesheet, error)
 }
 
 type service struct {
-	repo repo.Querier
-	db   *sql.DB
+	repo      repo.Querier
+	db        repo.TxBeginner
+	schedules schedule.Service
+	shifts    shift.Service
+	calendar  calendar.Service
+	events    webhook.Publisher
 }
 
-func NewService(repo repo.Querier, db *sql.DB) Service {
-	return &service{repo: repo, db: db}
+func NewService(repo repo.Querier, db repo.TxBeginner, schedules schedule.Service, shifts shift.Service, calendar calendar.Service, events webhook.Publisher) Service {
+	return &service{repo: repo, db: db, schedules: schedules, shifts: shifts, calendar: calendar, events: events}
 }
 
 type ReportResponse struct {
@@ -34,25 +66,54 @@ type ReportResponse struct {
 	TypeID         string  `json:"typeId"`
 	TypeName       string  `json:"typeName"`
 	TypeSystemName string  `json:"typeSystemName"`
+	Start          *string `json:"start"`
+	End            *string `json:"end"`
+	NightHours     float64 `json:"nightHours"`
 }
 
+// CreateReportParams - параметры отметки в табеле.
+// StartMinute/EndMinute - необязательное время начала и конца работы в минутах от начала суток.
 type CreateReportParams struct {
-	ID     string  `json:"id"`
-	UserID string  `json:"userId"`
-	Day    int32   `json:"day"`
-	Month  int32   `json:"month"`
-	Year   int32   `json:"year"`
-	Hours  float64 `json:"hours"`
-	Type   string  `json:"typeSystemName"`
+	ID          string  `json:"id"`
+	UserID      string  `json:"userId"`
+	Day         int32   `json:"day"`
+	Month       int32   `json:"month"`
+	Year        int32   `json:"year"`
+	Hours       float64 `json:"hours"`
+	Type        string  `json:"typeSystemName"`
+	StartMinute *int32  `json:"startMinute"`
+	EndMinute   *int32  `json:"endMinute"`
 }
 
 type UpdateReportParams struct {
-	ID    string  `json:"id"`
-	Hours float64 `json:"hours"`
-	Type  string  `json:"typeSystemName"`
+	ID          string  `json:"id"`
+	Hours       float64 `json:"hours"`
+	Type        string  `json:"typeSystemName"`
+	StartMinute *int32  `json:"startMinute"`
+	EndMinute   *int32  `json:"endMinute"`
+}
+
+// DayEntry - одна отметка (сегмент) дня определённого типа
+type DayEntry struct {
+	Hours       float64 `json:"hours"`
+	Type        string  `json:"typeSystemName"`
+	StartMinute *int32  `json:"startMinute"`
+	EndMinute   *int32  `json:"endMinute"`
+}
+
+// SetDayParams - полный набор отметок за день, заменяющий существующие
+type SetDayParams struct {
+	UserID  string     `json:"userId"`
+	Day     int32      `json:"day"`
+	Month   int32      `json:"month"`
+	Year    int32      `json:"year"`
+	Entries []DayEntry `json:"entries"`
 }
 
-func (s *service) List(ctx context.Context, prm repo.GetReportUserForMonthParams) (*[]repo.GetReportUserForMonthRow, error) {
+func (s *service) List(ctx context.Context, prm repo.GetReportUserForMonthParams) (_ *[]repo.GetReportUserForMonthRow, err error) {
+	ctx, end := tracing.Start(ctx, "report.List", tracing.User(prm.UserID), tracing.Month(prm.Month), tracing.Year(prm.Year))
+	defer end(&err)
+
 	reports, err := s.repo.GetReportUserForMonth(ctx, prm)
 	if err != nil {
 		return nil, fmt.Errorf("get user month report: %w", err)
@@ -61,60 +122,457 @@ func (s *service) List(ctx context.Context, prm repo.GetReportUserForMonthParams
 	return &reports, nil
 }
 
-func (s *service) Create(ctx context.Context, prm CreateReportParams) (*ReportResponse, error) {
+func (s *service) Create(ctx context.Context, prm CreateReportParams) (_ *ReportResponse, err error) {
+	ctx, end := tracing.Start(ctx, "report.Create", tracing.User(prm.UserID), tracing.Day(prm.Day), tracing.Month(prm.Month), tracing.Year(prm.Year))
+	defer end(&err)
+
 	reportType, err := s.repo.GetTypeBySystemName(ctx, prm.Type)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownType, prm.Type)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("get report type: %w", err)
 	}
 
-	if err := s.repo.CreateReportUser(ctx, repo.CreateReportUserParams{
-		ID:     prm.ID,
+	worked, err := s.workedTime(ctx, prm.UserID, prm.Day, prm.Month, prm.Year, prm.Hours, prm.StartMinute, prm.EndMinute)
+	if err != nil {
+		return nil, err
+	}
+
+	// день блокируется до проверок, чтобы параллельные запросы не прошли проверку вида
+	// и суммы часов одновременно; уникальный индекс report_user_day_type - последняя защита
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockDay(ctx, tx, prm.UserID, prm.Day, prm.Month, prm.Year); err != nil {
+		return nil, err
+	}
+	if err := closing.CheckMonthOpen(ctx, tx, prm.Month, prm.Year); err != nil {
+		return nil, err
+	}
+
+	exists, err := tx.CheckReportUserTypeExists(ctx, repo.CheckReportUserTypeExistsParams{
 		UserID: prm.UserID,
 		Day:    prm.Day,
 		Month:  prm.Month,
 		Year:   prm.Year,
-		Hours:  prm.Hours,
 		TypeID: reportType.ID,
-	}); err != nil {
+	})
+	if err != nil {
+		return nil, fmt.Errorf("check day entry: %w", err)
+	}
+	if exists > 0 {
+		return nil, ErrDuplicateType
+	}
+
+	dayHours, err := tx.GetReportUserDayTotalHours(ctx, repo.GetReportUserDayTotalHoursParams{
+		UserID: prm.UserID,
+		Day:    prm.Day,
+		Month:  prm.Month,
+		Year:   prm.Year,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get day total hours: %w", err)
+	}
+	if dayHours+prm.Hours > MaxDayHours {
+		return nil, ErrDayHoursExceeded
+	}
+
+	err = tx.CreateReportUser(ctx, repo.CreateReportUserParams{
+		ID:          prm.ID,
+		UserID:      prm.UserID,
+		Day:         prm.Day,
+		Month:       prm.Month,
+		Year:        prm.Year,
+		Hours:       prm.Hours,
+		TypeID:      reportType.ID,
+		StartMinute: worked.start,
+		EndMinute:   worked.end,
+		NightHours:  worked.nightHours,
+	})
+	if repo.IsDuplicateKey(err) {
+		return nil, ErrDuplicateType
+	}
+	if err != nil {
 		return nil, fmt.Errorf("create user report: %w", err)
 	}
 
-	return s.buildReportResponse(ctx, prm.ID)
+	if err := tx.Commit(); err != nil {
+		if repo.IsDuplicateKey(err) {
+			return nil, ErrDuplicateType
+		}
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return s.publish(ctx, webhook.EventReportCreated, prm.ID)
 }
 
-func (s *service) Update(ctx context.Context, prm UpdateReportParams) (*ReportResponse, error) {
+func (s *service) Update(ctx context.Context, prm UpdateReportParams) (_ *ReportResponse, err error) {
+	ctx, end := tracing.Start(ctx, "report.Update")
+	defer end(&err)
+
 	reportType, err := s.repo.GetTypeBySystemName(ctx, prm.Type)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownType, prm.Type)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("get report type: %w", err)
 	}
 
-	if err := s.repo.UpdateReportUser(ctx, repo.UpdateReportUserParams{
-		ID:     prm.ID,
-		Hours:  prm.Hours,
-		TypeID: reportType.ID,
-	}); err != nil {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	current, err := tx.GetReportUserByIdForUpdate(ctx, prm.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get user day report: %w", err)
+	}
+	if err := lockDay(ctx, tx, current.UserID, current.Day, current.Month, current.Year); err != nil {
+		return nil, err
+	}
+	if err := closing.CheckMonthOpen(ctx, tx, current.Month, current.Year); err != nil {
+		return nil, err
+	}
+
+	if current.TypeID != reportType.ID {
+		exists, err := tx.CheckReportUserTypeExists(ctx, repo.CheckReportUserTypeExistsParams{
+			UserID: current.UserID,
+			Day:    current.Day,
+			Month:  current.Month,
+			Year:   current.Year,
+			TypeID: reportType.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("check day entry: %w", err)
+		}
+		if exists > 0 {
+			return nil, ErrDuplicateType
+		}
+	}
+
+	dayHours, err := tx.GetReportUserDayTotalHours(ctx, repo.GetReportUserDayTotalHoursParams{
+		UserID: current.UserID,
+		Day:    current.Day,
+		Month:  current.Month,
+		Year:   current.Year,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get day total hours: %w", err)
+	}
+	if dayHours-current.Hours+prm.Hours > MaxDayHours {
+		return nil, ErrDayHoursExceeded
+	}
+
+	// распределение по проектам относится к виду отметки: при смене вида оно удаляется,
+	// при том же виде часы отметки не могут стать меньше распределенных
+	if current.TypeID != reportType.ID {
+		if err := tx.DeleteAllocationsByReport(ctx, prm.ID); err != nil {
+			return nil, fmt.Errorf("delete entry allocations: %w", err)
+		}
+	} else if prm.Hours < current.Hours {
+		allocations, err := tx.GetAllocationsByReport(ctx, prm.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get entry allocations: %w", err)
+		}
+		var allocated float64
+		for _, a := range allocations {
+			allocated += a.Hours
+		}
+		if allocated > prm.Hours {
+			return nil, project.ErrAllocationExceeded
+		}
+	}
+
+	// без нового интервала остается записанный; смена из плана - только если его не было
+	startMinute, endMinute := prm.StartMinute, prm.EndMinute
+	if (startMinute == nil || endMinute == nil) && current.StartMinute.Valid && current.EndMinute.Valid {
+		startMinute, endMinute = &current.StartMinute.Int32, &current.EndMinute.Int32
+	}
+
+	worked, err := s.workedTime(ctx, current.UserID, current.Day, current.Month, current.Year, prm.Hours, startMinute, endMinute)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.UpdateReportUser(ctx, repo.UpdateReportUserParams{
+		ID:          prm.ID,
+		Hours:       prm.Hours,
+		TypeID:      reportType.ID,
+		StartMinute: worked.start,
+		EndMinute:   worked.end,
+		NightHours:  worked.nightHours,
+	})
+	if repo.IsDuplicateKey(err) {
+		return nil, ErrDuplicateType
+	}
+	if err != nil {
 		return nil, fmt.Errorf("update user report: %w", err)
 	}
 
-	return s.buildReportResponse(ctx, prm.ID)
+	if err := tx.Commit(); err != nil {
+		if repo.IsDuplicateKey(err) {
+			return nil, ErrDuplicateType
+		}
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return s.publish(ctx, webhook.EventReportUpdated, prm.ID)
+}
+
+// lockDay блокирует отметки дня сотрудника до конца транзакции tx. Блокировка
+// берется до остальных чтений: в MySQL снимок транзакции начинается с первого чтения.
+func lockDay(ctx context.Context, tx repo.Tx, userID string, day, month, year int32) error {
+	err := tx.LockReportUserDay(ctx, repo.LockReportUserDayParams{
+		UserID: userID,
+		Day:    day,
+		Month:  month,
+		Year:   year,
+	})
+	if err != nil {
+		return fmt.Errorf("lock day entries: %w", err)
+	}
+	return nil
+}
+
+type workedTime struct {
+	start      sql.NullInt32
+	end        sql.NullInt32
+	nightHours float64
 }
 
-func (s *service) Delete(ctx context.Context, prm repo.DeleteReportUserParams) error {
-	if err := s.repo.DeleteReportUser(ctx, prm); err != nil {
+// workedTime определяет интервал работы и ночные часы.
+// Если время не указано, берётся запланированная на этот день смена.
+func (s *service) workedTime(ctx context.Context, userID string, day, month, year int32, hours float64, start, end *int32) (*workedTime, error) {
+	if start == nil || end == nil {
+		planned, err := s.shifts.PlannedFor(ctx, userID, day, month, year)
+		if err != nil {
+			return nil, err
+		}
+		if planned == nil {
+			return &workedTime{}, nil
+		}
+		start, end = &planned.StartMinute, &planned.EndMinute
+	}
+
+	window, err := s.shifts.NightWindow(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &workedTime{
+		start:      sql.NullInt32{Int32: *start, Valid: true},
+		end:        sql.NullInt32{Int32: *end, Valid: true},
+		nightHours: window.NightHours(*start, *end, hours),
+	}, nil
+}
+
+func (s *service) Delete(ctx context.Context, prm repo.DeleteReportUserParams) (err error) {
+	ctx, end := tracing.Start(ctx, "report.Delete", tracing.User(prm.UserID), tracing.Day(prm.Day), tracing.Month(prm.Month), tracing.Year(prm.Year))
+	defer end(&err)
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := closing.CheckMonthOpen(ctx, tx, prm.Month, prm.Year); err != nil {
+		return err
+	}
+	if err := tx.DeleteAllocationsByDay(ctx, repo.DeleteAllocationsByDayParams(prm)); err != nil {
+		return fmt.Errorf("delete day allocations: %w", err)
+	}
+	if err := tx.DeleteReportUser(ctx, prm); err != nil {
 		return fmt.Errorf("delete user report: %w", err)
 	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	s.events.Publish(ctx, webhook.EventReportDeleted, prm)
 	return nil
 }
 
+// DeleteEntry удаляет одну отметку дня, не трогая остальные
+func (s *service) DeleteEntry(ctx context.Context, id string) (err error) {
+	ctx, end := tracing.Start(ctx, "report.DeleteEntry")
+	defer end(&err)
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// удаление отсутствующей отметки ничего не делает, как и раньше
+	current, err := tx.GetReportUserById(ctx, id)
+	switch {
+	case err == nil:
+		if err := closing.CheckMonthOpen(ctx, tx, current.Month, current.Year); err != nil {
+			return err
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("get user day report: %w", err)
+	}
+
+	if err := tx.DeleteAllocationsByReport(ctx, id); err != nil {
+		return fmt.Errorf("delete entry allocations: %w", err)
+	}
+	if err := tx.DeleteReportUserById(ctx, id); err != nil {
+		return fmt.Errorf("delete user report entry: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	s.events.Publish(ctx, webhook.EventReportDeleted, map[string]string{"id": id})
+	return nil
+}
+
+func (s *service) Day(ctx context.Context, prm repo.GetReportUserForDayParams) (_ *[]repo.GetReportUserForDayRow, err error) {
+	ctx, end := tracing.Start(ctx, "report.Day", tracing.User(prm.UserID), tracing.Day(prm.Day), tracing.Month(prm.Month), tracing.Year(prm.Year))
+	defer end(&err)
+
+	entries, err := s.repo.GetReportUserForDay(ctx, prm)
+	if err != nil {
+		return nil, fmt.Errorf("get user day report: %w", err)
+	}
+
+	return &entries, nil
+}
+
+// SetDay заменяет все отметки дня переданным набором в одной транзакции
+func (s *service) SetDay(ctx context.Context, prm SetDayParams) (_ *[]repo.GetReportUserForDayRow, err error) {
+	ctx, end := tracing.Start(ctx, "report.SetDay", tracing.User(prm.UserID), tracing.Day(prm.Day), tracing.Month(prm.Month), tracing.Year(prm.Year))
+	defer end(&err)
+
+	var total float64
+	types := make(map[string]bool, len(prm.Entries))
+	rows := make([]repo.CreateReportUserParams, 0, len(prm.Entries))
+
+	for _, entry := range prm.Entries {
+		total += entry.Hours
+		if total > MaxDayHours {
+			return nil, ErrDayHoursExceeded
+		}
+
+		reportType, err := s.repo.GetTypeBySystemName(ctx, entry.Type)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownType, entry.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get report type %q: %w", entry.Type, err)
+		}
+		if types[reportType.ID] {
+			return nil, ErrDuplicateType
+		}
+		types[reportType.ID] = true
+
+		worked, err := s.workedTime(ctx, prm.UserID, prm.Day, prm.Month, prm.Year, entry.Hours, entry.StartMinute, entry.EndMinute)
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, repo.CreateReportUserParams{
+			ID:          uuid.NewString(),
+			UserID:      prm.UserID,
+			Day:         prm.Day,
+			Month:       prm.Month,
+			Year:        prm.Year,
+			Hours:       entry.Hours,
+			TypeID:      reportType.ID,
+			StartMinute: worked.start,
+			EndMinute:   worked.end,
+			NightHours:  worked.nightHours,
+		})
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockDay(ctx, tx, prm.UserID, prm.Day, prm.Month, prm.Year); err != nil {
+		return nil, err
+	}
+	if err := closing.CheckMonthOpen(ctx, tx, prm.Month, prm.Year); err != nil {
+		return nil, err
+	}
+	if err := tx.DeleteAllocationsByDay(ctx, repo.DeleteAllocationsByDayParams{
+		UserID: prm.UserID,
+		Day:    prm.Day,
+		Month:  prm.Month,
+		Year:   prm.Year,
+	}); err != nil {
+		return nil, fmt.Errorf("delete day allocations: %w", err)
+	}
+
+	if err := tx.DeleteReportUser(ctx, repo.DeleteReportUserParams{
+		UserID: prm.UserID,
+		Day:    prm.Day,
+		Month:  prm.Month,
+		Year:   prm.Year,
+	}); err != nil {
+		return nil, fmt.Errorf("delete user report: %w", err)
+	}
+
+	for _, row := range rows {
+		err := tx.CreateReportUser(ctx, row)
+		if repo.IsDuplicateKey(err) {
+			return nil, ErrDuplicateType
+		}
+		if err != nil {
+			return nil, fmt.Errorf("create user report: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		if repo.IsDuplicateKey(err) {
+			return nil, ErrDuplicateType
+		}
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	entries, err := s.Day(ctx, repo.GetReportUserForDayParams{
+		UserID: prm.UserID,
+		Day:    prm.Day,
+		Month:  prm.Month,
+		Year:   prm.Year,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.Publish(ctx, webhook.EventReportDaySet, entries)
+
+	return entries, nil
+}
+
 // monthStats содержит агрегированную статистику за месяц
 type monthStats struct {
-	TotalHours  float64 `json:"totalHours"`
-	WorkDays    int64   `json:"workDays"`
-	MedicalDays int64   `json:"medicalDays"`
+	TotalHours    float64                            `json:"totalHours"`
+	WorkDays      int64                              `json:"workDays"`
+	MedicalDays   int64                              `json:"medicalDays"`
+	ExpectedHours float64                            `json:"expectedHours"`
+	ExpectedDays  int64                              `json:"expectedDays"`
+	MissingDays   []int32                            `json:"missingDays"`
+	NightHours    float64                            `json:"nightHours"`
+	HolidayHours  float64                            `json:"holidayHours"`
+	ByType        []repo.GetReportUserStatsByTypeRow `json:"byType"`
 }
 
 // getMonthStats получает всю статистику за месяц одним вызовом
-func (s *service) MonthStats(ctx context.Context, userID string, month, year int32) (*monthStats, error) {
+func (s *service) MonthStats(ctx context.Context, userID string, month, year int32) (_ *monthStats, err error) {
+	ctx, end := tracing.Start(ctx, "report.MonthStats", tracing.User(userID), tracing.Month(month), tracing.Year(year))
+	defer end(&err)
+
 	// Получение общих часов
 	totalHours, err := s.repo.GetReportUserTotalHours(ctx, repo.GetReportUserTotalHoursParams{
 		UserID: userID,
@@ -152,13 +610,156 @@ func (s *service) MonthStats(ctx context.Context, userID string, month, year int
 		return nil, fmt.Errorf("count medical days: %w", err)
 	}
 
+	// Норма по графику сотрудника
+	expected, err := s.schedules.ExpectedMonth(ctx, userID, month, year)
+	if err != nil {
+		return nil, fmt.Errorf("get expected hours: %w", err)
+	}
+
+	// Ночные часы
+	nightHours, err := s.repo.GetReportUserNightHours(ctx, repo.GetReportUserNightHoursParams{
+		UserID: userID,
+		Month:  month,
+		Year:   year,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get night hours: %w", err)
+	}
+
+	reports, err := s.repo.GetReportUserForMonth(ctx, repo.GetReportUserForMonthParams{
+		UserID: userID,
+		Month:  month,
+		Year:   year,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get user month report: %w", err)
+	}
+
+	// Дни и часы по каждому типу отметок (день может содержать несколько типов)
+	byType, err := s.repo.GetReportUserStatsByType(ctx, repo.GetReportUserStatsByTypeParams{
+		UserID: userID,
+		Month:  month,
+		Year:   year,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get stats by type: %w", err)
+	}
+
+	// Часы в праздничные дни оплачиваются отдельно
+	calendarDays, err := s.calendar.MonthDays(ctx, month, year)
+	if err != nil {
+		return nil, fmt.Errorf("get calendar month: %w", err)
+	}
+
+	var holidayHours float64
+	for _, r := range reports {
+		if r.Day >= 1 && int(r.Day) <= len(*calendarDays) && (*calendarDays)[r.Day-1].Kind == calendar.DayKindHoliday {
+			holidayHours += r.Hours
+		}
+	}
+
+	// отключенным в каталоге пропуски не считаются, как и в MissingDays
+	inactive, err := s.inactive(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	missingDays := []int32{}
+	if !inactive {
+		missingDays = findMissingDays(reports, expected.Days, month, year)
+	}
+
 	return &monthStats{
-		TotalHours:  totalHours,
-		WorkDays:    workDays,
-		MedicalDays: medicalDays,
+		TotalHours:    totalHours,
+		WorkDays:      workDays,
+		MedicalDays:   medicalDays,
+		ExpectedHours: expected.TotalHours,
+		ExpectedDays:  int64(expected.WorkDays),
+		MissingDays:   missingDays,
+		NightHours:    nightHours,
+		HolidayHours:  holidayHours,
+		ByType:        byType,
 	}, nil
 }
 
+// MissingDays - дни без отметок. Для сотрудников, отключенных в каталоге, список всегда пуст.
+func (s *service) MissingDays(ctx context.Context, userID string, month, year int32) (_ *[]int32, err error) {
+	ctx, end := tracing.Start(ctx, "report.MissingDays", tracing.User(userID), tracing.Month(month), tracing.Year(year))
+	defer end(&err)
+
+	inactive, err := s.inactive(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if inactive {
+		return &[]int32{}, nil
+	}
+
+	expected, err := s.schedules.ExpectedMonth(ctx, userID, month, year)
+	if err != nil {
+		return nil, fmt.Errorf("get expected hours: %w", err)
+	}
+
+	reports, err := s.repo.GetReportUserForMonth(ctx, repo.GetReportUserForMonthParams{
+		UserID: userID,
+		Month:  month,
+		Year:   year,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get user month report: %w", err)
+	}
+
+	missingDays := findMissingDays(reports, expected.Days, month, year)
+
+	return &missingDays, nil
+}
+
+// inactive сообщает, что сотрудник отключен в каталоге
+func (s *service) inactive(ctx context.Context, userID string) (bool, error) {
+	inactive, err := s.repo.CheckDirectoryUserInactive(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("check user status: %w", err)
+	}
+	return inactive > 0, nil
+}
+
+// findMissingDays возвращает дни, рабочие по графику сотрудника, но без отметки в табеле.
+// Будущие дни не учитываются.
+func findMissingDays(reports []repo.GetReportUserForMonthRow, expected []schedule.ExpectedDay, month, year int32) []int32 {
+	reported := make(map[int32]bool, len(reports))
+	for _, r := range reports {
+		reported[r.Day] = true
+	}
+
+	today := time.Now()
+	missing := []int32{}
+
+	for _, day := range expected {
+		date := time.Date(int(year), time.Month(month), int(day.Day), 0, 0, 0, 0, today.Location())
+		if date.After(today) {
+			break
+		}
+
+		if day.IsWorking && !reported[day.Day] {
+			missing = append(missing, day.Day)
+		}
+	}
+
+	return missing
+}
+
+// publish собирает ответ по сохраненной отметке reportID и отправляет его вебхукам
+// событием event; тот же ответ возвращается клиенту
+func (s *service) publish(ctx context.Context, event, reportID string) (*ReportResponse, error) {
+	response, err := s.buildReportResponse(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.Publish(ctx, event, response)
+
+	return response, nil
+}
+
 // buildReportResponse создает ответ с отчетом и статистикой
 func (s *service) buildReportResponse(ctx context.Context, reportID string) (*ReportResponse, error) {
 	report, err := s.repo.GetReportUserById(ctx, reportID)
@@ -166,7 +767,7 @@ func (s *service) buildReportResponse(ctx context.Context, reportID string) (*Re
 		return nil, fmt.Errorf("get user day report: %w", err)
 	}
 
-	return &ReportResponse{
+	response := ReportResponse{
 		ID:             report.ID,
 		UserID:         report.UserID,
 		Day:            report.Day,
@@ -176,5 +777,14 @@ func (s *service) buildReportResponse(ctx context.Context, reportID string) (*Re
 		TypeID:         report.TypeID,
 		TypeName:       report.TypeName,
 		TypeSystemName: report.TypeSystemName,
-	}, nil
+		NightHours:     report.NightHours,
+	}
+
+	if report.StartMinute.Valid && report.EndMinute.Valid {
+		start := shift.FormatClock(report.StartMinute.Int32)
+		end := shift.FormatClock(report.EndMinute.Int32)
+		response.Start, response.End = &start, &end
+	}
+
+	return &response, nil
 }
@@ -0,0 +1,548 @@
+package report
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/calendar"
+	"TimeTrack/internal/closing"
+	"TimeTrack/internal/project"
+	"TimeTrack/internal/schedule"
+	"TimeTrack/internal/shift"
+	"TimeTrack/internal/tracing"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// events запоминает опубликованные события вместо отправки вебхуков
+type events []string
+
+func (e *events) Publish(ctx context.Context, event string, data any) {
+	*e = append(*e, event)
+}
+
+const userID = "0b9c7f5e-4a3d-4c1e-9f7a-2d8e6b1c5a40"
+
+// newTestService собирает сервис табеля с настоящими сервисами календаря, графиков
+// и смен над одним хранилищем в памяти. 1 мая 2025 года - праздник.
+func newTestService(t *testing.T) (Service, *memory.Store, *events) {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.New()
+
+	for _, rt := range []repo.CreateTypeParams{
+		{ID: "t-work", Name: "Явка", SystemName: "work", Code: "Я"},
+		{ID: "t-medical", Name: "Больничный", SystemName: "medical", Code: "Б"},
+		{ID: "t-remote", Name: "Удаленная работа", SystemName: "remote", Code: "УР"},
+		{ID: "t-holiday", Name: "Праздник", SystemName: "holiday", Code: "В"},
+	} {
+		if err := store.CreateType(ctx, rt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.CreateCalendarDay(ctx, repo.CreateCalendarDayParams{ID: "c-0501", Day: 1, Month: 5, Year: 2025, TypeID: "t-holiday"}); err != nil {
+		t.Fatal(err)
+	}
+
+	published := &events{}
+	calendarService := calendar.NewService(store, store, published)
+	scheduleService := schedule.NewService(store, store, calendarService)
+	shiftService := shift.NewService(store, store)
+
+	return NewService(store, store, scheduleService, shiftService, calendarService, published), store, published
+}
+
+func minute(hours int32) *int32 {
+	m := hours * 60
+	return &m
+}
+
+func TestCreate(t *testing.T) {
+	svc, _, published := newTestService(t)
+
+	report, err := svc.Create(context.Background(), CreateReportParams{
+		ID: "r-1", UserID: userID, Day: 5, Month: 5, Year: 2025,
+		Hours: 5, Type: "work", StartMinute: minute(18), EndMinute: minute(23),
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if report.TypeName != "Явка" || report.TypeSystemName != "work" {
+		t.Fatalf("type = %q/%q", report.TypeName, report.TypeSystemName)
+	}
+	if report.Start == nil || *report.Start != "18:00" || *report.End != "23:00" {
+		t.Fatalf("interval = %v-%v", report.Start, report.End)
+	}
+	// Ночное окно по умолчанию 22:00-06:00
+	if report.NightHours != 1 {
+		t.Fatalf("night hours = %v, want 1", report.NightHours)
+	}
+	if len(*published) != 1 || (*published)[0] != "report.created" {
+		t.Fatalf("events = %v", *published)
+	}
+}
+
+func TestCreateUsesPlannedShift(t *testing.T) {
+	svc, store, _ := newTestService(t)
+	ctx := context.Background()
+
+	if err := store.CreateShift(ctx, repo.CreateShiftParams{
+		ID: "s-1", UserID: userID, Day: 5, Month: 5, Year: 2025, StartMinute: 20 * 60, EndMinute: 8 * 60,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := svc.Create(ctx, CreateReportParams{ID: "r-1", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 12, Type: "work"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if report.Start == nil || *report.Start != "20:00" || *report.End != "08:00" {
+		t.Fatalf("interval = %v-%v, want planned shift", report.Start, report.End)
+	}
+	if report.NightHours != 8 {
+		t.Fatalf("night hours = %v, want 8", report.NightHours)
+	}
+}
+
+func TestCreateRejectsDuplicateTypeAndLongDay(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	ctx := context.Background()
+
+	if _, err := svc.Create(ctx, CreateReportParams{ID: "r-1", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 16, Type: "work"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	_, err := svc.Create(ctx, CreateReportParams{ID: "r-2", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 1, Type: "WORK"})
+	if !errors.Is(err, ErrDuplicateType) {
+		t.Fatalf("same type: err = %v, want ErrDuplicateType", err)
+	}
+
+	_, err = svc.Create(ctx, CreateReportParams{ID: "r-3", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 8.5, Type: "remote"})
+	if !errors.Is(err, ErrDayHoursExceeded) {
+		t.Fatalf("25.5 hours: err = %v, want ErrDayHoursExceeded", err)
+	}
+
+	if _, err := svc.Create(ctx, CreateReportParams{ID: "r-4", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 8, Type: "remote"}); err != nil {
+		t.Fatalf("exactly 24 hours: %v", err)
+	}
+}
+
+// racing - хранилище, в транзакциях которого параллельный запрос успевает записать
+// отметку дня после проверок: см. racingTx
+type racing struct {
+	*memory.Store
+}
+
+func (r racing) Begin(ctx context.Context) (repo.Tx, error) {
+	tx, err := r.Store.Begin(ctx)
+	return racingTx{tx}, err
+}
+
+// racingTx не видит отметок дня при проверке вида и не удаляет их: так выглядит
+// транзакция без блокировки дня, когда другой запрос вставил отметку параллельно
+type racingTx struct {
+	repo.Tx
+}
+
+func (racingTx) CheckReportUserTypeExists(ctx context.Context, arg repo.CheckReportUserTypeExistsParams) (int64, error) {
+	return 0, nil
+}
+
+func (racingTx) DeleteReportUser(ctx context.Context, arg repo.DeleteReportUserParams) error {
+	return nil
+}
+
+// TestDuplicateKey - отметку, прошедшую проверки, отсекает уникальный индекс
+// report_user_day_type; это та же ошибка ErrDuplicateType (409), а не сбой сервера
+func TestDuplicateKey(t *testing.T) {
+	_, store, published := newTestService(t)
+	ctx := context.Background()
+
+	if err := store.CreateReportUser(ctx, repo.CreateReportUserParams{
+		ID: "r-1", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 8, TypeID: "t-work",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	calendarService := calendar.NewService(store, store, published)
+	svc := NewService(store, racing{store}, schedule.NewService(store, store, calendarService), shift.NewService(store, store), calendarService, published)
+
+	_, err := svc.Create(ctx, CreateReportParams{ID: "r-2", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 4, Type: "work"})
+	if !errors.Is(err, ErrDuplicateType) || apperr.Status(apperr.Wrap(err, "failed")) != http.StatusConflict {
+		t.Fatalf("Create: err = %v, want ErrDuplicateType", err)
+	}
+
+	_, err = svc.SetDay(ctx, SetDayParams{UserID: userID, Day: 5, Month: 5, Year: 2025, Entries: []DayEntry{{Hours: 4, Type: "work"}}})
+	if !errors.Is(err, ErrDuplicateType) || apperr.Status(apperr.Wrap(err, "failed")) != http.StatusConflict {
+		t.Fatalf("SetDay: err = %v, want ErrDuplicateType", err)
+	}
+
+	if reports, err := store.GetReportUserForDay(ctx, repo.GetReportUserForDayParams{UserID: userID, Day: 5, Month: 5, Year: 2025}); err != nil || len(reports) != 1 || reports[0].ID != "r-1" {
+		t.Fatalf("day = %+v, %v, want only r-1", reports, err)
+	}
+}
+
+func TestCreateRejectsUnknownType(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	ctx := context.Background()
+
+	_, err := svc.Create(ctx, CreateReportParams{ID: "r-1", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 8, Type: "vacation"})
+	if !errors.Is(err, ErrUnknownType) {
+		t.Fatalf("Create: err = %v, want ErrUnknownType", err)
+	}
+	if status := apperr.Status(err); status != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", status)
+	}
+
+	_, err = svc.SetDay(ctx, SetDayParams{UserID: userID, Day: 5, Month: 5, Year: 2025, Entries: []DayEntry{{Hours: 8, Type: "vacation"}}})
+	if !errors.Is(err, ErrUnknownType) {
+		t.Fatalf("SetDay: err = %v, want ErrUnknownType", err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	ctx := context.Background()
+
+	for _, prm := range []CreateReportParams{
+		{ID: "r-1", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 8, Type: "work"},
+		{ID: "r-2", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 8, Type: "remote"},
+	} {
+		if _, err := svc.Create(ctx, prm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := svc.Update(ctx, UpdateReportParams{ID: "r-2", Hours: 4, Type: "work"}); !errors.Is(err, ErrDuplicateType) {
+		t.Fatalf("err = %v, want ErrDuplicateType", err)
+	}
+	if _, err := svc.Update(ctx, UpdateReportParams{ID: "r-2", Hours: 17, Type: "remote"}); !errors.Is(err, ErrDayHoursExceeded) {
+		t.Fatalf("err = %v, want ErrDayHoursExceeded", err)
+	}
+
+	report, err := svc.Update(ctx, UpdateReportParams{ID: "r-2", Hours: 16, Type: "medical"})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if report.Hours != 16 || report.TypeSystemName != "medical" {
+		t.Fatalf("report = %+v", report)
+	}
+
+	// отсутствующая отметка - 404, даже если обработчик обернул ошибку
+	_, err = svc.Update(ctx, UpdateReportParams{ID: "r-missing", Hours: 8, Type: "work"})
+	if !errors.Is(err, ErrNotFound) || apperr.Status(apperr.Wrap(err, "failed to update report")) != http.StatusNotFound {
+		t.Fatalf("missing report: err = %v, want ErrNotFound", err)
+	}
+}
+
+// TestUpdateAllocations - часы отметки не уменьшаются ниже распределенных по проектам,
+// смена вида отметки удаляет ее распределение
+func TestUpdateAllocations(t *testing.T) {
+	svc, store, _ := newTestService(t)
+	ctx := context.Background()
+
+	if _, err := svc.Create(ctx, CreateReportParams{ID: "r-1", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 8, Type: "work"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateProject(ctx, repo.CreateProjectParams{ID: "p-1", Code: "TT", Name: "TimeTrack", IsActive: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateAllocation(ctx, repo.CreateAllocationParams{ID: "a-1", ReportID: "r-1", ProjectID: "p-1", Hours: 6}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.Update(ctx, UpdateReportParams{ID: "r-1", Hours: 5, Type: "work"}); !errors.Is(err, project.ErrAllocationExceeded) {
+		t.Fatalf("err = %v, want ErrAllocationExceeded", err)
+	}
+	if _, err := svc.Update(ctx, UpdateReportParams{ID: "r-1", Hours: 6, Type: "work"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if _, err := svc.Update(ctx, UpdateReportParams{ID: "r-1", Hours: 6, Type: "medical"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	allocations, err := store.GetAllocationsByReport(ctx, "r-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(allocations) != 0 {
+		t.Fatalf("allocations after type change = %d, want 0", len(allocations))
+	}
+}
+
+// TestUpdateKeepsInterval - изменение без start/end не заменяет записанный интервал сменой из плана
+func TestUpdateKeepsInterval(t *testing.T) {
+	svc, store, _ := newTestService(t)
+	ctx := context.Background()
+
+	if _, err := svc.Create(ctx, CreateReportParams{
+		ID: "r-1", UserID: userID, Day: 5, Month: 5, Year: 2025,
+		Hours: 5, Type: "work", StartMinute: minute(18), EndMinute: minute(23),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateShift(ctx, repo.CreateShiftParams{
+		ID: "s-1", UserID: userID, Day: 5, Month: 5, Year: 2025, StartMinute: 9 * 60, EndMinute: 17 * 60,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := svc.Update(ctx, UpdateReportParams{ID: "r-1", Hours: 4, Type: "work"})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if report.Start == nil || *report.Start != "18:00" || *report.End != "23:00" {
+		t.Fatalf("interval = %v-%v, want stored 18:00-23:00", report.Start, report.End)
+	}
+	if report.NightHours != 1 {
+		t.Fatalf("night hours = %v, want 1", report.NightHours)
+	}
+}
+
+func TestSetDay(t *testing.T) {
+	svc, store, published := newTestService(t)
+	ctx := context.Background()
+
+	if _, err := svc.Create(ctx, CreateReportParams{ID: "r-1", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 8, Type: "medical"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateProject(ctx, repo.CreateProjectParams{ID: "p-1", Code: "TT", Name: "TimeTrack", IsActive: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateAllocation(ctx, repo.CreateAllocationParams{ID: "a-1", ReportID: "r-1", ProjectID: "p-1", Hours: 8}); err != nil {
+		t.Fatal(err)
+	}
+
+	day := SetDayParams{UserID: userID, Day: 5, Month: 5, Year: 2025}
+
+	day.Entries = []DayEntry{{Hours: 20, Type: "work"}, {Hours: 5, Type: "remote"}}
+	if _, err := svc.SetDay(ctx, day); !errors.Is(err, ErrDayHoursExceeded) {
+		t.Fatalf("err = %v, want ErrDayHoursExceeded", err)
+	}
+	day.Entries = []DayEntry{{Hours: 4, Type: "work"}, {Hours: 4, Type: "Work"}}
+	if _, err := svc.SetDay(ctx, day); !errors.Is(err, ErrDuplicateType) {
+		t.Fatalf("err = %v, want ErrDuplicateType", err)
+	}
+
+	// Отклоненные наборы не должны были изменить день
+	if _, err := store.GetReportUserById(ctx, "r-1"); err != nil {
+		t.Fatalf("entry was removed by rejected SetDay: %v", err)
+	}
+
+	day.Entries = []DayEntry{{Hours: 6, Type: "work"}, {Hours: 2, Type: "remote"}}
+	entries, err := svc.SetDay(ctx, day)
+	if err != nil {
+		t.Fatalf("SetDay: %v", err)
+	}
+	if len(*entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(*entries))
+	}
+	for _, e := range *entries {
+		if e.TypeSystemName == "medical" {
+			t.Fatal("previous entry was not replaced")
+		}
+	}
+
+	allocations, err := store.GetAllocationsByReport(ctx, "r-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(allocations) != 0 {
+		t.Fatalf("allocations of replaced entry = %d, want 0", len(allocations))
+	}
+	if (*published)[len(*published)-1] != "report.day_set" {
+		t.Fatalf("events = %v", *published)
+	}
+}
+
+func TestDeleteEntry(t *testing.T) {
+	svc, store, _ := newTestService(t)
+	ctx := context.Background()
+
+	for _, prm := range []CreateReportParams{
+		{ID: "r-1", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 6, Type: "work"},
+		{ID: "r-2", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 2, Type: "remote"},
+	} {
+		if _, err := svc.Create(ctx, prm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := svc.DeleteEntry(ctx, "r-1"); err != nil {
+		t.Fatalf("DeleteEntry: %v", err)
+	}
+
+	entries, err := svc.Day(ctx, repo.GetReportUserForDayParams{UserID: userID, Day: 5, Month: 5, Year: 2025})
+	if err != nil {
+		t.Fatalf("Day: %v", err)
+	}
+	if len(*entries) != 1 || (*entries)[0].ID != "r-2" {
+		t.Fatalf("entries = %+v, want only r-2", *entries)
+	}
+
+	total, err := store.GetReportUserDayTotalHours(ctx, repo.GetReportUserDayTotalHoursParams{UserID: userID, Day: 5, Month: 5, Year: 2025})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 {
+		t.Fatalf("day total = %v, want 2", total)
+	}
+}
+
+// TestMonthClosed - отметки закрытого месяца не меняются, пока его снова не откроют
+func TestMonthClosed(t *testing.T) {
+	svc, store, _ := newTestService(t)
+	ctx := context.Background()
+
+	if _, err := svc.Create(ctx, CreateReportParams{ID: "r-1", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 8, Type: "work"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CloseMonth(ctx, repo.CloseMonthParams{Month: 5, Year: 2025}); err != nil {
+		t.Fatal(err)
+	}
+
+	writes := map[string]func() error{
+		"Create": func() error {
+			_, err := svc.Create(ctx, CreateReportParams{ID: "r-2", UserID: userID, Day: 6, Month: 5, Year: 2025, Hours: 8, Type: "work"})
+			return err
+		},
+		"Update": func() error {
+			_, err := svc.Update(ctx, UpdateReportParams{ID: "r-1", Hours: 7, Type: "work"})
+			return err
+		},
+		"SetDay": func() error {
+			_, err := svc.SetDay(ctx, SetDayParams{UserID: userID, Day: 5, Month: 5, Year: 2025})
+			return err
+		},
+		"Delete": func() error {
+			return svc.Delete(ctx, repo.DeleteReportUserParams{UserID: userID, Day: 5, Month: 5, Year: 2025})
+		},
+		"DeleteEntry": func() error {
+			return svc.DeleteEntry(ctx, "r-1")
+		},
+	}
+	for name, write := range writes {
+		if err := write(); !errors.Is(err, closing.ErrMonthClosed) {
+			t.Errorf("%s: err = %v, want ErrMonthClosed", name, err)
+		}
+	}
+	if _, err := store.GetReportUserById(ctx, "r-1"); err != nil {
+		t.Fatalf("entry of the closed month: %v", err)
+	}
+
+	if err := store.ReopenMonth(ctx, repo.ReopenMonthParams{Month: 5, Year: 2025}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writes["Update"](); err != nil {
+		t.Fatalf("Update after reopen: %v", err)
+	}
+}
+
+func TestMonthStats(t *testing.T) {
+	svc, store, _ := newTestService(t)
+	ctx := context.Background()
+
+	for _, prm := range []CreateReportParams{
+		{ID: "r-0501", UserID: userID, Day: 1, Month: 5, Year: 2025, Hours: 4, Type: "work"},
+		{ID: "r-0505", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 6, Type: "work"},
+		{ID: "r-0505r", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 2.5, Type: "remote"},
+		{ID: "r-0506", UserID: userID, Day: 6, Month: 5, Year: 2025, Hours: 8, Type: "medical"},
+		{ID: "r-other", UserID: "another-user", Day: 6, Month: 5, Year: 2025, Hours: 8, Type: "work"},
+	} {
+		if _, err := svc.Create(ctx, prm); err != nil {
+			t.Fatalf("Create %s: %v", prm.ID, err)
+		}
+	}
+
+	stats, err := svc.MonthStats(ctx, userID, 5, 2025)
+	if err != nil {
+		t.Fatalf("MonthStats: %v", err)
+	}
+
+	if stats.TotalHours != 20.5 {
+		t.Errorf("total hours = %v, want 20.5", stats.TotalHours)
+	}
+	if stats.WorkDays != 2 || stats.MedicalDays != 1 {
+		t.Errorf("work/medical days = %d/%d, want 2/1", stats.WorkDays, stats.MedicalDays)
+	}
+	// 22 будних дня в мае 2025 года, 1 мая - праздник
+	if stats.ExpectedDays != 21 || stats.ExpectedHours != 168 {
+		t.Errorf("expected = %d days / %v hours, want 21 / 168", stats.ExpectedDays, stats.ExpectedHours)
+	}
+	if stats.HolidayHours != 4 {
+		t.Errorf("holiday hours = %v, want 4", stats.HolidayHours)
+	}
+	if len(stats.MissingDays) != 19 || stats.MissingDays[0] != 2 {
+		t.Errorf("missing days = %v", stats.MissingDays)
+	}
+
+	var names []string
+	for _, row := range stats.ByType {
+		names = append(names, row.TypeName)
+	}
+	if len(names) != 3 || names[0] != "Больничный" || names[1] != "Удаленная работа" || names[2] != "Явка" {
+		t.Errorf("by type = %v", names)
+	}
+	if work := stats.ByType[2]; work.DaysCount != 2 || work.TotalHours != 10 {
+		t.Errorf("work = %+v", work)
+	}
+
+	// у отключенного в каталоге сотрудника пропусков нет, как и в MissingDays
+	if err := store.CreateDirectoryUser(ctx, repo.CreateDirectoryUserParams{ID: userID, Login: "ivan", Name: "Иван"}); err != nil {
+		t.Fatal(err)
+	}
+	stats, err = svc.MonthStats(ctx, userID, 5, 2025)
+	if err != nil {
+		t.Fatalf("MonthStats: %v", err)
+	}
+	if stats.MissingDays == nil || len(stats.MissingDays) != 0 || stats.TotalHours != 20.5 {
+		t.Errorf("inactive user: missing days = %v, total hours = %v", stats.MissingDays, stats.TotalHours)
+	}
+}
+
+// TestMonthStatsSpans - каждый запрос MonthStats виден отдельным span внутри span метода
+func TestMonthStatsSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	_, store, published := newTestService(t)
+	traced := tracing.Querier(store)
+	calendarService := calendar.NewService(traced, store, published)
+	svc := NewService(traced, store, schedule.NewService(traced, store, calendarService), shift.NewService(traced, store), calendarService, published)
+
+	if _, err := svc.MonthStats(context.Background(), userID, 5, 2025); err != nil {
+		t.Fatalf("MonthStats: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	var stats tracetest.SpanStub
+	for _, s := range spans {
+		if s.Name == "report.MonthStats" {
+			stats = s
+		}
+	}
+	if !stats.SpanContext.IsValid() {
+		t.Fatalf("no report.MonthStats span in %d spans", len(spans))
+	}
+
+	children := map[string]bool{}
+	for _, s := range spans {
+		if s.Parent.SpanID() == stats.SpanContext.SpanID() {
+			children[s.Name] = true
+		}
+	}
+	for _, name := range []string{"repo.GetReportUserTotalHours", "repo.GetReportUserCountWork", "repo.GetTypeBySystemName", "repo.GetReportUserCountByType", "schedule.ExpectedMonth"} {
+		if !children[name] {
+			t.Errorf("report.MonthStats has no child span %s: %v", name, children)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package report
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/tracing"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// timesheetMark - одна отметка в ячейке табеля (код типа и часы)
+type timesheetMark struct {
+	Code  string
+	Hours float64
+}
+
+// timesheetRow - строка табеля: сотрудник и его отметки по дням месяца.
+// Name - ФИО из справочника сотрудников или UserID, если записи нет.
+type timesheetRow struct {
+	UserID      string
+	Name        string
+	Marks       map[int32][]timesheetMark
+	WorkDays    int
+	ActualHours float64
+}
+
+// timesheetDay - столбец табеля
+type timesheetDay struct {
+	Day       int32
+	IsWorking bool
+}
+
+// timesheet - табель учета рабочего времени команды за месяц
+type timesheet struct {
+	Month     int32
+	Year      int32
+	Days      []timesheetDay
+	Rows      []timesheetRow
+	NormHours float64
+	HasNorm   bool
+}
+
+// Timesheet собирает табель за месяц по всем сотрудникам, у которых есть отметки.
+// Норма берется из report_standard для указанного genderID.
+func (s *service) Timesheet(ctx context.Context, month, year, genderID int32) (_ *timesheet, err error) {
+	ctx, end := tracing.Start(ctx, "report.Timesheet", tracing.Month(month), tracing.Year(year))
+	defer end(&err)
+
+	calendarDays, err := s.calendar.MonthDays(ctx, month, year)
+	if err != nil {
+		return nil, fmt.Errorf("get calendar month: %w", err)
+	}
+
+	sheet := &timesheet{Month: month, Year: year}
+	for i, d := range *calendarDays {
+		sheet.Days = append(sheet.Days, timesheetDay{Day: int32(i + 1), IsWorking: d.IsWorking})
+	}
+
+	standard, err := s.repo.GetStandard(ctx, repo.GetStandardParams{
+		Month:    month,
+		Year:     year,
+		GenderID: genderID,
+	})
+	switch {
+	case err == nil:
+		sheet.NormHours = float64(standard.Hours)
+		sheet.HasNorm = true
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("get standard: %w", err)
+	}
+
+	reports, err := s.repo.GetReportUserForMonthAll(ctx, repo.GetReportUserForMonthAllParams{
+		Month: month,
+		Year:  year,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get month reports: %w", err)
+	}
+
+	names, err := s.employeeNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Отметки приходят отсортированными по сотруднику и дню
+	var row *timesheetRow
+	worked := make(map[int32]bool)
+	for _, r := range reports {
+		if row == nil || row.UserID != r.UserID {
+			name := names[r.UserID]
+			if name == "" {
+				name = r.UserID
+			}
+			sheet.Rows = append(sheet.Rows, timesheetRow{
+				UserID: r.UserID,
+				Name:   name,
+				Marks:  make(map[int32][]timesheetMark),
+			})
+			row = &sheet.Rows[len(sheet.Rows)-1]
+			clear(worked)
+		}
+
+		code := r.TypeCode
+		if code == "" {
+			code = r.TypeSystemName
+		}
+		row.Marks[r.Day] = append(row.Marks[r.Day], timesheetMark{Code: code, Hours: r.Hours})
+
+		if r.TypeSystemName == "work" || r.TypeSystemName == "weekend" {
+			row.ActualHours += r.Hours
+			if !worked[r.Day] {
+				worked[r.Day] = true
+				row.WorkDays++
+			}
+		}
+	}
+
+	return sheet, nil
+}
+
+// employeeNames - ФИО сотрудников из справочника по ID
+func (s *service) employeeNames(ctx context.Context) (map[string]string, error) {
+	users, err := s.repo.GetDirectoryUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get directory users: %w", err)
+	}
+
+	names := make(map[string]string, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,200 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const timesheetSheet = "Табель"
+
+// firstDayColumn - номер столбца первого дня месяца (A - №, B - сотрудник)
+const firstDayColumn = 3
+
+// WriteXLSX выводит табель в формате XLSX: строка на сотрудника, столбец на день,
+// выходные и праздники затенены, в конце - итоги факт/норма
+func (t *timesheet) WriteXLSX(w io.Writer) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetSheetName("Sheet1", timesheetSheet); err != nil {
+		return fmt.Errorf("rename sheet: %w", err)
+	}
+
+	styles, err := newTimesheetStyles(f)
+	if err != nil {
+		return err
+	}
+
+	totalsColumn := firstDayColumn + len(t.Days)
+	lastColumn := totalsColumn + 3
+
+	title := fmt.Sprintf("Табель учета рабочего времени за %02d.%d", t.Month, t.Year)
+	if err := f.SetCellValue(timesheetSheet, "A1", title); err != nil {
+		return err
+	}
+
+	header := []string{"№", "Сотрудник"}
+	for _, d := range t.Days {
+		header = append(header, strconv.Itoa(int(d.Day)))
+	}
+	header = append(header, "Дней", "Факт, ч", "Норма, ч", "Откл., ч")
+
+	for i, value := range header {
+		if err := setCell(f, i+1, 3, value, styles.header); err != nil {
+			return err
+		}
+	}
+
+	for i, row := range t.Rows {
+		line := i + 4
+
+		if err := setCell(f, 1, line, i+1, styles.cell); err != nil {
+			return err
+		}
+		if err := setCell(f, 2, line, row.Name, styles.text); err != nil {
+			return err
+		}
+
+		for j, d := range t.Days {
+			style := styles.cell
+			if !d.IsWorking {
+				style = styles.weekend
+			}
+			if err := setCell(f, firstDayColumn+j, line, formatMarks(row.Marks[d.Day]), style); err != nil {
+				return err
+			}
+		}
+
+		if err := setCell(f, totalsColumn, line, row.WorkDays, styles.cell); err != nil {
+			return err
+		}
+
+		totals := []any{row.ActualHours, "", ""}
+		if t.HasNorm {
+			totals[1] = t.NormHours
+			totals[2] = row.ActualHours - t.NormHours
+		}
+		for j, value := range totals {
+			if err := setCell(f, totalsColumn+1+j, line, value, styles.total); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := f.SetColWidth(timesheetSheet, "A", "A", 5); err != nil {
+		return err
+	}
+	if err := f.SetColWidth(timesheetSheet, "B", "B", 38); err != nil {
+		return err
+	}
+
+	first, _ := excelize.ColumnNumberToName(firstDayColumn)
+	last, _ := excelize.ColumnNumberToName(totalsColumn - 1)
+	if err := f.SetColWidth(timesheetSheet, first, last, 6); err != nil {
+		return err
+	}
+
+	first, _ = excelize.ColumnNumberToName(totalsColumn)
+	last, _ = excelize.ColumnNumberToName(lastColumn)
+	if err := f.SetColWidth(timesheetSheet, first, last, 10); err != nil {
+		return err
+	}
+
+	if err := f.SetPanes(timesheetSheet, &excelize.Panes{
+		Freeze:      true,
+		XSplit:      2,
+		YSplit:      3,
+		TopLeftCell: "C4",
+		ActivePane:  "bottomRight",
+	}); err != nil {
+		return err
+	}
+
+	return f.Write(w)
+}
+
+type timesheetStyles struct {
+	header  int
+	text    int
+	cell    int
+	weekend int
+	total   int
+}
+
+func newTimesheetStyles(f *excelize.File) (*timesheetStyles, error) {
+	border := []excelize.Border{
+		{Type: "left", Color: "000000", Style: 1},
+		{Type: "top", Color: "000000", Style: 1},
+		{Type: "right", Color: "000000", Style: 1},
+		{Type: "bottom", Color: "000000", Style: 1},
+	}
+	center := &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true}
+
+	var styles timesheetStyles
+	var err error
+
+	if styles.header, err = f.NewStyle(&excelize.Style{
+		Border:    border,
+		Alignment: center,
+		Font:      &excelize.Font{Bold: true},
+	}); err != nil {
+		return nil, fmt.Errorf("create header style: %w", err)
+	}
+
+	if styles.text, err = f.NewStyle(&excelize.Style{
+		Border:    border,
+		Alignment: &excelize.Alignment{Vertical: "center"},
+	}); err != nil {
+		return nil, fmt.Errorf("create text style: %w", err)
+	}
+
+	if styles.cell, err = f.NewStyle(&excelize.Style{
+		Border:    border,
+		Alignment: center,
+	}); err != nil {
+		return nil, fmt.Errorf("create cell style: %w", err)
+	}
+
+	if styles.weekend, err = f.NewStyle(&excelize.Style{
+		Border:    border,
+		Alignment: center,
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"D9D9D9"}},
+	}); err != nil {
+		return nil, fmt.Errorf("create weekend style: %w", err)
+	}
+
+	if styles.total, err = f.NewStyle(&excelize.Style{
+		Border:    border,
+		Alignment: center,
+		Font:      &excelize.Font{Bold: true},
+		NumFmt:    2,
+	}); err != nil {
+		return nil, fmt.Errorf("create total style: %w", err)
+	}
+
+	return &styles, nil
+}
+
+func setCell(f *excelize.File, col, row int, value any, style int) error {
+	cell, err := excelize.CoordinatesToCellName(col, row)
+	if err != nil {
+		return err
+	}
+	if err := f.SetCellValue(timesheetSheet, cell, value); err != nil {
+		return err
+	}
+	return f.SetCellStyle(timesheetSheet, cell, cell, style)
+}
+
+// formatMarks - содержимое ячейки дня: код и часы каждой отметки на отдельной строке
+func formatMarks(marks []timesheetMark) string {
+	lines := make([]string, 0, len(marks))
+	for _, m := range marks {
+		lines = append(lines, m.Code+" "+strconv.FormatFloat(m.Hours, 'f', -1, 64))
+	}
+	return strings.Join(lines, "\n")
+}
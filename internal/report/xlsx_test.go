@@ -0,0 +1,76 @@
+package report
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// TestTimesheetXLSX открывает выгруженную книгу и сверяет ячейки: ФИО из справочника
+// (или ID, если сотрудника в нем нет), коды и часы по дням, итоги факт/норма
+func TestTimesheetXLSX(t *testing.T) {
+	svc, store, _ := newTestService(t)
+	ctx := context.Background()
+	const annaID = "5d2e8a1f-7c3b-4e9d-8a6f-1b4c7e2d9f30"
+
+	if err := store.CreateDirectoryUser(ctx, repo.CreateDirectoryUserParams{ID: userID, Login: "ivanov", Name: "Иванов Иван", IsActive: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateStandard(ctx, repo.CreateStandardParams{ID: "s-0525", Month: 5, Year: 2025, Hours: 160, GenderID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	for _, prm := range []CreateReportParams{
+		{ID: "r-1", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 8, Type: "work"},
+		{ID: "r-2", UserID: userID, Day: 5, Month: 5, Year: 2025, Hours: 2, Type: "remote"},
+		{ID: "r-3", UserID: annaID, Day: 6, Month: 5, Year: 2025, Hours: 8, Type: "medical"},
+	} {
+		if _, err := svc.Create(ctx, prm); err != nil {
+			t.Fatalf("Create %s: %v", prm.ID, err)
+		}
+	}
+
+	sheet, err := svc.Timesheet(ctx, 5, 2025, 1)
+	if err != nil {
+		t.Fatalf("Timesheet: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := sheet.WriteXLSX(&buf); err != nil {
+		t.Fatalf("WriteXLSX: %v", err)
+	}
+
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("open workbook: %v", err)
+	}
+	defer f.Close()
+
+	// столбец C - 1 мая, G - 5 мая, H - 6 мая; AH-AK - итоги после 31 дня
+	for cell, want := range map[string]string{
+		"A1":  "Табель учета рабочего времени за 05.2025",
+		"B3":  "Сотрудник",
+		"C3":  "1",
+		"AH3": "Дней",
+		"A4":  "1",
+		"B4":  "Иванов Иван",
+		"G4":  "Я 8\nУР 2",
+		"H4":  "",
+		"AH4": "1",
+		"AI4": "8",
+		"AJ4": "160",
+		"AK4": "-152",
+		"B5":  annaID,
+		"H5":  "Б 8",
+		"AI5": "0",
+	} {
+		got, err := f.GetCellValue(timesheetSheet, cell, excelize.Options{RawCellValue: true})
+		if err != nil {
+			t.Fatalf("%s: %v", cell, err)
+		}
+		if got != want {
+			t.Errorf("%s = %q, want %q", cell, got, want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package schedule
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/validate"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NewHandler(service Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) List(c *fiber.Ctx) error {
+	schedules, err := h.service.List(c.UserContext())
+	if err != nil {
+		return apperr.Wrap(err, "failed to get schedules")
+	}
+
+	return c.JSON(schedules)
+}
+
+type createRequest struct {
+	Name       string                  `json:"name" validate:"required"`
+	Kind       repo.ReportScheduleKind `json:"kind" validate:"required,oneof=weekly shift"`
+	CycleStart *time.Time              `json:"cycleStart,omitempty"`
+	Hours      []float64               `json:"hours" validate:"required,min=1"`
+}
+
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req createRequest
+	if err := validate.Body(c, &req); err != nil {
+		return err
+	}
+
+	schedule, err := h.service.Create(c.UserContext(), CreateScheduleParams{
+		ID:         uuid.NewString(),
+		Name:       req.Name,
+		Kind:       req.Kind,
+		CycleStart: req.CycleStart,
+		Hours:      req.Hours,
+	})
+	if err != nil {
+		return apperr.Wrap(err, "failed to create schedule")
+	}
+
+	return c.Status(http.StatusCreated).JSON(schedule)
+}
+
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	scheduleID := c.Params("schedule")
+	if scheduleID == "" {
+		return apperr.Required("schedule")
+	}
+
+	if err := h.service.Delete(c.UserContext(), scheduleID); err != nil {
+		return apperr.Wrap(err, "failed to delete schedule")
+	}
+
+	c.Status(http.StatusOK)
+	return nil
+}
+
+func (h *Handler) UserSchedules(c *fiber.Ctx) error {
+	userID := c.Params("user")
+	if userID == "" {
+		return apperr.Required("user")
+	}
+
+	schedules, err := h.service.UserSchedules(c.UserContext(), userID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get user schedules")
+	}
+
+	return c.JSON(schedules)
+}
+
+type assignRequest struct {
+	UserID        string     `json:"userId" validate:"required,uuid"`
+	ScheduleID    string     `json:"scheduleId" validate:"required"`
+	EffectiveFrom time.Time  `json:"effectiveFrom" validate:"required"`
+	EffectiveTo   *time.Time `json:"effectiveTo,omitempty"`
+}
+
+func (h *Handler) Assign(c *fiber.Ctx) error {
+	var req assignRequest
+	if err := validate.Body(c, &req); err != nil {
+		return err
+	}
+
+	effectiveTo := sql.NullTime{}
+	if req.EffectiveTo != nil {
+		effectiveTo = sql.NullTime{Time: *req.EffectiveTo, Valid: true}
+	}
+
+	schedules, err := h.service.Assign(c.UserContext(), repo.CreateUserScheduleParams{
+		ID:            uuid.NewString(),
+		UserID:        req.UserID,
+		ScheduleID:    req.ScheduleID,
+		EffectiveFrom: req.EffectiveFrom,
+		EffectiveTo:   effectiveTo,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperr.NotFound("schedule_not_found", "schedule not found")
+		}
+		return apperr.Wrap(err, "failed to assign schedule")
+	}
+
+	return c.Status(http.StatusCreated).JSON(schedules)
+}
+
+func (h *Handler) Unassign(c *fiber.Ctx) error {
+	assignmentID := c.Params("assignment")
+	if assignmentID == "" {
+		return apperr.Required("assignment")
+	}
+
+	if err := h.service.Unassign(c.UserContext(), assignmentID); err != nil {
+		return apperr.Wrap(err, "failed to unassign schedule")
+	}
+
+	c.Status(http.StatusOK)
+	return nil
+}
+
+func (h *Handler) Expected(c *fiber.Ctx) error {
+	userID := c.Params("user")
+	if userID == "" {
+		return apperr.Required("user")
+	}
+
+	month, err := c.ParamsInt("month")
+	if err != nil || month < 1 || month > 12 {
+		return apperr.InvalidParam("month")
+	}
+
+	year, err := c.ParamsInt("year")
+	if err != nil || year < 1900 || year > 2100 {
+		return apperr.InvalidParam("year")
+	}
+
+	expected, err := h.service.ExpectedMonth(c.UserContext(), userID, int32(month), int32(year))
+	if err != nil {
+		return apperr.Wrap(err, "failed to get expected hours")
+	}
+
+	return c.JSON(expected)
+}
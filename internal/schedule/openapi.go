@@ -0,0 +1,25 @@
+package schedule
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты графиков работы для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/v1/schedule/list", Summary: "Графики работы",
+			Response: []scheduleRow{}},
+		{Method: http.MethodPost, Path: "/v1/schedule/create", Summary: "Создать график",
+			Request: createRequest{}, Status: http.StatusCreated, Response: scheduleRow{}},
+		{Method: http.MethodDelete, Path: "/v1/schedule/delete/:schedule", Summary: "Удалить график"},
+		{Method: http.MethodGet, Path: "/v1/schedule/user/:user", Summary: "Графики сотрудника по периодам",
+			Response: []repo.GetUserSchedulesRow{}},
+		{Method: http.MethodPost, Path: "/v1/schedule/assign", Summary: "Назначить график сотруднику",
+			Request: assignRequest{}, Status: http.StatusCreated, Response: []repo.GetUserSchedulesRow{}},
+		{Method: http.MethodDelete, Path: "/v1/schedule/unassign/:assignment", Summary: "Снять назначение графика"},
+		{Method: http.MethodGet, Path: "/v1/schedule/expected/:user/:month/:year", Summary: "Норма часов сотрудника по графику за месяц",
+			Response: monthExpectation{}},
+	}
+}
@@ -0,0 +1,419 @@
+package schedule
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/calendar"
+	"TimeTrack/internal/tracing"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrScheduleInUse   = apperr.Conflict("schedule_in_use", "schedule is assigned to users")
+	ErrScheduleOverlap = apperr.Conflict("schedule_overlap", "schedule assignment overlaps an existing one")
+	ErrInvalidSchedule = apperr.Invalid("invalid_schedule", "invalid schedule")
+)
+
+type Service interface {
+	List(ctx context.Context) (*[]scheduleRow, error)
+	Get(ctx context.Context, id string) (*scheduleRow, error)
+	Create(ctx context.Context, prm CreateScheduleParams) (*scheduleRow, error)
+	Delete(ctx context.Context, id string) error
+	UserSchedules(ctx context.Context, userID string) (*[]repo.GetUserSchedulesRow, error)
+	Assign(ctx context.Context, prm repo.CreateUserScheduleParams) (*[]repo.GetUserSchedulesRow, error)
+	Unassign(ctx context.Context, id string) error
+	ExpectedMonth(ctx context.Context, userID string, month, year int32) (*monthExpectation, error)
+}
+
+type service struct {
+	repo     repo.Querier
+	db       repo.TxBeginner
+	calendar calendar.Service
+}
+
+func NewService(repo repo.Querier, db repo.TxBeginner, calendar calendar.Service) Service {
+	return &service{repo: repo, db: db, calendar: calendar}
+}
+
+// scheduleRow - шаблон графика вместе с нормой часов по дням цикла.
+// Для недельного графика Hours[0] - понедельник, Hours[6] - воскресенье.
+type scheduleRow struct {
+	ID          string                  `json:"id"`
+	Name        string                  `json:"name"`
+	Kind        repo.ReportScheduleKind `json:"kind"`
+	CycleLength int32                   `json:"cycleLength"`
+	CycleStart  *time.Time              `json:"cycleStart"`
+	Hours       []float64               `json:"hours"`
+}
+
+type CreateScheduleParams struct {
+	ID         string                  `json:"id"`
+	Name       string                  `json:"name"`
+	Kind       repo.ReportScheduleKind `json:"kind"`
+	CycleStart *time.Time              `json:"cycleStart"`
+	Hours      []float64               `json:"hours"`
+}
+
+// ExpectedDay - норма часов сотрудника на день месяца
+type ExpectedDay struct {
+	Day       int32   `json:"day"`
+	Hours     float64 `json:"hours"`
+	IsWorking bool    `json:"isWorking"`
+	Schedule  string  `json:"scheduleId"`
+}
+
+type monthExpectation struct {
+	UserID      string        `json:"userId"`
+	Month       int32         `json:"month"`
+	Year        int32         `json:"year"`
+	TotalHours  float64       `json:"totalHours"`
+	WorkDays    int32         `json:"workDays"`
+	Days        []ExpectedDay `json:"days"`
+	HasSchedule bool          `json:"hasSchedule"`
+}
+
+func (s *service) List(ctx context.Context) (_ *[]scheduleRow, err error) {
+	ctx, end := tracing.Start(ctx, "schedule.List")
+	defer end(&err)
+
+	schedules, err := s.repo.GetSchedules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get schedules: %w", err)
+	}
+
+	rows := make([]scheduleRow, len(schedules))
+	for i, sch := range schedules {
+		row, err := s.buildScheduleRow(ctx, sch)
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = *row
+	}
+
+	return &rows, nil
+}
+
+func (s *service) Get(ctx context.Context, id string) (_ *scheduleRow, err error) {
+	ctx, end := tracing.Start(ctx, "schedule.Get")
+	defer end(&err)
+
+	sch, err := s.repo.GetScheduleById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get schedule: %w", err)
+	}
+
+	return s.buildScheduleRow(ctx, sch)
+}
+
+func (s *service) Create(ctx context.Context, prm CreateScheduleParams) (_ *scheduleRow, err error) {
+	ctx, end := tracing.Start(ctx, "schedule.Create")
+	defer end(&err)
+
+	cycleStart := sql.NullTime{}
+
+	switch prm.Kind {
+	case repo.ReportScheduleKindWeekly:
+		if len(prm.Hours) != 7 {
+			return nil, fmt.Errorf("%w: weekly schedule needs hours for 7 days", ErrInvalidSchedule)
+		}
+	case repo.ReportScheduleKindShift:
+		if len(prm.Hours) == 0 {
+			return nil, fmt.Errorf("%w: shift cycle is empty", ErrInvalidSchedule)
+		}
+		if prm.CycleStart == nil {
+			return nil, fmt.Errorf("%w: shift cycle needs a start date", ErrInvalidSchedule)
+		}
+		cycleStart = sql.NullTime{Time: *prm.CycleStart, Valid: true}
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidSchedule, prm.Kind)
+	}
+
+	for _, h := range prm.Hours {
+		if h < 0 || h > 24 {
+			return nil, fmt.Errorf("%w: hours must be between 0 and 24", ErrInvalidSchedule)
+		}
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := tx.CreateSchedule(ctx, repo.CreateScheduleParams{
+		ID:          prm.ID,
+		Name:        prm.Name,
+		Kind:        prm.Kind,
+		CycleLength: int32(len(prm.Hours)),
+		CycleStart:  cycleStart,
+	}); err != nil {
+		return nil, fmt.Errorf("create schedule: %w", err)
+	}
+
+	for i, h := range prm.Hours {
+		if err := tx.CreateScheduleDay(ctx, repo.CreateScheduleDayParams{
+			ID:         uuid.NewString(),
+			ScheduleID: prm.ID,
+			DayIndex:   int32(i),
+			Hours:      h,
+		}); err != nil {
+			return nil, fmt.Errorf("create schedule day: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return s.Get(ctx, prm.ID)
+}
+
+func (s *service) Delete(ctx context.Context, id string) (err error) {
+	ctx, end := tracing.Start(ctx, "schedule.Delete")
+	defer end(&err)
+
+	users, err := s.repo.CountUserSchedulesBySchedule(ctx, id)
+	if err != nil {
+		return fmt.Errorf("count schedule users: %w", err)
+	}
+	if users > 0 {
+		return ErrScheduleInUse
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := tx.DeleteScheduleDays(ctx, id); err != nil {
+		return fmt.Errorf("delete schedule days: %w", err)
+	}
+	if err := tx.DeleteSchedule(ctx, id); err != nil {
+		return fmt.Errorf("delete schedule: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (s *service) UserSchedules(ctx context.Context, userID string) (_ *[]repo.GetUserSchedulesRow, err error) {
+	ctx, end := tracing.Start(ctx, "schedule.UserSchedules", tracing.User(userID))
+	defer end(&err)
+
+	schedules, err := s.repo.GetUserSchedules(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user schedules: %w", err)
+	}
+
+	return &schedules, nil
+}
+
+func (s *service) Assign(ctx context.Context, prm repo.CreateUserScheduleParams) (_ *[]repo.GetUserSchedulesRow, err error) {
+	ctx, end := tracing.Start(ctx, "schedule.Assign", tracing.User(prm.UserID))
+	defer end(&err)
+
+	if prm.EffectiveTo.Valid && prm.EffectiveTo.Time.Before(prm.EffectiveFrom) {
+		return nil, fmt.Errorf("%w: effectiveTo is before effectiveFrom", ErrInvalidSchedule)
+	}
+
+	if _, err := s.repo.GetScheduleById(ctx, prm.ScheduleID); err != nil {
+		return nil, fmt.Errorf("get schedule: %w", err)
+	}
+
+	existing, err := s.repo.GetUserSchedules(ctx, prm.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("get user schedules: %w", err)
+	}
+
+	for _, e := range existing {
+		if periodsOverlap(e.EffectiveFrom, e.EffectiveTo, prm.EffectiveFrom, prm.EffectiveTo) {
+			return nil, ErrScheduleOverlap
+		}
+	}
+
+	if err := s.repo.CreateUserSchedule(ctx, prm); err != nil {
+		return nil, fmt.Errorf("create user schedule: %w", err)
+	}
+
+	return s.UserSchedules(ctx, prm.UserID)
+}
+
+func (s *service) Unassign(ctx context.Context, id string) (err error) {
+	ctx, end := tracing.Start(ctx, "schedule.Unassign")
+	defer end(&err)
+
+	if err := s.repo.DeleteUserSchedule(ctx, id); err != nil {
+		return fmt.Errorf("delete user schedule: %w", err)
+	}
+	return nil
+}
+
+// ExpectedMonth рассчитывает норму часов сотрудника по дням месяца.
+// Если на день не назначен график, используется производственный календарь (пятидневка).
+func (s *service) ExpectedMonth(ctx context.Context, userID string, month, year int32) (_ *monthExpectation, err error) {
+	ctx, end := tracing.Start(ctx, "schedule.ExpectedMonth", tracing.User(userID), tracing.Month(month), tracing.Year(year))
+	defer end(&err)
+
+	calendarDays, err := s.calendar.MonthDays(ctx, month, year)
+	if err != nil {
+		return nil, fmt.Errorf("get calendar month: %w", err)
+	}
+
+	assignments, err := s.repo.GetUserSchedules(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user schedules: %w", err)
+	}
+
+	schedules := make(map[string]*scheduleRow)
+
+	result := monthExpectation{
+		UserID: userID,
+		Month:  month,
+		Year:   year,
+		Days:   make([]ExpectedDay, len(*calendarDays)),
+	}
+
+	for i, calendarDay := range *calendarDays {
+		date := time.Date(int(year), time.Month(month), i+1, 0, 0, 0, 0, time.UTC)
+
+		day := ExpectedDay{
+			Day:   int32(i + 1),
+			Hours: calendarDay.ExpectedHours,
+		}
+
+		if assignment := findAssignment(assignments, date); assignment != nil {
+			sch, ok := schedules[assignment.ScheduleID]
+			if !ok {
+				sch, err = s.Get(ctx, assignment.ScheduleID)
+				if err != nil {
+					return nil, err
+				}
+				schedules[assignment.ScheduleID] = sch
+			}
+
+			day.Hours = sch.hoursFor(date, calendarDay.Kind, calendarDay.IsOverride)
+			day.Schedule = sch.ID
+			result.HasSchedule = true
+		}
+
+		day.IsWorking = day.Hours > 0
+		if day.IsWorking {
+			result.WorkDays++
+			result.TotalHours += day.Hours
+		}
+
+		result.Days[i] = day
+	}
+
+	return &result, nil
+}
+
+// hoursFor возвращает норму часов графика на дату.
+// Недельный график учитывает праздники, переносы и сокращённые дни из календаря,
+// сменный цикл идёт непрерывно и от календаря не зависит.
+func (sch *scheduleRow) hoursFor(date time.Time, kind calendar.DayKind, isOverride bool) float64 {
+	if len(sch.Hours) == 0 {
+		return 0
+	}
+
+	if sch.Kind == repo.ReportScheduleKindShift {
+		if sch.CycleStart == nil {
+			return 0
+		}
+
+		start := *sch.CycleStart
+		start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+
+		offset := int(date.Sub(start).Hours() / 24)
+		index := offset % len(sch.Hours)
+		if index < 0 {
+			index += len(sch.Hours)
+		}
+
+		return sch.Hours[index]
+	}
+
+	// time.Weekday начинается с воскресенья, а график - с понедельника
+	hours := sch.Hours[(int(date.Weekday())+6)%7%len(sch.Hours)]
+
+	switch kind {
+	case calendar.DayKindHoliday:
+		return 0
+	case calendar.DayKindWeekend:
+		if isOverride {
+			return 0
+		}
+	case calendar.DayKindShortened:
+		// Полный день сокращается на час, неполный - пропорционально своей доле от полного
+		share := min(hours/calendar.StandardDayHours, 1)
+		hours -= (calendar.StandardDayHours - calendar.ShortenedDayHours) * share
+	case calendar.DayKindWork:
+		// Рабочий день, перенесённый на выходной: берём обычную продолжительность дня по графику
+		if isOverride && hours == 0 {
+			for _, h := range sch.Hours {
+				hours = max(hours, h)
+			}
+		}
+	}
+
+	return max(hours, 0)
+}
+
+func findAssignment(assignments []repo.GetUserSchedulesRow, date time.Time) *repo.GetUserSchedulesRow {
+	for i := range assignments {
+		a := &assignments[i]
+		if periodsOverlap(a.EffectiveFrom, a.EffectiveTo, date, sql.NullTime{Time: date, Valid: true}) {
+			return a
+		}
+	}
+	return nil
+}
+
+// periodsOverlap проверяет пересечение периодов; незаданный конец означает бессрочный период
+func periodsOverlap(fromA time.Time, toA sql.NullTime, fromB time.Time, toB sql.NullTime) bool {
+	if toB.Valid && dayBefore(toB.Time, fromA) {
+		return false
+	}
+	if toA.Valid && dayBefore(toA.Time, fromB) {
+		return false
+	}
+	return true
+}
+
+func dayBefore(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC).Before(time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC))
+}
+
+func (s *service) buildScheduleRow(ctx context.Context, sch repo.ReportSchedule) (*scheduleRow, error) {
+	days, err := s.repo.GetScheduleDays(ctx, sch.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get schedule days: %w", err)
+	}
+
+	row := scheduleRow{
+		ID:          sch.ID,
+		Name:        sch.Name,
+		Kind:        sch.Kind,
+		CycleLength: sch.CycleLength,
+		Hours:       make([]float64, sch.CycleLength),
+	}
+
+	if sch.CycleStart.Valid {
+		row.CycleStart = &sch.CycleStart.Time
+	}
+
+	for _, d := range days {
+		if d.DayIndex >= 0 && d.DayIndex < sch.CycleLength {
+			row.Hours[d.DayIndex] = d.Hours
+		}
+	}
+
+	return &row, nil
+}
@@ -0,0 +1,179 @@
+package schedule
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/calendar"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+)
+
+// nop - публикация событий календаря, которая тестам не нужна
+type nop struct{}
+
+func (nop) Publish(ctx context.Context, event string, data any) {}
+
+const userID = "0b9c7f5e-4a3d-4c1e-9f7a-2d8e6b1c5a40"
+
+// newTestService - сервис графиков над хранилищем в памяти; в календаре мая 2025 года
+// праздники 1-2 мая и сокращенный четверг 8 мая
+func newTestService(t *testing.T) Service {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.New()
+
+	for _, rt := range []repo.CreateTypeParams{
+		{ID: "t-holiday", Name: "Праздник", SystemName: "holiday", Code: "В"},
+		{ID: "t-shortened", Name: "Сокращенный", SystemName: "shortened", Code: "Я"},
+	} {
+		if err := store.CreateType(ctx, rt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, day := range []repo.CreateCalendarDayParams{
+		{ID: "c-0501", Day: 1, Month: 5, Year: 2025, TypeID: "t-holiday"},
+		{ID: "c-0502", Day: 2, Month: 5, Year: 2025, TypeID: "t-holiday"},
+		{ID: "c-0508", Day: 8, Month: 5, Year: 2025, TypeID: "t-shortened"},
+	} {
+		if err := store.CreateCalendarDay(ctx, day); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return NewService(store, store, calendar.NewService(store, store, nop{}))
+}
+
+func date(day int) time.Time {
+	return time.Date(2025, 5, day, 0, 0, 0, 0, time.UTC)
+}
+
+// assign создает график и назначает его userID на период from - to (to == 0 - бессрочно)
+func assign(t *testing.T, svc Service, prm CreateScheduleParams, from, to int) {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := svc.Create(ctx, prm); err != nil {
+		t.Fatalf("Create %s: %v", prm.ID, err)
+	}
+	assignment := repo.CreateUserScheduleParams{ID: "a-" + prm.ID, UserID: userID, ScheduleID: prm.ID, EffectiveFrom: date(from)}
+	if to > 0 {
+		assignment.EffectiveTo = sql.NullTime{Time: date(to), Valid: true}
+	}
+	if _, err := svc.Assign(ctx, assignment); err != nil {
+		t.Fatalf("Assign %s: %v", prm.ID, err)
+	}
+}
+
+// checkDays сверяет норму дней месяца с want (день - часы)
+func checkDays(t *testing.T, got *monthExpectation, want map[int32]float64) {
+	t.Helper()
+	for _, day := range got.Days {
+		hours, ok := want[day.Day]
+		if !ok {
+			continue
+		}
+		if day.Hours != hours || day.IsWorking != (hours > 0) {
+			t.Errorf("day %d = %+v, want %g hours", day.Day, day, hours)
+		}
+	}
+}
+
+func TestExpectedMonth(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name     string
+		schedule CreateScheduleParams
+		// часы по дням: 1-2 мая праздники, 3-4 выходные, 8 мая (четверг) сокращенный
+		days  map[int32]float64
+		total float64
+	}{
+		{
+			name:     "five-day week",
+			schedule: CreateScheduleParams{Kind: repo.ReportScheduleKindWeekly, Hours: []float64{8, 8, 8, 8, 8, 0, 0}},
+			days:     map[int32]float64{1: 0, 3: 0, 5: 8, 8: 7, 9: 8},
+			// 20 рабочих дней, один из них сокращенный
+			total: 159,
+		},
+		{
+			name:     "part-time",
+			schedule: CreateScheduleParams{Kind: repo.ReportScheduleKindWeekly, Hours: []float64{4, 4, 4, 4, 4, 0, 0}},
+			days:     map[int32]float64{1: 0, 5: 4, 8: 3.5, 9: 4},
+			total:    79.5,
+		},
+		{
+			name:     "four-day week",
+			schedule: CreateScheduleParams{Kind: repo.ReportScheduleKindWeekly, Hours: []float64{10, 10, 10, 10, 0, 0, 0}},
+			// 16 дней Пн-Чт; день длиннее полного сокращается на тот же час
+			days:  map[int32]float64{5: 10, 8: 9, 9: 0},
+			total: 159,
+		},
+		{
+			name:     "shift cycle",
+			schedule: CreateScheduleParams{Kind: repo.ReportScheduleKindShift, CycleStart: ptr(date(1)), Hours: []float64{12, 12, 0, 0}},
+			// цикл идет без оглядки на праздники и сокращенные дни
+			days:  map[int32]float64{1: 12, 2: 12, 3: 0, 4: 0, 5: 12, 8: 0, 9: 12, 31: 0},
+			total: 16 * 12,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(t)
+			tt.schedule.ID, tt.schedule.Name = "s-1", tt.name
+			assign(t, svc, tt.schedule, 1, 0)
+
+			got, err := svc.ExpectedMonth(ctx, userID, 5, 2025)
+			if err != nil {
+				t.Fatalf("ExpectedMonth: %v", err)
+			}
+			if !got.HasSchedule || got.TotalHours != tt.total {
+				t.Errorf("total = %g, has schedule %t, want %g", got.TotalHours, got.HasSchedule, tt.total)
+			}
+			checkDays(t, got, tt.days)
+		})
+	}
+}
+
+// TestExpectedMonthAssignments - график меняется с даты назначения, а дни без
+// назначения считаются по производственному календарю
+func TestExpectedMonthAssignments(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+
+	assign(t, svc, CreateScheduleParams{ID: "s-five", Name: "Пятидневка", Kind: repo.ReportScheduleKindWeekly, Hours: []float64{8, 8, 8, 8, 8, 0, 0}}, 5, 15)
+	assign(t, svc, CreateScheduleParams{ID: "s-four", Name: "Четырехдневка", Kind: repo.ReportScheduleKindWeekly, Hours: []float64{10, 10, 10, 10, 0, 0, 0}}, 16, 0)
+
+	got, err := svc.ExpectedMonth(ctx, userID, 5, 2025)
+	if err != nil {
+		t.Fatalf("ExpectedMonth: %v", err)
+	}
+	checkDays(t, got, map[int32]float64{2: 0, 8: 7, 15: 8, 16: 0, 19: 10, 22: 10, 23: 0})
+	for _, day := range got.Days {
+		want := ""
+		switch {
+		case day.Day >= 5 && day.Day <= 15:
+			want = "s-five"
+		case day.Day >= 16:
+			want = "s-four"
+		}
+		if day.Schedule != want {
+			t.Errorf("day %d schedule = %q, want %q", day.Day, day.Schedule, want)
+		}
+	}
+	// 5-15 мая: 9 дней по 8 часов, 8 мая - 7; 16-31 мая: 8 дней Пн-Чт по 10
+	if got.TotalHours != 71+80 {
+		t.Errorf("total = %g, want %g", got.TotalHours, 71.0+80)
+	}
+
+	other, err := svc.ExpectedMonth(ctx, "5d2e8a1f-7c3b-4e9d-8a6f-1b4c7e2d9f30", 5, 2025)
+	if err != nil {
+		t.Fatalf("ExpectedMonth: %v", err)
+	}
+	if other.HasSchedule || other.TotalHours != 159 {
+		t.Errorf("without a schedule: total = %g, has schedule %t, want the calendar norm", other.TotalHours, other.HasSchedule)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
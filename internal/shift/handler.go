@@ -0,0 +1,200 @@
+package shift
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/validate"
+	"log/slog"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NewHandler(service Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) List(c *fiber.Ctx) error {
+	userID := c.Params("user")
+	if userID == "" {
+		return apperr.Required("user")
+	}
+
+	month, err := c.ParamsInt("month")
+	if err != nil || month < 1 || month > 12 {
+		return apperr.InvalidParam("month")
+	}
+
+	year, err := c.ParamsInt("year")
+	if err != nil || year < 1900 || year > 2100 {
+		return apperr.InvalidParam("year")
+	}
+
+	shifts, err := h.service.List(c.UserContext(), userID, int32(month), int32(year))
+	if err != nil {
+		return apperr.Wrap(err, "failed to get shifts")
+	}
+
+	return c.JSON(shifts)
+}
+
+func (h *Handler) ListAll(c *fiber.Ctx) error {
+	month, err := c.ParamsInt("month")
+	if err != nil || month < 1 || month > 12 {
+		return apperr.InvalidParam("month")
+	}
+
+	year, err := c.ParamsInt("year")
+	if err != nil || year < 1900 || year > 2100 {
+		return apperr.InvalidParam("year")
+	}
+
+	shifts, err := h.service.ListAll(c.UserContext(), int32(month), int32(year))
+	if err != nil {
+		return apperr.Wrap(err, "failed to get shifts")
+	}
+
+	return c.JSON(shifts)
+}
+
+type planRequest struct {
+	UserID string `json:"userId" validate:"required,uuid"`
+	Day    int32  `json:"day" validate:"required,min=1,max=31,date=Month Year"`
+	Month  int32  `json:"month" validate:"required,min=1,max=12"`
+	Year   int32  `json:"year" validate:"required,min=1900,max=2100"`
+	Start  string `json:"start" validate:"required"`
+	End    string `json:"end" validate:"required"`
+}
+
+func (h *Handler) Plan(c *fiber.Ctx) error {
+	var req planRequest
+	if err := validate.Body(c, &req); err != nil {
+		return err
+	}
+
+	start, err := ParseClock(req.Start)
+	if err != nil {
+		return apperr.InvalidField("start", "must be a time in HH:MM format")
+	}
+
+	end, err := ParseClock(req.End)
+	if err != nil {
+		return apperr.InvalidField("end", "must be a time in HH:MM format")
+	}
+
+	shift, err := h.service.Plan(c.UserContext(), repo.CreateShiftParams{
+		ID:          uuid.NewString(),
+		UserID:      req.UserID,
+		Day:         req.Day,
+		Month:       req.Month,
+		Year:        req.Year,
+		StartMinute: start,
+		EndMinute:   end,
+	})
+	if err != nil {
+		return apperr.Wrap(err, "failed to plan shift")
+	}
+
+	return c.Status(http.StatusCreated).JSON(shift)
+}
+
+type updateRequest struct {
+	ID    string `json:"id"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+func (h *Handler) Update(c *fiber.Ctx) error {
+	var req updateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperr.InvalidBody(err)
+	}
+
+	if _, err := uuid.Parse(req.ID); err != nil {
+		return apperr.InvalidField("id", "must be a valid UUID")
+	}
+
+	start, err := ParseClock(req.Start)
+	if err != nil {
+		return apperr.InvalidField("start", "must be a time in HH:MM format")
+	}
+
+	end, err := ParseClock(req.End)
+	if err != nil {
+		return apperr.InvalidField("end", "must be a time in HH:MM format")
+	}
+
+	shift, err := h.service.Update(c.UserContext(), repo.UpdateShiftParams{
+		StartMinute: start,
+		EndMinute:   end,
+		ID:          req.ID,
+	})
+	if err != nil {
+		return apperr.Wrap(err, "failed to update shift")
+	}
+
+	return c.JSON(shift)
+}
+
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	shiftID := c.Params("shift")
+	if shiftID == "" {
+		return apperr.Required("shift")
+	}
+
+	if err := h.service.Delete(c.UserContext(), shiftID); err != nil {
+		return apperr.Wrap(err, "failed to delete shift")
+	}
+
+	c.Status(http.StatusOK)
+	return nil
+}
+
+type nightWindowBody struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+func (h *Handler) GetNightWindow(c *fiber.Ctx) error {
+	window, err := h.service.NightWindow(c.UserContext())
+	if err != nil {
+		return apperr.Wrap(err, "failed to get night window")
+	}
+
+	return c.JSON(nightWindowBody{
+		Start: FormatClock(window.Start),
+		End:   FormatClock(window.End),
+	})
+}
+
+func (h *Handler) SetNightWindow(c *fiber.Ctx) error {
+	var req nightWindowBody
+	if err := c.BodyParser(&req); err != nil {
+		return apperr.InvalidBody(err)
+	}
+
+	start, err := ParseClock(req.Start)
+	if err != nil {
+		return apperr.InvalidField("start", "must be a time in HH:MM format")
+	}
+
+	end, err := ParseClock(req.End)
+	if err != nil {
+		return apperr.InvalidField("end", "must be a time in HH:MM format")
+	}
+
+	if err := h.service.SetNightWindow(c.UserContext(), NightWindow{Start: start, End: end}); err != nil {
+		return apperr.Wrap(err, "failed to update night window")
+	}
+
+	return c.JSON(req)
+}
@@ -0,0 +1,94 @@
+package shift
+
+import (
+	"TimeTrack/internal/apperr"
+	"fmt"
+)
+
+const minutesPerDay = 24 * 60
+
+var (
+	ErrInvalidClock = apperr.Invalid("invalid_clock", "invalid time, expected HH:MM")
+	// ErrEmptyNightWindow - начало и конец ночного окна совпадают: непонятно, ночь это
+	// на все сутки или ее нет, поэтому такое окно не сохраняется
+	ErrEmptyNightWindow = apperr.Invalid("empty_night_window", "night window start and end must differ",
+		apperr.Field{Field: "end", Message: "must differ from start"})
+)
+
+// NightWindow - ночной интервал в минутах от начала суток.
+// Если Start > End, интервал переходит через полночь (например, 22:00–06:00).
+type NightWindow struct {
+	Start int32 `json:"start"`
+	End   int32 `json:"end"`
+}
+
+// ParseClock переводит "HH:MM" в минуты от начала суток. Принимается только строгая
+// запись из двух цифр часов и двух цифр минут в пределах 00:00-23:59.
+func ParseClock(value string) (int32, error) {
+	if len(value) != 5 || value[2] != ':' {
+		return 0, ErrInvalidClock
+	}
+	hours, ok := twoDigits(value[:2])
+	if !ok || hours > 23 {
+		return 0, ErrInvalidClock
+	}
+	minutes, ok := twoDigits(value[3:])
+	if !ok || minutes > 59 {
+		return 0, ErrInvalidClock
+	}
+	return hours*60 + minutes, nil
+}
+
+// twoDigits - число из двух десятичных цифр
+func twoDigits(s string) (int32, bool) {
+	if s[0] < '0' || s[0] > '9' || s[1] < '0' || s[1] > '9' {
+		return 0, false
+	}
+	return int32(s[0]-'0')*10 + int32(s[1]-'0'), true
+}
+
+// FormatClock переводит минуты от начала суток в "HH:MM"
+func FormatClock(minute int32) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// Duration возвращает длительность интервала в минутах.
+// Конец раньше начала (или равный ему) означает переход через полночь.
+func Duration(start, end int32) int32 {
+	if end <= start {
+		end += minutesPerDay
+	}
+	return end - start
+}
+
+// NightMinutes считает, сколько минут интервала [start, end) попадает в ночное окно
+func (w NightWindow) NightMinutes(start, end int32) int32 {
+	if end <= start {
+		end += minutesPerDay
+	}
+
+	nightStart, nightEnd := w.Start, w.End
+	if nightEnd <= nightStart {
+		nightEnd += minutesPerDay
+	}
+
+	var total int32
+
+	// Интервал может задеть ночь предыдущих, текущих и следующих суток
+	for _, offset := range []int32{-minutesPerDay, 0, minutesPerDay} {
+		from := max(start, nightStart+offset)
+		to := min(end, nightEnd+offset)
+		if to > from {
+			total += to - from
+		}
+	}
+
+	return total
+}
+
+// NightHours возвращает ночные часы интервала смены.
+// Если фактически отработано меньше (перерывы), ночные часы не превышают отработанные.
+func (w NightWindow) NightHours(start, end int32, hours float64) float64 {
+	night := float64(w.NightMinutes(start, end)) / 60
+	return min(night, hours)
+}
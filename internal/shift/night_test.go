@@ -0,0 +1,18 @@
+package shift
+
+import "testing"
+
+func TestParseClock(t *testing.T) {
+	for value, want := range map[string]int32{"00:00": 0, "09:05": 545, "23:59": 1439} {
+		got, err := ParseClock(value)
+		if err != nil || got != want {
+			t.Errorf("ParseClock(%q) = %d, %v, want %d", value, got, err, want)
+		}
+	}
+
+	for _, value := range []string{"", "9:00", "09:5", "24:00", "12:60", "-1:30", "12:30x", "12-30", " 12:30", "+1:30", "1a:00"} {
+		if _, err := ParseClock(value); err != ErrInvalidClock {
+			t.Errorf("ParseClock(%q): err = %v, want ErrInvalidClock", value, err)
+		}
+	}
+}
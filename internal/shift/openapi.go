@@ -0,0 +1,25 @@
+package shift
+
+import (
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты смен для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/v1/shift/list/:month/:year", Summary: "Смены всех сотрудников за месяц",
+			Response: []shiftRow{}},
+		{Method: http.MethodGet, Path: "/v1/shift/list/:user/:month/:year", Summary: "Смены сотрудника за месяц",
+			Response: []shiftRow{}},
+		{Method: http.MethodPost, Path: "/v1/shift/create", Summary: "Запланировать смену",
+			Request: planRequest{}, Status: http.StatusCreated, Response: shiftRow{}},
+		{Method: http.MethodPost, Path: "/v1/shift/update", Summary: "Изменить смену",
+			Request: updateRequest{}, Response: shiftRow{}},
+		{Method: http.MethodDelete, Path: "/v1/shift/delete/:shift", Summary: "Удалить смену"},
+		{Method: http.MethodGet, Path: "/v1/shift/night-window", Summary: "Границы ночного времени",
+			Response: nightWindowBody{}},
+		{Method: http.MethodPost, Admin: true, Path: "/v1/shift/night-window", Summary: "Задать границы ночного времени",
+			Request: nightWindowBody{}, Response: nightWindowBody{}},
+	}
+}
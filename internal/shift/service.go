@@ -0,0 +1,274 @@
+package shift
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/closing"
+	"TimeTrack/internal/tracing"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrShiftExists   = apperr.Conflict("shift_exists", "shift already planned for this day")
+	ErrShiftNotFound = apperr.NotFound("shift_not_found", "shift not found")
+)
+
+type Service interface {
+	List(ctx context.Context, userID string, month, year int32) (*[]shiftRow, error)
+	ListAll(ctx context.Context, month, year int32) (*[]shiftRow, error)
+	Plan(ctx context.Context, prm repo.CreateShiftParams) (*shiftRow, error)
+	Update(ctx context.Context, prm repo.UpdateShiftParams) (*shiftRow, error)
+	Delete(ctx context.Context, id string) error
+	PlannedFor(ctx context.Context, userID string, day, month, year int32) (*repo.ReportShift, error)
+	NightWindow(ctx context.Context) (*NightWindow, error)
+	SetNightWindow(ctx context.Context, window NightWindow) error
+}
+
+type service struct {
+	repo repo.Querier
+	db   repo.TxBeginner
+}
+
+func NewService(repo repo.Querier, db repo.TxBeginner) Service {
+	return &service{repo: repo, db: db}
+}
+
+type shiftRow struct {
+	ID         string  `json:"id"`
+	UserID     string  `json:"userId"`
+	Day        int32   `json:"day"`
+	Month      int32   `json:"month"`
+	Year       int32   `json:"year"`
+	Start      string  `json:"start"`
+	End        string  `json:"end"`
+	Hours      float64 `json:"hours"`
+	NightHours float64 `json:"nightHours"`
+}
+
+func (s *service) List(ctx context.Context, userID string, month, year int32) (_ *[]shiftRow, err error) {
+	ctx, end := tracing.Start(ctx, "shift.List", tracing.User(userID), tracing.Month(month), tracing.Year(year))
+	defer end(&err)
+
+	shifts, err := s.repo.GetShiftsForMonth(ctx, repo.GetShiftsForMonthParams{
+		UserID: userID,
+		Month:  month,
+		Year:   year,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get shifts: %w", err)
+	}
+
+	return s.buildShiftRows(ctx, shifts)
+}
+
+func (s *service) ListAll(ctx context.Context, month, year int32) (_ *[]shiftRow, err error) {
+	ctx, end := tracing.Start(ctx, "shift.ListAll", tracing.Month(month), tracing.Year(year))
+	defer end(&err)
+
+	shifts, err := s.repo.GetShiftsForMonthAll(ctx, repo.GetShiftsForMonthAllParams{
+		Month: month,
+		Year:  year,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get shifts: %w", err)
+	}
+
+	return s.buildShiftRows(ctx, shifts)
+}
+
+// Plan, Update и Delete меняют смены только в открытых месяцах: по сменам
+// считаются ночные часы отметок, которые входят в выгрузку для расчета зарплаты
+func (s *service) Plan(ctx context.Context, prm repo.CreateShiftParams) (_ *shiftRow, err error) {
+	ctx, end := tracing.Start(ctx, "shift.Plan", tracing.User(prm.UserID), tracing.Day(prm.Day), tracing.Month(prm.Month), tracing.Year(prm.Year))
+	defer end(&err)
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := closing.CheckMonthOpen(ctx, tx, prm.Month, prm.Year); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.GetShift(ctx, repo.GetShiftParams{
+		UserID: prm.UserID,
+		Day:    prm.Day,
+		Month:  prm.Month,
+		Year:   prm.Year,
+	})
+	if err == nil {
+		return nil, ErrShiftExists
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("get shift: %w", err)
+	}
+
+	if err := tx.CreateShift(ctx, prm); err != nil {
+		return nil, fmt.Errorf("create shift: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return s.buildShiftResponse(ctx, prm.ID)
+}
+
+func (s *service) Update(ctx context.Context, prm repo.UpdateShiftParams) (_ *shiftRow, err error) {
+	ctx, end := tracing.Start(ctx, "shift.Update")
+	defer end(&err)
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := checkOpen(ctx, tx, prm.ID); err != nil {
+		return nil, err
+	}
+	if err := tx.UpdateShift(ctx, prm); err != nil {
+		return nil, fmt.Errorf("update shift: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return s.buildShiftResponse(ctx, prm.ID)
+}
+
+func (s *service) Delete(ctx context.Context, id string) (err error) {
+	ctx, end := tracing.Start(ctx, "shift.Delete")
+	defer end(&err)
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// удаление отсутствующей смены ничего не делает
+	err = checkOpen(ctx, tx, id)
+	if errors.Is(err, ErrShiftNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := tx.DeleteShift(ctx, id); err != nil {
+		return fmt.Errorf("delete shift: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// checkOpen возвращает closing.ErrMonthClosed, если смена id в закрытом месяце
+func checkOpen(ctx context.Context, q repo.Querier, id string) error {
+	shift, err := q.GetShiftById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrShiftNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("get shift: %w", err)
+	}
+	return closing.CheckMonthOpen(ctx, q, shift.Month, shift.Year)
+}
+
+// PlannedFor возвращает запланированную смену на день или nil, если смены нет
+func (s *service) PlannedFor(ctx context.Context, userID string, day, month, year int32) (_ *repo.ReportShift, err error) {
+	ctx, end := tracing.Start(ctx, "shift.PlannedFor", tracing.User(userID), tracing.Day(day), tracing.Month(month), tracing.Year(year))
+	defer end(&err)
+
+	shift, err := s.repo.GetShift(ctx, repo.GetShiftParams{
+		UserID: userID,
+		Day:    day,
+		Month:  month,
+		Year:   year,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get shift: %w", err)
+	}
+
+	return &shift, nil
+}
+
+func (s *service) NightWindow(ctx context.Context) (_ *NightWindow, err error) {
+	ctx, end := tracing.Start(ctx, "shift.NightWindow")
+	defer end(&err)
+
+	window, err := s.repo.GetSettingNightWindow(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get night window: %w", err)
+	}
+
+	return &NightWindow{Start: window.NightStartMinute, End: window.NightEndMinute}, nil
+}
+
+func (s *service) SetNightWindow(ctx context.Context, window NightWindow) (err error) {
+	ctx, end := tracing.Start(ctx, "shift.SetNightWindow")
+	defer end(&err)
+
+	if window.Start == window.End {
+		return ErrEmptyNightWindow
+	}
+
+	if err := s.repo.UpdateSettingNightWindow(ctx, repo.UpdateSettingNightWindowParams{
+		NightStartMinute: window.Start,
+		NightEndMinute:   window.End,
+	}); err != nil {
+		return fmt.Errorf("update night window: %w", err)
+	}
+	return nil
+}
+
+func (s *service) buildShiftResponse(ctx context.Context, id string) (*shiftRow, error) {
+	shift, err := s.repo.GetShiftById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrShiftNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get shift: %w", err)
+	}
+
+	rows, err := s.buildShiftRows(ctx, []repo.ReportShift{shift})
+	if err != nil {
+		return nil, err
+	}
+
+	return &(*rows)[0], nil
+}
+
+func (s *service) buildShiftRows(ctx context.Context, shifts []repo.ReportShift) (*[]shiftRow, error) {
+	window, err := s.NightWindow(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]shiftRow, len(shifts))
+	for i, sh := range shifts {
+		hours := float64(Duration(sh.StartMinute, sh.EndMinute)) / 60
+
+		rows[i] = shiftRow{
+			ID:         sh.ID,
+			UserID:     sh.UserID,
+			Day:        sh.Day,
+			Month:      sh.Month,
+			Year:       sh.Year,
+			Start:      FormatClock(sh.StartMinute),
+			End:        FormatClock(sh.EndMinute),
+			Hours:      hours,
+			NightHours: window.NightHours(sh.StartMinute, sh.EndMinute, hours),
+		}
+	}
+
+	return &rows, nil
+}
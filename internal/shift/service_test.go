@@ -0,0 +1,77 @@
+package shift
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// noWindow - хранилище без строки настроек ночного окна
+type noWindow struct {
+	*memory.Store
+}
+
+func (noWindow) GetSettingNightWindow(ctx context.Context) (repo.GetSettingNightWindowRow, error) {
+	return repo.GetSettingNightWindowRow{}, sql.ErrNoRows
+}
+
+const userID = "0b9c7f5e-4a3d-4c1e-9f7a-2d8e6b1c5a40"
+
+// TestNotFound - отсутствующая смена - ErrShiftNotFound (404), а отсутствующая
+// настройка ночного окна - сбой сервера, а не "смена не найдена"
+func TestNotFound(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	svc := NewService(store, store)
+
+	_, err := svc.Update(ctx, repo.UpdateShiftParams{ID: "missing", StartMinute: 540, EndMinute: 1080})
+	if !errors.Is(err, ErrShiftNotFound) || apperr.Status(apperr.Wrap(err, "failed to update shift")) != http.StatusNotFound {
+		t.Fatalf("missing shift: err = %v, want ErrShiftNotFound", err)
+	}
+	if err := svc.Delete(ctx, "missing"); err != nil {
+		t.Fatalf("delete missing shift: %v", err)
+	}
+
+	if _, err := svc.Plan(ctx, repo.CreateShiftParams{ID: "sh-1", UserID: userID, Day: 12, Month: 5, Year: 2025, StartMinute: 540, EndMinute: 1080}); err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	svc = NewService(noWindow{store}, store)
+	_, err = svc.Update(ctx, repo.UpdateShiftParams{ID: "sh-1", StartMinute: 600, EndMinute: 1080})
+	if err == nil || errors.Is(err, ErrShiftNotFound) || apperr.Status(apperr.Wrap(err, "failed to update shift")) != http.StatusInternalServerError {
+		t.Fatalf("missing night window: err = %v, want an internal error", err)
+	}
+}
+
+// TestSetNightWindow - окно с совпадающими началом и концом отклоняется ошибкой поля end,
+// и сохраненное окно не меняется
+func TestSetNightWindow(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	svc := NewService(store, store)
+
+	if err := svc.SetNightWindow(ctx, NightWindow{Start: 22 * 60, End: 6 * 60}); err != nil {
+		t.Fatalf("SetNightWindow: %v", err)
+	}
+
+	err := svc.SetNightWindow(ctx, NightWindow{Start: 22 * 60, End: 22 * 60})
+	var appErr *apperr.Error
+	if !errors.Is(err, ErrEmptyNightWindow) || !errors.As(err, &appErr) || len(appErr.Fields) != 1 || appErr.Fields[0].Field != "end" {
+		t.Fatalf("start == end: err = %v, want ErrEmptyNightWindow for end", err)
+	}
+	if status := apperr.Status(apperr.Wrap(err, "failed to update night window")); status != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", status)
+	}
+
+	window, err := svc.NightWindow(ctx)
+	if err != nil {
+		t.Fatalf("NightWindow: %v", err)
+	}
+	if window.Start != 22*60 || window.End != 6*60 {
+		t.Fatalf("window = %+v, want 22:00-06:00", window)
+	}
+}
@@ -0,0 +1,19 @@
+package standard
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты норм часов для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodPost, Admin: true, Path: "/v1/standard/create", Summary: "Задать норму часов на месяц",
+			Request: createRequest{}, Status: http.StatusCreated, Response: repo.ReportStandard{}},
+		{Method: http.MethodPost, Admin: true, Path: "/v1/standard/update", Summary: "Изменить норму часов",
+			Request: updateRequest{}},
+		{Method: http.MethodGet, Path: "/v1/standard/listforsetting/:year", Summary: "Нормы часов за год",
+			Response: []repo.ReportStandard{}, Export: true},
+	}
+}
@@ -2,8 +2,8 @@ package standard
 
 import (
 	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/tracing"
 	"context"
-	"database/sql"
 )
 
 type Service interface {
@@ -14,14 +14,17 @@ type Service interface {
 
 type service struct {
 	repo repo.Querier
-	db   *sql.DB
+	db   repo.TxBeginner
 }
 
-func NewService(repo repo.Querier, db *sql.DB) Service {
+func NewService(repo repo.Querier, db repo.TxBeginner) Service {
 	return &service{repo: repo, db: db}
 }
 
-func (s *service) ListForSetting(ctx context.Context, year int32) (*[]repo.ReportStandard, error) {
+func (s *service) ListForSetting(ctx context.Context, year int32) (_ *[]repo.ReportStandard, err error) {
+	ctx, end := tracing.Start(ctx, "standard.ListForSetting", tracing.Year(year))
+	defer end(&err)
+
 	standards, err := s.repo.GetStandardByYear(ctx, year)
 	if err != nil {
 		return nil, err
@@ -30,8 +33,11 @@ func (s *service) ListForSetting(ctx context.Context, year int32) (*[]repo.Repor
 	return &standards, nil
 }
 
-func (s *service) Create(ctx context.Context, prm repo.CreateStandardParams) (*repo.ReportStandard, error) {
-	err := s.repo.CreateStandard(ctx, prm)
+func (s *service) Create(ctx context.Context, prm repo.CreateStandardParams) (_ *repo.ReportStandard, err error) {
+	ctx, end := tracing.Start(ctx, "standard.Create", tracing.Month(prm.Month), tracing.Year(prm.Year))
+	defer end(&err)
+
+	err = s.repo.CreateStandard(ctx, prm)
 	if err != nil {
 		return nil, err
 	}
@@ -44,7 +50,10 @@ func (s *service) Create(ctx context.Context, prm repo.CreateStandardParams) (*r
 	return &standard, nil
 }
 
-func (s *service) Update(ctx context.Context, prm repo.UpdateStandardParams) error {
+func (s *service) Update(ctx context.Context, prm repo.UpdateStandardParams) (err error) {
+	ctx, end := tracing.Start(ctx, "standard.Update")
+	defer end(&err)
+
 	if err := s.repo.UpdateStandard(ctx, prm); err != nil {
 		return err
 	}
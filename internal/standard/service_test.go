@@ -0,0 +1,67 @@
+package standard
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestCreateReturnsStoredStandard(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	svc := NewService(store, store)
+
+	created, err := svc.Create(ctx, repo.CreateStandardParams{ID: "s-1", Month: 3, Year: 2025, Hours: 167, GenderID: 1})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID != "s-1" || created.Hours != 167 {
+		t.Fatalf("created = %+v", created)
+	}
+
+	if err := svc.Update(ctx, repo.UpdateStandardParams{ID: "s-1", Hours: 159}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := store.GetStandard(ctx, repo.GetStandardParams{Month: 3, Year: 2025, GenderID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Hours != 159 {
+		t.Fatalf("hours = %d, want 159", got.Hours)
+	}
+
+	if _, err := store.GetStandard(ctx, repo.GetStandardParams{Month: 3, Year: 2025, GenderID: 2}); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("other gender: err = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestListForSettingFiltersYearAndSortsByMonth(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	svc := NewService(store, store)
+
+	for _, prm := range []repo.CreateStandardParams{
+		{ID: "s-dec", Month: 12, Year: 2025, Hours: 176, GenderID: 1},
+		{ID: "s-jan", Month: 1, Year: 2025, Hours: 136, GenderID: 1},
+		{ID: "s-other", Month: 6, Year: 2024, Hours: 151, GenderID: 1},
+	} {
+		if _, err := svc.Create(ctx, prm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	standards, err := svc.ListForSetting(ctx, 2025)
+	if err != nil {
+		t.Fatalf("ListForSetting: %v", err)
+	}
+	if len(*standards) != 2 {
+		t.Fatalf("len = %d, want 2", len(*standards))
+	}
+	if (*standards)[0].ID != "s-jan" || (*standards)[1].ID != "s-dec" {
+		t.Fatalf("order = %s, %s", (*standards)[0].ID, (*standards)[1].ID)
+	}
+}
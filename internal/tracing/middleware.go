@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"TimeTrack/internal/apperr"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware открывает span запроса и кладет его в c.UserContext(): обработчики
+// передают этот контекст сервисам, и spans сервисов и запросов к базе становятся
+// дочерними. Заголовок traceparent от клиента продолжает его трассу. Запросы,
+// для которых skip возвращает true (пробы оркестратора), не трассируются.
+func Middleware(skip func(c *fiber.Ctx) bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if skip != nil && skip(c) {
+			return c.Next()
+		}
+
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headerCarrier{c})
+		ctx, span := tracer().Start(ctx, c.Method()+" "+c.Path(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Method()),
+				semconv.URLPath(c.Path()),
+			),
+		)
+		defer span.End()
+
+		c.SetUserContext(ctx)
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			// ответ с ошибкой пишет обработчик ошибок Fiber уже после middleware
+			status = apperr.Status(err)
+			span.RecordError(err)
+		}
+
+		// шаблон маршрута известен только после маршрутизации
+		route := c.Route().Path
+		span.SetName(c.Method() + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(status))
+		if user := c.Params("user"); user != "" {
+			span.SetAttributes(User(user))
+		}
+		if month, err := strconv.Atoi(c.Params("month")); err == nil {
+			span.SetAttributes(Month(int32(month)))
+		}
+		if year, err := strconv.Atoi(c.Params("year")); err == nil {
+			span.SetAttributes(Year(int32(year)))
+		}
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		return err
+	}
+}
+
+// headerCarrier - заголовки запроса Fiber для propagation.TextMapPropagator
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h.c.Request().Header.Set(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	var keys []string
+	h.c.Request().Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
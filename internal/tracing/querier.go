@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/adapter/observe"
+	"context"
+	"database/sql"
+	"errors"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Querier оборачивает запросы к базе: каждый вызов - span с именем запроса sqlc
+func Querier(q repo.Querier) repo.Querier {
+	return observe.Querier(q, observeQuery)
+}
+
+// Streamer - то же для построчных выборок: span длится до последней прочитанной строки
+func Streamer(s repo.Streamer) repo.Streamer {
+	return observe.Streamer(s, observeQuery)
+}
+
+// TxBeginner - то же для запросов внутри транзакций
+func TxBeginner(b repo.TxBeginner) repo.TxBeginner {
+	return observe.TxBeginner(b, observeQuery)
+}
+
+// observeQuery - observe.Observer, открывающий span запроса;
+// sql.ErrNoRows - обычный ответ, не ошибка
+func observeQuery(ctx context.Context, query string) (context.Context, func(err error)) {
+	ctx, span := tracer().Start(ctx, "repo."+query,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBOperationName(query)),
+	)
+	return ctx, func(err error) {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil
+		}
+		finish(span, err)
+	}
+}
@@ -0,0 +1,95 @@
+// Package tracing - трассировка OpenTelemetry: span на каждый HTTP-запрос (Middleware),
+// метод сервиса (Start) и запрос repo.Querier (Querier). Spans отправляются по
+// OTLP/HTTP в коллектор TRACING_ENDPOINT; без него используется no-op провайдер
+// и трассировка ничего не стоит.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentation = "TimeTrack"
+	serviceName     = "timetrack"
+)
+
+// Setup настраивает экспорт spans в коллектор endpoint (например,
+// http://otel-collector:4318). Возвращает функцию, которая отправляет
+// накопленные spans при остановке. Доля записываемых трасс задается стандартными
+// переменными OTEL_TRACES_SAMPLER и OTEL_TRACES_SAMPLER_ARG.
+func Setup(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("create OTLP exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// tracer берется из глобального провайдера при каждом вызове, чтобы замена
+// провайдера (Setup, тесты) действовала сразу
+func tracer() trace.Tracer {
+	return otel.GetTracerProvider().Tracer(instrumentation)
+}
+
+// Start открывает span метода сервиса, например "report.MonthStats", и возвращает
+// end, закрывающую его. end принимает адрес именованной ошибки метода, чтобы span
+// с ошибкой был отмечен ею: defer end(&err); метод без ошибки закрывает span через end(nil).
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, func(err *error)) {
+	ctx, span := tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+	return ctx, func(err *error) {
+		if err == nil {
+			finish(span, nil)
+			return
+		}
+		finish(span, *err)
+	}
+}
+
+// finish закрывает span, отмечая его ошибкой err
+func finish(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// User - идентификатор сотрудника
+func User(id string) attribute.KeyValue {
+	return attribute.String("timetrack.user_id", id)
+}
+
+func Day(day int32) attribute.KeyValue {
+	return attribute.Int("timetrack.day", int(day))
+}
+
+func Month(month int32) attribute.KeyValue {
+	return attribute.Int("timetrack.month", int(month))
+}
+
+func Year(year int32) attribute.KeyValue {
+	return attribute.Int("timetrack.year", int(year))
+}
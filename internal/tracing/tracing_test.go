@@ -0,0 +1,146 @@
+package tracing
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// record подменяет глобальный провайдер на запись spans в память
+func record(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		provider.Shutdown(context.Background())
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return exporter
+}
+
+func find(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, s := range spans {
+		if s.Name == name {
+			return s
+		}
+	}
+	t.Fatalf("no span %q in %d spans", name, len(spans))
+	return tracetest.SpanStub{}
+}
+
+func attr(s tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range s.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+// TestMiddleware - span запроса с маршрутом и параметрами, span сервиса
+// и запроса к базе - его потомки
+func TestMiddleware(t *testing.T) {
+	exporter := record(t)
+	q := Querier(memory.New())
+
+	app := fiber.New()
+	app.Use(Middleware(func(c *fiber.Ctx) bool { return c.Path() == "/healthz" }))
+	app.Get("/healthz", func(c *fiber.Ctx) error { return c.SendString("ok") })
+	app.Get("/v1/report/monthstats/:user/:month/:year", func(c *fiber.Ctx) (err error) {
+		ctx, end := Start(c.UserContext(), "report.MonthStats", User(c.Params("user")))
+		defer end(&err)
+		if _, err := q.GetTypeAll(ctx); err != nil {
+			return err
+		}
+		return c.SendString("ok")
+	})
+
+	req := httptest.NewRequest("GET", "/v1/report/monthstats/u-1/5/2025", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if _, err := app.Test(req); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Test(httptest.NewRequest("GET", "/healthz", nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("spans = %d, want request, service and query", len(spans))
+	}
+
+	request := find(t, spans, "GET /v1/report/monthstats/:user/:month/:year")
+	if request.SpanContext.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("trace id = %s, want the one from traceparent", request.SpanContext.TraceID())
+	}
+	if attr(request, "timetrack.user_id").AsString() != "u-1" || attr(request, "timetrack.month").AsInt64() != 5 || attr(request, "timetrack.year").AsInt64() != 2025 {
+		t.Errorf("attributes = %v", request.Attributes)
+	}
+	if attr(request, "http.response.status_code").AsInt64() != 200 {
+		t.Errorf("status = %v", attr(request, "http.response.status_code"))
+	}
+
+	service := find(t, spans, "report.MonthStats")
+	query := find(t, spans, "repo.GetTypeAll")
+	if service.Parent.SpanID() != request.SpanContext.SpanID() || query.Parent.SpanID() != service.SpanContext.SpanID() {
+		t.Errorf("spans are not nested: request %s, service parent %s, query parent %s",
+			request.SpanContext.SpanID(), service.Parent.SpanID(), query.Parent.SpanID())
+	}
+}
+
+// TestQuerierNoRows - отсутствие строки не отмечается как ошибка
+func TestQuerierNoRows(t *testing.T) {
+	exporter := record(t)
+
+	if _, err := Querier(memory.New()).GetVacationById(context.Background(), "missing"); err == nil {
+		t.Fatal("want sql.ErrNoRows")
+	}
+
+	span := find(t, exporter.GetSpans(), "repo.GetVacationById")
+	if span.Status.Code == codes.Error || len(span.Events) != 0 {
+		t.Fatalf("status = %+v, events = %d", span.Status, len(span.Events))
+	}
+	if attr(span, "db.operation.name").AsString() != "GetVacationById" {
+		t.Fatalf("attributes = %v", span.Attributes)
+	}
+}
+
+// TestStartError - ошибка метода отмечается на его span
+func TestStartError(t *testing.T) {
+	exporter := record(t)
+
+	failed := func() (err error) {
+		_, end := Start(context.Background(), "report.Create")
+		defer end(&err)
+		return errors.New("boom")
+	}
+	if err := failed(); err == nil {
+		t.Fatal("want error")
+	}
+	_, end := Start(context.Background(), "report.Units")
+	end(nil)
+
+	span := find(t, exporter.GetSpans(), "report.Create")
+	if span.Status.Code != codes.Error || span.Status.Description != "boom" || len(span.Events) != 1 {
+		t.Fatalf("status = %+v, events = %d", span.Status, len(span.Events))
+	}
+	if span := find(t, exporter.GetSpans(), "report.Units"); span.Status.Code == codes.Error {
+		t.Fatalf("status = %+v", span.Status)
+	}
+}
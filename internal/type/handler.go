@@ -1,8 +1,8 @@
 package types
 
 import (
+	"TimeTrack/internal/apperr"
 	"log/slog"
-	"net/http"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -20,27 +20,10 @@ func NewHandler(service Service, logger *slog.Logger) *Handler {
 }
 
 func (h *Handler) List(c *fiber.Ctx) error {
-	types, err := h.service.List(c.Context())
+	types, err := h.service.List(c.UserContext())
 	if err != nil {
-		h.logger.Error("failed to get vacations",
-			slog.String("error", err.Error()),
-		)
-		return h.respondError(c, http.StatusInternalServerError, "failed to get vacations")
+		return apperr.Wrap(err, "failed to get vacations")
 	}
 
 	return c.JSON(types)
 }
-
-// ErrorResponse представляет стандартный формат ошибки
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
-}
-
-// respondError - вспомогательный метод для отправки ошибок
-func (h *Handler) respondError(c *fiber.Ctx, status int, message string) error {
-	return c.Status(status).JSON(ErrorResponse{
-		Error:   http.StatusText(status),
-		Message: message,
-	})
-}
@@ -0,0 +1,15 @@
+package types
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты видов отметок для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/v1/type/list", Summary: "Виды отметок (явка, больничный...)",
+			Response: []repo.ReportType{}},
+	}
+}
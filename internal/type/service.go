@@ -2,8 +2,8 @@ package types
 
 import (
 	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/tracing"
 	"context"
-	"database/sql"
 )
 
 type Service interface {
@@ -12,14 +12,17 @@ type Service interface {
 
 type service struct {
 	repo repo.Querier
-	db   *sql.DB
+	db   repo.TxBeginner
 }
 
-func NewService(repo repo.Querier, db *sql.DB) Service {
+func NewService(repo repo.Querier, db repo.TxBeginner) Service {
 	return &service{repo: repo, db: db}
 }
 
-func (s *service) List(ctx context.Context) (*[]repo.ReportType, error) {
+func (s *service) List(ctx context.Context) (_ *[]repo.ReportType, err error) {
+	ctx, end := tracing.Start(ctx, "types.List")
+	defer end(&err)
+
 	types, err := s.repo.GetTypeAll(ctx)
 	if err != nil {
 		return nil, err
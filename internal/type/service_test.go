@@ -0,0 +1,52 @@
+package types
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"testing"
+)
+
+func TestListSortsByName(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+
+	for _, rt := range []repo.CreateTypeParams{
+		{ID: "t-work", Name: "Явка", SystemName: "work", Code: "Я"},
+		{ID: "t-medical", Name: "Больничный", SystemName: "medical", Code: "Б"},
+		{ID: "t-vacation", Name: "отпуск", SystemName: "vacation", Code: "ОТ"},
+	} {
+		if err := store.CreateType(ctx, rt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	types, err := NewService(store, store).List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	var names []string
+	for _, rt := range *types {
+		names = append(names, rt.Name)
+	}
+	want := []string{"Больничный", "отпуск", "Явка"}
+	if len(names) != len(want) {
+		t.Fatalf("names = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("names = %v, want %v", names, want)
+		}
+	}
+}
+
+func TestListEmpty(t *testing.T) {
+	types, err := NewService(memory.New(), memory.New()).List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(*types) != 0 {
+		t.Fatalf("len = %d, want 0", len(*types))
+	}
+}
@@ -2,7 +2,11 @@ package vacation
 
 import (
 	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/export"
+	"TimeTrack/internal/validate"
 	"database/sql"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
@@ -26,22 +30,17 @@ func NewHandler(service Service, logger *slog.Logger) *Handler {
 func (h *Handler) List(c *fiber.Ctx) error {
 	userID := c.Params("user")
 	if userID == "" {
-		return h.respondError(c, http.StatusBadRequest, "user ID is required")
+		return apperr.Required("user")
 	}
 
 	year, err := c.ParamsInt("year")
 	if err != nil || year < 1900 || year > 2100 {
-		return h.respondError(c, http.StatusBadRequest, "invalid year parameter")
+		return apperr.InvalidParam("year")
 	}
 
-	vacations, err := h.service.List(c.Context(), userID, int32(year))
+	vacations, err := h.service.List(c.UserContext(), userID, int32(year))
 	if err != nil {
-		h.logger.Error("failed to get vacations",
-			slog.String("user_id", userID),
-			slog.Int("year", year),
-			slog.String("error", err.Error()),
-		)
-		return h.respondError(c, http.StatusInternalServerError, "failed to get vacations")
+		return apperr.Wrap(err, "failed to get vacations")
 	}
 
 	return c.JSON(vacations)
@@ -50,36 +49,39 @@ func (h *Handler) List(c *fiber.Ctx) error {
 func (h *Handler) ListAll(c *fiber.Ctx) error {
 	year, err := c.ParamsInt("year")
 	if err != nil || year < 1900 || year > 2100 {
-		return h.respondError(c, http.StatusBadRequest, "invalid year parameter")
+		return apperr.InvalidParam("year")
 	}
 
-	vacations, err := h.service.ListAll(c.Context(), int32(year))
+	vacations, err := h.service.ListAll(c.UserContext(), int32(year))
 	if err != nil {
-		h.logger.Error("failed to get vacations",
-			slog.Int("year", year),
-			slog.String("error", err.Error()),
-		)
-		return h.respondError(c, http.StatusInternalServerError, "failed to get vacations")
+		return apperr.Wrap(err, "failed to get vacations")
 	}
 
-	return c.JSON(vacations)
+	if err := export.Stream(c, h.logger, fmt.Sprintf("vacations-%d", year), vacations); err != nil {
+		return apperr.Wrap(err, "failed to get vacations")
+	}
+	return nil
 }
 
-func (h *Handler) Create(c *fiber.Ctx) error {
-	type createRequest struct {
-		UserID      string                    `json:"userId"`
-		StartDate   time.Time                 `json:"startDate"`
-		EndDate     time.Time                 `json:"endDate"`
-		Year        int32                     `json:"year"`
-		Description string                    `json:"description"`
-		Status      repo.ReportVacationStatus `json:"status"`
-	}
+type createRequest struct {
+	UserID      string                    `json:"userId" validate:"required,uuid"`
+	StartDate   time.Time                 `json:"startDate" validate:"required"`
+	EndDate     time.Time                 `json:"endDate" validate:"required,gtefield=StartDate"`
+	Year        int32                     `json:"year" validate:"required,min=1900,max=2100"`
+	Description string                    `json:"description" validate:"max=100"` // MaxDescription
+	Status      repo.ReportVacationStatus `json:"status" validate:"omitempty,oneof=consideration rejected approved"`
+}
 
+func (h *Handler) Create(c *fiber.Ctx) error {
 	var req createRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		h.logger.Warn("invalid request body", slog.String("error", err.Error()))
-		return h.respondError(c, http.StatusBadRequest, "invalid request body")
+	if err := validate.Body(c, &req); err != nil {
+		return err
+	}
+
+	// новая заявка без статуса ждет рассмотрения
+	if req.Status == "" {
+		req.Status = repo.ReportVacationStatusConsideration
 	}
 
 	description := sql.NullString{
@@ -87,7 +89,7 @@ func (h *Handler) Create(c *fiber.Ctx) error {
 		Valid:  req.Description != "",
 	}
 
-	vacation, err := h.service.Create(c.Context(), repo.CreateVacationParams{
+	vacation, err := h.service.Create(c.UserContext(), repo.CreateVacationParams{
 		UserID:      req.UserID,
 		ID:          uuid.NewString(),
 		StartDate:   req.StartDate,
@@ -98,15 +100,7 @@ func (h *Handler) Create(c *fiber.Ctx) error {
 	})
 
 	if err != nil {
-		h.logger.Error("failed to get vacation stats",
-			slog.String("user_id", req.UserID),
-			slog.Time("startDate", req.StartDate),
-			slog.Time("endDate", req.EndDate),
-			slog.Int("year", int(req.Year)),
-			slog.String("desc", description.String),
-			slog.String("error", err.Error()),
-		)
-		return h.respondError(c, http.StatusInternalServerError, "failed to create vacation")
+		return apperr.Wrap(err, "failed to create vacation")
 	}
 
 	return c.JSON(vacation)
@@ -115,46 +109,39 @@ func (h *Handler) Create(c *fiber.Ctx) error {
 func (h *Handler) Stats(c *fiber.Ctx) error {
 	userID := c.Params("user")
 	if userID == "" {
-		return h.respondError(c, http.StatusBadRequest, "user ID is required")
+		return apperr.Required("user")
 	}
 
 	year, err := c.ParamsInt("year")
 	if err != nil || year < 1900 || year > 2100 {
-		return h.respondError(c, http.StatusBadRequest, "invalid year parameter")
+		return apperr.InvalidParam("year")
 	}
 
-	stats, err := h.service.Stats(c.Context(), userID, int32(year))
+	stats, err := h.service.Stats(c.UserContext(), userID, int32(year))
 	if err != nil {
-		h.logger.Error("failed to get vacation stats",
-			slog.String("user_id", userID),
-			slog.Int("year", year),
-			slog.String("error", err.Error()),
-		)
-		return h.respondError(c, http.StatusInternalServerError, "failed to get vacation stats")
+		return apperr.Wrap(err, "failed to get vacation stats")
 	}
 
 	return c.JSON(stats)
 }
 
-func (h *Handler) ChangeStatus(c *fiber.Ctx) error {
-	type changeStatus struct {
-		ID     string                    `json:"id"`
-		Status repo.ReportVacationStatus `json:"status"`
-	}
+type changeStatusRequest struct {
+	ID     string                    `json:"id" validate:"required,uuid"`
+	Status repo.ReportVacationStatus `json:"status" validate:"required,oneof=consideration rejected approved"`
+}
 
-	var req changeStatus
+func (h *Handler) ChangeStatus(c *fiber.Ctx) error {
+	var req changeStatusRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		h.logger.Warn("invalid request body", slog.String("error", err.Error()))
-		return h.respondError(c, http.StatusBadRequest, "invalid request body")
+	if err := validate.Body(c, &req); err != nil {
+		return err
 	}
 
-	if err := h.service.ChangeStatus(c.Context(), repo.UpdateVacationStatusParams{
+	if err := h.service.ChangeStatus(c.UserContext(), repo.UpdateVacationStatusParams{
 		ID:     req.ID,
 		Status: req.Status,
 	}); err != nil {
-		h.logger.Warn("filed change status", slog.String("error", err.Error()))
-		return h.respondError(c, http.StatusBadRequest, "filed change status")
+		return apperr.Wrap(err, "failed to change status")
 	}
 
 	c.Status(http.StatusOK)
@@ -164,12 +151,12 @@ func (h *Handler) ChangeStatus(c *fiber.Ctx) error {
 func (h *Handler) Years(c *fiber.Ctx) error {
 	userID := c.Params("user")
 	if userID == "" {
-		return h.respondError(c, http.StatusBadRequest, "user ID is required")
+		return apperr.Required("user")
 	}
 
-	years, err := h.service.Years(c.Context(), userID)
+	years, err := h.service.Years(c.UserContext(), userID)
 	if err != nil {
-		return h.respondError(c, http.StatusBadRequest, "failed get years")
+		return apperr.Wrap(err, "failed to get years")
 	}
 
 	return c.JSON(years)
@@ -178,32 +165,14 @@ func (h *Handler) Years(c *fiber.Ctx) error {
 func (h *Handler) Delete(c *fiber.Ctx) error {
 	vacationID := c.Params("vacation")
 	if vacationID == "" {
-		return h.respondError(c, http.StatusBadRequest, "vacation ID is required")
+		return apperr.Required("vacation")
 	}
 
-	err := h.service.Delete(c.Context(), vacationID)
+	err := h.service.Delete(c.UserContext(), vacationID)
 	if err != nil {
-		h.logger.Error("failed to delete report",
-			slog.String("vacation_id", vacationID),
-			slog.String("error", err.Error()),
-		)
-		return h.respondError(c, http.StatusInternalServerError, "failed to delete report")
+		return apperr.Wrap(err, "failed to delete report")
 	}
 
 	c.Status(http.StatusOK)
 	return nil
 }
-
-// ErrorResponse представляет стандартный формат ошибки
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
-}
-
-// respondError - вспомогательный метод для отправки ошибок
-func (h *Handler) respondError(c *fiber.Ctx, status int, message string) error {
-	return c.Status(status).JSON(ErrorResponse{
-		Error:   http.StatusText(status),
-		Message: message,
-	})
-}
@@ -0,0 +1,26 @@
+package vacation
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты отпусков для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/v1/vacation/list/:year", Summary: "Отпуска всех сотрудников за год",
+			Response: []vacationRow{}, Export: true},
+		{Method: http.MethodGet, Path: "/v1/vacation/list/:user/:year", Summary: "Отпуска сотрудника за год",
+			Response: []vacationRow{}},
+		{Method: http.MethodGet, Path: "/v1/vacation/stats/:user/:year", Summary: "Остаток и использованные дни отпуска",
+			Response: vacationStats{}},
+		{Method: http.MethodGet, Path: "/v1/vacation/years/:user", Summary: "Годы, за которые у сотрудника есть отпуска",
+			Response: []int32{}},
+		{Method: http.MethodPost, Path: "/v1/vacation/create", Summary: "Подать заявку на отпуск",
+			Request: createRequest{}, Response: repo.GetVacationByIdRow{}},
+		{Method: http.MethodPost, Path: "/v1/vacation/change-status", Summary: "Согласовать или отклонить заявку",
+			Request: changeStatusRequest{}},
+		{Method: http.MethodDelete, Path: "/v1/vacation/delete/:vacation", Summary: "Удалить заявку"},
+	}
+}
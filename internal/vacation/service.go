@@ -2,15 +2,29 @@ package vacation
 
 import (
 	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/closing"
+	"TimeTrack/internal/notify"
+	"TimeTrack/internal/tracing"
+	"TimeTrack/internal/webhook"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"iter"
 	"time"
 )
 
+// MaxDescription - длина комментария к заявке в символах (report_vacation.description varchar(100))
+const MaxDescription = 100
+
+var ErrNotFound = apperr.NotFound("vacation_not_found", "vacation not found")
+
 type Service interface {
 	List(ctx context.Context, userID string, year int32) (*[]vacationRow, error)
-	ListAll(ctx context.Context, year int32) (*[]vacationRow, error)
+	ListAll(ctx context.Context, year int32) (iter.Seq2[vacationRow, error], error)
+	Get(ctx context.Context, id string) (*vacationRow, error)
+	ApprovedDaysInMonth(ctx context.Context, month, year int32) (map[string]int16, error)
 	Stats(ctx context.Context, userID string, year int32) (*vacationStats, error)
 	Create(ctx context.Context, prm repo.CreateVacationParams) (*repo.GetVacationByIdRow, error)
 	ChangeStatus(ctx context.Context, prm repo.UpdateVacationStatusParams) error
@@ -19,12 +33,16 @@ type Service interface {
 }
 
 type service struct {
-	repo repo.Querier
-	db   *sql.DB
+	repo     repo.Querier
+	db       repo.TxBeginner
+	rows     repo.Streamer
+	events   webhook.Publisher
+	notifier notify.VacationNotifier
 }
 
-func NewService(repo repo.Querier, db *sql.DB) Service {
-	return &service{repo: repo, db: db}
+// NewService - rows читает построчно список отпусков всех сотрудников
+func NewService(repo repo.Querier, db repo.TxBeginner, rows repo.Streamer, events webhook.Publisher, notifier notify.VacationNotifier) Service {
+	return &service{repo: repo, db: db, rows: rows, events: events, notifier: notifier}
 }
 
 type vacationStats struct {
@@ -34,7 +52,10 @@ type vacationStats struct {
 	All           int32 `json:"all"`
 }
 
-func (s *service) Stats(ctx context.Context, userID string, year int32) (*vacationStats, error) {
+func (s *service) Stats(ctx context.Context, userID string, year int32) (_ *vacationStats, err error) {
+	ctx, end := tracing.Start(ctx, "vacation.Stats", tracing.User(userID), tracing.Year(year))
+	defer end(&err)
+
 	all, err := s.repo.GetSettingVacationDuration(ctx)
 	if err != nil {
 		return nil, err
@@ -88,7 +109,10 @@ type vacationRow struct {
 	CreateAt    time.Time                          `json:"createAt"`
 }
 
-func (s *service) List(ctx context.Context, userID string, year int32) (*[]vacationRow, error) {
+func (s *service) List(ctx context.Context, userID string, year int32) (_ *[]vacationRow, err error) {
+	ctx, end := tracing.Start(ctx, "vacation.List", tracing.User(userID), tracing.Year(year))
+	defer end(&err)
+
 	vacations, err := s.repo.GetVacationsByYear(ctx, repo.GetVacationsByYearParams{UserID: userID, Year: year})
 	if err != nil {
 		return nil, err
@@ -135,11 +159,11 @@ func (s *service) List(ctx context.Context, userID string, year int32) (*[]vacat
 	return &vacationRows, nil
 }
 
-func (s *service) ListAll(ctx context.Context, year int32) (*[]vacationRow, error) {
-	vacations, err := s.repo.GetAdminVacationsByYear(ctx, year)
-	if err != nil {
-		return nil, err
-	}
+// ListAll возвращает отпуска всех сотрудников за год по мере чтения из базы:
+// праздники загружаются сразу, заявки - при обходе результата
+func (s *service) ListAll(ctx context.Context, year int32) (_ iter.Seq2[vacationRow, error], err error) {
+	ctx, end := tracing.Start(ctx, "vacation.ListAll", tracing.Year(year))
+	defer end(&err)
 
 	holidays, err := s.repo.GetCalendarDaysAllByType(ctx, repo.GetCalendarDaysAllByTypeParams{Year: year, SystemName: "holiday"})
 	if err != nil {
@@ -154,32 +178,115 @@ func (s *service) ListAll(ctx context.Context, year int32) (*[]vacationRow, erro
 		holidayMap[key] = h
 	}
 
-	vacationRows := make([]vacationRow, len(vacations))
+	return func(yield func(vacationRow, error) bool) {
+		for v, err := range s.rows.StreamAdminVacationsByYear(ctx, year) {
+			if err != nil {
+				yield(vacationRow{}, err)
+				return
+			}
+
+			row := vacationRow{
+				ID:          v.ID,
+				UserID:      v.UserID,
+				StartDate:   v.StartDate,
+				EndDate:     v.EndDate,
+				Year:        v.Year,
+				Description: v.Description,
+				Status:      v.Status,
+				CountDay:    countVacationDays(holidayMap, v.StartDate, v.EndDate),
+				Holidays:    findHolidaysInRange(holidayMap, v.StartDate, v.EndDate),
+				CreateAt:    v.CreateAt,
+			}
+			if !yield(row, nil) {
+				return
+			}
+		}
+	}, nil
+}
 
-	for i, v := range vacations {
-		vacationHolidays := findHolidaysInRange(holidayMap, v.StartDate, v.EndDate)
+// Get возвращает заявку на отпуск с подсчетом дней и праздниками внутри периода
+func (s *service) Get(ctx context.Context, id string) (_ *vacationRow, err error) {
+	ctx, end := tracing.Start(ctx, "vacation.Get")
+	defer end(&err)
 
-		countDay := countVacationDays(
-			holidayMap,
-			v.StartDate,
-			v.EndDate,
-		)
+	v, err := s.repo.GetVacationById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
 
-		vacationRows[i] = vacationRow{
-			ID:          v.ID,
-			UserID:      v.UserID,
-			StartDate:   v.StartDate,
-			EndDate:     v.EndDate,
-			Year:        v.Year,
-			Description: v.Description,
-			Status:      v.Status,
-			CountDay:    countDay,
-			Holidays:    vacationHolidays,
-			CreateAt:    v.CreateAt,
+	holidays, err := s.repo.GetCalendarDaysAllByType(ctx, repo.GetCalendarDaysAllByTypeParams{Year: v.Year, SystemName: "holiday"})
+	if err != nil {
+		return nil, err
+	}
+
+	holidayMap := make(map[string]repo.GetCalendarDaysAllByTypeRow)
+	for _, h := range holidays {
+		key := fmt.Sprintf("%02d-%02d", h.Month, h.Day)
+		holidayMap[key] = h
+	}
+
+	return &vacationRow{
+		ID:          v.ID,
+		UserID:      v.UserID,
+		StartDate:   v.StartDate,
+		EndDate:     v.EndDate,
+		Year:        v.Year,
+		Description: v.Description,
+		Status:      v.Status,
+		CountDay:    countVacationDays(holidayMap, v.StartDate, v.EndDate),
+		Holidays:    findHolidaysInRange(holidayMap, v.StartDate, v.EndDate),
+		CreateAt:    v.CreateAt,
+	}, nil
+}
+
+// ApprovedDaysInMonth возвращает число дней согласованного отпуска в месяце по каждому сотруднику.
+// Отпуск, переходящий через границу месяца, учитывается только в пределах месяца.
+func (s *service) ApprovedDaysInMonth(ctx context.Context, month, year int32) (_ map[string]int16, err error) {
+	ctx, end := tracing.Start(ctx, "vacation.ApprovedDaysInMonth", tracing.Month(month), tracing.Year(year))
+	defer end(&err)
+
+	first := time.Date(int(year), time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+
+	vacations, err := s.repo.GetVacationsApprovedInRange(ctx, repo.GetVacationsApprovedInRangeParams{
+		StartDate: last,
+		EndDate:   first,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	holidays, err := s.repo.GetCalendarDaysAllByType(ctx, repo.GetCalendarDaysAllByTypeParams{Year: year, SystemName: "holiday"})
+	if err != nil {
+		return nil, err
+	}
+
+	holidayMap := make(map[string]repo.GetCalendarDaysAllByTypeRow)
+	for _, h := range holidays {
+		key := fmt.Sprintf("%02d-%02d", h.Month, h.Day)
+		holidayMap[key] = h
+	}
+
+	days := make(map[string]int16)
+	for _, v := range vacations {
+		loc := v.StartDate.Location()
+		start := time.Date(int(year), time.Month(month), 1, 0, 0, 0, 0, loc)
+		end := start.AddDate(0, 1, -1)
+
+		if v.StartDate.After(start) {
+			start = v.StartDate
+		}
+		if v.EndDate.Before(end) {
+			end = v.EndDate
 		}
+
+		days[v.UserID] += countVacationDays(holidayMap, start, end)
 	}
 
-	return &vacationRows, nil
+	return days, nil
 }
 
 func findHolidaysInRange(holidayMap map[string]repo.GetCalendarDaysAllByTypeRow, startDate, endDate time.Time) []repo.GetCalendarDaysAllByTypeRow {
@@ -218,11 +325,26 @@ func countVacationDays(
 	return count
 }
 
-func (s *service) Create(ctx context.Context, prm repo.CreateVacationParams) (*repo.GetVacationByIdRow, error) {
-	err := s.repo.CreateVacation(ctx, prm)
+// Create сохраняет заявку; период не должен задевать месяцы, закрытые для расчета зарплаты
+func (s *service) Create(ctx context.Context, prm repo.CreateVacationParams) (_ *repo.GetVacationByIdRow, err error) {
+	ctx, end := tracing.Start(ctx, "vacation.Create", tracing.User(prm.UserID), tracing.Year(prm.Year))
+	defer end(&err)
+
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := closing.CheckRangeOpen(ctx, tx, prm.StartDate, prm.EndDate); err != nil {
+		return nil, err
+	}
+	if err := tx.CreateVacation(ctx, prm); err != nil {
 		return nil, err
 	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
 
 	vacation, err := s.repo.GetVacationById(ctx, prm.ID)
 
@@ -230,19 +352,44 @@ func (s *service) Create(ctx context.Context, prm repo.CreateVacationParams) (*r
 		return nil, err
 	}
 
+	s.events.Publish(ctx, webhook.EventVacationCreated, vacation)
+	s.notifier.VacationSubmitted(ctx, vacation.ID)
+
 	return &vacation, nil
 }
 
-func (s *service) ChangeStatus(ctx context.Context, prm repo.UpdateVacationStatusParams) error {
-	err := s.repo.UpdateVacationStatus(ctx, prm)
+// ChangeStatus меняет статус заявки, если ее период не задевает закрытые месяцы:
+// согласованные дни отпуска входят в выгрузку для расчета зарплаты
+func (s *service) ChangeStatus(ctx context.Context, prm repo.UpdateVacationStatusParams) (err error) {
+	ctx, end := tracing.Start(ctx, "vacation.ChangeStatus")
+	defer end(&err)
+
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := checkOpen(ctx, tx, prm.ID); err != nil {
+		return err
+	}
+	if err := tx.UpdateVacationStatus(ctx, prm); err != nil {
 		return err
 	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+
+	s.events.Publish(ctx, webhook.EventVacationStatusChanged, prm)
+	s.notifier.VacationStatusChanged(ctx, prm.ID)
 
 	return nil
 }
 
-func (s *service) Years(ctx context.Context, userID string) (*[]int32, error) {
+func (s *service) Years(ctx context.Context, userID string) (_ *[]int32, err error) {
+	ctx, end := tracing.Start(ctx, "vacation.Years", tracing.User(userID))
+	defer end(&err)
+
 	years, err := s.repo.GetYearsVacation(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -251,9 +398,38 @@ func (s *service) Years(ctx context.Context, userID string) (*[]int32, error) {
 	return &years, nil
 }
 
-func (s *service) Delete(ctx context.Context, id string) error {
-	if err := s.repo.DeleteVacation(ctx, id); err != nil {
+func (s *service) Delete(ctx context.Context, id string) (err error) {
+	ctx, end := tracing.Start(ctx, "vacation.Delete")
+	defer end(&err)
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := checkOpen(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := tx.DeleteVacation(ctx, id); err != nil {
 		return err
 	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+
+	s.events.Publish(ctx, webhook.EventVacationDeleted, map[string]string{"id": id})
 	return nil
 }
+
+// checkOpen возвращает closing.ErrMonthClosed, если период заявки id задевает закрытый месяц
+func checkOpen(ctx context.Context, q repo.Querier, id string) error {
+	v, err := q.GetVacationById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("get vacation: %w", err)
+	}
+	return closing.CheckRangeOpen(ctx, q, v.StartDate, v.EndDate)
+}
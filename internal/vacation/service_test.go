@@ -0,0 +1,266 @@
+package vacation
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+// events запоминает опубликованные события вместо отправки вебхуков
+type events []string
+
+func (e *events) Publish(ctx context.Context, event string, data any) {
+	*e = append(*e, event)
+}
+
+// notifier запоминает заявки, о которых сервис сообщил бы по почте
+type notifier struct {
+	submitted []string
+	changed   []string
+}
+
+func (n *notifier) VacationSubmitted(ctx context.Context, vacationID string) {
+	n.submitted = append(n.submitted, vacationID)
+}
+
+func (n *notifier) VacationStatusChanged(ctx context.Context, vacationID string) {
+	n.changed = append(n.changed, vacationID)
+}
+
+const userID = "0b9c7f5e-4a3d-4c1e-9f7a-2d8e6b1c5a40"
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2025, month, d, 0, 0, 0, 0, time.Local)
+}
+
+// newTestService - сервис над хранилищем в памяти с неоплачиваемыми праздниками 1-2 мая
+// и оплачиваемым 9 мая 2025 года
+func newTestService(t *testing.T) (Service, *memory.Store, *events, *notifier) {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.New()
+
+	if err := store.CreateType(ctx, repo.CreateTypeParams{ID: "t-holiday", Name: "Праздник", SystemName: "holiday", Code: "В"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []repo.CreateCalendarDayParams{
+		{ID: "c-0501", Day: 1, Month: 5, Year: 2025, TypeID: "t-holiday"},
+		{ID: "c-0502", Day: 2, Month: 5, Year: 2025, TypeID: "t-holiday"},
+		{ID: "c-0509", Day: 9, Month: 5, Year: 2025, IsPaidVacation: true, TypeID: "t-holiday"},
+	} {
+		if err := store.CreateCalendarDay(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	published, notified := &events{}, &notifier{}
+	return NewService(store, store, store, published, notified), store, published, notified
+}
+
+func create(t *testing.T, svc Service, id string, start, end time.Time, status repo.ReportVacationStatus) {
+	t.Helper()
+	if _, err := svc.Create(context.Background(), repo.CreateVacationParams{
+		ID:        id,
+		UserID:    userID,
+		StartDate: start,
+		EndDate:   end,
+		Year:      int32(start.Year()),
+		Status:    status,
+	}); err != nil {
+		t.Fatalf("Create %s: %v", id, err)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	svc, _, published, notified := newTestService(t)
+
+	vacation, err := svc.Create(context.Background(), repo.CreateVacationParams{
+		ID:          "v-1",
+		UserID:      userID,
+		StartDate:   day(time.July, 1),
+		EndDate:     day(time.July, 14),
+		Year:        2025,
+		Description: sql.NullString{String: "на море", Valid: true},
+		Status:      repo.ReportVacationStatusConsideration,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if vacation.Description != "на море" || vacation.Status != repo.ReportVacationStatusConsideration {
+		t.Fatalf("vacation = %+v", vacation)
+	}
+	if vacation.CreateAt.IsZero() {
+		t.Fatal("create_at is not set")
+	}
+	if len(*published) != 1 || (*published)[0] != "vacation.created" {
+		t.Fatalf("events = %v", *published)
+	}
+	if len(notified.submitted) != 1 || notified.submitted[0] != "v-1" {
+		t.Fatalf("submitted = %v", notified.submitted)
+	}
+}
+
+func TestGetCountsDaysWithoutHolidays(t *testing.T) {
+	svc, _, _, _ := newTestService(t)
+	create(t, svc, "v-may", day(time.April, 28), day(time.May, 11), repo.ReportVacationStatusApproved)
+
+	vacation, err := svc.Get(context.Background(), "v-may")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	// 14 календарных дней, 1 и 2 мая не входят в отпуск, оплачиваемое 9 мая входит
+	if vacation.CountDay != 12 {
+		t.Fatalf("countDay = %d, want 12", vacation.CountDay)
+	}
+	if len(vacation.Holidays) != 3 {
+		t.Fatalf("holidays = %d, want 3", len(vacation.Holidays))
+	}
+	if vacation.Description != "" {
+		t.Fatalf("description = %q, want empty", vacation.Description)
+	}
+
+	if _, err := svc.Get(context.Background(), "v-missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("missing vacation: err = %v, want ErrNotFound", err)
+	}
+}
+
+// TestListAll - построчный список всех сотрудников с подсчетом дней, как у Get
+func TestListAll(t *testing.T) {
+	svc, _, _, _ := newTestService(t)
+	create(t, svc, "v-may", day(time.April, 28), day(time.May, 11), repo.ReportVacationStatusApproved)
+	create(t, svc, "v-july", day(time.July, 1), day(time.July, 14), repo.ReportVacationStatusConsideration)
+
+	rows, err := svc.ListAll(context.Background(), 2025)
+	if err != nil {
+		t.Fatalf("ListAll: %v", err)
+	}
+	days := make(map[string]int16)
+	for row, err := range rows {
+		if err != nil {
+			t.Fatal(err)
+		}
+		days[row.ID] = row.CountDay
+	}
+	if len(days) != 2 || days["v-may"] != 12 || days["v-july"] != 14 {
+		t.Fatalf("count days = %v, want v-may 12, v-july 14", days)
+	}
+}
+
+func TestStats(t *testing.T) {
+	svc, store, _, _ := newTestService(t)
+	ctx := context.Background()
+
+	clock := time.Date(2025, 1, 10, 9, 0, 0, 0, time.Local)
+	store.SetClock(func() time.Time { return clock })
+
+	create(t, svc, "v-may", day(time.April, 28), day(time.May, 11), repo.ReportVacationStatusApproved)
+	clock = clock.Add(time.Hour)
+	create(t, svc, "v-july", day(time.July, 1), day(time.July, 7), repo.ReportVacationStatusConsideration)
+	clock = clock.Add(time.Hour)
+	create(t, svc, "v-aug", day(time.August, 1), day(time.August, 10), repo.ReportVacationStatusRejected)
+
+	stats, err := svc.Stats(ctx, userID, 2025)
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	want := vacationStats{Approved: 12, Consideration: 7, Free: 11, All: 30}
+	if *stats != want {
+		t.Fatalf("stats = %+v, want %+v", *stats, want)
+	}
+
+	list, err := svc.List(ctx, userID, 2025)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(*list) != 3 || (*list)[0].ID != "v-aug" || (*list)[2].ID != "v-may" {
+		t.Fatalf("list is not ordered by create_at desc: %+v", *list)
+	}
+
+	create(t, svc, "v-oct", day(time.October, 1), day(time.October, 28), repo.ReportVacationStatusApproved)
+	stats, err = svc.Stats(ctx, userID, 2025)
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.Free != 0 || stats.Approved != 40 {
+		t.Fatalf("stats = %+v, want 40 approved and none free", *stats)
+	}
+}
+
+func TestApprovedDaysInMonth(t *testing.T) {
+	svc, _, _, _ := newTestService(t)
+	ctx := context.Background()
+
+	create(t, svc, "v-may", day(time.April, 28), day(time.May, 11), repo.ReportVacationStatusApproved)
+	create(t, svc, "v-june", day(time.May, 30), day(time.June, 5), repo.ReportVacationStatusConsideration)
+
+	cases := []struct {
+		month time.Month
+		want  int16
+	}{
+		{time.April, 3},
+		{time.May, 9},
+		{time.June, 0},
+	}
+	for _, c := range cases {
+		days, err := svc.ApprovedDaysInMonth(ctx, int32(c.month), 2025)
+		if err != nil {
+			t.Fatalf("ApprovedDaysInMonth %s: %v", c.month, err)
+		}
+		if days[userID] != c.want {
+			t.Errorf("%s: days = %d, want %d", c.month, days[userID], c.want)
+		}
+	}
+}
+
+func TestChangeStatus(t *testing.T) {
+	svc, _, published, notified := newTestService(t)
+	ctx := context.Background()
+	create(t, svc, "v-1", day(time.July, 1), day(time.July, 7), repo.ReportVacationStatusConsideration)
+
+	if err := svc.ChangeStatus(ctx, repo.UpdateVacationStatusParams{ID: "v-1", Status: repo.ReportVacationStatusApproved}); err != nil {
+		t.Fatalf("ChangeStatus: %v", err)
+	}
+
+	vacation, err := svc.Get(ctx, "v-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if vacation.Status != repo.ReportVacationStatusApproved {
+		t.Fatalf("status = %s, want approved", vacation.Status)
+	}
+	if (*published)[len(*published)-1] != "vacation.status_changed" {
+		t.Fatalf("events = %v", *published)
+	}
+	if len(notified.changed) != 1 || notified.changed[0] != "v-1" {
+		t.Fatalf("changed = %v", notified.changed)
+	}
+}
+
+func TestYearsAndDelete(t *testing.T) {
+	svc, _, published, _ := newTestService(t)
+	ctx := context.Background()
+	create(t, svc, "v-2025", day(time.July, 1), day(time.July, 7), repo.ReportVacationStatusApproved)
+	create(t, svc, "v-2026", day(time.July, 1).AddDate(1, 0, 0), day(time.July, 7).AddDate(1, 0, 0), repo.ReportVacationStatusApproved)
+
+	years, err := svc.Years(ctx, userID)
+	if err != nil {
+		t.Fatalf("Years: %v", err)
+	}
+	if len(*years) != 2 || (*years)[0] != 2026 || (*years)[1] != 2025 {
+		t.Fatalf("years = %v, want [2026 2025]", *years)
+	}
+
+	if err := svc.Delete(ctx, "v-2026"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if (*published)[len(*published)-1] != "vacation.deleted" {
+		t.Fatalf("events = %v", *published)
+	}
+	if _, err := svc.Get(ctx, "v-2026"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get deleted: err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,172 @@
+// Package validate - проверка тел запросов по тегам validate (go-playground/validator).
+// Ошибки возвращаются как apperr.Validation со списком полей под их именами в JSON
+// (userId, entries[0].hours).
+//
+// Кроме стандартных правил есть правило date для номера дня: date=Month Year - день
+// вместе с полями месяца и года той же структуры образует существующую дату (не 30 февраля).
+package validate
+
+import (
+	"TimeTrack/internal/apperr"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+var checker = newChecker()
+
+func newChecker() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	// в ошибках поля называются так же, как в JSON
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	if err := v.RegisterValidation("date", validDate); err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Body разбирает тело запроса в out и проверяет его
+func Body(c *fiber.Ctx, out any) error {
+	if err := c.BodyParser(out); err != nil {
+		return apperr.InvalidBody(err)
+	}
+	return Struct(out)
+}
+
+// Struct проверяет структуру по тегам validate; все ошибки полей - одним apperr.Validation
+func Struct(s any) error {
+	err := checker.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return fmt.Errorf("validate: %w", err)
+	}
+
+	root := reflect.TypeOf(s)
+	fields := make([]apperr.Field, len(errs))
+	for i, fe := range errs {
+		fields[i] = apperr.Field{Field: path(fe), Message: message(fe, root)}
+	}
+	return apperr.Validation(fields...)
+}
+
+// path - путь к полю без имени корневой структуры: "createRequest.entries[0].hours" -> "entries[0].hours"
+func path(fe validator.FieldError) string {
+	_, field, ok := strings.Cut(fe.Namespace(), ".")
+	if !ok {
+		return fe.Field()
+	}
+	return field
+}
+
+// message - текст ошибки поля; root - тип проверяемой структуры, из него берутся
+// JSON-имена полей, с которыми сравнивают (gtefield=StartDate -> startDate)
+func message(fe validator.FieldError, root reflect.Type) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "uuid", "uuid4":
+		return "must be a valid UUID"
+	case "min", "gte":
+		if isLength(fe.Kind()) {
+			return "must have at least " + fe.Param() + " items"
+		}
+		return "must be at least " + fe.Param()
+	case "max", "lte":
+		if isLength(fe.Kind()) {
+			return "must have at most " + fe.Param() + " items"
+		}
+		return "must be at most " + fe.Param()
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "gtefield":
+		return "must not be before " + siblingName(root, fe)
+	case "nefield":
+		return "must differ from " + siblingName(root, fe)
+	case "date":
+		return "must be a real date"
+	}
+	return "is invalid (" + fe.Tag() + ")"
+}
+
+func isLength(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Map || kind == reflect.String
+}
+
+// siblingName - JSON-имя поля fe.Param() из той же структуры, что и поле fe:
+// UserID с тегом json:"userId" -> userId. Путь к структуре берется из StructNamespace
+// ("contactRequest.Entries[0].ManagerID"); без тега json - имя поля Go.
+func siblingName(root reflect.Type, fe validator.FieldError) string {
+	t := elem(root)
+	parts := strings.Split(fe.StructNamespace(), ".")
+	for _, part := range parts[1 : len(parts)-1] {
+		name, _, _ := strings.Cut(part, "[")
+		field, ok := t.FieldByName(name)
+		if !ok {
+			return fe.Param()
+		}
+		t = elem(field.Type)
+	}
+
+	field, ok := t.FieldByName(fe.Param())
+	if !ok {
+		return fe.Param()
+	}
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+// elem - тип структуры под указателями, срезами и картами
+func elem(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+// validDate - правило date=Month Year: день с месяцем и годом из соседних полей - существующая дата.
+// Если месяц или год сами неверны, об этом сообщат их собственные правила.
+func validDate(fl validator.FieldLevel) bool {
+	names := strings.Fields(fl.Param())
+	if len(names) != 2 {
+		panic("validate: date wants two fields, e.g. date=Month Year")
+	}
+
+	parent := reflect.Indirect(fl.Parent())
+	month := parent.FieldByName(names[0])
+	year := parent.FieldByName(names[1])
+	if !month.CanInt() || !year.CanInt() {
+		panic("validate: date fields must be integers")
+	}
+
+	day, m, y := int(fl.Field().Int()), int(month.Int()), int(year.Int())
+	if m < 1 || m > 12 {
+		return true
+	}
+	return time.Date(y, time.Month(m), day, 0, 0, 0, 0, time.UTC).Day() == day
+}
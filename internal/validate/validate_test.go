@@ -0,0 +1,134 @@
+package validate
+
+import (
+	"TimeTrack/internal/apperr"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type entry struct {
+	Hours float64 `json:"hours" validate:"min=0,max=24"`
+	Type  string  `json:"typeSystemName" validate:"required"`
+}
+
+type dayRequest struct {
+	UserID  string  `json:"userId" validate:"required,uuid"`
+	Day     int32   `json:"day" validate:"required,min=1,max=31,date=Month Year"`
+	Month   int32   `json:"month" validate:"required,min=1,max=12"`
+	Year    int32   `json:"year" validate:"required,min=1900,max=2100"`
+	Entries []entry `json:"entries" validate:"dive"`
+}
+
+type contact struct {
+	UserID    string `json:"userId" validate:"required,uuid"`
+	ManagerID string `json:"managerId" validate:"omitempty,uuid,nefield=UserID"`
+}
+
+type period struct {
+	StartDate time.Time `json:"startDate" validate:"required"`
+	EndDate   time.Time `json:"endDate" validate:"required,gtefield=StartDate"`
+}
+
+const userID = "0b9c7f5e-4a3d-4c1e-9f7a-2d8e6b1c5a40"
+
+// fields - ошибки полей из err в виде "поле: сообщение"
+func fields(t *testing.T, err error) []string {
+	t.Helper()
+	var appErr *apperr.Error
+	if !errors.As(err, &appErr) || appErr.Code != apperr.CodeValidation {
+		t.Fatalf("err = %v, want validation error", err)
+	}
+	out := make([]string, len(appErr.Fields))
+	for i, f := range appErr.Fields {
+		out[i] = f.Field + ": " + f.Message
+	}
+	return out
+}
+
+func TestStructDate(t *testing.T) {
+	tests := []struct {
+		day, month, year int32
+		valid            bool
+	}{
+		{29, 2, 2024, true},
+		{29, 2, 2025, false},
+		{30, 2, 2024, false},
+		{31, 4, 2025, false},
+		{31, 12, 2025, true},
+	}
+
+	for _, tt := range tests {
+		err := Struct(dayRequest{UserID: userID, Day: tt.day, Month: tt.month, Year: tt.year})
+		if tt.valid && err != nil {
+			t.Errorf("%d.%d.%d: %v", tt.day, tt.month, tt.year, err)
+		}
+		if !tt.valid {
+			if got := fields(t, err); len(got) != 1 || got[0] != "day: must be a real date" {
+				t.Errorf("%d.%d.%d: fields = %v", tt.day, tt.month, tt.year, got)
+			}
+		}
+	}
+}
+
+func TestStructFields(t *testing.T) {
+	err := Struct(&dayRequest{
+		UserID:  "not-a-uuid",
+		Day:     5,
+		Month:   13,
+		Year:    2025,
+		Entries: []entry{{Hours: 8, Type: "work"}, {Hours: 25}},
+	})
+
+	want := []string{
+		"userId: must be a valid UUID",
+		"month: must be at most 12",
+		"entries[1].hours: must be at most 24",
+		"entries[1].typeSystemName: is required",
+	}
+	if got := fields(t, err); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("fields = %q, want %q", got, want)
+	}
+
+	day := time.Date(2025, 5, 5, 0, 0, 0, 0, time.UTC)
+	err = Struct(period{StartDate: day, EndDate: day.AddDate(0, 0, -1)})
+	if got := fields(t, err); len(got) != 1 || got[0] != "endDate: must not be before startDate" {
+		t.Fatalf("fields = %q", got)
+	}
+
+	err = Struct(contact{UserID: userID, ManagerID: userID})
+	if got := fields(t, err); len(got) != 1 || got[0] != "managerId: must differ from userId" {
+		t.Fatalf("fields = %q", got)
+	}
+}
+
+func TestBody(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: func(c *fiber.Ctx, err error) error {
+		return c.SendStatus(apperr.Status(err))
+	}})
+	app.Post("/", func(c *fiber.Ctx) error {
+		var req dayRequest
+		return Body(c, &req)
+	})
+
+	for body, status := range map[string]int{
+		`{"userId":"` + userID + `","day":1,"month":5,"year":2025}`:  http.StatusOK,
+		`{"userId":"` + userID + `","day":31,"month":6,"year":2025}`: http.StatusBadRequest,
+		`{"userId":`: http.StatusBadRequest,
+	} {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != status {
+			t.Errorf("%s: status = %d, want %d", body, resp.StatusCode, status)
+		}
+	}
+}
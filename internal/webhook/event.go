@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+// События, на которые можно подписаться
+const (
+	EventVacationCreated       = "vacation.created"
+	EventVacationStatusChanged = "vacation.status_changed"
+	EventVacationDeleted       = "vacation.deleted"
+	EventReportCreated         = "report.created"
+	EventReportUpdated         = "report.updated"
+	EventReportDeleted         = "report.deleted"
+	EventReportDaySet          = "report.day_set"
+	EventCalendarCreated       = "calendar.created"
+	EventCalendarDeleted       = "calendar.deleted"
+)
+
+// allEvents - подписка на все события
+const allEvents = "*"
+
+var Events = []string{
+	EventVacationCreated,
+	EventVacationStatusChanged,
+	EventVacationDeleted,
+	EventReportCreated,
+	EventReportUpdated,
+	EventReportDeleted,
+	EventReportDaySet,
+	EventCalendarCreated,
+	EventCalendarDeleted,
+}
+
+// Publisher - источник событий для сервисов; доставка выполняется асинхронно
+// и не влияет на результат операции, вызвавшей событие.
+type Publisher interface {
+	Publish(ctx context.Context, event string, data any)
+}
+
+// envelope - тело запроса к подписчику
+type envelope struct {
+	ID       string    `json:"id"`
+	Event    string    `json:"event"`
+	CreateAt time.Time `json:"createAt"`
+	Data     any       `json:"data"`
+}
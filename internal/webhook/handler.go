@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	"TimeTrack/internal/apperr"
+	"log/slog"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service Service
+	logger  *slog.Logger
+}
+
+func NewHandler(service Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Events - список событий, на которые можно подписаться
+func (h *Handler) Events(c *fiber.Ctx) error {
+	return c.JSON(Events)
+}
+
+func (h *Handler) List(c *fiber.Ctx) error {
+	webhooks, err := h.service.List(c.UserContext())
+	if err != nil {
+		return apperr.Wrap(err, "failed to get webhooks")
+	}
+
+	return c.JSON(webhooks)
+}
+
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req CreateParams
+	if err := c.BodyParser(&req); err != nil {
+		return apperr.InvalidBody(err)
+	}
+
+	webhook, err := h.service.Create(c.UserContext(), req)
+	if err != nil {
+		return apperr.Wrap(err, "failed to create webhook")
+	}
+
+	return c.Status(http.StatusCreated).JSON(webhook)
+}
+
+func (h *Handler) Update(c *fiber.Ctx) error {
+	var req UpdateParams
+	if err := c.BodyParser(&req); err != nil {
+		return apperr.InvalidBody(err)
+	}
+
+	if _, err := uuid.Parse(req.ID); err != nil {
+		return apperr.InvalidField("id", "must be a valid UUID")
+	}
+
+	webhook, err := h.service.Update(c.UserContext(), req)
+	if err != nil {
+		return apperr.Wrap(err, "failed to update webhook")
+	}
+
+	return c.JSON(webhook)
+}
+
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	webhookID := c.Params("webhook")
+	if webhookID == "" {
+		return apperr.Required("webhook")
+	}
+
+	if err := h.service.Delete(c.UserContext(), webhookID); err != nil {
+		return apperr.Wrap(err, "failed to delete webhook")
+	}
+
+	return nil
+}
+
+func (h *Handler) Deliveries(c *fiber.Ctx) error {
+	webhookID := c.Params("webhook")
+	if webhookID == "" {
+		return apperr.Required("webhook")
+	}
+
+	deliveries, err := h.service.Deliveries(c.UserContext(), webhookID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get webhook deliveries")
+	}
+
+	return c.JSON(deliveries)
+}
+
+func (h *Handler) Redeliver(c *fiber.Ctx) error {
+	deliveryID := c.Params("delivery")
+	if deliveryID == "" {
+		return apperr.Required("delivery")
+	}
+
+	delivery, err := h.service.Redeliver(c.UserContext(), deliveryID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to redeliver webhook")
+	}
+
+	return c.Status(http.StatusAccepted).JSON(delivery)
+}
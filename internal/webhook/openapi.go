@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/openapi"
+	"net/http"
+)
+
+// Operations - маршруты подписок на события для спецификации OpenAPI
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodGet, Admin: true, Path: "/v1/webhook/events", Summary: "События, на которые можно подписаться",
+			Response: []string{}},
+		{Method: http.MethodGet, Admin: true, Path: "/v1/webhook/list", Summary: "Подписки",
+			Response: []subscription{}},
+		{Method: http.MethodPost, Admin: true, Path: "/v1/webhook/create", Summary: "Создать подписку",
+			Request: CreateParams{}, Status: http.StatusCreated, Response: subscription{}},
+		{Method: http.MethodPost, Admin: true, Path: "/v1/webhook/update", Summary: "Изменить подписку",
+			Request: UpdateParams{}, Response: subscription{}},
+		{Method: http.MethodDelete, Admin: true, Path: "/v1/webhook/delete/:webhook", Summary: "Удалить подписку"},
+		{Method: http.MethodGet, Admin: true, Path: "/v1/webhook/deliveries/:webhook", Summary: "Доставки подписки",
+			Response: []repo.ReportWebhookDelivery{}},
+		{Method: http.MethodPost, Admin: true, Path: "/v1/webhook/redeliver/:delivery", Summary: "Повторить доставку",
+			Status: http.StatusAccepted, Response: repo.ReportWebhookDelivery{}},
+	}
+}
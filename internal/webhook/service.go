@@ -0,0 +1,567 @@
+package webhook
+
+import (
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"TimeTrack/internal/apperr"
+	"TimeTrack/internal/tracing"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Статусы доставки
+const (
+	StatusPending = "pending"
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+)
+
+// Заголовки запроса к подписчику. Подпись - HMAC-SHA256 тела запроса с секретом подписки.
+const (
+	HeaderEvent     = "X-TimeTrack-Event"
+	HeaderDelivery  = "X-TimeTrack-Delivery"
+	HeaderSignature = "X-TimeTrack-Signature"
+)
+
+const (
+	maxAttempts    = 6
+	initialBackoff = 2 * time.Second
+	maxBackoff     = 5 * time.Minute
+	requestTimeout = 10 * time.Second
+	// lease - на столько откладывается следующая попытка, пока идет текущая: если
+	// процесс остановится во время попытки, доставку потом повторит Run
+	lease = time.Minute
+	// pollInterval - как часто Run ищет доставки, время попытки которых наступило
+	pollInterval = 5 * time.Second
+)
+
+var (
+	ErrWebhookNotFound  = apperr.NotFound("webhook_not_found", "webhook not found")
+	ErrDeliveryNotFound = apperr.NotFound("delivery_not_found", "delivery not found")
+	ErrInvalidURL       = apperr.Invalid("invalid_webhook_url", "webhook url must be an absolute http(s) url")
+	ErrUnknownEvent     = apperr.Invalid("unknown_event", "unknown event")
+)
+
+type Service interface {
+	Publisher
+	List(ctx context.Context) (*[]subscription, error)
+	Create(ctx context.Context, prm CreateParams) (*subscription, error)
+	Update(ctx context.Context, prm UpdateParams) (*subscription, error)
+	Delete(ctx context.Context, id string) error
+	Deliveries(ctx context.Context, webhookID string) (*[]repo.ReportWebhookDelivery, error)
+	Redeliver(ctx context.Context, deliveryID string) (*repo.ReportWebhookDelivery, error)
+	Run(ctx context.Context)
+}
+
+type service struct {
+	repo     repo.Querier
+	db       repo.TxBeginner
+	cfg      Config
+	logger   *slog.Logger
+	client   *http.Client
+	resolver *net.Resolver
+}
+
+func NewService(repo repo.Querier, db repo.TxBeginner, cfg Config, logger *slog.Logger) Service {
+	return &service{
+		repo:     repo,
+		db:       db,
+		cfg:      cfg,
+		logger:   logger,
+		client:   newClient(cfg),
+		resolver: net.DefaultResolver,
+	}
+}
+
+// subscription - подписка без секрета; секрет возвращается только при создании
+type subscription struct {
+	ID       string    `json:"id"`
+	URL      string    `json:"url"`
+	Events   []string  `json:"events"`
+	IsActive bool      `json:"isActive"`
+	CreateAt time.Time `json:"createAt"`
+	Secret   string    `json:"secret,omitempty"`
+}
+
+// CreateParams - новая подписка. Пустой Secret генерируется, пустой Events - все события.
+type CreateParams struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+}
+
+type UpdateParams struct {
+	ID       string   `json:"id"`
+	URL      string   `json:"url"`
+	Events   []string `json:"events"`
+	IsActive bool     `json:"isActive"`
+}
+
+func (s *service) List(ctx context.Context) (_ *[]subscription, err error) {
+	ctx, end := tracing.Start(ctx, "webhook.List")
+	defer end(&err)
+
+	webhooks, err := s.repo.GetWebhooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get webhooks: %w", err)
+	}
+
+	subscriptions := make([]subscription, 0, len(webhooks))
+	for _, w := range webhooks {
+		subscriptions = append(subscriptions, toSubscription(w))
+	}
+
+	return &subscriptions, nil
+}
+
+func (s *service) Create(ctx context.Context, prm CreateParams) (_ *subscription, err error) {
+	ctx, end := tracing.Start(ctx, "webhook.Create")
+	defer end(&err)
+
+	if err := s.checkURL(ctx, prm.URL); err != nil {
+		return nil, err
+	}
+	events, err := normalize(prm.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := prm.Secret
+	if secret == "" {
+		if secret, err = newSecret(); err != nil {
+			return nil, fmt.Errorf("generate secret: %w", err)
+		}
+	}
+
+	id := uuid.NewString()
+	if err := s.repo.CreateWebhook(ctx, repo.CreateWebhookParams{
+		ID:       id,
+		Url:      prm.URL,
+		Secret:   secret,
+		Events:   events,
+		IsActive: true,
+	}); err != nil {
+		return nil, fmt.Errorf("create webhook: %w", err)
+	}
+
+	webhook, err := s.repo.GetWebhookById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook: %w", err)
+	}
+
+	sub := toSubscription(webhook)
+	sub.Secret = webhook.Secret
+	return &sub, nil
+}
+
+func (s *service) Update(ctx context.Context, prm UpdateParams) (_ *subscription, err error) {
+	ctx, end := tracing.Start(ctx, "webhook.Update")
+	defer end(&err)
+
+	if _, err := s.webhook(ctx, prm.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkURL(ctx, prm.URL); err != nil {
+		return nil, err
+	}
+	events, err := normalize(prm.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateWebhook(ctx, repo.UpdateWebhookParams{
+		Url:      prm.URL,
+		Events:   events,
+		IsActive: prm.IsActive,
+		ID:       prm.ID,
+	}); err != nil {
+		return nil, fmt.Errorf("update webhook: %w", err)
+	}
+
+	webhook, err := s.webhook(ctx, prm.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := toSubscription(*webhook)
+	return &sub, nil
+}
+
+// Delete удаляет подписку вместе с журналом доставок
+func (s *service) Delete(ctx context.Context, id string) (err error) {
+	ctx, end := tracing.Start(ctx, "webhook.Delete")
+	defer end(&err)
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := tx.DeleteWebhookDeliveries(ctx, id); err != nil {
+		return fmt.Errorf("delete webhook deliveries: %w", err)
+	}
+	if err := tx.DeleteWebhook(ctx, id); err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// Deliveries - последние 100 доставок подписки, новые сверху
+func (s *service) Deliveries(ctx context.Context, webhookID string) (_ *[]repo.ReportWebhookDelivery, err error) {
+	ctx, end := tracing.Start(ctx, "webhook.Deliveries")
+	defer end(&err)
+
+	deliveries, err := s.repo.GetWebhookDeliveries(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook deliveries: %w", err)
+	}
+
+	return &deliveries, nil
+}
+
+// Redeliver повторно отправляет сохраненное событие с новым счетчиком попыток
+func (s *service) Redeliver(ctx context.Context, deliveryID string) (_ *repo.ReportWebhookDelivery, err error) {
+	ctx, end := tracing.Start(ctx, "webhook.Redeliver")
+	defer end(&err)
+
+	delivery, err := s.repo.GetWebhookDeliveryById(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("get webhook delivery: %w", err)
+	}
+
+	webhook, err := s.webhook(ctx, delivery.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Status = StatusPending
+	delivery.Attempts = 0
+	delivery.ResponseCode = sql.NullInt32{}
+	delivery.Error = sql.NullString{}
+	delivery.NextAttemptAt = sql.NullTime{Time: time.Now().Add(lease), Valid: true}
+	if err := s.saveAttempt(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	go s.attempt(context.WithoutCancel(ctx), *webhook, delivery)
+
+	return &delivery, nil
+}
+
+// Publish сохраняет событие в журнал для каждой подходящей активной подписки
+// и отправляет его в фоне; неудачные попытки повторяет Run. Ошибки только логируются.
+func (s *service) Publish(ctx context.Context, event string, data any) {
+	ctx, end := tracing.Start(ctx, "webhook.Publish")
+	defer end(nil)
+
+	webhooks, err := s.repo.GetActiveWebhooks(ctx)
+	if err != nil {
+		s.logger.Error("failed to get webhooks",
+			slog.String("event", event),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	for _, webhook := range webhooks {
+		if !subscribed(webhook.Events, event) {
+			continue
+		}
+
+		id := uuid.NewString()
+		payload, err := json.Marshal(envelope{
+			ID:       id,
+			Event:    event,
+			CreateAt: time.Now(),
+			Data:     data,
+		})
+		if err != nil {
+			s.logger.Error("failed to encode webhook payload",
+				slog.String("event", event),
+				slog.String("error", err.Error()),
+			)
+			return
+		}
+
+		next := sql.NullTime{Time: time.Now().Add(lease), Valid: true}
+		if err := s.repo.CreateWebhookDelivery(ctx, repo.CreateWebhookDeliveryParams{
+			ID:            id,
+			WebhookID:     webhook.ID,
+			Event:         event,
+			Payload:       string(payload),
+			NextAttemptAt: next,
+		}); err != nil {
+			s.logger.Error("failed to create webhook delivery",
+				slog.String("webhook", webhook.ID),
+				slog.String("event", event),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+
+		go s.attempt(context.WithoutCancel(ctx), webhook, repo.ReportWebhookDelivery{
+			ID:            id,
+			WebhookID:     webhook.ID,
+			Event:         event,
+			Payload:       string(payload),
+			Status:        StatusPending,
+			NextAttemptAt: next,
+		})
+	}
+}
+
+// Run повторяет доставки, время попытки которых наступило: сразу при запуске - в том
+// числе прерванные остановкой процесса - и затем каждые pollInterval. Каждую доставку
+// берет один исполнитель, даже если Run работает в нескольких процессах. После отмены
+// ctx Run дожидается начатых попыток и возвращается.
+func (s *service) Run(ctx context.Context) {
+	var attempts sync.WaitGroup
+	defer attempts.Wait()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.retryDue(ctx, &attempts)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// retryDue захватывает доставки, время попытки которых наступило, и запускает попытки
+func (s *service) retryDue(ctx context.Context, attempts *sync.WaitGroup) {
+	now := time.Now()
+	deliveries, err := s.repo.GetDueWebhookDeliveries(ctx, sql.NullTime{Time: now, Valid: true})
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("failed to get due webhook deliveries", slog.String("error", err.Error()))
+		}
+		return
+	}
+
+	for _, delivery := range deliveries {
+		claimed, err := s.repo.ClaimWebhookDelivery(ctx, repo.ClaimWebhookDeliveryParams{
+			LeaseUntil: sql.NullTime{Time: now.Add(lease), Valid: true},
+			ID:         delivery.ID,
+			Now:        sql.NullTime{Time: now, Valid: true},
+		})
+		if err != nil {
+			s.logger.Error("failed to claim webhook delivery",
+				slog.String("delivery", delivery.ID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if claimed == 0 {
+			// доставку уже взял другой процесс
+			continue
+		}
+
+		webhook, err := s.webhook(ctx, delivery.WebhookID)
+		if err != nil {
+			s.logger.Error("failed to get webhook",
+				slog.String("delivery", delivery.ID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if !webhook.IsActive {
+			delivery.Status = StatusFailed
+			delivery.Error = sql.NullString{String: "webhook is inactive", Valid: true}
+			delivery.NextAttemptAt = sql.NullTime{}
+			if err := s.saveAttempt(ctx, delivery); err != nil {
+				s.logger.Error("failed to save webhook delivery",
+					slog.String("delivery", delivery.ID),
+					slog.String("error", err.Error()),
+				)
+			}
+			continue
+		}
+
+		attempts.Go(func() {
+			s.attempt(context.WithoutCancel(ctx), *webhook, delivery)
+		})
+	}
+}
+
+// attempt выполняет одну попытку доставки и записывает ее в журнал. После неудачной
+// попытки следующая назначается с экспоненциальной задержкой, пока не исчерпан maxAttempts.
+func (s *service) attempt(ctx context.Context, webhook repo.ReportWebhook, delivery repo.ReportWebhookDelivery) {
+	delivery.Attempts++
+	code, err := s.send(ctx, webhook, delivery)
+
+	delivery.ResponseCode = sql.NullInt32{Int32: int32(code), Valid: code != 0}
+	delivery.Error = sql.NullString{}
+	delivery.NextAttemptAt = sql.NullTime{}
+	switch {
+	case err == nil:
+		delivery.Status = StatusSuccess
+	case delivery.Attempts >= maxAttempts:
+		delivery.Status = StatusFailed
+	default:
+		delivery.Status = StatusPending
+		delivery.NextAttemptAt = sql.NullTime{Time: time.Now().Add(backoff(delivery.Attempts)), Valid: true}
+	}
+	if err != nil {
+		delivery.Error = sql.NullString{String: truncate(err.Error(), 500), Valid: true}
+	}
+
+	if err := s.saveAttempt(ctx, delivery); err != nil {
+		s.logger.Error("failed to save webhook delivery",
+			slog.String("delivery", delivery.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	if delivery.Status == StatusFailed {
+		s.logger.Warn("webhook delivery failed",
+			slog.String("webhook", webhook.ID),
+			slog.String("delivery", delivery.ID),
+			slog.String("event", delivery.Event),
+			slog.String("error", delivery.Error.String),
+		)
+	}
+}
+
+// send выполняет одну попытку доставки; успехом считается любой ответ 2xx
+func (s *service) send(ctx context.Context, webhook repo.ReportWebhook, delivery repo.ReportWebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "TimeTrack-Webhook")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (s *service) saveAttempt(ctx context.Context, delivery repo.ReportWebhookDelivery) error {
+	if err := s.repo.UpdateWebhookDelivery(ctx, repo.UpdateWebhookDeliveryParams{
+		Status:        delivery.Status,
+		Attempts:      delivery.Attempts,
+		ResponseCode:  delivery.ResponseCode,
+		Error:         delivery.Error,
+		NextAttemptAt: delivery.NextAttemptAt,
+		ID:            delivery.ID,
+	}); err != nil {
+		return fmt.Errorf("update webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (s *service) webhook(ctx context.Context, id string) (*repo.ReportWebhook, error) {
+	webhook, err := s.repo.GetWebhookById(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("get webhook: %w", err)
+	}
+	return &webhook, nil
+}
+
+// Sign - значение заголовка подписи: "sha256=" и HMAC-SHA256 тела в hex
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff - задержка перед следующей попыткой: 2с, 4с, 8с... но не больше maxBackoff
+func backoff(attempt int32) time.Duration {
+	delay := initialBackoff << (attempt - 1)
+	if delay <= 0 || delay > maxBackoff {
+		return maxBackoff
+	}
+	return delay
+}
+
+// normalize проверяет список событий и возвращает его в виде строки для хранения
+func normalize(events []string) (string, error) {
+	if len(events) == 0 || slices.Contains(events, allEvents) {
+		return allEvents, nil
+	}
+
+	for _, event := range events {
+		if !slices.Contains(Events, event) {
+			return "", fmt.Errorf("%w: %q", ErrUnknownEvent, event)
+		}
+	}
+
+	return strings.Join(events, ","), nil
+}
+
+func subscribed(events, event string) bool {
+	if events == allEvents {
+		return true
+	}
+	return slices.Contains(strings.Split(events, ","), event)
+}
+
+func toSubscription(w repo.ReportWebhook) subscription {
+	return subscription{
+		ID:       w.ID,
+		URL:      w.Url,
+		Events:   strings.Split(w.Events, ","),
+		IsActive: w.IsActive,
+		CreateAt: w.CreateAt,
+	}
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
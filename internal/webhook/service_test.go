@@ -0,0 +1,221 @@
+package webhook
+
+import (
+	"TimeTrack/internal/adapter/memory"
+	repo "TimeTrack/internal/adapter/mysql/sqlc"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestService - сервис вебхуков над хранилищем в памяти и подписка w-1 на адрес
+// тестового сервера, который отвечает status
+func newTestService(t *testing.T, status int) (*service, *memory.Store, *atomic.Int32) {
+	t.Helper()
+	store := memory.New()
+
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	if err := store.CreateWebhook(context.Background(), repo.CreateWebhookParams{
+		ID: "w-1", Url: server.URL, Secret: "s", Events: allEvents, IsActive: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// тестовый сервер слушает loopback
+	svc := NewService(store, store, Config{AllowPrivate: true}, slog.New(slog.NewTextHandler(io.Discard, nil))).(*service)
+	return svc, store, &received
+}
+
+// createDelivery - ожидающая доставка со временем следующей попытки next
+func createDelivery(t *testing.T, store *memory.Store, id string, next time.Time) {
+	t.Helper()
+	if err := store.CreateWebhookDelivery(context.Background(), repo.CreateWebhookDeliveryParams{
+		ID: id, WebhookID: "w-1", Event: EventReportCreated, Payload: "{}",
+		NextAttemptAt: sql.NullTime{Time: next, Valid: true},
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestRun - при запуске повторяются доставки, время попытки которых наступило,
+// после отмены контекста Run возвращается
+func TestRun(t *testing.T) {
+	svc, store, received := newTestService(t, http.StatusOK)
+	createDelivery(t, store, "dl-due", time.Now().Add(-time.Minute))
+	createDelivery(t, store, "dl-later", time.Now().Add(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Run(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		delivery, err := store.GetWebhookDeliveryById(context.Background(), "dl-due")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if delivery.Status == StatusSuccess {
+			if delivery.Attempts != 1 || delivery.NextAttemptAt.Valid {
+				t.Fatalf("delivery = %+v", delivery)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("due delivery was not retried: %+v", delivery)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not stop")
+	}
+
+	if n := received.Load(); n != 1 {
+		t.Fatalf("requests = %d, want 1", n)
+	}
+	if later, _ := store.GetWebhookDeliveryById(context.Background(), "dl-later"); later.Status != StatusPending || later.Attempts != 0 {
+		t.Fatalf("delivery that is not due = %+v", later)
+	}
+}
+
+// TestAttemptSchedulesRetry - после неудачной попытки следующая назначается в базе
+// с задержкой, последняя попытка завершает доставку ошибкой
+func TestAttemptSchedulesRetry(t *testing.T) {
+	svc, store, _ := newTestService(t, http.StatusBadGateway)
+	ctx := context.Background()
+	createDelivery(t, store, "dl-1", time.Now())
+
+	webhook, _ := store.GetWebhookById(ctx, "w-1")
+	delivery, _ := store.GetWebhookDeliveryById(ctx, "dl-1")
+	before := time.Now()
+	svc.attempt(ctx, webhook, delivery)
+
+	delivery, _ = store.GetWebhookDeliveryById(ctx, "dl-1")
+	if delivery.Status != StatusPending || delivery.Attempts != 1 || delivery.ResponseCode.Int32 != http.StatusBadGateway {
+		t.Fatalf("delivery = %+v", delivery)
+	}
+	if next := delivery.NextAttemptAt.Time; !delivery.NextAttemptAt.Valid || next.Before(before.Add(initialBackoff)) || next.After(time.Now().Add(initialBackoff)) {
+		t.Fatalf("next attempt = %v, want in %s", delivery.NextAttemptAt, initialBackoff)
+	}
+
+	delivery.Attempts = maxAttempts - 1
+	svc.attempt(ctx, webhook, delivery)
+	delivery, _ = store.GetWebhookDeliveryById(ctx, "dl-1")
+	if delivery.Status != StatusFailed || delivery.NextAttemptAt.Valid {
+		t.Fatalf("last attempt: delivery = %+v", delivery)
+	}
+}
+
+// TestRetryDueInactive - доставка отключенной подписки не отправляется и завершается ошибкой
+func TestRetryDueInactive(t *testing.T) {
+	svc, store, received := newTestService(t, http.StatusOK)
+	ctx := context.Background()
+	createDelivery(t, store, "dl-1", time.Now().Add(-time.Minute))
+	if err := store.UpdateWebhook(ctx, repo.UpdateWebhookParams{ID: "w-1", Url: "https://example.com", Events: allEvents, IsActive: false}); err != nil {
+		t.Fatal(err)
+	}
+
+	var attempts sync.WaitGroup
+	svc.retryDue(ctx, &attempts)
+	attempts.Wait()
+
+	delivery, _ := store.GetWebhookDeliveryById(ctx, "dl-1")
+	if delivery.Status != StatusFailed || delivery.Error.String != "webhook is inactive" || received.Load() != 0 {
+		t.Fatalf("delivery = %+v, requests = %d", delivery, received.Load())
+	}
+}
+
+// TestCreatePrivateURL - подписка на внутренний адрес отклоняется, в том числе по имени,
+// которое разрешается в loopback
+func TestCreatePrivateURL(t *testing.T) {
+	store := memory.New()
+	svc := NewService(store, store, Config{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	for rawURL, want := range map[string]error{
+		"http://127.0.0.1:8080/hook":            ErrPrivateURL,
+		"http://localhost/hook":                 ErrPrivateURL,
+		"http://10.1.2.3/hook":                  ErrPrivateURL,
+		"https://192.168.0.10/hook":             ErrPrivateURL,
+		"http://169.254.169.254/latest/meta":    ErrPrivateURL,
+		"http://100.64.0.1/hook":                ErrPrivateURL,
+		"http://0.0.0.0/hook":                   ErrPrivateURL,
+		"http://[::1]/hook":                     ErrPrivateURL,
+		"http://[fe80::1]/hook":                 ErrPrivateURL,
+		"http://[::ffff:127.0.0.1]/hook":        ErrPrivateURL,
+		"ftp://93.184.215.14/hook":              ErrInvalidURL,
+		"https://93.184.215.14/timetrack/hooks": nil,
+	} {
+		_, err := svc.Create(ctx, CreateParams{URL: rawURL})
+		if !errors.Is(err, want) {
+			t.Errorf("%s: err = %v, want %v", rawURL, err, want)
+		}
+	}
+
+	webhooks, err := store.GetWebhooks(ctx)
+	if err != nil || len(webhooks) != 1 {
+		t.Fatalf("webhooks = %+v, %v, want only the public one", webhooks, err)
+	}
+	if _, err := svc.Update(ctx, UpdateParams{ID: webhooks[0].ID, URL: "http://127.0.0.1/hook"}); !errors.Is(err, ErrPrivateURL) {
+		t.Fatalf("update to a private url: err = %v", err)
+	}
+}
+
+// TestSendPrivateAddress - адрес проверяется и при соединении: подписка, которая уже
+// указывает на внутренний адрес (например, имя стало разрешаться в него), не доставляется
+func TestSendPrivateAddress(t *testing.T) {
+	_, store, received := newTestService(t, http.StatusOK)
+	svc := NewService(store, store, Config{}, slog.New(slog.NewTextHandler(io.Discard, nil))).(*service)
+	ctx := context.Background()
+	createDelivery(t, store, "dl-1", time.Now())
+
+	webhook, _ := store.GetWebhookById(ctx, "w-1")
+	delivery, _ := store.GetWebhookDeliveryById(ctx, "dl-1")
+	if _, err := svc.send(ctx, webhook, delivery); !errors.Is(err, ErrPrivateURL) {
+		t.Fatalf("err = %v, want ErrPrivateURL", err)
+	}
+	if received.Load() != 0 {
+		t.Fatal("request reached a private address")
+	}
+}
+
+// TestSendRedirect - перенаправление не выполняется и считается неудачной попыткой
+func TestSendRedirect(t *testing.T) {
+	svc, store, received := newTestService(t, http.StatusOK)
+	ctx := context.Background()
+	createDelivery(t, store, "dl-1", time.Now())
+
+	target, _ := store.GetWebhookById(ctx, "w-1")
+	redirect := httptest.NewServer(http.RedirectHandler(target.Url, http.StatusTemporaryRedirect))
+	t.Cleanup(redirect.Close)
+	target.Url = redirect.URL
+
+	delivery, _ := store.GetWebhookDeliveryById(ctx, "dl-1")
+	code, err := svc.send(ctx, target, delivery)
+	if err == nil || code != http.StatusTemporaryRedirect {
+		t.Fatalf("code = %d, err = %v, want a failed redirect", code, err)
+	}
+	if received.Load() != 0 {
+		t.Fatal("redirect was followed")
+	}
+}
@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"TimeTrack/internal/apperr"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+var ErrPrivateURL = apperr.Invalid("private_webhook_url", "webhook url must not point to a loopback, private or link-local address")
+
+// Config - ограничения адресов подписчиков. AllowPrivate разрешает loopback, частные
+// и link-local адреса - для подписчиков в той же внутренней сети; по умолчанию
+// такие адреса запрещены, чтобы подписка не открывала доступ к внутренним сервисам.
+type Config struct {
+	AllowPrivate bool
+}
+
+// sharedAddressSpace - адреса операторского NAT (RFC 6598), тоже не публичные
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// forbidden - адрес, на который доставка запрещена: loopback, частные сети, link-local
+// (в том числе метаданные облака 169.254.169.254), unspecified и multicast
+func forbidden(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || sharedAddressSpace.Contains(ip)
+}
+
+// checkURL проверяет адрес подписки: абсолютный http(s), а без AllowPrivate все адреса
+// хоста должны быть публичными. Имя может позже указать на другой адрес, поэтому
+// доставка проверяет адрес еще раз при каждом соединении (dialControl).
+func (s *service) checkURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+	if s.cfg.AllowPrivate {
+		return nil
+	}
+
+	host := u.Hostname()
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if forbidden(ip) {
+			return ErrPrivateURL
+		}
+		return nil
+	}
+
+	ips, err := s.resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("%w: host %q does not resolve", ErrInvalidURL, host)
+	}
+	for _, ip := range ips {
+		if forbidden(ip) {
+			return ErrPrivateURL
+		}
+	}
+	return nil
+}
+
+// newClient - клиент доставки. Перенаправления не выполняются: ответ 3xx считается
+// неудачной попыткой, иначе подписчик мог бы направить запрос на внутренний адрес.
+// Прокси из окружения не используется, чтобы проверялся адрес самого подписчика.
+func newClient(cfg Config) *http.Client {
+	dialer := &net.Dialer{Timeout: requestTimeout, KeepAlive: 30 * time.Second}
+	if !cfg.AllowPrivate {
+		dialer.Control = dialControl
+	}
+
+	return &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: requestTimeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// dialControl отклоняет соединение с запрещенным адресом уже после разрешения имени
+func dialControl(network, address string, _ syscall.RawConn) error {
+	addr, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("webhook address %q: %w", address, err)
+	}
+	if forbidden(addr.Addr()) {
+		return fmt.Errorf("webhook address %s: %w", addr.Addr(), ErrPrivateURL)
+	}
+	return nil
+}